@@ -0,0 +1,572 @@
+package v1beta1
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spotify/flink-on-k8s-operator/internal/util"
+)
+
+const (
+	flinkConfigSavepointsDir       = "state.savepoints.dir"
+	flinkConfigSavepointsDirV2     = "execution.checkpointing.savepoint-dir"
+	flinkConfigStateBackend        = "state.backend.type"
+	flinkConfigStateBackendV1      = "state.backend"
+	flinkConfigIncremental         = "state.backend.incremental"
+	flinkConfigIncrementalV2       = "execution.checkpointing.incremental"
+	flinkConfigUnaligned           = "execution.checkpointing.unaligned.enabled"
+	flinkConfigUnalignedV1         = "execution.checkpointing.unaligned"
+	flinkConfigCheckpointTimeout   = "execution.checkpointing.timeout"
+	flinkConfigLocalRecovery       = "execution.state-recovery.from-local"
+	flinkConfigLocalRecoveryV1     = "state.backend.local-recovery"
+	flinkConfigLocalRootDirs       = "taskmanager.state.local.root-dirs"
+	flinkConfigExternalResources   = "external-resources"
+	flinkConfigScheduler           = "jobmanager.scheduler"
+	flinkConfigSchedulerMode       = "scheduler-mode"
+	flinkConfigMaxParallelism      = "pipeline.max-parallelism"
+	flinkConfigAdaptivePrefix      = "jobmanager.adaptive-scheduler."
+	flinkConfigCheckpointsDir      = "state.checkpoints.dir"
+	flinkConfigCheckpointsDirV2    = "execution.checkpointing.dir"
+	flinkConfigCheckpointStorage   = "state.checkpoint-storage"
+	flinkConfigCheckpointStorageV2 = "execution.checkpointing.storage"
+	flinkConfigCheckpointInterval  = "execution.checkpointing.interval"
+	flinkConfigMaxConcurrent       = "execution.checkpointing.max-concurrent-checkpoints"
+	flinkConfigFailoverStrategy    = "jobmanager.execution.failover-strategy"
+	flinkConfigRuntimeMode         = "execution.runtime-mode"
+	flinkConfigResolveOrder        = "classloader.resolve-order"
+	flinkConfigUnalignedSplits     = "pipeline.watermark-alignment.allow-unaligned-source-splits"
+	flinkConfigRestartStrategy     = "restart-strategy"
+	flinkConfigAutoGenerateUIDs    = "pipeline.auto-generate-uids"
+
+	flinkConfigRocksDBManagedMemory     = "state.backend.rocksdb.memory.managed"
+	flinkConfigRocksDBFixedMemory       = "state.backend.rocksdb.memory.fixed-per-slot"
+	flinkConfigRocksDBWriteBufferRatio  = "state.backend.rocksdb.memory.write-buffer-ratio"
+	flinkConfigRocksDBHighPrioPoolRatio = "state.backend.rocksdb.memory.high-prio-pool-ratio"
+	flinkConfigRocksDBBlockCacheSize    = "state.backend.rocksdb.block.cache-size"
+	flinkConfigRocksDBWriteBufferSize   = "state.backend.rocksdb.writebuffer.size"
+
+	flinkConfigJobResultStorePath           = "job-result-store.storage-path"
+	flinkConfigJobResultStoreDeleteOnCommit = "job-result-store.delete-on-commit"
+
+	flinkConfigTMManagedFraction     = "taskmanager.memory.managed.fraction"
+	flinkConfigTMManagedSize         = "taskmanager.memory.managed.size"
+	flinkConfigTMNetworkFraction     = "taskmanager.memory.network.fraction"
+	flinkConfigTMNetworkMin          = "taskmanager.memory.network.min"
+	flinkConfigTMNetworkMax          = "taskmanager.memory.network.max"
+	flinkConfigTMJVMOverheadFraction = "taskmanager.memory.jvm-overhead.fraction"
+	flinkConfigTMJVMOverheadMin      = "taskmanager.memory.jvm-overhead.min"
+	flinkConfigTMJVMOverheadMax      = "taskmanager.memory.jvm-overhead.max"
+	flinkConfigJMJVMOverheadFraction = "jobmanager.memory.jvm-overhead.fraction"
+	flinkConfigJMJVMOverheadMin      = "jobmanager.memory.jvm-overhead.min"
+	flinkConfigJMJVMOverheadMax      = "jobmanager.memory.jvm-overhead.max"
+
+	flinkConfigJobManagerRPCPort   = "jobmanager.rpc.port"
+	flinkConfigBlobServerPort      = "blob.server.port"
+	flinkConfigQueryServerPort     = "query.server.port"
+	flinkConfigRestPort            = "rest.port"
+	flinkConfigTaskManagerRPCPort  = "taskmanager.rpc.port"
+	flinkConfigTaskManagerDataPort = "taskmanager.data.port"
+
+	// StateBackendHashMap is the Flink default state backend.
+	StateBackendHashMap = "hashmap"
+	StateBackendRocksDB = "rocksdb"
+	StateBackendForSt   = "forst"
+
+	// CheckpointStorageJobManager is the Flink default checkpoint storage without a checkpoint directory.
+	CheckpointStorageJobManager = "jobmanager"
+	CheckpointStorageFileSystem = "filesystem"
+
+	// RuntimeModeStreaming is the Flink default runtime mode of the jobs.
+	RuntimeModeStreaming = "STREAMING"
+	RuntimeModeBatch     = "BATCH"
+	RuntimeModeAutomatic = "AUTOMATIC"
+)
+
+// Flink properties under these prefixes only affect the web UI, metrics reporting,
+// the history server or logging and never call for a job restart.
+var restartInsensitiveConfigPrefixes = []string{
+	"web.",
+	"metrics.",
+	"historyserver.",
+	"rest.flamegraph.",
+	"env.log.",
+}
+
+// ConfigChangeRequiresRestart returns true if a change of the Flink property requires
+// the job to restart to be applied. Unknown properties are assumed to require a restart.
+func ConfigChangeRequiresRestart(key string) bool {
+	for _, prefix := range restartInsensitiveConfigPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return false
+		}
+	}
+	return true
+}
+
+// ParsedFlinkConfig is a read-only view of spec.flinkProperties with typed accessors.
+// +kubebuilder:object:generate=false
+type ParsedFlinkConfig map[string]string
+
+// ParsedFlinkConfig returns the parsed view of the cluster Flink properties.
+func (fc *FlinkCluster) ParsedFlinkConfig() ParsedFlinkConfig {
+	return ParsedFlinkConfig(fc.Spec.FlinkProperties)
+}
+
+// Get returns the trimmed value of the key and whether it is set to a non-blank value.
+func (c ParsedFlinkConfig) Get(key string) (string, bool) {
+	v := strings.TrimSpace(c[key])
+	return v, v != ""
+}
+
+// GetAny returns the value of the first key that is set, which is useful for
+// options that were renamed between Flink versions.
+func (c ParsedFlinkConfig) GetAny(keys ...string) (string, bool) {
+	for _, key := range keys {
+		if v, ok := c.Get(key); ok {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+// StateBackend returns the configured state backend in lower case, resolving the Flink
+// default, hashmap, when it is unset. Legacy names and factory class names are kept as is.
+func (c ParsedFlinkConfig) StateBackend() string {
+	if v, ok := c.GetAny(flinkConfigStateBackend, flinkConfigStateBackendV1); ok {
+		return strings.ToLower(v)
+	}
+	return StateBackendHashMap
+}
+
+// SupportsIncrementalCheckpoints returns true if the configured state backend can take
+// incremental checkpoints.
+func (c ParsedFlinkConfig) SupportsIncrementalCheckpoints() bool {
+	return c.SupportsLargeState()
+}
+
+// SupportsLargeState returns true if the configured state backend keeps the state on disk
+// and can hold state larger than the memory of the TaskManagers.
+func (c ParsedFlinkConfig) SupportsLargeState() bool {
+	var backend = c.StateBackend()
+	return strings.Contains(backend, StateBackendRocksDB) || strings.Contains(backend, StateBackendForSt)
+}
+
+// CheckpointStorage returns the configured checkpoint storage in lower case and whether it is
+// set, resolving the Flink default when it is unset: filesystem with a checkpoint directory
+// and jobmanager otherwise.
+func (c ParsedFlinkConfig) CheckpointStorage() (string, bool) {
+	if v, ok := c.GetAny(flinkConfigCheckpointStorage, flinkConfigCheckpointStorageV2); ok {
+		return strings.ToLower(v), true
+	}
+	if c.CheckpointsDir() != "" {
+		return CheckpointStorageFileSystem, false
+	}
+	return CheckpointStorageJobManager, false
+}
+
+// PeriodicCheckpoints returns true if a checkpoint interval is configured.
+func (c ParsedFlinkConfig) PeriodicCheckpoints() bool {
+	_, ok := c.Get(flinkConfigCheckpointInterval)
+	return ok
+}
+
+// IncrementalCheckpoints returns the incremental checkpoints setting and whether it is set.
+func (c ParsedFlinkConfig) IncrementalCheckpoints() (enabled bool, set bool) {
+	v, ok := c.GetAny(flinkConfigIncremental, flinkConfigIncrementalV2)
+	return ok && strings.EqualFold(v, "true"), ok
+}
+
+// LocalRecovery returns the task-local recovery setting and the key it is set with, if any.
+func (c ParsedFlinkConfig) LocalRecovery() (enabled bool, key string) {
+	for _, key := range []string{flinkConfigLocalRecovery, flinkConfigLocalRecoveryV1} {
+		if v, ok := c.Get(key); ok {
+			return strings.EqualFold(v, "true"), key
+		}
+	}
+	return false, ""
+}
+
+// UnalignedCheckpoints returns true if unaligned checkpoints are enabled.
+func (c ParsedFlinkConfig) UnalignedCheckpoints() bool {
+	v, ok := c.GetAny(flinkConfigUnaligned, flinkConfigUnalignedV1)
+	return ok && strings.EqualFold(v, "true")
+}
+
+// AdaptiveScheduler returns true if the jobs run with the adaptive scheduler, which
+// rescales the jobs in place when TaskManagers are added or removed. The reactive
+// mode implies the adaptive scheduler.
+func (c ParsedFlinkConfig) AdaptiveScheduler() bool {
+	if v, ok := c.Get(flinkConfigSchedulerMode); ok && strings.EqualFold(v, "reactive") {
+		return true
+	}
+	v, ok := c.Get(flinkConfigScheduler)
+	return ok && strings.EqualFold(v, "adaptive")
+}
+
+// RuntimeMode returns the configured runtime mode of the jobs in upper case, resolving the
+// Flink default, STREAMING, when it is unset.
+func (c ParsedFlinkConfig) RuntimeMode() string {
+	if v, ok := c.Get(flinkConfigRuntimeMode); ok {
+		return strings.ToUpper(v)
+	}
+	return RuntimeModeStreaming
+}
+
+// RuntimeMode returns the runtime mode of the jobs in upper case, set typed in the job execution
+// mode or in flinkProperties, resolving the Flink default, STREAMING, when it is unset.
+func (fc *FlinkCluster) RuntimeMode() string {
+	if jobSpec := fc.Spec.Job; jobSpec != nil && jobSpec.ExecutionMode != nil {
+		return strings.ToUpper(string(*jobSpec.ExecutionMode))
+	}
+	return fc.ParsedFlinkConfig().RuntimeMode()
+}
+
+// ClassloaderResolveOrder returns the resolve order of the user code classloader, set typed in
+// the job or in flinkProperties, resolving the Flink default, child-first, when it is unset.
+func (fc *FlinkCluster) ClassloaderResolveOrder() ClassloaderResolveOrder {
+	if jobSpec := fc.Spec.Job; jobSpec != nil && jobSpec.ClassloaderResolveOrder != nil {
+		return *jobSpec.ClassloaderResolveOrder
+	}
+	if v, ok := fc.ParsedFlinkConfig().Get(flinkConfigResolveOrder); ok {
+		return ClassloaderResolveOrder(strings.ToLower(v))
+	}
+	return ClassloaderResolveOrderChildFirst
+}
+
+// SupportsInPlaceRescale returns true if the jobs of the cluster are rescaled in place when
+// TaskManagers are added or removed, that is, they run with the adaptive scheduler.
+func (fc *FlinkCluster) SupportsInPlaceRescale() bool {
+	return fc.ParsedFlinkConfig().AdaptiveScheduler()
+}
+
+// UpperBoundMaxParallelism is the largest max parallelism Flink supports.
+const UpperBoundMaxParallelism = 1 << 15
+
+// MaxParallelism returns the max parallelism of the jobs, nil if it is unset.
+func (c ParsedFlinkConfig) MaxParallelism() (*int32, error) {
+	v, ok := c.Get(flinkConfigMaxParallelism)
+	if !ok {
+		return nil, nil
+	}
+	parsed, err := strconv.ParseInt(v, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %v", flinkConfigMaxParallelism, v)
+	}
+	var maxParallelism = int32(parsed)
+	return &maxParallelism, nil
+}
+
+// DefaultMaxParallelism returns the max parallelism Flink derives for an operator with the
+// parallelism when the max parallelism is unset: 1.5 times the parallelism rounded up to the
+// next power of two, within 128 and the upper bound.
+func DefaultMaxParallelism(parallelism int32) int32 {
+	var maxParallelism int64 = 128
+	for maxParallelism < int64(parallelism)+int64(parallelism)/2 {
+		maxParallelism *= 2
+	}
+	return int32(min(maxParallelism, UpperBoundMaxParallelism))
+}
+
+// DefaultCheckpointTimeout is the Flink default checkpoint timeout.
+const DefaultCheckpointTimeout = 10 * time.Minute
+
+// CheckpointTimeout returns the positive checkpoint timeout set in the Flink properties and
+// whether it is set.
+func (c ParsedFlinkConfig) CheckpointTimeout() (time.Duration, bool) {
+	return c.positiveDuration(flinkConfigCheckpointTimeout)
+}
+
+// CheckpointInterval returns the positive checkpoint interval set in the Flink properties and
+// whether it is set.
+func (c ParsedFlinkConfig) CheckpointInterval() (time.Duration, bool) {
+	return c.positiveDuration(flinkConfigCheckpointInterval)
+}
+
+func (c ParsedFlinkConfig) positiveDuration(key string) (time.Duration, bool) {
+	v, ok := c.Get(key)
+	if !ok {
+		return 0, false
+	}
+	d, err := util.ParseFlinkDuration(v)
+	if err != nil || d <= 0 {
+		return 0, false
+	}
+	return d, true
+}
+
+// CheckpointTimeout returns the checkpoint timeout of the jobs, set typed in the job or in
+// flinkProperties, resolving the Flink default when it is unset.
+func (fc *FlinkCluster) CheckpointTimeout() time.Duration {
+	if jobSpec := fc.Spec.Job; jobSpec != nil && jobSpec.CheckpointTimeoutSeconds != nil {
+		return time.Duration(*jobSpec.CheckpointTimeoutSeconds) * time.Second
+	}
+	if d, ok := fc.ParsedFlinkConfig().CheckpointTimeout(); ok {
+		return d
+	}
+	return DefaultCheckpointTimeout
+}
+
+// FlinkNetworkPorts is the resolved set of ports Flink listens on, which the container
+// ports, the services and the Flink config of the cluster are generated from.
+// +kubebuilder:object:generate=false
+type FlinkNetworkPorts struct {
+	JobManagerRPC    int32
+	Blob             int32
+	Query            int32
+	REST             int32
+	TaskManagerRPC   int32
+	TaskManagerData  int32
+	TaskManagerQuery int32
+	// Keys of the Flink properties set to a port range, whose first port is resolved above.
+	PortRanges []string
+}
+
+// IsPortRange returns true if the Flink property of the port is set to a port range.
+func (p FlinkNetworkPorts) IsPortRange(key string) bool {
+	return slices.Contains(p.PortRanges, key)
+}
+
+// NetworkPorts resolves the Flink network ports of the cluster. A port set in the Flink
+// properties takes precedence over the port in the JobManager or TaskManager spec, so that
+// the ports the operator opens match the ports Flink listens on. For a port range, the first
+// port of the range is used, which Flink binds unless it is taken. An invalid port property
+// is reported in the error and the spec port is used instead.
+func (fc *FlinkCluster) NetworkPorts() (FlinkNetworkPorts, error) {
+	var jmPorts JobManagerPorts
+	if fc.Spec.JobManager != nil {
+		jmPorts = fc.Spec.JobManager.Ports
+	}
+	var tmPorts TaskManagerPorts
+	if fc.Spec.TaskManager != nil {
+		tmPorts = fc.Spec.TaskManager.Ports
+	}
+
+	var config = fc.ParsedFlinkConfig()
+	var errs []string
+	var portRanges []string
+	var resolve = func(key string, specPort *int32, defaultPort int32) int32 {
+		configPort, isRange, err := config.port(key)
+		if err != nil {
+			errs = append(errs, err.Error())
+		}
+		if isRange && !slices.Contains(portRanges, key) {
+			portRanges = append(portRanges, key)
+		}
+		switch {
+		case configPort != nil:
+			return *configPort
+		case specPort != nil:
+			return *specPort
+		}
+		return defaultPort
+	}
+
+	var ports = FlinkNetworkPorts{
+		JobManagerRPC:    resolve(flinkConfigJobManagerRPCPort, jmPorts.RPC, 6123),
+		Blob:             resolve(flinkConfigBlobServerPort, jmPorts.Blob, 6124),
+		Query:            resolve(flinkConfigQueryServerPort, jmPorts.Query, 6125),
+		REST:             resolve(flinkConfigRestPort, jmPorts.UI, 8081),
+		TaskManagerRPC:   resolve(flinkConfigTaskManagerRPCPort, tmPorts.RPC, 6122),
+		TaskManagerData:  resolve(flinkConfigTaskManagerDataPort, tmPorts.Data, 6121),
+		TaskManagerQuery: resolve(flinkConfigQueryServerPort, tmPorts.Query, 6125),
+	}
+	ports.PortRanges = portRanges
+	if len(errs) > 0 {
+		return ports, fmt.Errorf("%s", strings.Join(errs, ", "))
+	}
+	return ports, nil
+}
+
+// flinkConfigPortRangeKeys are the port properties for which Flink accepts a port range, e.g.,
+// `50100-50200`, or a list of ports and ranges, e.g., `50100,50200-50300`.
+var flinkConfigPortRangeKeys = []string{
+	flinkConfigBlobServerPort,
+	flinkConfigQueryServerPort,
+	flinkConfigTaskManagerRPCPort,
+}
+
+// port returns the port of the key, nil if it is unset, and whether it is set to a port range.
+// For a port range, the first port of the range is returned.
+func (c ParsedFlinkConfig) port(key string) (*int32, bool, error) {
+	v, ok := c.Get(key)
+	if !ok {
+		return nil, false, nil
+	}
+	if slices.Contains(flinkConfigPortRangeKeys, key) && strings.ContainsAny(v, "-,") {
+		first, err := parsePortRange(v)
+		if err != nil {
+			return nil, false, fmt.Errorf("%s in flinkProperties must be a port or port range between 1 and 65535, got %q", key, v)
+		}
+		return &first, true, nil
+	}
+	port, err := strconv.ParseInt(v, 10, 32)
+	if err != nil || port < 1 || port > 65535 {
+		return nil, false, fmt.Errorf("%s in flinkProperties must be a single port between 1 and 65535, got %q", key, v)
+	}
+	var p = int32(port)
+	return &p, false, nil
+}
+
+// parsePortRange parses a list of ports and port ranges and returns its first port.
+func parsePortRange(v string) (int32, error) {
+	var first int32
+	for i, part := range strings.Split(v, ",") {
+		var bounds = strings.SplitN(strings.TrimSpace(part), "-", 2)
+		var start, end int64
+		var err error
+		if start, err = strconv.ParseInt(strings.TrimSpace(bounds[0]), 10, 32); err != nil {
+			return 0, err
+		}
+		end = start
+		if len(bounds) == 2 {
+			if end, err = strconv.ParseInt(strings.TrimSpace(bounds[1]), 10, 32); err != nil {
+				return 0, err
+			}
+		}
+		if start < 1 || end > 65535 || start > end {
+			return 0, fmt.Errorf("invalid port range %q", part)
+		}
+		if i == 0 {
+			first = int32(start)
+		}
+	}
+	return first, nil
+}
+
+// MemoryFraction is a memory fraction of a Flink component and whether it is in effect.
+// +kubebuilder:object:generate=false
+type MemoryFraction struct {
+	Key   string
+	Value float64
+	// Set is false when the Flink default is used.
+	Set bool
+	// InEffect is false when the memory is sized by absolute settings instead.
+	InEffect bool
+}
+
+// memoryFraction returns the memory fraction of the key, the Flink default if it is unset.
+// The fraction is not in effect when the absolute size is set: either the size key is set,
+// or the min and max keys are set to the same size.
+func (c ParsedFlinkConfig) memoryFraction(key string, defaultValue float64, sizeKey, minKey, maxKey string) (MemoryFraction, error) {
+	var fraction = MemoryFraction{Key: key, Value: defaultValue, InEffect: true}
+	if v, ok := c.Get(key); ok {
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return fraction, fmt.Errorf("%s in flinkProperties must be a number, got %q", key, v)
+		}
+		if f < 0 || f >= 1 {
+			return fraction, fmt.Errorf("%s in flinkProperties must be at least 0 and less than 1, got %v", key, v)
+		}
+		fraction.Value = f
+		fraction.Set = true
+	}
+	if sizeKey != "" {
+		if _, ok := c.Get(sizeKey); ok {
+			fraction.InEffect = false
+		}
+	}
+	if minKey != "" {
+		minSize, minOK := c.Get(minKey)
+		maxSize, maxOK := c.Get(maxKey)
+		if minOK && maxOK && strings.EqualFold(minSize, maxSize) {
+			fraction.InEffect = false
+		}
+	}
+	return fraction, nil
+}
+
+// TaskManagerMemoryFractions returns the fractions of the total Flink memory of TaskManagers,
+// the managed memory and the network memory fractions.
+func (c ParsedFlinkConfig) TaskManagerMemoryFractions() ([]MemoryFraction, error) {
+	managed, err := c.memoryFraction(flinkConfigTMManagedFraction, 0.4, flinkConfigTMManagedSize, "", "")
+	if err != nil {
+		return nil, err
+	}
+	network, err := c.memoryFraction(flinkConfigTMNetworkFraction, 0.1, "", flinkConfigTMNetworkMin, flinkConfigTMNetworkMax)
+	if err != nil {
+		return nil, err
+	}
+	return []MemoryFraction{managed, network}, nil
+}
+
+// JVMOverheadFractions returns the JVM overhead fractions of the total process memory of
+// the JobManager and TaskManagers.
+func (c ParsedFlinkConfig) JVMOverheadFractions() ([]MemoryFraction, error) {
+	jm, err := c.memoryFraction(flinkConfigJMJVMOverheadFraction, 0.1, "", flinkConfigJMJVMOverheadMin, flinkConfigJMJVMOverheadMax)
+	if err != nil {
+		return nil, err
+	}
+	tm, err := c.memoryFraction(flinkConfigTMJVMOverheadFraction, 0.1, "", flinkConfigTMJVMOverheadMin, flinkConfigTMJVMOverheadMax)
+	if err != nil {
+		return nil, err
+	}
+	return []MemoryFraction{jm, tm}, nil
+}
+
+// SavepointsDir returns the default savepoint target directory configured in Flink.
+func (c ParsedFlinkConfig) SavepointsDir() string {
+	v, _ := c.GetAny(flinkConfigSavepointsDir, flinkConfigSavepointsDirV2)
+	return v
+}
+
+// CheckpointsDir returns the checkpoint directory configured in Flink.
+func (c ParsedFlinkConfig) CheckpointsDir() string {
+	v, _ := c.GetAny(flinkConfigCheckpointsDir, flinkConfigCheckpointsDirV2)
+	return v
+}
+
+// JobResultStoreDir returns the directory of the job result store: spec.jobResultStore.storageDir
+// takes precedence over the storage path in the Flink properties.
+func (fc *FlinkCluster) JobResultStoreDir() string {
+	if jrs := fc.Spec.JobResultStore; jrs != nil && strings.TrimSpace(jrs.StorageDir) != "" {
+		return strings.TrimSpace(jrs.StorageDir)
+	}
+	v, _ := fc.ParsedFlinkConfig().Get(flinkConfigJobResultStorePath)
+	return v
+}
+
+// IsDurableStorageDir returns true if the directory is on a shared file system which outlives
+// the pods, e.g., `gs://` or `s3://`. Local paths and `file://` URIs are not durable, as they
+// resolve to the container file system of each pod.
+func IsDurableStorageDir(dir string) bool {
+	scheme, _, found := strings.Cut(strings.TrimSpace(dir), "://")
+	return found && scheme != "" && !strings.EqualFold(scheme, "file")
+}
+
+// StorageDirsOverlap returns true if the directories are the same or one is nested in the other.
+func StorageDirsOverlap(a, b string) bool {
+	a = strings.TrimRight(strings.TrimSpace(a), "/")
+	b = strings.TrimRight(strings.TrimSpace(b), "/")
+	if a == "" || b == "" {
+		return false
+	}
+	return a == b || strings.HasPrefix(a, b+"/") || strings.HasPrefix(b, a+"/")
+}
+
+// SavepointsDir returns the directory savepoints are written to: spec.job.savepointsDir
+// takes precedence over the savepoint directory in the Flink properties.
+func (fc *FlinkCluster) SavepointsDir() string {
+	if fc.Spec.Job != nil && !isBlank(fc.Spec.Job.SavepointsDir) {
+		return strings.TrimSpace(*fc.Spec.Job.SavepointsDir)
+	}
+	return fc.ParsedFlinkConfig().SavepointsDir()
+}
+
+// SavepointsConfigured returns true if a savepoint can be triggered for the job,
+// that is, the job has a savepoint target directory either from spec.job.savepointsDir
+// or from the Flink properties. Without it, Flink rejects savepoint requests.
+func (fc *FlinkCluster) SavepointsConfigured() bool {
+	return fc.Spec.Job != nil && fc.SavepointsDir() != ""
+}
+
+// CoordinatedSavepointsDir returns the directory coordinated savepoints are written to:
+// spec.coordinatedSavepoint.savepointsDir takes precedence over the Flink properties.
+func (fc *FlinkCluster) CoordinatedSavepointsDir() string {
+	if cs := fc.Spec.CoordinatedSavepoint; cs != nil && !isBlank(cs.SavepointsDir) {
+		return strings.TrimSpace(*cs.SavepointsDir)
+	}
+	return fc.ParsedFlinkConfig().SavepointsDir()
+}
@@ -0,0 +1,186 @@
+package v1beta1
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestSavepointsConfigured(t *testing.T) {
+	var savepointsDir = "gs://my-bucket/savepoints/"
+	var blank = "  "
+	tests := []struct {
+		name            string
+		job             *JobSpec
+		flinkProperties map[string]string
+		expectedDir     string
+		expected        bool
+	}{
+		{
+			name:     "session cluster",
+			job:      nil,
+			expected: false,
+		},
+		{
+			name:     "no savepoints dir",
+			job:      &JobSpec{},
+			expected: false,
+		},
+		{
+			name:     "blank spec.job.savepointsDir",
+			job:      &JobSpec{SavepointsDir: &blank},
+			expected: false,
+		},
+		{
+			name:        "spec.job.savepointsDir",
+			job:         &JobSpec{SavepointsDir: &savepointsDir},
+			expectedDir: savepointsDir,
+			expected:    true,
+		},
+		{
+			name:            "state.savepoints.dir",
+			job:             &JobSpec{},
+			flinkProperties: map[string]string{"state.savepoints.dir": "gs://other-bucket/savepoints/"},
+			expectedDir:     "gs://other-bucket/savepoints/",
+			expected:        true,
+		},
+		{
+			name:            "execution.checkpointing.savepoint-dir",
+			job:             &JobSpec{},
+			flinkProperties: map[string]string{"execution.checkpointing.savepoint-dir": "gs://other-bucket/savepoints/"},
+			expectedDir:     "gs://other-bucket/savepoints/",
+			expected:        true,
+		},
+		{
+			name:            "spec.job.savepointsDir takes precedence",
+			job:             &JobSpec{SavepointsDir: &savepointsDir},
+			flinkProperties: map[string]string{"state.savepoints.dir": "gs://other-bucket/savepoints/"},
+			expectedDir:     savepointsDir,
+			expected:        true,
+		},
+		{
+			name:            "blank state.savepoints.dir",
+			job:             &JobSpec{},
+			flinkProperties: map[string]string{"state.savepoints.dir": ""},
+			expected:        false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cluster := &FlinkCluster{Spec: FlinkClusterSpec{Job: tt.job, FlinkProperties: tt.flinkProperties}}
+			assert.Equal(t, cluster.SavepointsConfigured(), tt.expected)
+			assert.Equal(t, cluster.SavepointsDir(), tt.expectedDir)
+		})
+	}
+}
+
+func TestStateBackend(t *testing.T) {
+	assert.Equal(t, ParsedFlinkConfig(nil).StateBackend(), StateBackendHashMap)
+	assert.Equal(t, ParsedFlinkConfig{"state.backend": "RocksDB"}.StateBackend(), StateBackendRocksDB)
+	assert.Equal(t, ParsedFlinkConfig{"state.backend.type": "rocksdb", "state.backend": "hashmap"}.StateBackend(), StateBackendRocksDB)
+	assert.Equal(t, ParsedFlinkConfig{"state.backend.type": "org.apache.flink.contrib.streaming.state.EmbeddedRocksDBStateBackendFactory"}.SupportsIncrementalCheckpoints(), true)
+}
+
+func TestCheckpointStorage(t *testing.T) {
+	var storage, set = ParsedFlinkConfig(nil).CheckpointStorage()
+	assert.Equal(t, storage, CheckpointStorageJobManager)
+	assert.Equal(t, set, false)
+	storage, set = ParsedFlinkConfig{"state.checkpoints.dir": "gs://my-bucket/checkpoints"}.CheckpointStorage()
+	assert.Equal(t, storage, CheckpointStorageFileSystem)
+	assert.Equal(t, set, false)
+	storage, set = ParsedFlinkConfig{"state.checkpoint-storage": "JobManager", "state.checkpoints.dir": "gs://my-bucket/checkpoints"}.CheckpointStorage()
+	assert.Equal(t, storage, CheckpointStorageJobManager)
+	assert.Equal(t, set, true)
+}
+
+func TestDefaultMaxParallelism(t *testing.T) {
+	assert.Equal(t, DefaultMaxParallelism(1), int32(128))
+	assert.Equal(t, DefaultMaxParallelism(85), int32(128))
+	assert.Equal(t, DefaultMaxParallelism(86), int32(256))
+	assert.Equal(t, DefaultMaxParallelism(1000), int32(2048))
+	assert.Equal(t, DefaultMaxParallelism(30000), int32(UpperBoundMaxParallelism))
+}
+
+func TestStorageDirs(t *testing.T) {
+	assert.Assert(t, IsDurableStorageDir("gs://my-bucket/dir"))
+	assert.Assert(t, IsDurableStorageDir("s3a://my-bucket/dir"))
+	assert.Assert(t, !IsDurableStorageDir("file:///tmp/dir"))
+	assert.Assert(t, !IsDurableStorageDir("/tmp/dir"))
+
+	assert.Assert(t, StorageDirsOverlap("gs://my-bucket/dir", "gs://my-bucket/dir/"))
+	assert.Assert(t, StorageDirsOverlap("gs://my-bucket/dir/sub", "gs://my-bucket/dir"))
+	assert.Assert(t, StorageDirsOverlap("gs://my-bucket/dir", "gs://my-bucket/dir/sub"))
+	assert.Assert(t, !StorageDirsOverlap("gs://my-bucket/dir", "gs://my-bucket/dir-2"))
+	assert.Assert(t, !StorageDirsOverlap("gs://my-bucket/dir", ""))
+}
+
+func TestConfigChangeRequiresRestart(t *testing.T) {
+	assert.Equal(t, ConfigChangeRequiresRestart("web.refresh-interval"), false)
+	assert.Equal(t, ConfigChangeRequiresRestart("metrics.reporter.prom.port"), false)
+	assert.Equal(t, ConfigChangeRequiresRestart("env.log.max"), false)
+	assert.Equal(t, ConfigChangeRequiresRestart("taskmanager.numberOfTaskSlots"), true)
+	assert.Equal(t, ConfigChangeRequiresRestart("state.backend.type"), true)
+}
+
+func TestNetworkPorts(t *testing.T) {
+	var jmRPCPort int32 = 8001
+	var uiPort int32 = 8004
+	var tmDataPort int32 = 8101
+
+	// Defaults without spec ports.
+	ports, err := (&FlinkCluster{}).NetworkPorts()
+	assert.NilError(t, err)
+	assert.DeepEqual(t, ports, FlinkNetworkPorts{
+		JobManagerRPC:    6123,
+		Blob:             6124,
+		Query:            6125,
+		REST:             8081,
+		TaskManagerRPC:   6122,
+		TaskManagerData:  6121,
+		TaskManagerQuery: 6125,
+	})
+
+	// Flink properties take precedence over spec ports.
+	var cluster = &FlinkCluster{
+		Spec: FlinkClusterSpec{
+			JobManager:  &JobManagerSpec{Ports: JobManagerPorts{RPC: &jmRPCPort, UI: &uiPort}},
+			TaskManager: &TaskManagerSpec{Ports: TaskManagerPorts{Data: &tmDataPort}},
+			FlinkProperties: map[string]string{
+				"jobmanager.rpc.port":  "7123",
+				"blob.server.port":     " 7124 ",
+				"query.server.port":    "7125",
+				"taskmanager.rpc.port": "7122",
+			},
+		},
+	}
+	ports, err = cluster.NetworkPorts()
+	assert.NilError(t, err)
+	assert.DeepEqual(t, ports, FlinkNetworkPorts{
+		JobManagerRPC:    7123,
+		Blob:             7124,
+		Query:            7125,
+		REST:             8004,
+		TaskManagerRPC:   7122,
+		TaskManagerData:  8101,
+		TaskManagerQuery: 7125,
+	})
+
+	// The first port of a port range is used.
+	cluster.Spec.FlinkProperties = map[string]string{"blob.server.port": "50100-50200", "taskmanager.rpc.port": "50300,50400-50500"}
+	ports, err = cluster.NetworkPorts()
+	assert.NilError(t, err)
+	assert.Equal(t, ports.Blob, int32(50100))
+	assert.Equal(t, ports.TaskManagerRPC, int32(50300))
+	assert.DeepEqual(t, ports.PortRanges, []string{"blob.server.port", "taskmanager.rpc.port"})
+	assert.Assert(t, ports.IsPortRange("blob.server.port"))
+	assert.Assert(t, !ports.IsPortRange("rest.port"))
+
+	// Invalid ports, and ranges of properties which do not accept them; the spec port is kept.
+	cluster.Spec.FlinkProperties = map[string]string{"blob.server.port": "50200-50100", "rest.port": "8080-8090"}
+	ports, err = cluster.NetworkPorts()
+	assert.Error(t, err, `blob.server.port in flinkProperties must be a port or port range between 1 and 65535, got "50200-50100", `+
+		`rest.port in flinkProperties must be a single port between 1 and 65535, got "8080-8090"`)
+	assert.Equal(t, ports.Blob, int32(6124))
+	assert.Equal(t, ports.REST, int32(8004))
+}
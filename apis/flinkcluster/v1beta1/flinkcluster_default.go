@@ -17,6 +17,9 @@ limitations under the License.
 package v1beta1
 
 import (
+	"fmt"
+	"strconv"
+
 	"dario.cat/mergo"
 	"github.com/hashicorp/go-version"
 	corev1 "k8s.io/api/core/v1"
@@ -30,6 +33,40 @@ const (
 )
 
 var v10, _ = version.NewVersion("1.10")
+var v114, _ = version.NewVersion("1.14")
+var v115, _ = version.NewVersion("1.15")
+var v117, _ = version.NewVersion("1.17")
+
+// DefaultingConfig holds the operator-wide defaults which the mutating webhook applies to
+// the unset fields of FlinkClusters, so that they are consistent across the fleet.
+// +kubebuilder:object:generate=false
+type DefaultingConfig struct {
+	// Default of spec.job.takeSavepointOnUpdate, nil leaves the field unset.
+	TakeSavepointOnUpdate *bool
+}
+
+// ParseDefaultingConfig parses the operator-wide defaults from the operator flags. An empty
+// value leaves the default unset.
+func ParseDefaultingConfig(takeSavepointOnUpdate string) (DefaultingConfig, error) {
+	var config DefaultingConfig
+	if takeSavepointOnUpdate != "" {
+		v, err := strconv.ParseBool(takeSavepointOnUpdate)
+		if err != nil {
+			return config, fmt.Errorf("invalid default takeSavepointOnUpdate: %v, must be true or false", takeSavepointOnUpdate)
+		}
+		config.TakeSavepointOnUpdate = &v
+	}
+	return config, nil
+}
+
+// Sets the operator-wide defaults for unspecified FlinkCluster properties without overriding
+// the values set in the spec.
+func _SetOperatorDefault(cluster *FlinkCluster, config DefaultingConfig) {
+	if jobSpec := cluster.Spec.Job; jobSpec != nil && jobSpec.TakeSavepointOnUpdate == nil && config.TakeSavepointOnUpdate != nil {
+		var takeSavepointOnUpdate = *config.TakeSavepointOnUpdate
+		jobSpec.TakeSavepointOnUpdate = &takeSavepointOnUpdate
+	}
+}
 
 // Sets default values for unspecified FlinkCluster properties.
 func _SetDefault(cluster *FlinkCluster) {
@@ -43,14 +80,16 @@ func _SetDefault(cluster *FlinkCluster) {
 	if cluster.Spec.JobManager == nil {
 		cluster.Spec.JobManager = &JobManagerSpec{}
 	}
-	_SetJobManagerDefault(cluster.Spec.JobManager, flinkVersion)
 	if cluster.Spec.TaskManager == nil {
 		cluster.Spec.TaskManager = &TaskManagerSpec{}
 	}
-	_SetTaskManagerDefault(cluster.Spec.TaskManager, flinkVersion)
+	// Probes check the RPC ports Flink actually listens on, which flinkProperties may override.
+	ports, _ := cluster.NetworkPorts()
+	_SetJobManagerDefault(cluster.Spec.JobManager, flinkVersion, ports.JobManagerRPC)
+	_SetTaskManagerDefault(cluster.Spec.TaskManager, flinkVersion, ports.TaskManagerRPC)
 }
 
-func _SetJobManagerDefault(jmSpec *JobManagerSpec, flinkVersion *version.Version) {
+func _SetJobManagerDefault(jmSpec *JobManagerSpec, flinkVersion *version.Version, rpcPort int32) {
 	if jmSpec == nil {
 		return
 	}
@@ -74,7 +113,7 @@ func _SetJobManagerDefault(jmSpec *JobManagerSpec, flinkVersion *version.Version
 		var livenessProbe = corev1.Probe{
 			ProbeHandler: corev1.ProbeHandler{
 				TCPSocket: &corev1.TCPSocketAction{
-					Port: intstr.FromInt(int(*jmSpec.Ports.RPC)),
+					Port: intstr.FromInt(int(rpcPort)),
 				},
 			},
 			TimeoutSeconds:      10,
@@ -90,7 +129,7 @@ func _SetJobManagerDefault(jmSpec *JobManagerSpec, flinkVersion *version.Version
 		var readinessProbe = corev1.Probe{
 			ProbeHandler: corev1.ProbeHandler{
 				TCPSocket: &corev1.TCPSocketAction{
-					Port: intstr.FromInt(int(*jmSpec.Ports.RPC)),
+					Port: intstr.FromInt(int(rpcPort)),
 				},
 			},
 			TimeoutSeconds:      10,
@@ -105,7 +144,7 @@ func _SetJobManagerDefault(jmSpec *JobManagerSpec, flinkVersion *version.Version
 	}
 }
 
-func _SetTaskManagerDefault(tmSpec *TaskManagerSpec, flinkVersion *version.Version) {
+func _SetTaskManagerDefault(tmSpec *TaskManagerSpec, flinkVersion *version.Version, rpcPort int32) {
 	if tmSpec == nil {
 		return
 	}
@@ -128,7 +167,7 @@ func _SetTaskManagerDefault(tmSpec *TaskManagerSpec, flinkVersion *version.Versi
 		var livenessProbe = corev1.Probe{
 			ProbeHandler: corev1.ProbeHandler{
 				TCPSocket: &corev1.TCPSocketAction{
-					Port: intstr.FromInt(int(*tmSpec.Ports.RPC)),
+					Port: intstr.FromInt(int(rpcPort)),
 				},
 			},
 			TimeoutSeconds:      10,
@@ -144,7 +183,7 @@ func _SetTaskManagerDefault(tmSpec *TaskManagerSpec, flinkVersion *version.Versi
 		var readinessProbe = corev1.Probe{
 			ProbeHandler: corev1.ProbeHandler{
 				TCPSocket: &corev1.TCPSocketAction{
-					Port: intstr.FromInt(int(*tmSpec.Ports.RPC)),
+					Port: intstr.FromInt(int(rpcPort)),
 				},
 			},
 			TimeoutSeconds:      10,
@@ -17,6 +17,7 @@ limitations under the License.
 package v1beta1
 
 import (
+	"context"
 	"testing"
 
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -368,3 +369,76 @@ func TestSetNonDefault(t *testing.T) {
 		expectedCluster,
 		cmpopts.IgnoreUnexported(resource.Quantity{}))
 }
+
+// Tests probes check the RPC ports set in the Flink properties.
+func TestSetDefaultProbePortsFromFlinkProperties(t *testing.T) {
+	var jmRPCPort int32 = 6123
+	var tmRPCPort int32 = 6122
+	var cluster = FlinkCluster{
+		Spec: FlinkClusterSpec{
+			JobManager:  &JobManagerSpec{Ports: JobManagerPorts{RPC: &jmRPCPort}},
+			TaskManager: &TaskManagerSpec{Ports: TaskManagerPorts{RPC: &tmRPCPort}},
+			FlinkProperties: map[string]string{
+				"jobmanager.rpc.port":  "7123",
+				"taskmanager.rpc.port": "7122",
+			},
+		},
+	}
+
+	_SetDefault(&cluster)
+
+	assert.Equal(t, cluster.Spec.JobManager.LivenessProbe.TCPSocket.Port, intstr.FromInt(7123))
+	assert.Equal(t, cluster.Spec.JobManager.ReadinessProbe.TCPSocket.Port, intstr.FromInt(7123))
+	assert.Equal(t, cluster.Spec.TaskManager.LivenessProbe.TCPSocket.Port, intstr.FromInt(7122))
+	assert.Equal(t, cluster.Spec.TaskManager.ReadinessProbe.TCPSocket.Port, intstr.FromInt(7122))
+}
+
+func TestParseDefaultingConfig(t *testing.T) {
+	config, err := ParseDefaultingConfig("")
+	assert.NilError(t, err)
+	assert.Assert(t, config.TakeSavepointOnUpdate == nil)
+
+	config, err = ParseDefaultingConfig("true")
+	assert.NilError(t, err)
+	assert.Equal(t, *config.TakeSavepointOnUpdate, true)
+
+	_, err = ParseDefaultingConfig("always")
+	assert.Error(t, err, "invalid default takeSavepointOnUpdate: always, must be true or false")
+}
+
+// Tests the operator-wide defaults only apply to the unset fields.
+func TestSetOperatorDefault(t *testing.T) {
+	var enabled = true
+	var disabled = false
+	tests := []struct {
+		name     string
+		config   DefaultingConfig
+		value    *bool
+		expected *bool
+	}{
+		{name: "unset with default", config: DefaultingConfig{TakeSavepointOnUpdate: &enabled}, expected: &enabled},
+		{name: "set with default", config: DefaultingConfig{TakeSavepointOnUpdate: &enabled}, value: &disabled, expected: &disabled},
+		{name: "unset without default"},
+		{name: "set without default", value: &disabled, expected: &disabled},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cluster = &FlinkCluster{Spec: FlinkClusterSpec{Job: &JobSpec{TakeSavepointOnUpdate: tt.value}}}
+			var defaulter = &flinkClusterDefaulter{config: tt.config}
+			assert.NilError(t, defaulter.Default(context.Background(), cluster))
+			assert.DeepEqual(t, cluster.Spec.Job.TakeSavepointOnUpdate, tt.expected)
+		})
+	}
+
+	// The default is not shared between clusters.
+	var config = DefaultingConfig{TakeSavepointOnUpdate: &enabled}
+	var cluster = &FlinkCluster{Spec: FlinkClusterSpec{Job: &JobSpec{}}}
+	_SetOperatorDefault(cluster, config)
+	*cluster.Spec.Job.TakeSavepointOnUpdate = false
+	assert.Equal(t, *config.TakeSavepointOnUpdate, true)
+
+	// Session clusters have no job to default.
+	cluster = &FlinkCluster{}
+	_SetOperatorDefault(cluster, config)
+	assert.Assert(t, cluster.Spec.Job == nil)
+}
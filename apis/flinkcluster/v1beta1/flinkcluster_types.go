@@ -106,14 +106,103 @@ const (
 	JobRestartPolicyFromSavepointOnFailure JobRestartPolicy = "FromSavepointOnFailure"
 )
 
+// FailoverStrategy defines which tasks Flink restarts when a task fails. Flink recovers the
+// job within its run, the job restartPolicy of the operator only applies once the job failed.
+type FailoverStrategy string
+
+const (
+	// FailoverStrategyRegion - restart only the pipelined region of the failed task.
+	FailoverStrategyRegion FailoverStrategy = "region"
+
+	// FailoverStrategyFull - restart all the tasks of the job.
+	FailoverStrategyFull FailoverStrategy = "full"
+)
+
+// ClassloaderResolveOrder defines whether the user code classloader looks classes up in the
+// job JAR and classPath before or after the Flink classpath.
+type ClassloaderResolveOrder string
+
+const (
+	// ClassloaderResolveOrderChildFirst - load the classes of the user code first, the Flink default.
+	ClassloaderResolveOrderChildFirst ClassloaderResolveOrder = "child-first"
+
+	// ClassloaderResolveOrderParentFirst - load the classes of the Flink classpath first.
+	ClassloaderResolveOrderParentFirst ClassloaderResolveOrder = "parent-first"
+)
+
+// JobExecutionMode defines whether the job processes unbounded or bounded sources, which tells
+// whether the completion of the job is expected.
+type JobExecutionMode string
+
+const (
+	// JobExecutionModeStreaming - the job runs until it is stopped, its completion is unexpected.
+	JobExecutionModeStreaming JobExecutionMode = "Streaming"
+
+	// JobExecutionModeBatch - the job completes once its bounded sources are processed.
+	JobExecutionModeBatch JobExecutionMode = "Batch"
+)
+
+// OperatorUIDPolicy defines whether Flink generates the UIDs of the operators the job does not
+// set, by which the state of the operators is mapped when the job is restored.
+type OperatorUIDPolicy string
+
+const (
+	// OperatorUIDPolicyGenerated - generate the missing UIDs from the job graph, the Flink default.
+	// The generated UIDs change with the job graph, which breaks the restore of the state.
+	OperatorUIDPolicyGenerated OperatorUIDPolicy = "Generated"
+
+	// OperatorUIDPolicyRequired - reject the job whose operators do not all set a UID.
+	OperatorUIDPolicyRequired OperatorUIDPolicy = "Required"
+)
+
+// JobStopMode defines how a job is stopped when it is cancelled.
+type JobStopMode string
+
+const (
+	// JobStopModeGraceful - stop the job with a savepoint, requires savepoints to be configured.
+	JobStopModeGraceful JobStopMode = "Graceful"
+
+	// JobStopModeCancel - cancel the job without taking a savepoint.
+	JobStopModeCancel JobStopMode = "Cancel"
+)
+
+// ConfigChangeRestartPolicy defines whether a change of only the Flink properties
+// restarts the job.
+type ConfigChangeRestartPolicy string
+
+const (
+	// ConfigChangeRestartPolicyAlways - restart the job on any Flink properties change.
+	ConfigChangeRestartPolicyAlways ConfigChangeRestartPolicy = "Always"
+
+	// ConfigChangeRestartPolicySensitiveOnly - restart the job only if a changed
+	// property requires a restart to take effect.
+	ConfigChangeRestartPolicySensitiveOnly ConfigChangeRestartPolicy = "SensitiveOnly"
+
+	// ConfigChangeRestartPolicyNever - never restart the job on a Flink properties change.
+	ConfigChangeRestartPolicyNever ConfigChangeRestartPolicy = "Never"
+)
+
+// ReplicaDriftPolicy defines how the operator responds to a workload it manages being
+// scaled to other replicas than the spec, e.g. manually.
+type ReplicaDriftPolicy string
+
+const (
+	// ReplicaDriftPolicyReconcile - scale the workload back to the replicas of the spec.
+	ReplicaDriftPolicyReconcile ReplicaDriftPolicy = "Reconcile"
+
+	// ReplicaDriftPolicyWarn - only emit a warning event, leaving the workload scaled.
+	ReplicaDriftPolicyWarn ReplicaDriftPolicy = "Warn"
+)
+
 // User requested control
 const (
 	// control annotation key
 	ControlAnnotation = "flinkclusters.flinkoperator.k8s.io/user-control"
 
 	// control name
-	ControlNameSavepoint = "savepoint"
-	ControlNameJobCancel = "job-cancel"
+	ControlNameSavepoint            = "savepoint"
+	ControlNameJobCancel            = "job-cancel"
+	ControlNameCoordinatedSavepoint = "coordinated-savepoint"
 
 	// control state
 	ControlStateRequested  = "Requested"
@@ -122,6 +211,146 @@ const (
 	ControlStateFailed     = "Failed"
 )
 
+// SkipSavepointOnNextUpdateAnnotation skips the savepoint for the next job update only.
+// The value is a nonce; once an update has been applied with the nonce, the nonce is
+// recorded in the job status and has no further effect. Set a new nonce to skip again.
+const SkipSavepointOnNextUpdateAnnotation = "flinkclusters.flinkoperator.k8s.io/skip-savepoint-on-next-update"
+
+// RestartTriggerAnnotation restarts the running job from a savepoint when its value, a nonce,
+// changes, e.g., to pick up a refreshed external config. The nonce is recorded in the job
+// status when the job is submitted again and has no further effect. Set a new nonce to
+// restart again.
+const RestartTriggerAnnotation = "flinkclusters.flinkoperator.k8s.io/restart-trigger"
+
+// ReconcilePausedAnnotation pauses the reconciliation of the cluster when set to "true".
+// While paused, the operator takes no action on the cluster and its Flink jobs, e.g.,
+// restarts, updates and savepoints, and only reports the Paused condition.
+const ReconcilePausedAnnotation = "flinkclusters.flinkoperator.k8s.io/reconcile-paused"
+
+// GracefulShutdownAnnotation drains the cluster when set to "true": the running jobs are
+// stopped with a savepoint, and the operator takes no other action on the cluster until the
+// annotation is removed, so that the cluster can be torn down without them being restarted.
+const GracefulShutdownAnnotation = "flinkclusters.flinkoperator.k8s.io/graceful-shutdown"
+
+// EstimatedMonthlyCostAnnotation is set by the operator to the estimated monthly cost of the
+// resources requested by the cluster, when the operator is configured with a price list.
+const EstimatedMonthlyCostAnnotation = "flinkclusters.flinkoperator.k8s.io/estimated-monthly-cost"
+
+// TeardownFinalizer is added to the clusters by the operator to tear down a deleted
+// cluster in order: the job is stopped with a final savepoint before the pods are deleted,
+// and the HA ConfigMap and PersistentVolumeClaims are deleted after the pods are gone.
+const TeardownFinalizer = "flinkclusters.flinkoperator.k8s.io/teardown"
+
+// JobManagerReadySchedulingGate gates the scheduling of the TaskManager pods with
+// `taskManager.waitForJobManager`, until the operator removes it once the JobManager is ready.
+const JobManagerReadySchedulingGate = "flinkoperator.k8s.io/jobmanager-ready"
+
+// Cluster condition types and reasons.
+const (
+	// ClusterConditionConfigDrift is true when the Flink configuration reported by the
+	// running JobManager differs from the configuration rendered from the spec.
+	ClusterConditionConfigDrift = "ConfigDrift"
+
+	ConfigDriftReasonDetected = "RunningConfigDiffers"
+	ConfigDriftReasonNone     = "RunningConfigMatches"
+
+	// ClusterConditionPodsUnschedulable is true when JobManager or TaskManager pods
+	// have been pending for a while because the scheduler cannot place them.
+	ClusterConditionPodsUnschedulable = "PodsUnschedulable"
+
+	PodsUnschedulableReasonInsufficientResources = "InsufficientResources"
+	PodsUnschedulableReasonUnsatisfiableAffinity = "UnsatisfiableAffinity"
+	PodsUnschedulableReasonUnboundVolumeClaim    = "UnboundPersistentVolumeClaim"
+	PodsUnschedulableReasonUnschedulable         = "Unschedulable"
+	PodsUnschedulableReasonNone                  = "PodsScheduled"
+
+	// ClusterConditionTaskManagerRPCUnreachable is true when TaskManagers repeatedly
+	// restart because they cannot connect or register to the JobManager RPC.
+	ClusterConditionTaskManagerRPCUnreachable = "TaskManagerRPCUnreachable"
+
+	TaskManagerRPCUnreachableReasonRegistrationFailing = "RegistrationFailing"
+	TaskManagerRPCUnreachableReasonNone                = "TaskManagersReachable"
+
+	// ClusterConditionImageArchitectureConflict is true when no node of the cluster has the
+	// architecture the image is declared for, so that its pods cannot be scheduled.
+	ClusterConditionImageArchitectureConflict = "ImageArchitectureConflict"
+
+	ImageArchitectureConflictReasonNoMatchingNodes = "NoMatchingNodes"
+	ImageArchitectureConflictReasonNone            = "MatchingNodesFound"
+
+	// ClusterConditionUnexpectedJobCompletion is true when the job of the `Streaming` execution
+	// mode completed without being stopped by the operator.
+	ClusterConditionUnexpectedJobCompletion = "UnexpectedJobCompletion"
+
+	UnexpectedJobCompletionReasonStreamingJobCompleted = "StreamingJobCompleted"
+	UnexpectedJobCompletionReasonNone                  = "JobNotCompleted"
+
+	// ClusterConditionOperatorUIDsChanged is true when operator UIDs of the previous job run
+	// are missing in the job restored from its savepoint, so that their state is not restored.
+	ClusterConditionOperatorUIDsChanged = "OperatorUIDsChanged"
+
+	OperatorUIDsChangedReasonRemoved = "OperatorUIDsRemoved"
+	OperatorUIDsChangedReasonNone    = "OperatorUIDsStable"
+
+	// ClusterConditionPaused is true while the reconciliation of the cluster is paused
+	// with the reconcile-paused annotation.
+	ClusterConditionPaused = "Paused"
+
+	PausedReasonAnnotation = "ReconcilePausedAnnotation"
+	PausedReasonResumed    = "ReconcileResumed"
+
+	// ClusterConditionSavepointAvailable reports whether the savepoint the job waits for
+	// with `waitForSavepoint` is available to restore the job from.
+	ClusterConditionSavepointAvailable = "SavepointAvailable"
+
+	SavepointAvailableReasonFound    = "SavepointFound"
+	SavepointAvailableReasonWaiting  = "WaitingForSavepoint"
+	SavepointAvailableReasonTimedOut = "SavepointWaitTimedOut"
+
+	// ClusterConditionSavepointValidated reports whether the savepoint validation webhook
+	// approved the savepoint the job is restored from.
+	ClusterConditionSavepointValidated = "SavepointValidated"
+
+	SavepointValidatedReasonApproved   = "SavepointApproved"
+	SavepointValidatedReasonRejected   = "SavepointRejected"
+	SavepointValidatedReasonFailed     = "ValidationFailed"
+	SavepointValidatedReasonFailedOpen = "ValidationFailedOpen"
+
+	// ClusterConditionSavepointSkipped is true when the job was updated without taking a
+	// savepoint, so the state since the latest savepoint may have been lost.
+	ClusterConditionSavepointSkipped = "SavepointSkipped"
+
+	SavepointSkippedReasonAnnotation     = "SkipSavepointAnnotation"
+	SavepointSkippedReasonBudgetExceeded = "UpdateDowntimeBudgetExceeded"
+	SavepointSkippedReasonDisabled       = "TakeSavepointOnUpdateDisabled"
+
+	// ClusterConditionPendingAction reports the next action the operator intends to take
+	// on the cluster, and is false when no action is pending.
+	ClusterConditionPendingAction = "PendingAction"
+
+	PendingActionReasonRestartJob        = "RestartJob"
+	PendingActionReasonUpdate            = "UpdateCluster"
+	PendingActionReasonSavepoint         = "TakeSavepoint"
+	PendingActionReasonWaitForSavepoint  = "WaitForSavepoint"
+	PendingActionReasonWaitForScheduling = "WaitForScheduling"
+	PendingActionReasonNone              = "None"
+
+	// ClusterConditionJobsDrained reports the graceful shutdown requested with the
+	// graceful-shutdown annotation, and is true once no job of the cluster is running.
+	ClusterConditionJobsDrained = "JobsDrained"
+
+	JobsDrainedReasonDraining = "Draining"
+	JobsDrainedReasonDrained  = "Drained"
+	JobsDrainedReasonTimedOut = "DrainTimedOut"
+
+	// ClusterConditionJobManagerReplicaDrift is true while the JobManager StatefulSet is scaled
+	// to other replicas than the spec and left as is with the `Warn` replica drift policy.
+	ClusterConditionJobManagerReplicaDrift = "JobManagerReplicaDrift"
+
+	JobManagerReplicaDriftReasonDrifted = "ReplicasDrifted"
+	JobManagerReplicaDriftReasonNone    = "ReplicasMatchSpec"
+)
+
 // Savepoint status
 type SavepointReason string
 
@@ -135,6 +364,17 @@ const (
 	SavepointReasonJobCancel     SavepointReason = "job cancel"
 	SavepointReasonScheduled     SavepointReason = "scheduled"
 	SavepointReasonUpdate        SavepointReason = "update"
+	SavepointReasonDelete        SavepointReason = "delete"
+	SavepointReasonRestart       SavepointReason = "restart"
+)
+
+// RestoreKind is the kind of state a job is restored from.
+type RestoreKind string
+
+const (
+	RestoreKindSavepoint  RestoreKind = "Savepoint"
+	RestoreKindCheckpoint RestoreKind = "Checkpoint"
+	RestoreKindNone       RestoreKind = "None"
 )
 
 // SavepointFormatType specifies the binary format of a savepoint.
@@ -161,8 +401,27 @@ type ImageSpec struct {
 	// _(Optional)_ Secrets for image pull.
 	// [More info](https://kubernetes.io/docs/tasks/configure-pod-container/pull-image-private-registry/#create-a-pod-that-uses-your-secret)
 	PullSecrets []corev1.LocalObjectReference `json:"pullSecrets,omitempty"`
+
+	// _(Optional)_ The CPU architecture the image is built for, one of `amd64, arm64, multi-arch`.
+	// The JobManager, TaskManager and job submitter pods are scheduled to the nodes with the
+	// matching `kubernetes.io/arch` label. A `multi-arch` image, whose manifest list covers
+	// several architectures, is not constrained.
+	// +kubebuilder:validation:Enum=amd64;arm64;multi-arch
+	Architecture *ImageArchitecture `json:"architecture,omitempty"`
 }
 
+// ImageArchitecture is the CPU architecture a Flink image is built for.
+type ImageArchitecture string
+
+const (
+	// ImageArchitectureAMD64 - the image runs on x86-64 nodes.
+	ImageArchitectureAMD64 ImageArchitecture = "amd64"
+	// ImageArchitectureARM64 - the image runs on 64-bit ARM nodes.
+	ImageArchitectureARM64 ImageArchitecture = "arm64"
+	// ImageArchitectureMultiArch - the image is a multi-arch manifest list and runs on any node.
+	ImageArchitectureMultiArch ImageArchitecture = "multi-arch"
+)
+
 // NamedPort defines the container port properties.
 type NamedPort struct {
 	// _(Optional)_ If specified, this must be an IANA_SVC_NAME and unique within the pod. Each
@@ -181,7 +440,8 @@ type NamedPort struct {
 	Protocol string `json:"protocol,omitempty"`
 }
 
-// JobManagerPorts defines ports of JobManager.
+// JobManagerPorts defines ports of JobManager. The jobmanager.rpc.port, blob.server.port,
+// query.server.port and rest.port Flink properties take precedence over these ports.
 type JobManagerPorts struct {
 	// RPC port, default: `6123`.
 	// +kubebuilder:validation:Minimum=1
@@ -233,6 +493,15 @@ type JobManagerSpec struct {
 	// +kubebuilder:validation:Maximum=1
 	Replicas *int32 `json:"replicas,omitempty"`
 
+	// _(Optional)_ How the operator responds when the JobManager StatefulSet is scaled to other
+	// replicas than `replicas`, e.g. manually, default: `Reconcile`.
+	// `Reconcile`: scales the StatefulSet back to `replicas`.
+	// `Warn`: only reports the drift with the `JobManagerReplicaDrift` condition and a warning event.
+	// The StatefulSet is left as is while the reconciliation is paused with the reconcile-paused annotation.
+	// +kubebuilder:default:=Reconcile
+	// +kubebuilder:validation:Enum=Reconcile;Warn
+	ReplicaDriftPolicy *ReplicaDriftPolicy `json:"replicaDriftPolicy,omitempty"`
+
 	// Access scope, default: `Cluster`.
 	// `Cluster`: accessible from within the same cluster.
 	// `VPC`: accessible from within the same VPC.
@@ -338,9 +607,62 @@ type JobManagerSpec struct {
 	// _(Optional)_ Adding entries to JobManager pod /etc/hosts with HostAliases
 	// [More info](https://kubernetes.io/docs/tasks/network/customize-hosts-file-for-pods/)
 	HostAliases []corev1.HostAlias `json:"hostAliases,omitempty"`
+
+	// _(Optional)_ Hook run in the JobManager container before it is stopped, which must complete
+	// within the termination grace period of 60 seconds. If omitted, the hook sleeps for 30 seconds.
+	// [More info](https://kubernetes.io/docs/concepts/containers/container-lifecycle-hooks/)
+	PreStop *corev1.LifecycleHandler `json:"preStop,omitempty"`
+
+	// _(Optional)_ Rescale settings of the adaptive scheduler. Requires the adaptive scheduler,
+	// `jobmanager.scheduler: adaptive` or `scheduler-mode: reactive` in `flinkProperties`.
+	AdaptiveScheduler *AdaptiveSchedulerSpec `json:"adaptiveScheduler,omitempty"`
+
+	// _(Optional)_ Which tasks Flink restarts when a task fails, `jobmanager.execution.failover-strategy`.
+	// If omitted, `region` is set for the streaming jobs and the Flink default applies otherwise.
+	// Flink restarts the tasks within the job run, the job `restartPolicy` only applies once the
+	// restart strategy of Flink is exhausted and the job failed.
+	// [More info](https://nightlies.apache.org/flink/flink-docs-stable/docs/ops/state/task_failure_recovery/#failover-strategies)
+	// +kubebuilder:validation:Enum=region;full
+	FailoverStrategy *FailoverStrategy `json:"failoverStrategy,omitempty"`
+}
+
+// RestartEscalationSpec defines how the restarts of a failed job escalate from the restart
+// strategy of Flink to the operator, expanded into the `restart-strategy.*` Flink properties.
+type RestartEscalationSpec struct {
+	// The restarts of the tasks by Flink before the job fails and the operator steps in,
+	// `restart-strategy.fixed-delay.attempts`. With 0, the operator restarts the job on the
+	// first failure.
+	// +kubebuilder:validation:Minimum=0
+	FlinkRestartAttempts int32 `json:"flinkRestartAttempts"`
+
+	// _(Optional)_ The delay in seconds between the restarts by Flink,
+	// `restart-strategy.fixed-delay.delay`. If omitted, the Flink default applies.
+	// +kubebuilder:validation:Minimum=0
+	FlinkRestartDelaySeconds *int32 `json:"flinkRestartDelaySeconds,omitempty"`
+}
+
+// AdaptiveSchedulerSpec defines how the adaptive scheduler rescales the jobs, expanded into the
+// `jobmanager.adaptive-scheduler.*` Flink properties.
+type AdaptiveSchedulerSpec struct {
+	// _(Optional)_ The minimum increase of the parallelism for a job to scale up,
+	// `jobmanager.adaptive-scheduler.min-parallelism-increase`.
+	// +kubebuilder:validation:Minimum=1
+	MinParallelismIncrease *int32 `json:"minParallelismIncrease,omitempty"`
+
+	// _(Optional)_ The maximum time in seconds to wait for the resources to run the job with its
+	// parallelism before running it with fewer resources, `jobmanager.adaptive-scheduler.resource-wait-timeout`.
+	// +kubebuilder:validation:Minimum=1
+	ResourceWaitTimeoutSeconds *int32 `json:"resourceWaitTimeoutSeconds,omitempty"`
+
+	// _(Optional)_ The time in seconds the available resources must be stable before the job runs or
+	// rescales with them, `jobmanager.adaptive-scheduler.resource-stabilization-timeout`.
+	// It must not exceed `resourceWaitTimeoutSeconds`.
+	// +kubebuilder:validation:Minimum=0
+	ResourceStabilizationTimeoutSeconds *int32 `json:"resourceStabilizationTimeoutSeconds,omitempty"`
 }
 
-// TaskManagerPorts defines ports of TaskManager.
+// TaskManagerPorts defines ports of TaskManager. The taskmanager.data.port,
+// taskmanager.rpc.port and query.server.port Flink properties take precedence over these ports.
 type TaskManagerPorts struct {
 	// Data port, default: `6121`.
 	// +kubebuilder:validation:Minimum=1
@@ -502,9 +824,139 @@ type TaskManagerSpec struct {
 	// [More info](https://kubernetes.io/docs/tasks/network/customize-hosts-file-for-pods/)
 	HostAliases []corev1.HostAlias `json:"hostAliases,omitempty"`
 
+	// _(Optional)_ Hook run in the TaskManager container before it is stopped, which must complete
+	// within the termination grace period of 60 seconds. If omitted, the hook sleeps for 30 seconds,
+	// so that a TaskManager keeps running its tasks, e.g. to acknowledge an in-flight checkpoint,
+	// while the job is drained or cancelled.
+	// [More info](https://kubernetes.io/docs/concepts/containers/container-lifecycle-hooks/)
+	PreStop *corev1.LifecycleHandler `json:"preStop,omitempty"`
+
 	// _(Optional)_ HorizontalPodAutoscaler for TaskManager.
 	// [More info](https://kubernetes.io/docs/tasks/run-application/horizontal-pod-autoscale/)
 	HorizontalPodAutoscaler *HorizontalPodAutoscalerSpec `json:"horizontalPodAutoscaler,omitempty"`
+
+	// _(Optional)_ Enables Flink fine-grained resource management with the slot resource profiles
+	// of each TaskManager. For Flink 1.14+.
+	// [More info](https://nightlies.apache.org/flink/flink-docs-stable/docs/deployment/finegrained_resource/)
+	FineGrainedResources *FineGrainedResourcesSpec `json:"fineGrainedResources,omitempty"`
+
+	// _(Optional)_ External resources of each TaskManager, e.g., GPUs, exposed to the
+	// operators through the Flink external resource framework. Each resource must be
+	// requested with the same amount in `resources`.
+	// [More info](https://nightlies.apache.org/flink/flink-docs-stable/docs/deployment/advanced/external_resources/)
+	ExternalResources []ExternalResourceSpec `json:"externalResources,omitempty"`
+
+	// _(Optional)_ Enables task-local recovery, which restores the state of the tasks from a local
+	// copy on the TaskManager after a failover instead of downloading it from the checkpoint storage.
+	// The local copy is kept in a TaskManager volume, which is provisioned if not specified.
+	// [More info](https://nightlies.apache.org/flink/flink-docs-stable/docs/ops/state/large_state_tuning/#task-local-recovery)
+	LocalRecovery *LocalRecoverySpec `json:"localRecovery,omitempty"`
+
+	// _(Optional)_ Memory and performance options of the RocksDB state backend, expanded into the
+	// `state.backend.rocksdb.*` Flink properties. Requires `state.backend.type: rocksdb` in `flinkProperties`.
+	// [More info](https://nightlies.apache.org/flink/flink-docs-stable/docs/ops/state/large_state_tuning/#tuning-rocksdb-memory)
+	RocksDB *RocksDBOptions `json:"rocksDB,omitempty"`
+
+	// _(Optional)_ Gates the scheduling of the TaskManager pods until the JobManager is ready, so that
+	// the TaskManagers do not crash-loop while waiting for it, default: `false`. With high availability,
+	// the pods wait until a JobManager is elected leader. Requires Kubernetes 1.27+.
+	// [More info](https://kubernetes.io/docs/concepts/scheduling-eviction/pod-scheduling-readiness/)
+	WaitForJobManager *bool `json:"waitForJobManager,omitempty"`
+}
+
+// RocksDBOptions defines the memory and performance options of the RocksDB state backend.
+type RocksDBOptions struct {
+	// _(Optional)_ Whether RocksDB uses the managed memory of the slots, `state.backend.rocksdb.memory.managed`,
+	// default: `true`.
+	ManagedMemory *bool `json:"managedMemory,omitempty"`
+
+	// _(Optional)_ Fixed memory shared by the RocksDB instances of each slot instead of the managed
+	// memory, `state.backend.rocksdb.memory.fixed-per-slot`.
+	FixedMemoryPerSlot *resource.Quantity `json:"fixedMemoryPerSlot,omitempty"`
+
+	// _(Optional)_ Percentage of the RocksDB memory for the write buffers,
+	// `state.backend.rocksdb.memory.write-buffer-ratio`, default: `50`.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=99
+	WriteBufferRatio *int32 `json:"writeBufferRatio,omitempty"`
+
+	// _(Optional)_ Percentage of the block cache reserved for the index and filter blocks,
+	// `state.backend.rocksdb.memory.high-prio-pool-ratio`, default: `10`.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=99
+	HighPriorityPoolRatio *int32 `json:"highPriorityPoolRatio,omitempty"`
+
+	// _(Optional)_ Block cache size of each column family, `state.backend.rocksdb.block.cache-size`.
+	// Only applies when the RocksDB memory is not bounded, with `managedMemory: false` and without `fixedMemoryPerSlot`.
+	BlockCacheSize *resource.Quantity `json:"blockCacheSize,omitempty"`
+
+	// _(Optional)_ Write buffer size of each column family, `state.backend.rocksdb.writebuffer.size`.
+	// Only applies when the RocksDB memory is not bounded, with `managedMemory: false` and without `fixedMemoryPerSlot`.
+	WriteBufferSize *resource.Quantity `json:"writeBufferSize,omitempty"`
+}
+
+// LocalRecoverySpec defines the local storage of the task-local recovery of TaskManagers.
+type LocalRecoverySpec struct {
+	// _(Optional)_ Name of the TaskManager volume in `volumes` or `volumeClaimTemplates` to keep
+	// the local state copy in, e.g., a persistent volume to also recover locally after the pod restarts.
+	// The volume is mounted at its mount in `volumeMounts`, or at `/flink-local-recovery` if not mounted.
+	// If not specified, an `emptyDir` volume named `local-recovery` is provisioned.
+	VolumeName *string `json:"volumeName,omitempty"`
+
+	// _(Optional)_ Size limit of the provisioned `emptyDir` volume.
+	SizeLimit *resource.Quantity `json:"sizeLimit,omitempty"`
+}
+
+// ExternalResourceSpec defines an external resource of TaskManagers in Flink.
+type ExternalResourceSpec struct {
+	// Name of the external resource in Flink, e.g., `gpu`.
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9_-]+$`
+	Name string `json:"name"`
+
+	// Kubernetes extended resource backing the external resource, e.g., `nvidia.com/gpu`.
+	ResourceName corev1.ResourceName `json:"resourceName"`
+
+	// Amount of the external resource on each TaskManager.
+	// +kubebuilder:validation:Minimum=1
+	Amount int64 `json:"amount"`
+
+	// _(Optional)_ Factory class of the driver which exposes the resource to the operators.
+	// Defaults to the Flink GPU driver, `org.apache.flink.externalresource.gpu.GPUDriverFactory`,
+	// for GPU resources.
+	DriverFactoryClass *string `json:"driverFactoryClass,omitempty"`
+
+	// _(Optional)_ Parameters of the driver, e.g., `discovery-script.path` of the GPU driver.
+	DriverParams map[string]string `json:"driverParams,omitempty"`
+}
+
+// FineGrainedResourcesSpec defines the fine-grained resource management of TaskManagers.
+type FineGrainedResourcesSpec struct {
+	// Resource profiles of the slots on each TaskManager, passed to the job in the
+	// `FLINK_SLOT_PROFILES` environment variable to declare its slot sharing groups with. The total
+	// cpu of the profiles cannot exceed the TaskManager cpu, and their total memory the task heap,
+	// task off-heap and managed memory of the TaskManager.
+	// +kubebuilder:validation:MinItems=1
+	SlotProfiles []SlotResourceProfile `json:"slotProfiles"`
+
+	// _(Optional)_ Maximum number of slots in the cluster, `slotmanager.number-of-slots.max`.
+	// +kubebuilder:validation:Minimum=1
+	MaxSlots *int32 `json:"maxSlots,omitempty"`
+}
+
+// SlotResourceProfile defines the resources of a group of slots on a TaskManager.
+type SlotResourceProfile struct {
+	// Name of the profile, e.g., the slot sharing group whose requirements the profile matches.
+	Name string `json:"name"`
+
+	// _(Optional)_ Number of slots with this profile on each TaskManager, default: `1`.
+	// +kubebuilder:validation:Minimum=1
+	Slots *int32 `json:"slots,omitempty"`
+
+	// CPU cores of each slot.
+	CPU resource.Quantity `json:"cpu"`
+
+	// Memory of each slot.
+	Memory resource.Quantity `json:"memory"`
 }
 
 // CleanupAction defines the action to take after job finishes.
@@ -544,10 +996,40 @@ type JobSpec struct {
 	// You may add support to more protocol by setting the `java.protocol.handler.pkgs` java option
 	ClassPath []string `json:"classPath,omitempty"`
 
+	// _(Optional)_ Whether the classes of the job JAR and `classPath` are loaded before or after
+	// the classes of the Flink classpath, `classloader.resolve-order`. If omitted, the Flink
+	// default `child-first` applies. The effective value is reported in the job status.
+	// [More info](https://nightlies.apache.org/flink/flink-docs-stable/docs/ops/debugging/debugging_classloading/#inverted-class-loading-and-classloader-resolution-order)
+	// +kubebuilder:validation:Enum=child-first;parent-first
+	ClassloaderResolveOrder *ClassloaderResolveOrder `json:"classloaderResolveOrder,omitempty"`
+
+	// _(Optional)_ Execution mode of the job, `execution.runtime-mode`, one of `Streaming, Batch`.
+	// A `Streaming` job which completes, e.g., as a bounded source ended, is reported with the
+	// `UnexpectedJobCompletion` condition, and handled like a failed job by `restartPolicy` and
+	// `cleanupPolicy.afterJobFails`. If omitted, the completion of the job is a success.
+	// +kubebuilder:validation:Enum=Streaming;Batch
+	ExecutionMode *JobExecutionMode `json:"executionMode,omitempty"`
+
+	// _(Optional)_ Whether the operator UIDs missing in the job are generated, one of
+	// `Generated, Required`, `pipeline.auto-generate-uids`. With `Required`, Flink rejects the
+	// job unless all its operators set a UID. In any case, the operator UIDs of the job graph are
+	// compared across the job runs, and the `OperatorUIDsChanged` condition reports the operators
+	// whose state the job restored from a savepoint cannot find.
+	// +kubebuilder:validation:Enum=Generated;Required
+	OperatorUIDPolicy *OperatorUIDPolicy `json:"operatorUIDPolicy,omitempty"`
+
 	// _(Optional)_ JAR file of the job. It could be a local file or remote URI,
 	// depending on which protocols (e.g., `https://, gs://`) are supported by the Flink image.
 	JarFile *string `json:"jarFile,omitempty"`
 
+	// _(Optional)_ Uploads `jarFile` through the `/jars` endpoint of the Flink REST API and runs
+	// the job by the ID of the uploaded JAR, instead of submitting it with `flink run`, which
+	// uploads the JAR on every submission. The uploaded JAR is recorded with its content hash in
+	// the job status and run again while its content is the same. It is uploaded again when the
+	// JAR changes or the JobManager no longer has it, e.g., after it restarted. Requires the
+	// `Detached` mode and a local or `http(s)://` `jarFile`, and cannot be used with `classPath`.
+	RESTJarUpload *bool `json:"restJarUpload,omitempty"`
+
 	// _(Optional)_ Fully qualified Java class name of the job.
 	ClassName *string `json:"className,omitempty"`
 
@@ -569,6 +1051,16 @@ type JobSpec struct {
 	// If flink job must be restored from the latest available savepoint when Flink job updating, this field must be unspecified.
 	FromSavepoint *string `json:"fromSavepoint,omitempty"`
 
+	// _(Optional)_ Waits for `fromSavepoint` to be available before the job is submitted, instead of
+	// failing the submission, e.g., to migrate a job from another cluster which is still taking the savepoint.
+	// The savepoint is available once a FlinkCluster in the same namespace recorded it as completed.
+	WaitForSavepoint *WaitForSavepointSpec `json:"waitForSavepoint,omitempty"`
+
+	// _(Optional)_ External service which approves each savepoint or checkpoint before the job is
+	// restored from it, e.g., to check its metadata, lineage or approval. The job is not submitted
+	// while the restore source is not approved, which the `SavepointValidated` condition reports.
+	SavepointValidationWebhook *SavepointValidationWebhookSpec `json:"savepointValidationWebhook,omitempty"`
+
 	// Allow non-restored state, default: `false`.
 	// +kubebuilder:default:=false
 	AllowNonRestoredState *bool `json:"allowNonRestoredState,omitempty"`
@@ -582,8 +1074,23 @@ type JobSpec struct {
 
 	// _(Optional)_ Should take savepoint before updating job, default: `true`.
 	// If this is set as false, maxStateAgeToRestoreSeconds must be provided to limit the savepoint age to restore.
+	// When unset, the webhook sets it to the operator default if `--default-take-savepoint-on-update` is configured.
 	TakeSavepointOnUpdate *bool `json:"takeSavepointOnUpdate,omitempty"`
 
+	// _(Optional)_ Downtime budget of the savepoint taken before updating the job, in seconds.
+	// When the savepoint is projected from the durations of the recorded savepoints, or
+	// observed, to take longer, the update proceeds without it and the job is restored
+	// from the latest savepoint available.
+	// +kubebuilder:validation:Minimum=1
+	MaxUpdateDowntimeSeconds *int32 `json:"maxUpdateDowntimeSeconds,omitempty"`
+
+	// _(Optional)_ How the job is stopped when it is cancelled, `Graceful` or `Cancel`.
+	// `Graceful` stops the job with a savepoint and requires `savepointsDir` or
+	// `state.savepoints.dir`; `Cancel` cancels the job without a savepoint.
+	// If unset, the job is stopped with a savepoint only when savepoints are configured.
+	// +kubebuilder:validation:Enum=Graceful;Cancel
+	StopMode *JobStopMode `json:"stopMode,omitempty"`
+
 	// _(Optional)_ Maximum age of the savepoint that allowed to restore state.
 	// This is applied to auto restart on failure, update from stopped state and update without taking savepoint.
 	// If nil, job can be restarted only when the latest savepoint is the final job state (created by "stop with savepoint")
@@ -598,9 +1105,29 @@ type JobSpec struct {
 	// cluster to trigger a new savepoint to `savepointsDir` on demand.
 	SavepointGeneration int32 `json:"savepointGeneration,omitempty"`
 
+	// _(Optional)_ Maximum time the savepoint may take per trigger source, before it is
+	// considered failed. Unset timeouts default to `execution.checkpointing.timeout`.
+	SavepointTimeouts *SavepointTimeoutsSpec `json:"savepointTimeouts,omitempty"`
+
+	// _(Optional)_ Timeout in seconds of the checkpoints of the job, `execution.checkpointing.timeout`.
+	// It must be greater than `execution.checkpointing.interval` and not exceed `maxStateAgeToRestoreSeconds`.
+	// +kubebuilder:validation:Minimum=1
+	CheckpointTimeoutSeconds *int32 `json:"checkpointTimeoutSeconds,omitempty"`
+
+	// _(Optional)_ The maximum number of checkpoints of the job in progress at the same time,
+	// `execution.checkpointing.max-concurrent-checkpoints`.
+	// +kubebuilder:validation:Minimum=1
+	MaxConcurrentCheckpoints *int32 `json:"maxConcurrentCheckpoints,omitempty"`
+
 	// _(Optional)_ Job parallelism; if not set parallelism will be #replicas * #slots.
+	// It must not be greater than `pipeline.max-parallelism` in `flinkProperties`.
 	Parallelism *int32 `json:"parallelism,omitempty"`
 
+	// _(Optional)_ Watermark alignment of the sources of the job. Only `allowUnalignedSourceSplits`
+	// is expanded into a Flink property, the other settings are passed to the job in environment
+	// variables, which it aligns its sources with. Requires Flink 1.15 or later.
+	WatermarkAlignment *WatermarkAlignmentSpec `json:"watermarkAlignment,omitempty"`
+
 	// No logging output to STDOUT, default: `false`.
 	// +kubebuilder:default:=false
 	NoLoggingToStdout *bool `json:"noLoggingToStdout,omitempty"`
@@ -644,6 +1171,27 @@ type JobSpec struct {
 	// +kubebuilder:validation:Enum=Never;FromSavepointOnFailure
 	RestartPolicy *JobRestartPolicy `json:"restartPolicy,omitempty"`
 
+	// _(Optional)_ The number of consecutive failures of the job restored from the same
+	// savepoint, before any newer savepoint is taken, after which the savepoint is marked
+	// poison. The operator then restarts the job from the latest savepoint in the savepoint
+	// inventory which is not poison, or stops restarting it if there is none.
+	// If not specified, the job is restarted from the same savepoint regardless of its failures.
+	// +kubebuilder:validation:Minimum=1
+	MaxRestoreFailures *int32 `json:"maxRestoreFailures,omitempty"`
+
+	// _(Optional)_ Keeps the latest savepoint of the job primed to fail over to, for setups
+	// without high availability. When the job fails and the primed savepoint is still fresh,
+	// the operator restarts the job from it regardless of `restartPolicy`; otherwise the
+	// failure is handled by `restartPolicy`.
+	WarmStandby *WarmStandbySpec `json:"warmStandby,omitempty"`
+
+	// _(Optional)_ Escalates the restarts of a failed job from Flink to the operator. Flink
+	// restarts the tasks first with its `fixed-delay` restart strategy, and only when the attempts
+	// are exhausted and the job failed does the operator restart it from the latest savepoint by
+	// `restartPolicy`, which must be `FromSavepointOnFailure`. A job failing without exhausting
+	// the attempts, e.g., on an error Flink does not recover from, is not restarted.
+	RestartEscalation *RestartEscalationSpec `json:"restartEscalation,omitempty"`
+
 	// The action to take after job finishes.
 	// +kubebuilder:default:={afterJobSucceeds:DeleteCluster, afterJobFails:KeepCluster, afterJobCancelled:DeleteCluster}
 	CleanupPolicy *CleanupPolicy `json:"cleanupPolicy,omitempty"`
@@ -682,6 +1230,110 @@ type JobSpec struct {
 	Mode *JobMode `json:"mode,omitempty"`
 }
 
+// WaitForSavepointSpec defines how the job submission waits for the savepoint to restore from.
+type WaitForSavepointSpec struct {
+	// Maximum time to wait for the savepoint, default: `1800`.
+	// The job is not submitted after the wait timed out, until the spec is changed.
+	// +kubebuilder:default:=1800
+	// +kubebuilder:validation:Minimum=1
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+}
+
+// SavepointValidationWebhookSpec defines the external service which validates the savepoint
+// the job is restored from.
+type SavepointValidationWebhookSpec struct {
+	// The URL the operator posts the namespace and name of the cluster and the savepoint location
+	// to, as JSON with the `namespace`, `cluster` and `savepoint` fields. The service approves the
+	// savepoint by responding `{"approved": true}`, otherwise with `{"approved": false, "reason": "..."}`.
+	URL string `json:"url"`
+
+	// Maximum time to wait for the response, default: `10`.
+	// +kubebuilder:default:=10
+	// +kubebuilder:validation:Minimum=1
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+
+	// How the restore is handled when the call to the service fails or times out, one of
+	// `FailClosed, FailOpen`, default: `FailClosed`. With `FailClosed`, the job is not submitted
+	// until the service approves the savepoint. With `FailOpen`, the job is restored from the savepoint.
+	// +kubebuilder:default:=FailClosed
+	// +kubebuilder:validation:Enum=FailClosed;FailOpen
+	FailurePolicy SavepointValidationFailurePolicy `json:"failurePolicy,omitempty"`
+}
+
+// SavepointValidationFailurePolicy defines how the restore is handled when the savepoint
+// validation webhook fails.
+type SavepointValidationFailurePolicy string
+
+const (
+	// SavepointValidationFailClosed - do not restore from the savepoint until it is approved.
+	SavepointValidationFailClosed SavepointValidationFailurePolicy = "FailClosed"
+
+	// SavepointValidationFailOpen - restore from the savepoint as if it were approved.
+	SavepointValidationFailOpen SavepointValidationFailurePolicy = "FailOpen"
+)
+
+// SavepointTimeoutsSpec defines the savepoint timeout of each trigger source.
+type SavepointTimeoutsSpec struct {
+	// _(Optional)_ Timeout of the savepoint taken to update the job, or to restart it with the
+	// restart-trigger annotation.
+	// +kubebuilder:validation:Minimum=1
+	UpdateSeconds *int32 `json:"updateSeconds,omitempty"`
+
+	// _(Optional)_ Timeout of the savepoint scheduled by `autoSavepointSeconds`.
+	// +kubebuilder:validation:Minimum=1
+	ScheduledSeconds *int32 `json:"scheduledSeconds,omitempty"`
+
+	// _(Optional)_ Timeout of the final savepoint taken when the cluster is deleted.
+	// It is at most `3600` so that the teardown does not hang.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=3600
+	DeleteSeconds *int32 `json:"deleteSeconds,omitempty"`
+
+	// _(Optional)_ Timeout of the savepoint requested by the user, with the `savepoint`
+	// control or `savepointGeneration`.
+	// +kubebuilder:validation:Minimum=1
+	UserRequestedSeconds *int32 `json:"userRequestedSeconds,omitempty"`
+
+	// _(Optional)_ Timeout of the savepoint taken to stop the job with the `job-cancel` control.
+	// +kubebuilder:validation:Minimum=1
+	JobCancelSeconds *int32 `json:"jobCancelSeconds,omitempty"`
+}
+
+// WatermarkAlignmentSpec defines how far the watermarks of the sources of a job may drift
+// apart, so that a fast source does not race ahead of the others. Flink has no option for the
+// group, the max drift and the update interval, they are passed to the main method of the job
+// in the `FLINK_WATERMARK_ALIGNMENT_GROUP`, `FLINK_WATERMARK_ALIGNMENT_MAX_DRIFT_SECONDS` and
+// `FLINK_WATERMARK_ALIGNMENT_UPDATE_INTERVAL_SECONDS` environment variables, which the job
+// passes to `WatermarkStrategy.withWatermarkAlignment` of its sources.
+type WatermarkAlignmentSpec struct {
+	// The alignment group of the sources.
+	// +kubebuilder:validation:MinLength=1
+	Group string `json:"group"`
+
+	// The maximum drift in seconds of the watermark of a source ahead of the lowest watermark of
+	// the group.
+	// +kubebuilder:validation:Minimum=1
+	MaxDriftSeconds int32 `json:"maxDriftSeconds"`
+
+	// _(Optional)_ How often in seconds the watermarks of the group are aligned. It must not
+	// exceed `maxDriftSeconds`.
+	// +kubebuilder:validation:Minimum=1
+	UpdateIntervalSeconds *int32 `json:"updateIntervalSeconds,omitempty"`
+
+	// _(Optional)_ Whether the sources which cannot pause single splits are still aligned as a whole,
+	// `pipeline.watermark-alignment.allow-unaligned-source-splits`. Requires Flink 1.17 or later.
+	AllowUnalignedSourceSplits *bool `json:"allowUnalignedSourceSplits,omitempty"`
+}
+
+// WarmStandbySpec defines the warm standby of a job.
+type WarmStandbySpec struct {
+	// Maximum age of the primed savepoint to fail over to, default: `600`.
+	// An older savepoint is not used for the failover.
+	// +kubebuilder:default:=600
+	// +kubebuilder:validation:Minimum=1
+	MaxSavepointAgeSeconds *int32 `json:"maxSavepointAgeSeconds,omitempty"`
+}
+
 type BatchSchedulerSpec struct {
 	// BatchScheduler name.
 	Name string `json:"name"`
@@ -770,6 +1422,61 @@ type FlinkClusterSpec struct {
 	// Recreate components when updating flinkcluster, default: true.
 	// +kubebuilder:default:=true
 	RecreateOnUpdate *bool `json:"recreateOnUpdate,omitempty"`
+
+	// Whether a job cluster update that only changes `flinkProperties` restarts the job,
+	// default: Always. With `SensitiveOnly`, changes of properties which do not require a
+	// restart, e.g. `web.*` and `metrics.*`, only update the ConfigMap; with `Never`, no
+	// properties change restarts the job. Without a restart, the new properties take
+	// effect the next time the Flink pods restart.
+	// +kubebuilder:default:=Always
+	// +kubebuilder:validation:Enum=Always;SensitiveOnly;Never
+	ConfigChangeRestartPolicy *ConfigChangeRestartPolicy `json:"configChangeRestartPolicy,omitempty"`
+
+	// _(Optional)_ The minimum interval in seconds between polls of the Flink REST API
+	// endpoints which are not required to track the job state, i.e., the job exceptions
+	// of a running job and the JobManager config. Unset or 0 polls them on every reconcile.
+	// +kubebuilder:validation:Minimum=0
+	ObservabilitySamplingSeconds *int32 `json:"observabilitySamplingSeconds,omitempty"`
+
+	// _(Optional)_ Session jobs whose savepoints are triggered together with the
+	// `coordinated-savepoint` user control. Changing it does not update the cluster.
+	CoordinatedSavepoint *CoordinatedSavepointSpec `json:"coordinatedSavepoint,omitempty"`
+
+	// _(Optional)_ Job result store of the high availability services, which records the
+	// results of the completed jobs so that they are not run again after a JobManager
+	// failover. Requires high availability to be enabled in `flinkProperties` and Flink 1.15+.
+	JobResultStore *JobResultStoreSpec `json:"jobResultStore,omitempty"`
+
+	// _(Optional)_ Maximum time in seconds the graceful shutdown requested with the
+	// `graceful-shutdown` annotation may take, default: 600. The jobs which are not stopped
+	// with a savepoint by then are cancelled without one. Changing it does not update the cluster.
+	// +kubebuilder:validation:Minimum=1
+	GracefulShutdownTimeoutSeconds *int32 `json:"gracefulShutdownTimeoutSeconds,omitempty"`
+}
+
+// JobResultStoreSpec defines the job result store, expanded into the `job-result-store.*`
+// Flink properties.
+type JobResultStoreSpec struct {
+	// Durable directory of the job results, e.g., `gs://my-bucket/job-results`. It must differ
+	// from the checkpoint and savepoint directories.
+	// +kubebuilder:validation:MinLength=1
+	StorageDir string `json:"storageDir"`
+
+	// _(Optional)_ Whether the result of a job is deleted once its resources are cleaned up,
+	// default: true. Keeping the results prevents a completed job from running again when HA
+	// data of the cluster is recovered after the cleanup.
+	DeleteOnCommit *bool `json:"deleteOnCommit,omitempty"`
+}
+
+// CoordinatedSavepointSpec defines a group of session jobs whose savepoints are taken together,
+// e.g., the jobs of a pipeline which need a coherent recovery point.
+type CoordinatedSavepointSpec struct {
+	// Names of the running session jobs.
+	// +kubebuilder:validation:MinItems=1
+	JobNames []string `json:"jobNames"`
+
+	// _(Optional)_ Savepoint target directory, default: the savepoint directory in `flinkProperties`.
+	SavepointsDir *string `json:"savepointsDir,omitempty"`
 }
 
 // HadoopConfig defines configs for Hadoop.
@@ -826,6 +1533,9 @@ type JobManagerStatus struct {
 	ReadyReplicas int32 `json:"readyReplicas,omitempty"`
 
 	Ready string `json:"ready"`
+
+	// The QoS class of the JobManager pods, derived from the resources of their containers.
+	QoSClass corev1.PodQOSClass `json:"qosClass,omitempty"`
 }
 
 type TaskManagerStatus struct {
@@ -843,6 +1553,9 @@ type TaskManagerStatus struct {
 
 	Ready string `json:"ready"`
 
+	// The QoS class of the TaskManager pods, derived from the resources of their containers.
+	QoSClass corev1.PodQOSClass `json:"qosClass,omitempty"`
+
 	Selector string `json:"selector"`
 }
 
@@ -922,20 +1635,141 @@ type JobStatus struct {
 	// The savepoint recorded in savepointLocation is the final state of the job.
 	FinalSavepoint bool `json:"finalSavepoint,omitempty"`
 
+	// The latest savepoint primed for the failover when warm standby is enabled.
+	PrimedSavepoint *PrimedSavepoint `json:"primedSavepoint,omitempty"`
+
+	// The latest completed checkpoint of the job retained in the checkpoint storage, which the
+	// job can be restored from like a savepoint.
+	RetainedCheckpoint *RetainedCheckpoint `json:"retainedCheckpoint,omitempty"`
+
 	// The timestamp of the Flink job deployment that creating job submitter.
 	DeployTime string `json:"deployTime,omitempty"`
 
 	// The Flink job started timestamp.
 	StartTime string `json:"startTime,omitempty"`
 
+	// The timestamp the job entered its current state.
+	StateTime string `json:"stateTime,omitempty"`
+
+	// The cumulative time the job spent in each state it left over its life, one entry per state,
+	// accumulated on each state transition. The time in the current state since `stateTime` is added once the job
+	// leaves it.
+	StateDurations []JobStateDuration `json:"stateDurations,omitempty"`
+
 	// The number of restarts.
 	RestartCount int32 `json:"restartCount,omitempty"`
 
+	// The restarts of the job by the operator and of its tasks within Flink.
+	Restarts *JobRestartsStatus `json:"restarts,omitempty"`
+
+	// The number of consecutive failures of the job restored from `fromSavepoint` before any
+	// newer savepoint was taken.
+	RestoreFailureCount int32 `json:"restoreFailureCount,omitempty"`
+
+	// Savepoints marked poison after `maxRestoreFailures` failures of the job restored from
+	// them. The operator does not restart the job from them until the job is updated.
+	PoisonSavepoints []string `json:"poisonSavepoints,omitempty"`
+
 	// Job completion time. Present when job is terminated regardless of its state.
 	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
 
 	// Reasons for the job failure. Present if job state is Failure
 	FailureReasons []string `json:"failureReasons,omitempty"`
+
+	// The nonce of the skip-savepoint-on-next-update annotation which has been
+	// consumed by a completed update.
+	SkipSavepointNonce string `json:"skipSavepointNonce,omitempty"`
+
+	// The nonce of the restart-trigger annotation which the job was last submitted with.
+	RestartTriggerNonce string `json:"restartTriggerNonce,omitempty"`
+
+	// The JAR of the job uploaded to the JobManager with `restJarUpload`, which the next
+	// submission runs again if its content is the same.
+	UploadedJar *UploadedJarStatus `json:"uploadedJar,omitempty"`
+
+	// The operator UIDs of the job graph of the current and the previous job runs.
+	OperatorUIDs *OperatorUIDsStatus `json:"operatorUIDs,omitempty"`
+
+	// The effective `classloader.resolve-order` of the job, as reported by the running
+	// JobManager once observed.
+	ClassloaderResolveOrder string `json:"classloaderResolveOrder,omitempty"`
+}
+
+// UploadedJarStatus is the JAR of the job uploaded to the JobManager through the REST API.
+type UploadedJarStatus struct {
+	// The ID of the JAR in the JobManager.
+	ID string `json:"id"`
+
+	// The SHA-256 hash of the JAR content.
+	Hash string `json:"hash"`
+}
+
+// OperatorUIDsStatus is the operator UIDs of the job graph, observed as the IDs of the job
+// vertices which Flink derives from the UIDs, compared with the previous job run.
+type OperatorUIDsStatus struct {
+	// The ID of the Flink job the UIDs are observed for.
+	JobID string `json:"jobID"`
+
+	// The operator UIDs of the job, sorted.
+	Current []string `json:"current,omitempty"`
+
+	// The operator UIDs of the previous job run, sorted.
+	Previous []string `json:"previous,omitempty"`
+
+	// The operator UIDs of the previous job run missing in the job restored from a savepoint,
+	// whose state cannot be restored.
+	Removed []string `json:"removed,omitempty"`
+}
+
+// JobStateDuration is the cumulative time the job spent in a state.
+type JobStateDuration struct {
+	// The job state.
+	State JobState `json:"state"`
+
+	// The cumulative seconds in the state.
+	Seconds int64 `json:"seconds"`
+}
+
+// JobRestartsStatus is the number of restarts of the job by the operator and by Flink. Task
+// level instability shows in the Flink restarts, job level instability in the operator restarts.
+type JobRestartsStatus struct {
+	// The restarts of the failed job by the operator, the same as restartCount.
+	OperatorRestarts int32 `json:"operatorRestarts"`
+
+	// The restarts of the tasks by Flink within the current job run, from the `numRestarts`
+	// metric of the job.
+	FlinkInternalRestarts int32 `json:"flinkInternalRestarts"`
+
+	// The restarts by the operator and by Flink combined.
+	TotalRestarts int32 `json:"totalRestarts"`
+
+	// Whether the restart strategy of Flink gave up on the failed job, as reported by the root
+	// exception of the job in the Flink API.
+	FlinkRestartsExhausted bool `json:"flinkRestartsExhausted,omitempty"`
+}
+
+// PrimedSavepoint is the savepoint a job with warm standby fails over to.
+type PrimedSavepoint struct {
+	// The ID of the Flink job the savepoint was taken from.
+	JobID string `json:"jobID,omitempty"`
+
+	// Savepoint location.
+	Location string `json:"location"`
+
+	// Savepoint completed timestamp.
+	Time string `json:"time"`
+}
+
+// RetainedCheckpoint is a completed checkpoint retained in the checkpoint storage.
+type RetainedCheckpoint struct {
+	// The ID of the checkpoint.
+	ID int64 `json:"id"`
+
+	// Checkpoint location.
+	Location string `json:"location"`
+
+	// Checkpoint completed timestamp.
+	Time string `json:"time"`
 }
 
 // SavepointStatus is the status of savepoint progress.
@@ -965,6 +1799,65 @@ type SavepointStatus struct {
 	Message string `json:"message,omitempty"`
 }
 
+// SavepointRecord is a successful savepoint kept in the savepoint inventory.
+type SavepointRecord struct {
+	// The savepoint location URI.
+	Location string `json:"location"`
+
+	// The time the savepoint completed.
+	Time string `json:"time,omitempty"`
+
+	// The ID of the Flink job the savepoint was taken from.
+	JobID string `json:"jobID,omitempty"`
+
+	// Savepoint triggered reason.
+	TriggerReason SavepointReason `json:"triggerReason,omitempty"`
+
+	// The Flink version of the cluster which produced the savepoint.
+	FlinkVersion string `json:"flinkVersion,omitempty"`
+
+	// The size of the savepoint in bytes, if reported by Flink.
+	SizeBytes *int64 `json:"sizeBytes,omitempty"`
+
+	// The time the savepoint took from trigger to completion, in seconds.
+	DurationSeconds *int64 `json:"durationSeconds,omitempty"`
+}
+
+// CoordinatedSavepointStatus is the status of a group of savepoints triggered together.
+type CoordinatedSavepointStatus struct {
+	// Savepoint state of the group, succeeded only when the savepoints of all jobs succeeded,
+	// failed as soon as the savepoint of any job failed. The savepoints of the other jobs
+	// are still tracked until they complete.
+	State string `json:"state"`
+
+	// Savepoints triggered time.
+	TriggerTime string `json:"triggerTime,omitempty"`
+
+	// Group status update time.
+	UpdateTime string `json:"updateTime,omitempty"`
+
+	// Savepoint status of each job in the group.
+	Jobs []CoordinatedSavepointJobStatus `json:"jobs,omitempty"`
+
+	// Names of the jobs whose savepoint failed.
+	FailedJobs []string `json:"failedJobs,omitempty"`
+
+	// Group savepoint message.
+	Message string `json:"message,omitempty"`
+}
+
+// CoordinatedSavepointJobStatus is the savepoint status of a job in a coordinated savepoint.
+type CoordinatedSavepointJobStatus struct {
+	// The name of the Flink job.
+	JobName string `json:"jobName"`
+
+	// Savepoint status of the job.
+	Savepoint SavepointStatus `json:"savepoint"`
+
+	// Savepoint location, non-empty when the savepoint succeeded.
+	Location string `json:"location,omitempty"`
+}
+
 type RevisionStatus struct {
 	// When the controller creates new ControllerRevision, it generates hash string from the FlinkCluster spec
 	// which is to be stored in ControllerRevision and uses it to compose the ControllerRevision name.
@@ -1013,6 +1906,96 @@ type JobManagerServiceStatus struct {
 	LoadBalancerIngress []corev1.LoadBalancerIngress `json:"loadBalancerIngress,omitempty"`
 }
 
+// CheckpointAlignmentStatus is the status of the checkpoint alignment of the job.
+type CheckpointAlignmentStatus struct {
+	// The sampled checkpoints, oldest first. At most 5 samples are kept.
+	Samples []CheckpointAlignmentSample `json:"samples,omitempty"`
+
+	// The alignment of the recent aligned checkpoints took half of the checkpoint timeout
+	// or longer, which indicates sustained backpressure.
+	High bool `json:"high,omitempty"`
+}
+
+// SlotRegistrationStatus is the registration of the TaskManager slots with the JobManager
+// and the health of the TaskManager groups.
+type SlotRegistrationStatus struct {
+	// Slot registration and health of each group of TaskManagers.
+	Groups []TaskManagerGroupSlots `json:"groups,omitempty"`
+
+	// Names of the groups whose slot registration has lagged for longer than the
+	// TaskManagers take to register during a normal startup.
+	LaggingGroups []string `json:"laggingGroups,omitempty"`
+
+	// Names of the unhealthy groups. The TaskManagers are healthy when it is empty.
+	UnhealthyGroups []string `json:"unhealthyGroups,omitempty"`
+}
+
+// JobManagerLeaderStatus is the JobManager elected leader by the Kubernetes high availability
+// services of Flink. The last leader is kept while no JobManager is leader, e.g., during a failover.
+type JobManagerLeaderStatus struct {
+	// The identity of the leader election lock held by the leader JobManager.
+	Identity string `json:"identity"`
+
+	// The name of the leader JobManager pod, resolved from the leader address in the HA ConfigMap.
+	Pod string `json:"pod,omitempty"`
+
+	// The time the leader was elected, from which the age of the leadership is measured.
+	ChangeTime string `json:"changeTime,omitempty"`
+
+	// The times the leadership moved from one JobManager to another, oldest first.
+	// At most 5 changes are kept.
+	RecentChanges []string `json:"recentChanges,omitempty"`
+}
+
+// TaskManagerGroupSlots is the slot registration and health of a group of TaskManagers,
+// the TaskManager pods owned by the same workload.
+type TaskManagerGroupSlots struct {
+	// Name of the workload owning the TaskManager pods.
+	Name string `json:"name"`
+
+	// The desired number of TaskManager pods of the group.
+	Replicas int32 `json:"replicas"`
+
+	// The number of TaskManager pods of the group with a Ready condition.
+	ReadyReplicas int32 `json:"readyReplicas"`
+
+	// Slots of the running TaskManager pods of the group.
+	ExpectedSlots int32 `json:"expectedSlots"`
+
+	// Slots registered with the JobManager by the TaskManagers of the group.
+	RegisteredSlots int32 `json:"registeredSlots"`
+
+	// The time the slot registration of the group started lagging, cleared when it catches up.
+	LaggingSince string `json:"laggingSince,omitempty"`
+
+	// The latest error of the TaskManager pods of the group, e.g. a crashing container.
+	LastError string `json:"lastError,omitempty"`
+
+	// The time not all desired pods of the group were ready or their slot registration
+	// started lagging, cleared when the group recovers.
+	UnhealthySince string `json:"unhealthySince,omitempty"`
+
+	// All desired pods of the group are ready and their slot registration is not lagging,
+	// or they have not been for less than the grace period of a normal startup or rolling
+	// update. A group without desired pods is healthy.
+	Healthy bool `json:"healthy"`
+}
+
+// CheckpointAlignmentSample is the alignment of a completed checkpoint.
+type CheckpointAlignmentSample struct {
+	// The ID of the checkpoint.
+	CheckpointID int64 `json:"checkpointID"`
+
+	// The longest alignment duration among the subtasks in milliseconds.
+	AlignmentDurationMillis int64 `json:"alignmentDurationMillis"`
+
+	// The checkpoint was unaligned.
+	Unaligned bool `json:"unaligned,omitempty"`
+
+	// The time the checkpoint was sampled.
+	Time string `json:"time"`
+}
+
 // FlinkClusterStatus defines the observed state of FlinkCluster
 type FlinkClusterStatus struct {
 	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
@@ -1030,9 +2013,35 @@ type FlinkClusterStatus struct {
 	// The status of savepoint progress.
 	Savepoint *SavepointStatus `json:"savepoint,omitempty"`
 
+	// The status of the coordinated savepoint of session jobs.
+	CoordinatedSavepoint *CoordinatedSavepointStatus `json:"coordinatedSavepoint,omitempty"`
+
+	// The latest successful savepoints of the job, oldest first, which can be used to pick
+	// a restore point. At most 10 savepoints are kept.
+	SavepointInventory []SavepointRecord `json:"savepointInventory,omitempty"`
+
+	// The checkpoint alignment of the running job, which grows with backpressure before
+	// checkpoints start failing.
+	CheckpointAlignment *CheckpointAlignmentStatus `json:"checkpointAlignment,omitempty"`
+
+	// The registration of the TaskManager slots with the JobManager.
+	SlotRegistration *SlotRegistrationStatus `json:"slotRegistration,omitempty"`
+
+	// The leader JobManager with the Kubernetes high availability.
+	JobManagerLeader *JobManagerLeaderStatus `json:"jobManagerLeader,omitempty"`
+
 	// The status of revision.
 	Revision RevisionStatus `json:"revision,omitempty"`
 
+	// The latest observations of the cluster.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Last time the Flink REST API endpoints sampled with `observabilitySamplingSeconds` were polled.
+	LastObservabilityPollTime string `json:"lastObservabilityPollTime,omitempty"`
+
 	// Last update timestamp for this status.
 	LastUpdateTime string `json:"lastUpdateTime,omitempty"`
 }
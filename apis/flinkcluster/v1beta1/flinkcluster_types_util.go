@@ -2,19 +2,49 @@ package v1beta1
 
 import (
 	"fmt"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/spotify/flink-on-k8s-operator/internal/util"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 )
 
 const (
 	haConfigType       = "high-availability"
 	haConfigStorageDir = "high-availability.storageDir"
 	haConfigClusterId  = "kubernetes.cluster-id"
+
+	haKubernetesFactory = "org.apache.flink.kubernetes.highavailability.KubernetesHaServicesFactory"
+)
+
+// SavepointInventoryLimit is the maximum number of records in the savepoint inventory.
+const SavepointInventoryLimit = 10
+
+// CheckpointAlignmentSampleLimit is the maximum number of sampled checkpoints in the
+// checkpoint alignment status.
+const CheckpointAlignmentSampleLimit = 5
+
+// JobManagerLeaderChangeLimit is the maximum number of recent leader changes in the
+// JobManager leader status.
+const JobManagerLeaderChangeLimit = 5
+
+// The leadership is flapping when it changed this many times within the churn window.
+const (
+	JobManagerLeaderChurnThreshold = 3
+	JobManagerLeaderChurnWindow    = 10 * time.Minute
 )
 
+// TerminationGracePeriodSeconds is the termination grace period of the JobManager and
+// TaskManager pods, within which their preStop hooks must complete.
+const TerminationGracePeriodSeconds = 60
+
+// DefaultPreStopSleepSeconds is the time the default preStop hook of the JobManager and
+// TaskManager containers sleeps for.
+const DefaultPreStopSleepSeconds = 30
+
 func (j *JobStatus) IsActive() bool {
 	return j != nil &&
 		(j.State == JobStateRunning || j.State == JobStateDeploying)
@@ -41,8 +71,69 @@ func (j *JobStatus) IsStopped() bool {
 			j.IsFailed())
 }
 
-func (j *JobStatus) IsTerminated(spec *JobSpec) bool {
-	return j.IsStopped() && !j.ShouldRestart(spec)
+func (j *JobStatus) IsTerminated(spec *JobSpec, compareTime time.Time) bool {
+	return j.IsStopped() && !j.ShouldRestart(spec, compareTime)
+}
+
+// IsUnexpectedCompletion returns true if the job of the Streaming execution mode succeeded
+// without a final savepoint, that is, it completed on its own rather than being stopped by the
+// operator. The completion of a Batch job, or of a job without execution mode, is a success.
+func (j *JobStatus) IsUnexpectedCompletion(spec *JobSpec) bool {
+	return j != nil && spec != nil && j.State == JobStateSucceeded && !j.FinalSavepoint &&
+		spec.ExecutionMode != nil && *spec.ExecutionMode == JobExecutionModeStreaming
+}
+
+// TimeInState returns the cumulative time the job spent in the state over its life at now,
+// including the time in the current state since stateTime.
+func (j *JobStatus) TimeInState(state JobState, now time.Time) time.Duration {
+	if j == nil {
+		return 0
+	}
+	var total time.Duration
+	for _, d := range j.StateDurations {
+		if d.State == state {
+			total += time.Duration(d.Seconds) * time.Second
+		}
+	}
+	if j.State == state && j.StateTime != "" {
+		total += max(now.Sub(util.GetTime(j.StateTime)), 0)
+	}
+	return total
+}
+
+// RunningTime returns the cumulative time the job spent running at now.
+func (j *JobStatus) RunningTime(now time.Time) time.Duration {
+	return j.TimeInState(JobStateRunning, now)
+}
+
+// FailedTime returns the cumulative time the job spent failed, lost or failed to deploy at now.
+func (j *JobStatus) FailedTime(now time.Time) time.Duration {
+	return j.TimeInState(JobStateFailed, now) + j.TimeInState(JobStateLost, now) +
+		j.TimeInState(JobStateDeployFailed, now)
+}
+
+// Age returns how long the JobManager has been leader at now.
+func (l *JobManagerLeaderStatus) Age(now time.Time) time.Duration {
+	if l == nil || l.ChangeTime == "" {
+		return 0
+	}
+	return max(now.Sub(util.GetTime(l.ChangeTime)), 0)
+}
+
+// IsFlapping returns true if the leadership moved between the JobManagers churn threshold times
+// or more within the churn window before now, e.g., as the leases expire on an overloaded API
+// server. A single failover, or a leader which is lost and elected again, is not flapping.
+func (l *JobManagerLeaderStatus) IsFlapping(now time.Time) bool {
+	if l == nil {
+		return false
+	}
+	var changes int
+	for _, change := range l.RecentChanges {
+		if now.Sub(util.GetTime(change)) <= JobManagerLeaderChurnWindow {
+			changes++
+		}
+	}
+	return changes >= JobManagerLeaderChurnThreshold
 }
 
 // IsSavepointUpToDate check if the recorded savepoint is up-to-date compared to maxStateAgeToRestoreSeconds.
@@ -64,24 +155,198 @@ func (j *JobStatus) IsSavepointUpToDate(spec *JobSpec, compareTime time.Time) bo
 }
 
 // ShouldRestart returns true if the controller should restart failed job.
-// The controller can restart the job if policy is set to FromSavepointOnFailure.
+// The controller can restart the job if policy is set to FromSavepointOnFailure
+// or warm standby has a primed savepoint which is still fresh at compareTime.
 // Job will restart from savepoint if the savepoint was taken successfully.
-func (j *JobStatus) ShouldRestart(spec *JobSpec) bool {
-	if j == nil || !j.IsFailed() || spec == nil {
+// A streaming job which completed unexpectedly is restarted like a failed job.
+func (j *JobStatus) ShouldRestart(spec *JobSpec, compareTime time.Time) bool {
+	if j == nil || spec == nil || !(j.IsFailed() || j.IsUnexpectedCompletion(spec)) {
+		return false
+	}
+
+	restartEnabled := spec.RestartPolicy != nil && *spec.RestartPolicy == JobRestartPolicyFromSavepointOnFailure &&
+		!slices.Contains(j.PoisonSavepoints, j.RestoreSavepoint()) && j.IsRestartEscalated(spec)
+	return restartEnabled || j.IsPrimedSavepointFresh(spec, compareTime)
+}
+
+// IsRestartEscalated returns true if the restart of the failed job is handed over from Flink
+// to the operator. Without restartEscalation, every failure is. With it, a job failed in Flink
+// is only once the restart strategy of Flink gave up on it or the observed Flink restarts reached
+// the attempts, while a lost job or a failed deployment is outside of the Flink restarts.
+func (j *JobStatus) IsRestartEscalated(spec *JobSpec) bool {
+	if spec == nil || spec.RestartEscalation == nil || j.State != JobStateFailed {
+		return true
+	}
+	return j.Restarts != nil && (j.Restarts.FlinkRestartsExhausted ||
+		j.Restarts.FlinkInternalRestarts >= spec.RestartEscalation.FlinkRestartAttempts)
+}
+
+// RestoreSavepoint returns the savepoint the failed job is restarted from: the latest
+// savepoint, or the savepoint from which the job was restored if there is none.
+func (j *JobStatus) RestoreSavepoint() string {
+	if j.SavepointLocation != "" {
+		return j.SavepointLocation
+	}
+	return j.FromSavepoint
+}
+
+// RestoreSourceIsPoison returns true if the job failed threshold times in a row after it was
+// restored from fromSavepoint and before any newer savepoint was taken, that is, restoring
+// from the savepoint crashes the job. A threshold of 0 disables the detection.
+func (j *JobStatus) RestoreSourceIsPoison(threshold int) bool {
+	return j != nil && threshold > 0 && j.FromSavepoint != "" && int(j.RestoreFailureCount) >= threshold
+}
+
+// BestRestoreSource returns the state the job is best restored from at now, the kind of the
+// source and the reason of the choice. The final savepoint of the job, or a savepoint within
+// maxStateAgeToRestoreSeconds, is preferred to a newer checkpoint. Otherwise the newest source
+// among the latest savepoint, the fresh primed savepoint, the savepoint inventory and the
+// retained checkpoint is chosen. Poison sources are skipped even if they are newer.
+func (fc *FlinkCluster) BestRestoreSource(now time.Time) (path string, kind RestoreKind, reason string) {
+	var job = fc.Status.Components.Job
+	var spec = fc.Spec.Job
+	if job == nil {
+		return "", RestoreKindNone, "the job has no savepoint or retained checkpoint"
+	}
+
+	var savepoint = job.RestoreSavepoint()
+	if savepoint != "" && !slices.Contains(job.PoisonSavepoints, savepoint) {
+		if job.FinalSavepoint {
+			return savepoint, RestoreKindSavepoint, fmt.Sprintf("savepoint %s is the final state of the job", savepoint)
+		}
+		if spec != nil && job.IsSavepointUpToDate(spec, now) {
+			return savepoint, RestoreKindSavepoint, fmt.Sprintf("savepoint %s is within maxStateAgeToRestoreSeconds", savepoint)
+		}
+	}
+
+	// The candidates are ordered by reliability, the savepoints are self-contained and
+	// preferred to a checkpoint of the same time.
+	type candidate struct {
+		path string
+		kind RestoreKind
+		time string
+	}
+	var candidates []candidate
+	if savepoint != "" {
+		candidates = append(candidates, candidate{savepoint, RestoreKindSavepoint, job.SavepointTime})
+	}
+	if job.IsPrimedSavepointFresh(spec, now) {
+		candidates = append(candidates, candidate{job.PrimedSavepoint.Location, RestoreKindSavepoint, job.PrimedSavepoint.Time})
+	}
+	for _, record := range fc.Status.SavepointInventory {
+		candidates = append(candidates, candidate{record.Location, RestoreKindSavepoint, record.Time})
+	}
+	if checkpoint := job.RetainedCheckpoint; checkpoint != nil && checkpoint.Location != "" {
+		candidates = append(candidates, candidate{checkpoint.Location, RestoreKindCheckpoint, checkpoint.Time})
+	}
+
+	var best *candidate
+	var bestTime time.Time
+	var skipped []string
+	for i, c := range candidates {
+		if slices.Contains(job.PoisonSavepoints, c.path) {
+			if !slices.Contains(skipped, c.path) {
+				skipped = append(skipped, c.path)
+			}
+			continue
+		}
+		// A source without a valid time, e.g. fromSavepoint, is older than any other.
+		t, _ := time.Parse(time.RFC3339, c.time)
+		if best == nil || t.After(bestTime) {
+			best, bestTime = &candidates[i], t
+		}
+	}
+	if best == nil {
+		if len(skipped) > 0 {
+			return "", RestoreKindNone, fmt.Sprintf("all restore sources are poison: %s", strings.Join(skipped, ", "))
+		}
+		return "", RestoreKindNone, "the job has no savepoint or retained checkpoint"
+	}
+	reason = fmt.Sprintf("%s %s is the newest restore source", strings.ToLower(string(best.kind)), best.path)
+	if len(skipped) > 0 {
+		reason += fmt.Sprintf(", skipped poison %s", strings.Join(skipped, ", "))
+	}
+	return best.path, best.kind, reason
+}
+
+// GetMaxRestoreFailures returns the threshold of the poison savepoint detection, 0 if disabled.
+func (s *JobSpec) GetMaxRestoreFailures() int {
+	if s == nil || s.MaxRestoreFailures == nil {
+		return 0
+	}
+	return int(*s.MaxRestoreFailures)
+}
+
+// IsPrimedSavepointFresh returns true if warm standby is enabled and the primed savepoint
+// is younger than warmStandby.maxSavepointAgeSeconds at compareTime, so the job can fail
+// over to it.
+func (j *JobStatus) IsPrimedSavepointFresh(spec *JobSpec, compareTime time.Time) bool {
+	if j == nil || spec == nil || spec.WarmStandby == nil ||
+		j.PrimedSavepoint == nil || j.PrimedSavepoint.Location == "" || j.PrimedSavepoint.Time == "" {
 		return false
 	}
+	var maxAge = 600
+	if spec.WarmStandby.MaxSavepointAgeSeconds != nil {
+		maxAge = int(*spec.WarmStandby.MaxSavepointAgeSeconds)
+	}
+	return !util.HasTimeElapsed(j.PrimedSavepoint.Time, compareTime, maxAge)
+}
 
-	restartEnabled := spec.RestartPolicy != nil && *spec.RestartPolicy == JobRestartPolicyFromSavepointOnFailure
-	return restartEnabled
+// GetTimeoutSeconds returns the maximum time to wait for the savepoint.
+func (w *WaitForSavepointSpec) GetTimeoutSeconds() int {
+	if w.TimeoutSeconds == nil {
+		return 1800
+	}
+	return int(*w.TimeoutSeconds)
+}
+
+// GetTimeoutSeconds returns the maximum time to wait for the response of the webhook.
+func (w *SavepointValidationWebhookSpec) GetTimeoutSeconds() int {
+	if w.TimeoutSeconds == nil {
+		return 10
+	}
+	return int(*w.TimeoutSeconds)
+}
+
+// FailsOpen returns true if the job is restored from the savepoint when the webhook fails.
+func (w *SavepointValidationWebhookSpec) FailsOpen() bool {
+	return w.FailurePolicy == SavepointValidationFailOpen
+}
+
+// MaxDeleteSavepointTimeoutSeconds bounds the final savepoint of the deleted cluster, so
+// that the teardown does not hang.
+const MaxDeleteSavepointTimeoutSeconds = 3600
+
+// GetSeconds returns the timeout of the savepoint triggered for the reason, nil if unset.
+func (s *SavepointTimeoutsSpec) GetSeconds(reason SavepointReason) *int32 {
+	if s == nil {
+		return nil
+	}
+	switch reason {
+	case SavepointReasonUpdate, SavepointReasonRestart:
+		return s.UpdateSeconds
+	case SavepointReasonScheduled:
+		return s.ScheduledSeconds
+	case SavepointReasonDelete:
+		return s.DeleteSeconds
+	case SavepointReasonUserRequested:
+		return s.UserRequestedSeconds
+	case SavepointReasonJobCancel:
+		return s.JobCancelSeconds
+	}
+	return nil
 }
 
 // UpdateReady returns true if job is ready to proceed update.
-func (j *JobStatus) UpdateReady(spec *JobSpec, observeTime time.Time) bool {
+// When skipSavepoint is true, the update proceeds without waiting for a savepoint.
+func (j *JobStatus) UpdateReady(spec *JobSpec, observeTime time.Time, skipSavepoint bool) bool {
 	var takeSavepointOnUpdate = spec.TakeSavepointOnUpdate == nil || *spec.TakeSavepointOnUpdate
 	switch {
 	case j == nil:
 		fallthrough
 	case !isBlank(spec.FromSavepoint):
+		fallthrough
+	case skipSavepoint:
 		return true
 	case j.IsActive():
 		// When job is active and takeSavepointOnUpdate is true, only after taking savepoint with final job state,
@@ -108,10 +373,171 @@ func (j *JobStatus) UpdateReady(spec *JobSpec, observeTime time.Time) bool {
 	return false
 }
 
+// ProjectedSavepointSeconds returns the projected duration of the next savepoint, the longest
+// duration of the recorded savepoints, and false if no duration is recorded.
+func ProjectedSavepointSeconds(inventory []SavepointRecord) (int64, bool) {
+	var projected int64
+	var ok bool
+	for _, r := range inventory {
+		if r.DurationSeconds != nil && (!ok || *r.DurationSeconds > projected) {
+			projected = *r.DurationSeconds
+			ok = true
+		}
+	}
+	return projected, ok
+}
+
+// ExceedsUpdateDowntimeBudget returns true if the savepoint before the update does not fit in
+// spec.job.maxUpdateDowntimeSeconds: it is projected to take longer from the recorded savepoints,
+// or the savepoint triggered for the update of the current job has not succeeded within it.
+func (fc *FlinkCluster) ExceedsUpdateDowntimeBudget(now time.Time) bool {
+	var jobSpec = fc.Spec.Job
+	if jobSpec == nil || jobSpec.MaxUpdateDowntimeSeconds == nil {
+		return false
+	}
+	var budget = int64(*jobSpec.MaxUpdateDowntimeSeconds)
+
+	var savepoint = fc.Status.Savepoint
+	var job = fc.Status.Components.Job
+	if savepoint != nil && job != nil && savepoint.JobID == job.ID &&
+		savepoint.TriggerReason == SavepointReasonUpdate && savepoint.State != SavepointStateSucceeded &&
+		util.HasTimeElapsed(savepoint.TriggerTime, now, int(budget)) {
+		return true
+	}
+
+	projected, ok := ProjectedSavepointSeconds(fc.Status.SavepointInventory)
+	return ok && projected > budget
+}
+
+// ReconcilePaused returns true if the reconciliation of the cluster is paused with the
+// reconcile-paused annotation.
+func (fc *FlinkCluster) ReconcilePaused() bool {
+	return strings.EqualFold(strings.TrimSpace(fc.Annotations[ReconcilePausedAnnotation]), "true")
+}
+
+// GracefulShutdownRequested returns true if the graceful shutdown of the cluster is requested
+// with the graceful-shutdown annotation.
+func (fc *FlinkCluster) GracefulShutdownRequested() bool {
+	return strings.EqualFold(strings.TrimSpace(fc.Annotations[GracefulShutdownAnnotation]), "true")
+}
+
+// DefaultGracefulShutdownTimeoutSeconds is the maximum time the graceful shutdown of the
+// cluster takes when `gracefulShutdownTimeoutSeconds` is unset.
+const DefaultGracefulShutdownTimeoutSeconds = 600
+
+// GracefulShutdownTimeout returns the maximum time the graceful shutdown of the cluster may take.
+func (fc *FlinkCluster) GracefulShutdownTimeout() time.Duration {
+	if seconds := fc.Spec.GracefulShutdownTimeoutSeconds; seconds != nil {
+		return time.Duration(*seconds) * time.Second
+	}
+	return DefaultGracefulShutdownTimeoutSeconds * time.Second
+}
+
+// SkipSavepointOnNextUpdate returns true if the skip-savepoint-on-next-update annotation
+// carries a nonce which has not been consumed by a previous update.
+func (fc *FlinkCluster) SkipSavepointOnNextUpdate() bool {
+	return fc.PendingSkipSavepointNonce() != ""
+}
+
+// PendingSkipSavepointNonce returns the nonce of the skip-savepoint-on-next-update annotation
+// if it has not been consumed yet, otherwise an empty string.
+func (fc *FlinkCluster) PendingSkipSavepointNonce() string {
+	nonce := strings.TrimSpace(fc.Annotations[SkipSavepointOnNextUpdateAnnotation])
+	if job := fc.Status.Components.Job; job != nil && job.SkipSavepointNonce == nonce {
+		return ""
+	}
+	return nonce
+}
+
+// PendingRestartTriggerNonce returns the nonce of the restart-trigger annotation if the job
+// has not been submitted with it yet, otherwise an empty string.
+func (fc *FlinkCluster) PendingRestartTriggerNonce() string {
+	nonce := strings.TrimSpace(fc.Annotations[RestartTriggerAnnotation])
+	if job := fc.Status.Components.Job; job != nil && job.RestartTriggerNonce == nonce {
+		return ""
+	}
+	return nonce
+}
+
+// NodeArchitecture returns the `kubernetes.io/arch` of the nodes the image runs on, or an
+// empty string if the image is not constrained to an architecture.
+func (fc *FlinkCluster) NodeArchitecture() string {
+	var arch = fc.Spec.Image.Architecture
+	if arch == nil || *arch == ImageArchitectureMultiArch {
+		return ""
+	}
+	return string(*arch)
+}
+
 func (s *SavepointStatus) IsFailed() bool {
 	return s != nil && (s.State == SavepointStateTriggerFailed || s.State == SavepointStateFailed)
 }
 
+// AddSavepointRecord returns the inventory with the record appended, unless its location
+// is already recorded. The oldest records beyond SavepointInventoryLimit are dropped.
+func AddSavepointRecord(inventory []SavepointRecord, record SavepointRecord) []SavepointRecord {
+	if record.Location == "" {
+		return inventory
+	}
+	for _, r := range inventory {
+		if r.Location == record.Location {
+			return inventory
+		}
+	}
+	var newInventory = append(make([]SavepointRecord, 0, len(inventory)+1), inventory...)
+	newInventory = append(newInventory, record)
+	if len(newInventory) > SavepointInventoryLimit {
+		newInventory = newInventory[len(newInventory)-SavepointInventoryLimit:]
+	}
+	return newInventory
+}
+
+func (s *CoordinatedSavepointStatus) IsInProgress() bool {
+	return s != nil && s.State == SavepointStateInProgress
+}
+
+// HasPendingSavepoints returns true if the savepoint of any job in the group is still in
+// progress, even after the group failed.
+func (s *CoordinatedSavepointStatus) HasPendingSavepoints() bool {
+	if s == nil {
+		return false
+	}
+	for _, job := range s.Jobs {
+		if job.Savepoint.State == SavepointStateInProgress {
+			return true
+		}
+	}
+	return false
+}
+
+// Aggregate derives the state of the group from the savepoint status of each job.
+// The group succeeds only when all savepoints succeeded and fails as soon as any
+// savepoint failed, the failed jobs are recorded in FailedJobs. The savepoints still
+// in progress of a failed group are kept polling until they complete.
+func (s *CoordinatedSavepointStatus) Aggregate() {
+	var failedJobs []string
+	var succeeded int
+	for _, job := range s.Jobs {
+		switch {
+		case job.Savepoint.IsFailed():
+			failedJobs = append(failedJobs, job.JobName)
+		case job.Savepoint.State == SavepointStateSucceeded:
+			succeeded++
+		}
+	}
+	s.FailedJobs = failedJobs
+	switch {
+	case len(failedJobs) > 0:
+		s.State = SavepointStateFailed
+		s.Message = fmt.Sprintf("Savepoint failed for jobs: %s", strings.Join(failedJobs, ", "))
+	case len(s.Jobs) > 0 && succeeded == len(s.Jobs):
+		s.State = SavepointStateSucceeded
+		s.Message = ""
+	default:
+		s.State = SavepointStateInProgress
+	}
+}
+
 func (r *RevisionStatus) IsUpdateTriggered() bool {
 	return r.CurrentRevision != r.NextRevision
 }
@@ -128,6 +554,291 @@ func (tm *TaskManagerSpec) GetResources() *corev1.ResourceList {
 	return util.UpperBoundedResourceList(tm.Resources)
 }
 
+// EffectiveQoSClass returns the QoS classes of the JobManager and TaskManager pods, derived from
+// the resources of their containers, including the init containers and sidecars. The class of a
+// component is empty if the component is not specified.
+func (fc *FlinkCluster) EffectiveQoSClass() (jmQoS, tmQoS corev1.PodQOSClass) {
+	if jm := fc.Spec.JobManager; jm != nil {
+		jmQoS = util.GetPodQOSClass(jm.InitContainers,
+			append([]corev1.Container{{Name: "jobmanager", Resources: jm.Resources}}, jm.Sidecars...))
+	}
+	if tm := fc.Spec.TaskManager; tm != nil {
+		tmQoS = util.GetPodQOSClass(tm.InitContainers,
+			append([]corev1.Container{{Name: "taskmanager", Resources: tm.Resources}}, tm.Sidecars...))
+	}
+	return jmQoS, tmQoS
+}
+
+// TotalResourceRequests returns the resources requested by the JobManager and TaskManager pods of
+// the cluster, including their sidecars. The replicas of a component are the observed ones once it
+// is recorded in the status, the replicas of the spec before. A stopped cluster requests nothing.
+func (fc *FlinkCluster) TotalResourceRequests() corev1.ResourceList {
+	var total = corev1.ResourceList{}
+	if fc.Status.State == ClusterStateStopped {
+		return total
+	}
+	if jm := fc.Spec.JobManager; jm != nil {
+		var replicas int32 = 1
+		if jm.Replicas != nil {
+			replicas = *jm.Replicas
+		}
+		if status := fc.Status.Components.JobManager; status != nil {
+			replicas = status.Replicas
+		}
+		util.AddResourceRequests(total,
+			append([]corev1.Container{{Name: "jobmanager", Resources: jm.Resources}}, jm.Sidecars...), replicas)
+	}
+	if tm := fc.Spec.TaskManager; tm != nil {
+		var replicas int32
+		if tm.Replicas != nil {
+			replicas = *tm.Replicas
+		}
+		if status := fc.Status.Components.TaskManager; status != nil {
+			replicas = status.Replicas
+		}
+		util.AddResourceRequests(total,
+			append([]corev1.Container{{Name: "taskmanager", Resources: tm.Resources}}, tm.Sidecars...), replicas)
+	}
+	return total
+}
+
+// HoursPerMonth is the average number of hours in a month.
+const HoursPerMonth = 730
+
+// ResourcePriceList holds the hourly prices of the resources requested by the pods, provided by
+// the operator flags.
+// +kubebuilder:object:generate=false
+type ResourcePriceList struct {
+	// Price of a CPU core per hour.
+	CPUPerHour float64
+	// Price of a GiB of memory per hour.
+	MemoryGiBPerHour float64
+	// Price of a GPU per hour.
+	GPUPerHour float64
+}
+
+// IsZero returns true if no price is set, in which case no cost is estimated.
+func (p ResourcePriceList) IsZero() bool {
+	return p.CPUPerHour == 0 && p.MemoryGiBPerHour == 0 && p.GPUPerHour == 0
+}
+
+// ParseResourcePriceList parses the price list from the operator flags. An empty price is zero.
+func ParseResourcePriceList(cpu, memory, gpu string) (ResourcePriceList, error) {
+	var priceList ResourcePriceList
+	for _, price := range []struct {
+		name  string
+		value string
+		dest  *float64
+	}{
+		{"cpu", cpu, &priceList.CPUPerHour},
+		{"memory", memory, &priceList.MemoryGiBPerHour},
+		{"gpu", gpu, &priceList.GPUPerHour},
+	} {
+		if price.value == "" {
+			continue
+		}
+		v, err := strconv.ParseFloat(price.value, 64)
+		if err != nil || v < 0 {
+			return priceList, fmt.Errorf("invalid %v price per hour: %v, must be a non-negative number", price.name, price.value)
+		}
+		*price.dest = v
+	}
+	return priceList, nil
+}
+
+// ResourceCost is the estimated cost of the resources requested by a cluster.
+// +kubebuilder:object:generate=false
+type ResourceCost struct {
+	Hourly  float64
+	Monthly float64
+}
+
+// EstimatedCost returns the estimated cost of the resources requested by the cluster, priced with
+// the price list. It is a rough estimate which ignores the idle capacity of the nodes.
+func (fc *FlinkCluster) EstimatedCost(priceList ResourcePriceList) ResourceCost {
+	var requests = fc.TotalResourceRequests()
+	var hourly float64
+	for name, quantity := range requests {
+		switch {
+		case name == corev1.ResourceCPU:
+			hourly += quantity.AsApproximateFloat64() * priceList.CPUPerHour
+		case name == corev1.ResourceMemory:
+			hourly += quantity.AsApproximateFloat64() / (1 << 30) * priceList.MemoryGiBPerHour
+		case IsGPUResourceName(name):
+			hourly += quantity.AsApproximateFloat64() * priceList.GPUPerHour
+		}
+	}
+	return ResourceCost{Hourly: hourly, Monthly: hourly * HoursPerMonth}
+}
+
+// GPUDriverFactoryClass is the factory class of the Flink GPU driver.
+const GPUDriverFactoryClass = "org.apache.flink.externalresource.gpu.GPUDriverFactory"
+
+// IsGPUResourceName returns true if the Kubernetes extended resource is a GPU, e.g., `nvidia.com/gpu`.
+func IsGPUResourceName(name corev1.ResourceName) bool {
+	return strings.HasSuffix(string(name), "/gpu")
+}
+
+// GetDriverFactoryClass returns the driver factory class of the external resource; the
+// Flink GPU driver for GPU resources if unset.
+func (r *ExternalResourceSpec) GetDriverFactoryClass() string {
+	if !isBlank(r.DriverFactoryClass) {
+		return strings.TrimSpace(*r.DriverFactoryClass)
+	}
+	if IsGPUResourceName(r.ResourceName) {
+		return GPUDriverFactoryClass
+	}
+	return ""
+}
+
+// LocalRecoveryVolumeName is the name of the volume provisioned for the task-local recovery.
+const LocalRecoveryVolumeName = "local-recovery"
+
+// GetVolumeName returns the name of the volume keeping the local state copy.
+func (r *LocalRecoverySpec) GetVolumeName() string {
+	if !isBlank(r.VolumeName) {
+		return strings.TrimSpace(*r.VolumeName)
+	}
+	return LocalRecoveryVolumeName
+}
+
+// GetSlots returns the number of slots with the profile on each TaskManager.
+func (p *SlotResourceProfile) GetSlots() int32 {
+	if p.Slots == nil {
+		return 1
+	}
+	return *p.Slots
+}
+
+// TotalSlots returns the number of slots on each TaskManager.
+func (s *FineGrainedResourcesSpec) TotalSlots() int32 {
+	var slots int32
+	for i := range s.SlotProfiles {
+		slots += s.SlotProfiles[i].GetSlots()
+	}
+	return slots
+}
+
+// TotalResources returns the resources of all slots on each TaskManager.
+func (s *FineGrainedResourcesSpec) TotalResources() corev1.ResourceList {
+	var cpu, memory resource.Quantity
+	for i := range s.SlotProfiles {
+		var profile = &s.SlotProfiles[i]
+		for n := int32(0); n < profile.GetSlots(); n++ {
+			cpu.Add(profile.CPU)
+			memory.Add(profile.Memory)
+		}
+	}
+	return corev1.ResourceList{corev1.ResourceCPU: cpu, corev1.ResourceMemory: memory}
+}
+
+// The Flink defaults of the TaskManager memory components which are not available to the slots.
+const (
+	tmJvmMetaspaceBytes      int64 = 256 << 20
+	tmJvmOverheadMinBytes    int64 = 192 << 20
+	tmJvmOverheadMaxBytes    int64 = 1 << 30
+	tmNetworkMinBytes        int64 = 64 << 20
+	tmNetworkMaxBytes        int64 = 1 << 30
+	tmFrameworkMemoryBytes   int64 = 256 << 20 // framework heap and off-heap, 128m each
+	tmMemoryFractionPercents int64 = 10        // of the JVM overhead and the network memory
+)
+
+// GetSlotMemoryBudget returns the memory of a TaskManager the slot profiles of the fine-grained
+// resource management share, i.e., the task heap, task off-heap and managed memory, rounded down
+// to MiB. It is the Flink process memory, `memoryProcessRatio` of the container memory, less the
+// JVM metaspace, the JVM overhead, the network and the framework memory with their Flink defaults.
+func (tm *TaskManagerSpec) GetSlotMemoryBudget() resource.Quantity {
+	var ratio int64 = 80
+	if tm.MemoryProcessRatio != nil {
+		ratio = int64(*tm.MemoryProcessRatio)
+	}
+	var clamp = func(bytes, lower, upper int64) int64 {
+		return min(max(bytes, lower), upper)
+	}
+	var process = tm.GetResources().Memory().Value() * ratio / 100
+	var flinkMemory = process - tmJvmMetaspaceBytes -
+		clamp(process*tmMemoryFractionPercents/100, tmJvmOverheadMinBytes, tmJvmOverheadMaxBytes)
+	var budget = flinkMemory - tmFrameworkMemoryBytes -
+		clamp(flinkMemory*tmMemoryFractionPercents/100, tmNetworkMinBytes, tmNetworkMaxBytes)
+	if budget < 0 {
+		budget = 0
+	}
+	return *resource.NewQuantity(budget>>20<<20, resource.BinarySI)
+}
+
+// GetJobParallelism returns the parallelism of the job, #replicas * #slots of the
+// TaskManagers if it is not set in the job spec.
+func (fc *FlinkCluster) GetJobParallelism() (int32, error) {
+	if fc.Spec.Job.Parallelism != nil {
+		return *fc.Spec.Job.Parallelism, nil
+	}
+
+	value, err := fc.GetTaskManagerTaskSlots()
+	if err != nil {
+		return 0, err
+	}
+
+	parallelism := *fc.Spec.TaskManager.Replicas * value
+	return parallelism, nil
+}
+
+// GetTaskManagerTaskSlots returns the number of slots of each TaskManager, half of its
+// CPU cores if it is not set in the Flink properties or with fine-grained resources.
+func (fc *FlinkCluster) GetTaskManagerTaskSlots() (int32, error) {
+	if ts, ok := fc.Spec.FlinkProperties["taskmanager.numberOfTaskSlots"]; ok {
+		parsed, err := strconv.ParseInt(ts, 10, 32)
+		if err != nil {
+			return 0, err
+		}
+		return int32(parsed), nil
+	}
+
+	// With fine-grained resource management, the slots are defined by the slot profiles.
+	if fineGrainedResources := fc.Spec.TaskManager.FineGrainedResources; fineGrainedResources != nil {
+		return fineGrainedResources.TotalSlots(), nil
+	}
+
+	resources := fc.Spec.TaskManager.GetResources()
+	slots := int32(resources.Cpu().Value()) / 2
+	if slots == 0 {
+		return 1, nil
+	}
+	return slots, nil
+}
+
+// AdaptiveSchedulerTMRollPercent is the percentage of the TaskManagers rolled at once when the
+// job runs with the adaptive scheduler, which rescales the job to the remaining slots instead of
+// waiting for the rolled ones.
+const AdaptiveSchedulerTMRollPercent = 50
+
+// MaxConcurrentTMRoll returns how many TaskManagers can be rolled at once by an update while the
+// remaining ones keep enough slots for the parallelism of the job. The job of a session cluster
+// runs elsewhere, so the slots it requires are unknown and the TaskManagers are rolled one at a
+// time. With the adaptive scheduler, up to AdaptiveSchedulerTMRollPercent of the TaskManagers are
+// rolled at once, as the job rescales down during the roll instead of stalling. At least one
+// TaskManager is rolled so that the update progresses. A TaskManager StatefulSet only rolls more
+// than one pod at once with the MaxUnavailableStatefulSet feature gate of Kubernetes enabled,
+// otherwise it rolls one pod at a time whatever the concurrency.
+func (fc *FlinkCluster) MaxConcurrentTMRoll() int32 {
+	if fc.Spec.TaskManager == nil || fc.Spec.TaskManager.Replicas == nil || *fc.Spec.TaskManager.Replicas <= 1 {
+		return 1
+	}
+	var replicas = *fc.Spec.TaskManager.Replicas
+	var spare int32
+	if fc.Spec.Job != nil {
+		slots, err := fc.GetTaskManagerTaskSlots()
+		parallelism, perr := fc.GetJobParallelism()
+		if err == nil && perr == nil && slots > 0 {
+			var required = (parallelism + slots - 1) / slots
+			spare = replicas - required
+		}
+	}
+	if fc.SupportsInPlaceRescale() {
+		spare = max(spare, replicas*AdaptiveSchedulerTMRollPercent/100)
+	}
+	return min(max(spare, 1), replicas)
+}
+
 func (fc *FlinkCluster) IsHighAvailabilityEnabled() bool {
 	if fc.Spec.FlinkProperties == nil {
 		return false
@@ -147,9 +858,35 @@ func (fc *FlinkCluster) IsHighAvailabilityEnabled() bool {
 	return true
 }
 
+// IsKubernetesHighAvailability returns true if high availability is enabled with the Kubernetes
+// HA services, which elect the leader JobManager in the HA ConfigMap.
+func (fc *FlinkCluster) IsKubernetesHighAvailability() bool {
+	if !fc.IsHighAvailabilityEnabled() {
+		return false
+	}
+	var v = strings.TrimSpace(fc.Spec.FlinkProperties[haConfigType])
+	return strings.EqualFold(v, "kubernetes") || v == haKubernetesFactory
+}
+
 func (fc *FlinkCluster) GetHAConfigMapName() string {
 	if !fc.IsHighAvailabilityEnabled() {
 		return ""
 	}
 	return fmt.Sprintf("%s-cluster-config-map", fc.Spec.FlinkProperties[haConfigClusterId])
 }
+
+// GetPreStopSeconds gets how long a preStop hook takes, known when the hook sleeps, through
+// the sleep action or a `sleep <seconds>` command.
+func GetPreStopSeconds(handler *corev1.LifecycleHandler) (int64, bool) {
+	if handler == nil {
+		return 0, false
+	}
+	if handler.Sleep != nil {
+		return handler.Sleep.Seconds, true
+	}
+	if handler.Exec != nil && len(handler.Exec.Command) == 2 && handler.Exec.Command[0] == "sleep" {
+		seconds, err := strconv.ParseInt(handler.Exec.Command[1], 10, 64)
+		return seconds, err == nil
+	}
+	return 0, false
+}
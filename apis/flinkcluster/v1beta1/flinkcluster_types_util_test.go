@@ -17,11 +17,14 @@ limitations under the License.
 package v1beta1
 
 import (
+	"fmt"
 	"testing"
 	"time"
 
 	"github.com/spotify/flink-on-k8s-operator/internal/util"
 	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -111,7 +114,7 @@ func TestShouldRestartJob(t *testing.T) {
 		SavepointTime:     tc.ToString(savepointTime),
 		CompletionTime:    &metav1.Time{Time: jobCompletionTime},
 	}
-	var restart = jobStatus.ShouldRestart(&jobSpec)
+	var restart = jobStatus.ShouldRestart(&jobSpec, time.Now())
 	assert.Equal(t, restart, true)
 
 	// Not restart without savepoint
@@ -123,7 +126,7 @@ func TestShouldRestartJob(t *testing.T) {
 		State:          JobStateFailed,
 		CompletionTime: &metav1.Time{Time: jobCompletionTime},
 	}
-	restart = jobStatus.ShouldRestart(&jobSpec)
+	restart = jobStatus.ShouldRestart(&jobSpec, time.Now())
 	assert.Equal(t, restart, true)
 
 	// Not restart with restartPolicy Never
@@ -137,7 +140,7 @@ func TestShouldRestartJob(t *testing.T) {
 		SavepointTime:     tc.ToString(savepointTime),
 		CompletionTime:    &metav1.Time{Time: jobCompletionTime},
 	}
-	restart = jobStatus.ShouldRestart(&jobSpec)
+	restart = jobStatus.ShouldRestart(&jobSpec, time.Now())
 	assert.Equal(t, restart, false)
 
 	// Not restart with old savepoint
@@ -152,6 +155,842 @@ func TestShouldRestartJob(t *testing.T) {
 		SavepointTime:     tc.ToString(savepointTime),
 		CompletionTime:    &metav1.Time{Time: jobCompletionTime},
 	}
-	restart = jobStatus.ShouldRestart(&jobSpec)
+	restart = jobStatus.ShouldRestart(&jobSpec, time.Now())
 	assert.Equal(t, restart, false)
 }
+
+func TestIsPrimedSavepointFresh(t *testing.T) {
+	var tc = &util.TimeConverter{}
+	var neverRestart = JobRestartPolicyNever
+	var maxSavepointAge = int32(300) // 5 min
+	var now = time.Now()
+	var jobSpec = JobSpec{
+		RestartPolicy: &neverRestart,
+		WarmStandby:   &WarmStandbySpec{MaxSavepointAgeSeconds: &maxSavepointAge},
+	}
+	var jobStatus = JobStatus{
+		State: JobStateFailed,
+		PrimedSavepoint: &PrimedSavepoint{
+			Location: "gs://my-bucket/savepoint-123",
+			Time:     tc.ToString(now.Add(-time.Minute)),
+		},
+	}
+
+	// Fail over to the fresh primed savepoint regardless of restartPolicy.
+	assert.Equal(t, jobStatus.IsPrimedSavepointFresh(&jobSpec, now), true)
+	assert.Equal(t, jobStatus.ShouldRestart(&jobSpec, now), true)
+
+	// Stale primed savepoint falls back to restartPolicy.
+	assert.Equal(t, jobStatus.IsPrimedSavepointFresh(&jobSpec, now.Add(5*time.Minute)), false)
+	jobStatus.PrimedSavepoint.Time = tc.ToString(now.Add(-10 * time.Minute))
+	assert.Equal(t, jobStatus.ShouldRestart(&jobSpec, now), false)
+
+	// Warm standby disabled.
+	jobStatus.PrimedSavepoint.Time = tc.ToString(now.Add(-time.Minute))
+	jobSpec.WarmStandby = nil
+	assert.Equal(t, jobStatus.IsPrimedSavepointFresh(&jobSpec, now), false)
+	assert.Equal(t, jobStatus.ShouldRestart(&jobSpec, now), false)
+}
+
+func TestShouldRestartEscalatedJob(t *testing.T) {
+	var restartOnFailure = JobRestartPolicyFromSavepointOnFailure
+	var jobSpec = JobSpec{
+		RestartPolicy:     &restartOnFailure,
+		RestartEscalation: &RestartEscalationSpec{FlinkRestartAttempts: 3},
+	}
+	var jobStatus = JobStatus{
+		State:             JobStateFailed,
+		SavepointLocation: "gs://my-bucket/savepoint-1",
+		Restarts:          &JobRestartsStatus{FlinkInternalRestarts: 1},
+	}
+
+	// Flink has restarts left, the job failed on an error Flink does not recover from.
+	assert.Equal(t, jobStatus.IsRestartEscalated(&jobSpec), false)
+	assert.Equal(t, jobStatus.ShouldRestart(&jobSpec, time.Now()), false)
+
+	// The restart strategy of Flink gave up on the job.
+	jobStatus.Restarts.FlinkRestartsExhausted = true
+	assert.Equal(t, jobStatus.ShouldRestart(&jobSpec, time.Now()), true)
+
+	// The observed Flink restarts reached the attempts.
+	jobStatus.Restarts = &JobRestartsStatus{FlinkInternalRestarts: 3}
+	assert.Equal(t, jobStatus.ShouldRestart(&jobSpec, time.Now()), true)
+
+	// A lost job is outside of the Flink restarts.
+	jobStatus = JobStatus{State: JobStateLost, SavepointLocation: "gs://my-bucket/savepoint-1"}
+	assert.Equal(t, jobStatus.ShouldRestart(&jobSpec, time.Now()), true)
+
+	// Without escalation, every failure is restarted.
+	jobSpec.RestartEscalation = nil
+	jobStatus = JobStatus{State: JobStateFailed, SavepointLocation: "gs://my-bucket/savepoint-1"}
+	assert.Equal(t, jobStatus.ShouldRestart(&jobSpec, time.Now()), true)
+}
+
+func TestIsUnexpectedCompletion(t *testing.T) {
+	var streaming = JobExecutionModeStreaming
+	var batch = JobExecutionModeBatch
+	var restartOnFailure = JobRestartPolicyFromSavepointOnFailure
+	var succeeded = JobStatus{State: JobStateSucceeded, SavepointLocation: "gs://my-bucket/savepoint-1"}
+
+	// The completion of a streaming job is unexpected, and restarted like a failure.
+	var jobSpec = JobSpec{ExecutionMode: &streaming, RestartPolicy: &restartOnFailure}
+	assert.Equal(t, succeeded.IsUnexpectedCompletion(&jobSpec), true)
+	assert.Equal(t, succeeded.ShouldRestart(&jobSpec, time.Now()), true)
+	assert.Equal(t, succeeded.IsTerminated(&jobSpec, time.Now()), false)
+
+	// Without restart policy, the completed streaming job is terminated.
+	jobSpec.RestartPolicy = nil
+	assert.Equal(t, succeeded.IsUnexpectedCompletion(&jobSpec), true)
+	assert.Equal(t, succeeded.ShouldRestart(&jobSpec, time.Now()), false)
+
+	// The streaming job stopped with a final savepoint by the operator.
+	jobSpec.RestartPolicy = &restartOnFailure
+	var stopped = JobStatus{State: JobStateSucceeded, SavepointLocation: "gs://my-bucket/savepoint-1", FinalSavepoint: true}
+	assert.Equal(t, stopped.IsUnexpectedCompletion(&jobSpec), false)
+	assert.Equal(t, stopped.ShouldRestart(&jobSpec, time.Now()), false)
+
+	// A running streaming job.
+	var running = JobStatus{State: JobStateRunning}
+	assert.Equal(t, running.IsUnexpectedCompletion(&jobSpec), false)
+
+	// The completion of a batch job, or of a job without execution mode, is a success.
+	for _, spec := range []JobSpec{{ExecutionMode: &batch, RestartPolicy: &restartOnFailure}, {RestartPolicy: &restartOnFailure}} {
+		assert.Equal(t, succeeded.IsUnexpectedCompletion(&spec), false)
+		assert.Equal(t, succeeded.ShouldRestart(&spec, time.Now()), false)
+		assert.Equal(t, succeeded.IsTerminated(&spec, time.Now()), true)
+	}
+}
+
+func TestJobManagerLeaderAge(t *testing.T) {
+	var now = time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	var leader = &JobManagerLeaderStatus{Identity: "leader-a", ChangeTime: "2024-05-01T09:45:00Z"}
+	assert.Equal(t, leader.Age(now), 15*time.Minute)
+	leader.ChangeTime = ""
+	assert.Equal(t, leader.Age(now), time.Duration(0))
+	leader = nil
+	assert.Equal(t, leader.Age(now), time.Duration(0))
+}
+
+func TestJobManagerLeaderIsFlapping(t *testing.T) {
+	var now = time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	var leader = &JobManagerLeaderStatus{
+		Identity:      "leader-d",
+		RecentChanges: []string{"2024-05-01T09:52:00Z", "2024-05-01T09:55:00Z", "2024-05-01T09:58:00Z"},
+	}
+	assert.Equal(t, leader.IsFlapping(now), true)
+
+	// The oldest change is out of the churn window.
+	leader.RecentChanges[0] = "2024-05-01T09:40:00Z"
+	assert.Equal(t, leader.IsFlapping(now), false)
+
+	// Failovers spread over hours.
+	leader.RecentChanges = []string{"2024-05-01T06:00:00Z", "2024-05-01T08:00:00Z", "2024-05-01T09:58:00Z"}
+	assert.Equal(t, leader.IsFlapping(now), false)
+
+	leader = nil
+	assert.Equal(t, leader.IsFlapping(now), false)
+}
+
+func TestRestoreSourceIsPoison(t *testing.T) {
+	var restartOnFailure = JobRestartPolicyFromSavepointOnFailure
+	var jobSpec = JobSpec{RestartPolicy: &restartOnFailure}
+	var jobStatus = JobStatus{
+		State:               JobStateFailed,
+		FromSavepoint:       "gs://my-bucket/savepoint-2",
+		SavepointLocation:   "gs://my-bucket/savepoint-2",
+		RestoreFailureCount: 2,
+	}
+	assert.Equal(t, jobStatus.RestoreSourceIsPoison(3), false)
+	assert.Equal(t, jobStatus.RestoreSourceIsPoison(2), true)
+	assert.Equal(t, jobStatus.RestoreSourceIsPoison(0), false)
+
+	// Not restarted from the poison savepoint.
+	assert.Equal(t, jobStatus.ShouldRestart(&jobSpec, time.Now()), true)
+	jobStatus.PoisonSavepoints = []string{"gs://my-bucket/savepoint-2"}
+	assert.Equal(t, jobStatus.ShouldRestart(&jobSpec, time.Now()), false)
+
+	// Restarted from the savepoint it fell back to.
+	jobStatus.SavepointLocation = "gs://my-bucket/savepoint-1"
+	assert.Equal(t, jobStatus.RestoreSavepoint(), "gs://my-bucket/savepoint-1")
+	assert.Equal(t, jobStatus.ShouldRestart(&jobSpec, time.Now()), true)
+
+	// Not restored from a savepoint.
+	jobStatus = JobStatus{State: JobStateFailed, RestoreFailureCount: 5}
+	assert.Equal(t, jobStatus.RestoreSourceIsPoison(1), false)
+}
+
+func TestBestRestoreSource(t *testing.T) {
+	var tc = &util.TimeConverter{}
+	var now = time.Now()
+	var maxStateAge = int32(300)
+	var maxSavepointAge = int32(600)
+	var ago = func(d time.Duration) string { return tc.ToString(now.Add(-d)) }
+
+	tests := []struct {
+		name           string
+		spec           *JobSpec
+		job            *JobStatus
+		inventory      []SavepointRecord
+		expectedPath   string
+		expectedKind   RestoreKind
+		expectedReason string
+	}{
+		{
+			name:           "no job status",
+			spec:           &JobSpec{},
+			expectedKind:   RestoreKindNone,
+			expectedReason: "the job has no savepoint or retained checkpoint",
+		},
+		{
+			name:           "no state",
+			spec:           &JobSpec{},
+			job:            &JobStatus{},
+			expectedKind:   RestoreKindNone,
+			expectedReason: "the job has no savepoint or retained checkpoint",
+		},
+		{
+			name: "final savepoint over newer checkpoint",
+			spec: &JobSpec{},
+			job: &JobStatus{
+				SavepointLocation:  "gs://my-bucket/savepoint-1",
+				SavepointTime:      ago(time.Hour),
+				FinalSavepoint:     true,
+				RetainedCheckpoint: &RetainedCheckpoint{ID: 7, Location: "gs://my-bucket/chk-7", Time: ago(time.Minute)},
+			},
+			expectedPath:   "gs://my-bucket/savepoint-1",
+			expectedKind:   RestoreKindSavepoint,
+			expectedReason: "savepoint gs://my-bucket/savepoint-1 is the final state of the job",
+		},
+		{
+			name: "up-to-date savepoint over newer checkpoint",
+			spec: &JobSpec{MaxStateAgeToRestoreSeconds: &maxStateAge},
+			job: &JobStatus{
+				SavepointLocation:  "gs://my-bucket/savepoint-1",
+				SavepointTime:      ago(2 * time.Minute),
+				RetainedCheckpoint: &RetainedCheckpoint{ID: 7, Location: "gs://my-bucket/chk-7", Time: ago(time.Minute)},
+			},
+			expectedPath:   "gs://my-bucket/savepoint-1",
+			expectedKind:   RestoreKindSavepoint,
+			expectedReason: "savepoint gs://my-bucket/savepoint-1 is within maxStateAgeToRestoreSeconds",
+		},
+		{
+			name: "newer checkpoint over old savepoint",
+			spec: &JobSpec{MaxStateAgeToRestoreSeconds: &maxStateAge},
+			job: &JobStatus{
+				SavepointLocation:  "gs://my-bucket/savepoint-1",
+				SavepointTime:      ago(time.Hour),
+				RetainedCheckpoint: &RetainedCheckpoint{ID: 7, Location: "gs://my-bucket/chk-7", Time: ago(time.Minute)},
+			},
+			expectedPath:   "gs://my-bucket/chk-7",
+			expectedKind:   RestoreKindCheckpoint,
+			expectedReason: "checkpoint gs://my-bucket/chk-7 is the newest restore source",
+		},
+		{
+			name: "newer savepoint over old checkpoint",
+			spec: &JobSpec{},
+			job: &JobStatus{
+				SavepointLocation:  "gs://my-bucket/savepoint-2",
+				SavepointTime:      ago(time.Minute),
+				RetainedCheckpoint: &RetainedCheckpoint{ID: 7, Location: "gs://my-bucket/chk-7", Time: ago(time.Hour)},
+			},
+			expectedPath:   "gs://my-bucket/savepoint-2",
+			expectedKind:   RestoreKindSavepoint,
+			expectedReason: "savepoint gs://my-bucket/savepoint-2 is the newest restore source",
+		},
+		{
+			name: "savepoint preferred to checkpoint of the same time",
+			spec: &JobSpec{},
+			job: &JobStatus{
+				SavepointLocation:  "gs://my-bucket/savepoint-2",
+				SavepointTime:      ago(time.Hour),
+				RetainedCheckpoint: &RetainedCheckpoint{ID: 7, Location: "gs://my-bucket/chk-7", Time: ago(time.Hour)},
+			},
+			expectedPath:   "gs://my-bucket/savepoint-2",
+			expectedKind:   RestoreKindSavepoint,
+			expectedReason: "savepoint gs://my-bucket/savepoint-2 is the newest restore source",
+		},
+		{
+			name: "newer poison savepoint skipped for older checkpoint",
+			spec: &JobSpec{MaxStateAgeToRestoreSeconds: &maxStateAge},
+			job: &JobStatus{
+				FromSavepoint:      "gs://my-bucket/savepoint-2",
+				SavepointLocation:  "gs://my-bucket/savepoint-2",
+				SavepointTime:      ago(time.Minute),
+				PoisonSavepoints:   []string{"gs://my-bucket/savepoint-2"},
+				RetainedCheckpoint: &RetainedCheckpoint{ID: 7, Location: "gs://my-bucket/chk-7", Time: ago(time.Hour)},
+			},
+			expectedPath:   "gs://my-bucket/chk-7",
+			expectedKind:   RestoreKindCheckpoint,
+			expectedReason: "checkpoint gs://my-bucket/chk-7 is the newest restore source, skipped poison gs://my-bucket/savepoint-2",
+		},
+		{
+			name: "poison final savepoint skipped",
+			spec: &JobSpec{},
+			job: &JobStatus{
+				SavepointLocation:  "gs://my-bucket/savepoint-2",
+				SavepointTime:      ago(time.Minute),
+				FinalSavepoint:     true,
+				PoisonSavepoints:   []string{"gs://my-bucket/savepoint-2"},
+				RetainedCheckpoint: &RetainedCheckpoint{ID: 7, Location: "gs://my-bucket/chk-7", Time: ago(time.Hour)},
+			},
+			expectedPath:   "gs://my-bucket/chk-7",
+			expectedKind:   RestoreKindCheckpoint,
+			expectedReason: "checkpoint gs://my-bucket/chk-7 is the newest restore source, skipped poison gs://my-bucket/savepoint-2",
+		},
+		{
+			name: "newer poison checkpoint skipped for older savepoint",
+			spec: &JobSpec{},
+			job: &JobStatus{
+				FromSavepoint:      "gs://my-bucket/chk-7",
+				SavepointLocation:  "gs://my-bucket/savepoint-1",
+				SavepointTime:      ago(time.Hour),
+				PoisonSavepoints:   []string{"gs://my-bucket/chk-7"},
+				RetainedCheckpoint: &RetainedCheckpoint{ID: 7, Location: "gs://my-bucket/chk-7", Time: ago(time.Minute)},
+			},
+			expectedPath:   "gs://my-bucket/savepoint-1",
+			expectedKind:   RestoreKindSavepoint,
+			expectedReason: "savepoint gs://my-bucket/savepoint-1 is the newest restore source, skipped poison gs://my-bucket/chk-7",
+		},
+		{
+			name: "poison savepoint falls back to the inventory",
+			spec: &JobSpec{},
+			job: &JobStatus{
+				SavepointLocation: "gs://my-bucket/savepoint-3",
+				SavepointTime:     ago(time.Minute),
+				PoisonSavepoints:  []string{"gs://my-bucket/savepoint-3"},
+			},
+			inventory: []SavepointRecord{
+				{Location: "gs://my-bucket/savepoint-1", Time: ago(2 * time.Hour)},
+				{Location: "gs://my-bucket/savepoint-2", Time: ago(time.Hour)},
+				{Location: "gs://my-bucket/savepoint-3", Time: ago(time.Minute)},
+			},
+			expectedPath:   "gs://my-bucket/savepoint-2",
+			expectedKind:   RestoreKindSavepoint,
+			expectedReason: "savepoint gs://my-bucket/savepoint-2 is the newest restore source, skipped poison gs://my-bucket/savepoint-3",
+		},
+		{
+			name: "fresh primed savepoint",
+			spec: &JobSpec{WarmStandby: &WarmStandbySpec{MaxSavepointAgeSeconds: &maxSavepointAge}},
+			job: &JobStatus{
+				SavepointLocation:  "gs://my-bucket/savepoint-1",
+				SavepointTime:      ago(time.Hour),
+				PrimedSavepoint:    &PrimedSavepoint{Location: "gs://my-bucket/savepoint-2", Time: ago(time.Minute)},
+				RetainedCheckpoint: &RetainedCheckpoint{ID: 7, Location: "gs://my-bucket/chk-7", Time: ago(5 * time.Minute)},
+			},
+			expectedPath:   "gs://my-bucket/savepoint-2",
+			expectedKind:   RestoreKindSavepoint,
+			expectedReason: "savepoint gs://my-bucket/savepoint-2 is the newest restore source",
+		},
+		{
+			name: "stale primed savepoint ignored",
+			spec: &JobSpec{WarmStandby: &WarmStandbySpec{MaxSavepointAgeSeconds: &maxSavepointAge}},
+			job: &JobStatus{
+				PrimedSavepoint:    &PrimedSavepoint{Location: "gs://my-bucket/savepoint-2", Time: ago(time.Hour)},
+				RetainedCheckpoint: &RetainedCheckpoint{ID: 7, Location: "gs://my-bucket/chk-7", Time: ago(2 * time.Hour)},
+			},
+			expectedPath:   "gs://my-bucket/chk-7",
+			expectedKind:   RestoreKindCheckpoint,
+			expectedReason: "checkpoint gs://my-bucket/chk-7 is the newest restore source",
+		},
+		{
+			name: "checkpoint over fromSavepoint without time",
+			spec: &JobSpec{},
+			job: &JobStatus{
+				FromSavepoint:      "gs://my-bucket/savepoint-0",
+				RetainedCheckpoint: &RetainedCheckpoint{ID: 7, Location: "gs://my-bucket/chk-7", Time: ago(time.Hour)},
+			},
+			expectedPath:   "gs://my-bucket/chk-7",
+			expectedKind:   RestoreKindCheckpoint,
+			expectedReason: "checkpoint gs://my-bucket/chk-7 is the newest restore source",
+		},
+		{
+			name: "fromSavepoint only",
+			spec: &JobSpec{},
+			job: &JobStatus{
+				FromSavepoint: "gs://my-bucket/savepoint-0",
+			},
+			expectedPath:   "gs://my-bucket/savepoint-0",
+			expectedKind:   RestoreKindSavepoint,
+			expectedReason: "savepoint gs://my-bucket/savepoint-0 is the newest restore source",
+		},
+		{
+			name: "all sources poison",
+			spec: &JobSpec{},
+			job: &JobStatus{
+				FromSavepoint:      "gs://my-bucket/chk-7",
+				SavepointLocation:  "gs://my-bucket/savepoint-1",
+				SavepointTime:      ago(time.Hour),
+				PoisonSavepoints:   []string{"gs://my-bucket/savepoint-1", "gs://my-bucket/chk-7"},
+				RetainedCheckpoint: &RetainedCheckpoint{ID: 7, Location: "gs://my-bucket/chk-7", Time: ago(time.Minute)},
+			},
+			inventory:      []SavepointRecord{{Location: "gs://my-bucket/savepoint-1", Time: ago(time.Hour)}},
+			expectedKind:   RestoreKindNone,
+			expectedReason: "all restore sources are poison: gs://my-bucket/savepoint-1, gs://my-bucket/chk-7",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cluster = &FlinkCluster{
+				Spec: FlinkClusterSpec{Job: tt.spec},
+				Status: FlinkClusterStatus{
+					Components:         FlinkClusterComponentsStatus{Job: tt.job},
+					SavepointInventory: tt.inventory,
+				},
+			}
+			path, kind, reason := cluster.BestRestoreSource(now)
+			assert.Equal(t, path, tt.expectedPath)
+			assert.Equal(t, kind, tt.expectedKind)
+			assert.Equal(t, reason, tt.expectedReason)
+		})
+	}
+}
+
+func TestGetSlotMemoryBudget(t *testing.T) {
+	var memoryProcessRatio int32 = 80
+	var newTaskManagerSpec = func(memory string) *TaskManagerSpec {
+		return &TaskManagerSpec{
+			Resources: corev1.ResourceRequirements{
+				Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse(memory)},
+			},
+			MemoryProcessRatio: &memoryProcessRatio,
+		}
+	}
+
+	// 3276Mi of process memory less 256Mi of metaspace, 327Mi of JVM overhead, 269Mi of
+	// network and 256Mi of framework memory.
+	var budget = newTaskManagerSpec("4Gi").GetSlotMemoryBudget()
+	assert.Equal(t, budget.String(), "2167Mi")
+
+	// The JVM overhead and the network memory are capped at 1Gi.
+	budget = newTaskManagerSpec("32Gi").GetSlotMemoryBudget()
+	assert.Equal(t, budget.String(), "23654Mi")
+
+	// Nothing is left to the slots.
+	budget = newTaskManagerSpec("512Mi").GetSlotMemoryBudget()
+	assert.Equal(t, budget.IsZero(), true)
+}
+
+func TestExceedsUpdateDowntimeBudget(t *testing.T) {
+	var tc = &util.TimeConverter{}
+	var now = time.Now()
+	var budget int32 = 60
+	var fast int64 = 20
+	var slow int64 = 90
+	var newCluster = func(durations ...*int64) *FlinkCluster {
+		var cluster = &FlinkCluster{
+			Spec: FlinkClusterSpec{Job: &JobSpec{MaxUpdateDowntimeSeconds: &budget}},
+			Status: FlinkClusterStatus{
+				Components: FlinkClusterComponentsStatus{Job: &JobStatus{ID: "job-1", State: JobStateRunning}},
+			},
+		}
+		for _, d := range durations {
+			cluster.Status.SavepointInventory = append(cluster.Status.SavepointInventory, SavepointRecord{DurationSeconds: d})
+		}
+		return cluster
+	}
+
+	tests := []struct {
+		name      string
+		cluster   *FlinkCluster
+		savepoint *SavepointStatus
+		expected  bool
+	}{
+		{
+			name:    "no savepoint history",
+			cluster: newCluster(),
+		},
+		{
+			name:    "projected within budget",
+			cluster: newCluster(&fast, nil),
+		},
+		{
+			name:     "projected over budget",
+			cluster:  newCluster(&fast, &slow),
+			expected: true,
+		},
+		{
+			name:    "update savepoint in progress within budget",
+			cluster: newCluster(&fast),
+			savepoint: &SavepointStatus{JobID: "job-1", TriggerReason: SavepointReasonUpdate,
+				State: SavepointStateInProgress, TriggerTime: tc.ToString(now.Add(-30 * time.Second))},
+		},
+		{
+			name:    "update savepoint in progress over budget",
+			cluster: newCluster(&fast),
+			savepoint: &SavepointStatus{JobID: "job-1", TriggerReason: SavepointReasonUpdate,
+				State: SavepointStateInProgress, TriggerTime: tc.ToString(now.Add(-2 * time.Minute))},
+			expected: true,
+		},
+		{
+			name:    "update savepoint of previous job",
+			cluster: newCluster(&fast),
+			savepoint: &SavepointStatus{JobID: "job-0", TriggerReason: SavepointReasonUpdate,
+				State: SavepointStateFailed, TriggerTime: tc.ToString(now.Add(-2 * time.Minute))},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.cluster.Status.Savepoint = tt.savepoint
+			assert.Equal(t, tt.cluster.ExceedsUpdateDowntimeBudget(now), tt.expected)
+		})
+	}
+
+	// No budget.
+	var cluster = newCluster(&slow)
+	cluster.Spec.Job.MaxUpdateDowntimeSeconds = nil
+	assert.Equal(t, cluster.ExceedsUpdateDowntimeBudget(now), false)
+}
+
+func TestUpdateReadySkipSavepoint(t *testing.T) {
+	var jobSpec = JobSpec{}
+	var jobStatus = JobStatus{State: JobStateRunning}
+
+	// Savepoint is required to proceed update by default.
+	assert.Equal(t, jobStatus.UpdateReady(&jobSpec, time.Now(), false), false)
+	// Update proceeds without the final savepoint when it is skipped.
+	assert.Equal(t, jobStatus.UpdateReady(&jobSpec, time.Now(), true), true)
+
+	jobStatus.FinalSavepoint = true
+	assert.Equal(t, jobStatus.UpdateReady(&jobSpec, time.Now(), false), true)
+}
+
+func TestSkipSavepointOnNextUpdate(t *testing.T) {
+	var cluster = FlinkCluster{
+		Status: FlinkClusterStatus{
+			Components: FlinkClusterComponentsStatus{Job: &JobStatus{State: JobStateRunning}},
+		},
+	}
+	assert.Equal(t, cluster.SkipSavepointOnNextUpdate(), false)
+
+	// The annotation with a new nonce requests to skip the savepoint.
+	cluster.Annotations = map[string]string{SkipSavepointOnNextUpdateAnnotation: "nonce-1"}
+	assert.Equal(t, cluster.SkipSavepointOnNextUpdate(), true)
+	assert.Equal(t, cluster.PendingSkipSavepointNonce(), "nonce-1")
+
+	// Once the nonce is consumed by an update, it has no further effect.
+	cluster.Status.Components.Job.SkipSavepointNonce = "nonce-1"
+	assert.Equal(t, cluster.SkipSavepointOnNextUpdate(), false)
+	assert.Equal(t, cluster.PendingSkipSavepointNonce(), "")
+
+	// A new nonce requests to skip the savepoint again.
+	cluster.Annotations[SkipSavepointOnNextUpdateAnnotation] = "nonce-2"
+	assert.Equal(t, cluster.SkipSavepointOnNextUpdate(), true)
+
+	// Blank nonce is ignored.
+	cluster.Annotations[SkipSavepointOnNextUpdateAnnotation] = " "
+	assert.Equal(t, cluster.SkipSavepointOnNextUpdate(), false)
+}
+
+func TestCoordinatedSavepointStatusAggregate(t *testing.T) {
+	var newGroup = func(states ...string) *CoordinatedSavepointStatus {
+		var group = &CoordinatedSavepointStatus{}
+		for i, state := range states {
+			group.Jobs = append(group.Jobs, CoordinatedSavepointJobStatus{
+				JobName:   fmt.Sprintf("job-%d", i),
+				Savepoint: SavepointStatus{State: state},
+			})
+		}
+		return group
+	}
+
+	// All succeeded.
+	var group = newGroup(SavepointStateSucceeded, SavepointStateSucceeded)
+	group.Aggregate()
+	assert.Equal(t, group.State, SavepointStateSucceeded)
+	assert.Assert(t, group.FailedJobs == nil)
+
+	// Waiting for the remaining savepoints.
+	group = newGroup(SavepointStateSucceeded, SavepointStateInProgress)
+	group.Aggregate()
+	assert.Equal(t, group.State, SavepointStateInProgress)
+	assert.Equal(t, group.IsInProgress(), true)
+
+	// Partial failure fails the group and exposes the failed jobs.
+	group = newGroup(SavepointStateSucceeded, SavepointStateFailed, SavepointStateInProgress, SavepointStateTriggerFailed)
+	group.Aggregate()
+	assert.Equal(t, group.State, SavepointStateFailed)
+	assert.DeepEqual(t, group.FailedJobs, []string{"job-1", "job-3"})
+	assert.Equal(t, group.Message, "Savepoint failed for jobs: job-1, job-3")
+	assert.Equal(t, group.IsInProgress(), false)
+	assert.Equal(t, group.HasPendingSavepoints(), true)
+
+	// The failed group stays failed once the remaining savepoints complete.
+	group.Jobs[2].Savepoint.State = SavepointStateSucceeded
+	group.Aggregate()
+	assert.Equal(t, group.State, SavepointStateFailed)
+	assert.Equal(t, group.HasPendingSavepoints(), false)
+}
+
+func TestAddSavepointRecord(t *testing.T) {
+	var inventory []SavepointRecord
+
+	// Appends records in order.
+	inventory = AddSavepointRecord(inventory, SavepointRecord{Location: "gs://bucket/savepoint-0"})
+	inventory = AddSavepointRecord(inventory, SavepointRecord{Location: "gs://bucket/savepoint-1"})
+	assert.Equal(t, len(inventory), 2)
+	assert.Equal(t, inventory[1].Location, "gs://bucket/savepoint-1")
+
+	// Skips a location which is already recorded and a record without location.
+	inventory = AddSavepointRecord(inventory, SavepointRecord{Location: "gs://bucket/savepoint-0"})
+	inventory = AddSavepointRecord(inventory, SavepointRecord{})
+	assert.Equal(t, len(inventory), 2)
+
+	// Drops the oldest records beyond the limit.
+	for i := 2; i < SavepointInventoryLimit+3; i++ {
+		inventory = AddSavepointRecord(inventory, SavepointRecord{Location: fmt.Sprintf("gs://bucket/savepoint-%d", i)})
+	}
+	assert.Equal(t, len(inventory), SavepointInventoryLimit)
+	assert.Equal(t, inventory[0].Location, "gs://bucket/savepoint-3")
+	assert.Equal(t, inventory[SavepointInventoryLimit-1].Location, "gs://bucket/savepoint-12")
+}
+
+func TestEffectiveQoSClass(t *testing.T) {
+	var resources = corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("1"),
+		corev1.ResourceMemory: resource.MustParse("2Gi"),
+	}
+	var guaranteed = corev1.ResourceRequirements{Requests: resources, Limits: resources}
+	var burstable = corev1.ResourceRequirements{Requests: resources}
+	tests := []struct {
+		name          string
+		jobManager    *JobManagerSpec
+		taskManager   *TaskManagerSpec
+		expectedJmQoS corev1.PodQOSClass
+		expectedTmQoS corev1.PodQOSClass
+	}{
+		{
+			name:          "guaranteed",
+			jobManager:    &JobManagerSpec{Resources: guaranteed},
+			taskManager:   &TaskManagerSpec{Resources: guaranteed},
+			expectedJmQoS: corev1.PodQOSGuaranteed,
+			expectedTmQoS: corev1.PodQOSGuaranteed,
+		},
+		{
+			name:          "burstable jobmanager and best effort taskmanager",
+			jobManager:    &JobManagerSpec{Resources: burstable},
+			taskManager:   &TaskManagerSpec{},
+			expectedJmQoS: corev1.PodQOSBurstable,
+			expectedTmQoS: corev1.PodQOSBestEffort,
+		},
+		{
+			name:          "best effort jobmanager and guaranteed taskmanager",
+			jobManager:    &JobManagerSpec{},
+			taskManager:   &TaskManagerSpec{Resources: guaranteed},
+			expectedJmQoS: corev1.PodQOSBestEffort,
+			expectedTmQoS: corev1.PodQOSGuaranteed,
+		},
+		{
+			name:          "limits only",
+			jobManager:    &JobManagerSpec{Resources: corev1.ResourceRequirements{Limits: resources}},
+			taskManager:   &TaskManagerSpec{Resources: corev1.ResourceRequirements{Limits: resources}},
+			expectedJmQoS: corev1.PodQOSGuaranteed,
+			expectedTmQoS: corev1.PodQOSGuaranteed,
+		},
+		{
+			name: "sidecars without resources",
+			jobManager: &JobManagerSpec{
+				Resources: guaranteed,
+				Sidecars:  []corev1.Container{{Name: "sidecar"}},
+			},
+			taskManager: &TaskManagerSpec{
+				Resources:      guaranteed,
+				InitContainers: []corev1.Container{{Name: "init"}},
+			},
+			expectedJmQoS: corev1.PodQOSBurstable,
+			expectedTmQoS: corev1.PodQOSBurstable,
+		},
+		{
+			name:          "sidecar with resources",
+			jobManager:    &JobManagerSpec{Sidecars: []corev1.Container{{Name: "sidecar", Resources: burstable}}},
+			expectedJmQoS: corev1.PodQOSBurstable,
+		},
+		{
+			name: "no components",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cluster = &FlinkCluster{Spec: FlinkClusterSpec{JobManager: tt.jobManager, TaskManager: tt.taskManager}}
+			jmQoS, tmQoS := cluster.EffectiveQoSClass()
+			assert.Equal(t, jmQoS, tt.expectedJmQoS)
+			assert.Equal(t, tmQoS, tt.expectedTmQoS)
+		})
+	}
+}
+
+func TestMaxConcurrentTMRoll(t *testing.T) {
+	var adaptive = map[string]string{"jobmanager.scheduler": "adaptive", "taskmanager.numberOfTaskSlots": "2"}
+	var slots = map[string]string{"taskmanager.numberOfTaskSlots": "2"}
+	tests := []struct {
+		name            string
+		replicas        int32
+		parallelism     int32
+		flinkProperties map[string]string
+		sessionCluster  bool
+		expected        int32
+	}{
+		{
+			name:            "spare TaskManagers",
+			replicas:        10,
+			parallelism:     12,
+			flinkProperties: slots,
+			expected:        4,
+		},
+		{
+			name:            "partially used TaskManager",
+			replicas:        10,
+			parallelism:     13,
+			flinkProperties: slots,
+			expected:        3,
+		},
+		{
+			name:            "all slots required",
+			replicas:        10,
+			parallelism:     20,
+			flinkProperties: slots,
+			expected:        1,
+		},
+		{
+			name:            "parallelism from the slots",
+			replicas:        10,
+			flinkProperties: slots,
+			expected:        1,
+		},
+		{
+			name:            "more slots required than available",
+			replicas:        4,
+			parallelism:     16,
+			flinkProperties: slots,
+			expected:        1,
+		},
+		{
+			name:            "adaptive scheduler",
+			replicas:        10,
+			parallelism:     20,
+			flinkProperties: adaptive,
+			expected:        5,
+		},
+		{
+			name:            "adaptive scheduler with more spare TaskManagers",
+			replicas:        10,
+			parallelism:     4,
+			flinkProperties: adaptive,
+			expected:        8,
+		},
+		{
+			name:            "adaptive scheduler with a single TaskManager",
+			replicas:        1,
+			parallelism:     2,
+			flinkProperties: adaptive,
+			expected:        1,
+		},
+		{
+			name:            "session cluster",
+			replicas:        10,
+			flinkProperties: slots,
+			sessionCluster:  true,
+			expected:        1,
+		},
+		{
+			name:            "adaptive session cluster",
+			replicas:        3,
+			flinkProperties: adaptive,
+			sessionCluster:  true,
+			expected:        1,
+		},
+		{
+			name:     "no replicas",
+			expected: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cluster = &FlinkCluster{
+				Spec: FlinkClusterSpec{
+					FlinkProperties: tt.flinkProperties,
+					TaskManager:     &TaskManagerSpec{},
+				},
+			}
+			if tt.replicas != 0 {
+				cluster.Spec.TaskManager.Replicas = &tt.replicas
+			}
+			if !tt.sessionCluster {
+				cluster.Spec.Job = &JobSpec{}
+				if tt.parallelism != 0 {
+					cluster.Spec.Job.Parallelism = &tt.parallelism
+				}
+			}
+			assert.Equal(t, cluster.MaxConcurrentTMRoll(), tt.expected)
+		})
+	}
+}
+
+func TestEstimatedCost(t *testing.T) {
+	var jmReplicas, tmReplicas int32 = 1, 3
+	var priceList = ResourcePriceList{CPUPerHour: 0.04, MemoryGiBPerHour: 0.005, GPUPerHour: 1}
+	var cluster = &FlinkCluster{
+		Spec: FlinkClusterSpec{
+			JobManager: &JobManagerSpec{
+				Replicas: &jmReplicas,
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("1"),
+						corev1.ResourceMemory: resource.MustParse("2Gi"),
+					},
+				},
+			},
+			TaskManager: &TaskManagerSpec{
+				Replicas: &tmReplicas,
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("2"),
+						corev1.ResourceMemory: resource.MustParse("4Gi"),
+					},
+					Limits: corev1.ResourceList{"nvidia.com/gpu": resource.MustParse("1")},
+				},
+				Sidecars: []corev1.Container{{
+					Name: "sidecar",
+					Resources: corev1.ResourceRequirements{
+						Limits: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m")},
+					},
+				}},
+			},
+		},
+	}
+
+	var requests = cluster.TotalResourceRequests()
+	assert.Equal(t, requests.Cpu().String(), "8500m")
+	assert.Equal(t, requests.Memory().String(), "14Gi")
+	assert.Equal(t, requests.Name("nvidia.com/gpu", resource.DecimalSI).String(), "3")
+
+	// 8.5 CPUs, 14 GiB of memory and 3 GPUs.
+	var cost = cluster.EstimatedCost(priceList)
+	assert.Equal(t, fmt.Sprintf("%.2f", cost.Hourly), "3.41")
+	assert.Equal(t, fmt.Sprintf("%.2f", cost.Monthly), "2489.30")
+
+	// The TaskManagers are scaled to zero, only the JobManager is left.
+	cluster.Status.Components.JobManager = &JobManagerStatus{Replicas: 1}
+	cluster.Status.Components.TaskManager = &TaskManagerStatus{State: ComponentStateDeleted}
+	cost = cluster.EstimatedCost(priceList)
+	assert.Equal(t, fmt.Sprintf("%.2f", cost.Hourly), "0.05")
+
+	// The cluster is stopped.
+	cluster.Status.State = ClusterStateStopped
+	assert.Equal(t, cluster.EstimatedCost(priceList), ResourceCost{})
+}
+
+func TestParseResourcePriceList(t *testing.T) {
+	priceList, err := ParseResourcePriceList("0.04", "", "1.5")
+	assert.NilError(t, err)
+	assert.Equal(t, priceList, ResourcePriceList{CPUPerHour: 0.04, GPUPerHour: 1.5})
+	assert.Assert(t, !priceList.IsZero())
+
+	priceList, err = ParseResourcePriceList("", "", "")
+	assert.NilError(t, err)
+	assert.Assert(t, priceList.IsZero())
+
+	_, err = ParseResourcePriceList("", "-1", "")
+	assert.Error(t, err, "invalid memory price per hour: -1, must be a non-negative number")
+	_, err = ParseResourcePriceList("cheap", "", "")
+	assert.Error(t, err, "invalid cpu price per hour: cheap, must be a non-negative number")
+}
@@ -19,7 +19,10 @@ package v1beta1
 import (
 	"encoding/json"
 	"fmt"
+	"math"
+	"net/url"
 	"reflect"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -36,14 +39,18 @@ import (
 )
 
 const (
-	InvalidControlAnnMsg           = "invalid value for annotation key: %v, value: %v, available values: savepoint, job-cancel"
+	InvalidControlAnnMsg           = "invalid value for annotation key: %v, value: %v, available values: savepoint, job-cancel, coordinated-savepoint"
 	InvalidJobStateForJobCancelMsg = "job-cancel is not allowed because job is not started yet or already terminated, annotation: %v"
 	InvalidJobStateForSavepointMsg = "savepoint is not allowed because job is not started yet or already stopped, annotation: %v"
-	InvalidSavepointDirMsg         = "savepoint is not allowed without spec.job.savepointsDir, annotation: %v"
+	InvalidSavepointDirMsg         = "savepoint is not allowed without spec.job.savepointsDir or state.savepoints.dir in flinkProperties, annotation: %v"
 	SessionClusterWarnMsg          = "%v is not allowed for session cluster, annotation: %v"
+	InvalidCoordinatedSavepointMsg = "coordinated-savepoint is not allowed without spec.coordinatedSavepoint, annotation: %v"
 	ControlChangeWarnMsg           = "change is not allowed for control in progress, annotation: %v"
 	dns1035ErrorMsg                = "cluster name %s is invalid: a DNS-1035 name must consist of lower case alphanumeric characters or '-', start with an alphabetic character, and end with an alphanumeric character (e.g. 'my-name', or 'abc-123', regex used for validation is '[a-z]([-a-z0-9]*[a-z0-9])?'"
 	maxClusterNameLength           = 49 // 63 - 14 (max suffix length)
+
+	// Minimum number of checkpoint intervals between two periodic savepoints.
+	minSavepointCheckpointIntervals = 3
 )
 
 // Validator validates CUD requests for the CR.
@@ -77,13 +84,188 @@ func (v *Validator) ValidateCreate(cluster *FlinkCluster) error {
 	if err != nil {
 		return err
 	}
+	err = v.validateNetworkPorts(cluster)
+	if err != nil {
+		return err
+	}
+	err = v.validateImageArchitecture(cluster)
+	if err != nil {
+		return err
+	}
+	err = v.validateExternalResources(cluster)
+	if err != nil {
+		return err
+	}
+	err = v.validateLocalRecovery(cluster)
+	if err != nil {
+		return err
+	}
+	err = v.validateAdaptiveScheduler(cluster)
+	if err != nil {
+		return err
+	}
+	err = v.validateFailoverStrategy(cluster)
+	if err != nil {
+		return err
+	}
+	err = v.validateClassloaderResolveOrder(cluster)
+	if err != nil {
+		return err
+	}
+	err = v.validateExecutionMode(cluster)
+	if err != nil {
+		return err
+	}
+	err = v.validateOperatorUIDPolicy(cluster)
+	if err != nil {
+		return err
+	}
+	err = v.validateWatermarkAlignment(flinkVersion, cluster)
+	if err != nil {
+		return err
+	}
+	err = v.validateCheckpointTuning(cluster)
+	if err != nil {
+		return err
+	}
+	err = v.validateRestartEscalation(cluster)
+	if err != nil {
+		return err
+	}
+	err = v.validateRocksDBOptions(cluster)
+	if err != nil {
+		return err
+	}
+	err = v.validateMemoryFractions(cluster.ParsedFlinkConfig())
+	if err != nil {
+		return err
+	}
 	err = v.validateJob(cluster.Spec.Job)
 	if err != nil {
 		return err
 	}
+	err = v.validateCoordinatedSavepoint(cluster)
+	if err != nil {
+		return err
+	}
+	err = v.validateConfigChangeRestartPolicy(cluster.Spec.ConfigChangeRestartPolicy)
+	if err != nil {
+		return err
+	}
+	err = v.validateJobStopMode(cluster)
+	if err != nil {
+		return err
+	}
+	err = v.validateMaxParallelism(cluster)
+	if err != nil {
+		return err
+	}
+	err = v.validateJobResultStore(flinkVersion, cluster)
+	if err != nil {
+		return err
+	}
+	err = v.validateApplicationHighAvailability(flinkVersion, cluster)
+	if err != nil {
+		return err
+	}
 	return nil
 }
 
+// Warnings returns warnings about settings which are valid but likely unintended.
+func (v *Validator) Warnings(cluster *FlinkCluster) []string {
+	var warnings []string
+	if w := v.checkIncrementalCheckpoints(cluster.ParsedFlinkConfig()); w != "" {
+		warnings = append(warnings, w)
+	}
+	if w := v.checkJobResultStoreDir(cluster); w != "" {
+		warnings = append(warnings, w)
+	}
+	if w := v.checkJobResultStoreDurability(cluster); w != "" {
+		warnings = append(warnings, w)
+	}
+	if w := v.checkCheckpointStorage(cluster.ParsedFlinkConfig()); w != "" {
+		warnings = append(warnings, w)
+	}
+	if w := v.checkSavepointSpacing(cluster); w != "" {
+		warnings = append(warnings, w)
+	}
+	return warnings
+}
+
+// Incremental checkpoints silently degrade to full checkpoints with the hashmap backend.
+func (v *Validator) checkIncrementalCheckpoints(config ParsedFlinkConfig) string {
+	if enabled, _ := config.IncrementalCheckpoints(); !enabled || config.SupportsIncrementalCheckpoints() {
+		return ""
+	}
+	return fmt.Sprintf(
+		"incremental checkpoints are enabled but the %s state backend does not support them, full checkpoints are taken instead; set %s to %s or disable incremental checkpoints",
+		config.StateBackend(), flinkConfigStateBackend, StateBackendRocksDB)
+}
+
+// The jobmanager checkpoint storage keeps the checkpoints in the JobManager heap, which cannot
+// hold the large state the RocksDB and ForSt backends are used for. The default storage is only
+// checked when periodic checkpoints are configured, a job may not take checkpoints at all.
+func (v *Validator) checkCheckpointStorage(config ParsedFlinkConfig) string {
+	storage, set := config.CheckpointStorage()
+	if storage != CheckpointStorageJobManager || !config.SupportsLargeState() ||
+		(!set && !config.PeriodicCheckpoints()) {
+		return ""
+	}
+	return fmt.Sprintf(
+		"the %s state backend is used for large state but the checkpoints are stored in the JobManager heap, "+
+			"which fails once the state outgrows it; set %s to %s with a checkpoint directory in %s",
+		config.StateBackend(), flinkConfigCheckpointStorage, CheckpointStorageFileSystem, flinkConfigCheckpointsDir)
+}
+
+// Periodic savepoints taken every few checkpoints contend with the checkpoints for the
+// JobManager and the checkpoint storage, and delay both. Savepoints spaced by several checkpoint
+// intervals, or jobs without periodic checkpoints, are not flagged.
+func (v *Validator) checkSavepointSpacing(cluster *FlinkCluster) string {
+	var jobSpec = cluster.Spec.Job
+	if jobSpec == nil || jobSpec.AutoSavepointSeconds == nil || *jobSpec.AutoSavepointSeconds <= 0 {
+		return ""
+	}
+	interval, ok := cluster.ParsedFlinkConfig().CheckpointInterval()
+	if !ok {
+		return ""
+	}
+	var savepointInterval = time.Duration(*jobSpec.AutoSavepointSeconds) * time.Second
+	var minSpacing = minSavepointCheckpointIntervals * interval
+	if savepointInterval >= minSpacing {
+		return ""
+	}
+	return fmt.Sprintf(
+		"job autoSavepointSeconds %d takes a savepoint every %.1f checkpoints of %v %v, "+
+			"the savepoints and checkpoints contend for the JobManager; space the savepoints at least %v apart",
+		*jobSpec.AutoSavepointSeconds, float64(savepointInterval)/float64(interval),
+		flinkConfigCheckpointInterval, interval, minSpacing)
+}
+
+// A job result store nested with the savepoints is at risk when the savepoints are cleaned up.
+func (v *Validator) checkJobResultStoreDir(cluster *FlinkCluster) string {
+	var dir = cluster.JobResultStoreDir()
+	for _, savepointsDir := range []string{cluster.SavepointsDir(), cluster.CoordinatedSavepointsDir()} {
+		if StorageDirsOverlap(dir, savepointsDir) {
+			return fmt.Sprintf(
+				"job result store directory %v is co-located with the savepoint directory %v, "+
+					"cleaning up the savepoints can delete the job results", dir, savepointsDir)
+		}
+	}
+	return ""
+}
+
+// The job result store directory set in flinkProperties is not rejected when it is not durable,
+// e.g., a local path of a test setup, but the job results are lost with the JobManager pod.
+func (v *Validator) checkJobResultStoreDurability(cluster *FlinkCluster) string {
+	var dir = cluster.JobResultStoreDir()
+	if cluster.Spec.JobResultStore != nil || dir == "" || IsDurableStorageDir(dir) {
+		return ""
+	}
+	return fmt.Sprintf(
+		"job result store directory %v of %v is not durable, the job results are lost with the JobManager pod; "+
+			"use a shared file system, e.g., gs:// or s3://", dir, flinkConfigJobResultStorePath)
+}
+
 // ValidateUpdate validates update request.
 func (v *Validator) ValidateUpdate(old *FlinkCluster, new *FlinkCluster) error {
 	var err error
@@ -140,18 +322,22 @@ func (v *Validator) checkControlAnnotations(old *FlinkCluster, new *FlinkCluster
 			var job = old.Status.Components.Job
 			if old.Spec.Job == nil {
 				return fmt.Errorf(SessionClusterWarnMsg, ControlNameJobCancel, ControlAnnotation)
-			} else if job == nil || job.IsTerminated(old.Spec.Job) {
+			} else if job == nil || job.IsTerminated(old.Spec.Job, time.Now()) {
 				return errors.NewResourceExpired(fmt.Sprintf(InvalidJobStateForJobCancelMsg, ControlAnnotation))
 			}
 		case ControlNameSavepoint:
 			var job = old.Status.Components.Job
 			if old.Spec.Job == nil {
 				return fmt.Errorf(SessionClusterWarnMsg, ControlNameSavepoint, ControlAnnotation)
-			} else if old.Spec.Job.SavepointsDir == nil || *old.Spec.Job.SavepointsDir == "" {
+			} else if !old.SavepointsConfigured() {
 				return fmt.Errorf(InvalidSavepointDirMsg, ControlAnnotation)
 			} else if job == nil || job.IsStopped() {
 				return fmt.Errorf(InvalidJobStateForSavepointMsg, ControlAnnotation)
 			}
+		case ControlNameCoordinatedSavepoint:
+			if old.Spec.CoordinatedSavepoint == nil {
+				return fmt.Errorf(InvalidCoordinatedSavepointMsg, ControlAnnotation)
+			}
 		default:
 			return fmt.Errorf(InvalidControlAnnMsg, ControlAnnotation, newUserControl)
 		}
@@ -247,13 +433,16 @@ func (v *Validator) validateJobUpdate(old *FlinkCluster, new *FlinkCluster) erro
 		oldJobSpec, _ := json.Marshal(old.Spec.Job)
 		newJobSpec, _ := json.Marshal(new.Spec.Job)
 		return fmt.Errorf("you cannot change cluster type between session cluster and job cluster, old spec.job: %q, new spec.job: %q", oldJobSpec, newJobSpec)
-	case old.Spec.Job.SavepointsDir == nil || *old.Spec.Job.SavepointsDir == "":
-		return fmt.Errorf("updating job is not allowed when spec.job.savepointsDir was not provided")
-	case old.Spec.Job.SavepointsDir != nil && *old.Spec.Job.SavepointsDir != "" &&
-		(new.Spec.Job.SavepointsDir == nil || *new.Spec.Job.SavepointsDir == ""):
-		return fmt.Errorf("removing savepointsDir is not allowed")
+	case !old.SavepointsConfigured():
+		return fmt.Errorf("updating job is not allowed when neither spec.job.savepointsDir nor %s in flinkProperties was provided", flinkConfigSavepointsDir)
+	case !new.SavepointsConfigured():
+		return fmt.Errorf("removing savepointsDir or %s is not allowed", flinkConfigSavepointsDir)
 	case old.IsHighAvailabilityEnabled() != new.IsHighAvailabilityEnabled():
 		return fmt.Errorf("updating highAvailability settings is not allowed")
+	case isApplicationHighAvailability(new) &&
+		old.Spec.FlinkProperties[haConfigClusterId] != new.Spec.FlinkProperties[haConfigClusterId]:
+		return fmt.Errorf("updating %v is not allowed in application mode with high availability, "+
+			"the job would not recover from the HA data of the previous cluster-id", haConfigClusterId)
 	case !isBlank(new.Spec.Job.FromSavepoint):
 		return nil
 	default:
@@ -262,7 +451,7 @@ func (v *Validator) validateJobUpdate(old *FlinkCluster, new *FlinkCluster) erro
 		var takeSavepointOnUpdate = new.Spec.Job.TakeSavepointOnUpdate == nil || *new.Spec.Job.TakeSavepointOnUpdate
 		var skipTakeSavepoint = !takeSavepointOnUpdate || oldJob.IsStopped()
 		var now = time.Now()
-		if skipTakeSavepoint && oldJob != nil && !oldJob.UpdateReady(new.Spec.Job, now) {
+		if skipTakeSavepoint && oldJob != nil && !oldJob.UpdateReady(new.Spec.Job, now, false) {
 			oldJobJson, _ := json.Marshal(oldJob)
 			var takeSP, maxStateAge string
 			if new.Spec.Job.TakeSavepointOnUpdate == nil {
@@ -357,6 +546,10 @@ func (v *Validator) validateJobManager(flinkVersion *version.Version, jmSpec *Jo
 		return err
 	}
 
+	if err := v.validatePreStop(jmSpec.PreStop, "jobmanager"); err != nil {
+		return err
+	}
+
 	if flinkVersion == nil || flinkVersion.LessThan(v10) {
 		if jmSpec.MemoryProcessRatio != nil {
 			return fmt.Errorf("MemoryProcessRatio config cannot be used with flinkVersion < 1.11', use " +
@@ -419,6 +612,10 @@ func (v *Validator) validateTaskManager(flinkVersion *version.Version, tmSpec *T
 		return err
 	}
 
+	if err := v.validatePreStop(tmSpec.PreStop, "taskmanager"); err != nil {
+		return err
+	}
+
 	if flinkVersion == nil || flinkVersion.LessThan(v10) {
 		if tmSpec.MemoryProcessRatio != nil {
 			return fmt.Errorf("MemoryProcessRatio config cannot be used with flinkVersion < 1.11', use " +
@@ -448,6 +645,132 @@ func (v *Validator) validateTaskManager(flinkVersion *version.Version, tmSpec *T
 		}
 	}
 
+	if tmSpec.FineGrainedResources != nil {
+		if flinkVersion == nil || flinkVersion.LessThan(v114) {
+			return fmt.Errorf("fineGrainedResources config cannot be used with flinkVersion < 1.14")
+		}
+		err = v.validateFineGrainedResources(tmSpec)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Validates that the preStop hook completes within the termination grace period, otherwise
+// the container is killed before the hook completes. Hooks of unknown duration are accepted.
+func (v *Validator) validatePreStop(handler *corev1.LifecycleHandler, component string) error {
+	seconds, ok := GetPreStopSeconds(handler)
+	if !ok {
+		return nil
+	}
+	if seconds < 0 {
+		return fmt.Errorf("%s preStop sleep must not be negative", component)
+	}
+	if seconds > TerminationGracePeriodSeconds {
+		return fmt.Errorf("%s preStop takes %d seconds, which exceeds the termination grace period of %d seconds",
+			component, seconds, TerminationGracePeriodSeconds)
+	}
+	return nil
+}
+
+// Validates the slot profiles fit in the TaskManager. The cpu of the profiles is shared from the
+// TaskManager cpu, and their memory from the memory budget of the slots, see GetSlotMemoryBudget.
+func (v *Validator) validateFineGrainedResources(tmSpec *TaskManagerSpec) error {
+	var spec = tmSpec.FineGrainedResources
+	var tmResources = tmSpec.GetResources()
+	if len(spec.SlotProfiles) == 0 {
+		return fmt.Errorf("taskmanager fineGrainedResources.slotProfiles is unspecified")
+	}
+	var names = make(map[string]bool)
+	for _, profile := range spec.SlotProfiles {
+		if strings.TrimSpace(profile.Name) == "" {
+			return fmt.Errorf("taskmanager fineGrainedResources slot profile name is unspecified")
+		}
+		if names[profile.Name] {
+			return fmt.Errorf("duplicate slot profile name %q in taskmanager fineGrainedResources", profile.Name)
+		}
+		names[profile.Name] = true
+		if profile.GetSlots() < 1 {
+			return fmt.Errorf("slots of slot profile %q must be greater than 0", profile.Name)
+		}
+		if profile.CPU.Sign() <= 0 || profile.Memory.Sign() <= 0 {
+			return fmt.Errorf("cpu and memory of slot profile %q must be greater than 0", profile.Name)
+		}
+	}
+	if spec.MaxSlots != nil && *spec.MaxSlots < spec.TotalSlots() {
+		return fmt.Errorf("taskmanager fineGrainedResources.maxSlots %d is less than the slots of a TaskManager %d",
+			*spec.MaxSlots, spec.TotalSlots())
+	}
+
+	var total = spec.TotalResources()
+	if total.Cpu().Cmp(*tmResources.Cpu()) > 0 {
+		return fmt.Errorf("total cpu of slot profiles %s exceeds taskmanager cpu %s",
+			total.Cpu().String(), tmResources.Cpu().String())
+	}
+	var memoryBudget = tmSpec.GetSlotMemoryBudget()
+	if total.Memory().Cmp(memoryBudget) > 0 {
+		return fmt.Errorf("total memory of slot profiles %s exceeds the task memory %s of taskmanager memory %s",
+			total.Memory().String(), memoryBudget.String(), tmResources.Memory().String())
+	}
+	return nil
+}
+
+func (v *Validator) validateCoordinatedSavepoint(cluster *FlinkCluster) error {
+	var spec = cluster.Spec.CoordinatedSavepoint
+	if spec == nil {
+		return nil
+	}
+	if cluster.Spec.Job != nil {
+		return fmt.Errorf("coordinatedSavepoint is only supported for session clusters")
+	}
+	if len(spec.JobNames) == 0 {
+		return fmt.Errorf("coordinatedSavepoint.jobNames must not be empty")
+	}
+	var names = make(map[string]struct{}, len(spec.JobNames))
+	for _, name := range spec.JobNames {
+		if strings.TrimSpace(name) == "" {
+			return fmt.Errorf("coordinatedSavepoint.jobNames must not contain a blank name")
+		}
+		if _, ok := names[name]; ok {
+			return fmt.Errorf("duplicate job name %q in coordinatedSavepoint.jobNames", name)
+		}
+		names[name] = struct{}{}
+	}
+	if cluster.CoordinatedSavepointsDir() == "" {
+		return fmt.Errorf("coordinatedSavepoint requires coordinatedSavepoint.savepointsDir or %s in flinkProperties", flinkConfigSavepointsDir)
+	}
+	return nil
+}
+
+func (v *Validator) validateConfigChangeRestartPolicy(policy *ConfigChangeRestartPolicy) error {
+	if policy == nil {
+		return nil
+	}
+	switch *policy {
+	case ConfigChangeRestartPolicyAlways, ConfigChangeRestartPolicySensitiveOnly, ConfigChangeRestartPolicyNever:
+		return nil
+	default:
+		return fmt.Errorf("invalid configChangeRestartPolicy: %v", *policy)
+	}
+}
+
+// A graceful stop cannot complete without a savepoint target directory, which would
+// leave the job cancellation hanging.
+func (v *Validator) validateJobStopMode(cluster *FlinkCluster) error {
+	if cluster.Spec.Job == nil || cluster.Spec.Job.StopMode == nil {
+		return nil
+	}
+	switch *cluster.Spec.Job.StopMode {
+	case JobStopModeGraceful:
+		if !cluster.SavepointsConfigured() {
+			return fmt.Errorf("job stopMode Graceful requires spec.job.savepointsDir or %s in flinkProperties, set stopMode to Cancel to stop the job without savepoint", flinkConfigSavepointsDir)
+		}
+	case JobStopModeCancel:
+	default:
+		return fmt.Errorf("invalid job stopMode: %v", *cluster.Spec.Job.StopMode)
+	}
 	return nil
 }
 
@@ -473,6 +796,10 @@ func (v *Validator) validateJob(jobSpec *JobSpec) error {
 		return fmt.Errorf("job parallelism must be >= 1")
 	}
 
+	if err := v.validateRESTJarUpload(jobSpec); err != nil {
+		return err
+	}
+
 	switch *jobSpec.RestartPolicy {
 	case JobRestartPolicyNever:
 	case JobRestartPolicyFromSavepointOnFailure:
@@ -488,6 +815,18 @@ func (v *Validator) validateJob(jobSpec *JobSpec) error {
 		return fmt.Errorf("maxStateAgeToRestoreSeconds must be specified when takeSavepointOnUpdate is set as false")
 	}
 
+	if jobSpec.WaitForSavepoint != nil && isBlank(jobSpec.FromSavepoint) {
+		return fmt.Errorf("job waitForSavepoint requires fromSavepoint to be specified")
+	}
+
+	if err := v.validateSavepointTimeouts(jobSpec.SavepointTimeouts); err != nil {
+		return err
+	}
+
+	if err := v.validateSavepointValidationWebhook(jobSpec.SavepointValidationWebhook); err != nil {
+		return err
+	}
+
 	if jobSpec.CancelRequested != nil && *jobSpec.CancelRequested {
 		return fmt.Errorf(
 			"property `cancelRequested` cannot be set to true for a new job")
@@ -496,6 +835,89 @@ func (v *Validator) validateJob(jobSpec *JobSpec) error {
 	return nil
 }
 
+// validateSavepointTimeouts checks the timeouts are positive and the final savepoint of the
+// deleted cluster is bounded.
+func (v *Validator) validateSavepointTimeouts(spec *SavepointTimeoutsSpec) error {
+	if spec == nil {
+		return nil
+	}
+	var timeouts = []struct {
+		name    string
+		seconds *int32
+	}{
+		{"updateSeconds", spec.UpdateSeconds},
+		{"scheduledSeconds", spec.ScheduledSeconds},
+		{"deleteSeconds", spec.DeleteSeconds},
+		{"userRequestedSeconds", spec.UserRequestedSeconds},
+		{"jobCancelSeconds", spec.JobCancelSeconds},
+	}
+	for _, t := range timeouts {
+		if t.seconds != nil && *t.seconds < 1 {
+			return fmt.Errorf("job savepointTimeouts %s must be positive", t.name)
+		}
+	}
+	if spec.DeleteSeconds != nil && *spec.DeleteSeconds > MaxDeleteSavepointTimeoutSeconds {
+		return fmt.Errorf("job savepointTimeouts deleteSeconds %d exceeds the maximum %d",
+			*spec.DeleteSeconds, MaxDeleteSavepointTimeoutSeconds)
+	}
+	return nil
+}
+
+// validateSavepointValidationWebhook checks the webhook is called at an absolute HTTP(S) URL.
+func (v *Validator) validateSavepointValidationWebhook(spec *SavepointValidationWebhookSpec) error {
+	if spec == nil {
+		return nil
+	}
+	webhookURL, err := url.Parse(spec.URL)
+	if err != nil || (webhookURL.Scheme != "http" && webhookURL.Scheme != "https") || webhookURL.Host == "" {
+		return fmt.Errorf("job savepointValidationWebhook url must be an http(s) URL, got %q", spec.URL)
+	}
+	if spec.TimeoutSeconds != nil && *spec.TimeoutSeconds < 1 {
+		return fmt.Errorf("job savepointValidationWebhook timeoutSeconds must be positive")
+	}
+	switch spec.FailurePolicy {
+	case "", SavepointValidationFailClosed, SavepointValidationFailOpen:
+	default:
+		return fmt.Errorf("job savepointValidationWebhook failurePolicy must be FailClosed or FailOpen, got %v", spec.FailurePolicy)
+	}
+	return nil
+}
+
+// validateApplicationHighAvailability checks an application mode job with high availability
+// records its results in a durable job result store. Without it, a job which completed before
+// a JobManager failover is run again by the recovered JobManager. Flink keeps the job results
+// in the HA storage directory unless the job result store directory is set.
+func (v *Validator) validateApplicationHighAvailability(flinkVersion *version.Version, cluster *FlinkCluster) error {
+	if !isApplicationHighAvailability(cluster) {
+		return nil
+	}
+	if flinkVersion != nil && flinkVersion.LessThan(v115) {
+		return fmt.Errorf("application mode with high availability requires flinkVersion >= 1.15, " +
+			"older versions have no job result store and may run a completed job again after a JobManager failover")
+	}
+	var dir = cluster.JobResultStoreDir()
+	if dir == "" {
+		if v, ok := cluster.Spec.FlinkProperties[flinkConfigJobResultStorePath]; ok && strings.TrimSpace(v) == "" {
+			return fmt.Errorf("application mode with high availability requires a job result store, "+
+				"%v cannot be blank so that a completed job is not run again after a JobManager failover", flinkConfigJobResultStorePath)
+		}
+		dir = strings.TrimSpace(cluster.Spec.FlinkProperties[haConfigStorageDir])
+	}
+	if !IsDurableStorageDir(dir) {
+		return fmt.Errorf("application mode with high availability requires a durable job result store, "+
+			"directory %v is not durable, use a shared file system, e.g., gs:// or s3://", dir)
+	}
+	return nil
+}
+
+// isApplicationHighAvailability returns true if the job runs in application mode with high
+// availability enabled.
+func isApplicationHighAvailability(cluster *FlinkCluster) bool {
+	var jobSpec = cluster.Spec.Job
+	return jobSpec != nil && jobSpec.Mode != nil && *jobSpec.Mode == JobModeApplication &&
+		cluster.IsHighAvailabilityEnabled()
+}
+
 func (v *Validator) validateResourceRequirements(rr corev1.ResourceRequirements, component string) error {
 	memoryNotSet := true
 	cpuNotSet := true
@@ -525,6 +947,679 @@ func (v *Validator) validateResourceRequirements(rr corev1.ResourceRequirements,
 	return nil
 }
 
+// validateMaxParallelism checks the job parallelism does not exceed the max parallelism,
+// which Flink rejects at submission. When the max parallelism is unset, Flink derives it
+// from the parallelism, up to its upper bound.
+func (v *Validator) validateMaxParallelism(cluster *FlinkCluster) error {
+	if cluster.Spec.Job == nil {
+		return nil
+	}
+	maxParallelism, err := cluster.ParsedFlinkConfig().MaxParallelism()
+	if err != nil {
+		return fmt.Errorf("%v in flinkProperties", err)
+	}
+	if maxParallelism != nil && (*maxParallelism < 1 || *maxParallelism > UpperBoundMaxParallelism) {
+		return fmt.Errorf("%s in flinkProperties must be between 1 and %d, got %d",
+			flinkConfigMaxParallelism, UpperBoundMaxParallelism, *maxParallelism)
+	}
+
+	var source = "job parallelism"
+	if cluster.Spec.Job.Parallelism == nil {
+		if cluster.Spec.TaskManager == nil || cluster.Spec.TaskManager.Replicas == nil {
+			return nil
+		}
+		source = "job parallelism derived from taskmanager replicas * task slots"
+	}
+	parallelism, err := cluster.GetJobParallelism()
+	if err != nil {
+		return nil
+	}
+
+	if maxParallelism == nil {
+		if derived := DefaultMaxParallelism(parallelism); parallelism > derived {
+			return fmt.Errorf("%s %d is greater than the max parallelism %d Flink derives when %s is unset",
+				source, parallelism, derived, flinkConfigMaxParallelism)
+		}
+		return nil
+	}
+	if parallelism > *maxParallelism {
+		return fmt.Errorf("%s %d is greater than %s %d in flinkProperties",
+			source, parallelism, flinkConfigMaxParallelism, *maxParallelism)
+	}
+	return nil
+}
+
+// validateExternalResources checks the external resources of TaskManagers match their
+// resources, so that the resources Kubernetes allocates are exposed to Flink and Flink
+// does not expect resources the pods do not have.
+func (v *Validator) validateExternalResources(cluster *FlinkCluster) error {
+	var tmSpec = cluster.Spec.TaskManager
+	if tmSpec == nil {
+		return nil
+	}
+	var externalResources = tmSpec.ExternalResources
+	if _, ok := cluster.ParsedFlinkConfig().Get(flinkConfigExternalResources); ok {
+		if len(externalResources) > 0 {
+			return fmt.Errorf("taskmanager externalResources cannot be used with %v in flinkProperties", flinkConfigExternalResources)
+		}
+		// External resources are configured manually.
+		return nil
+	}
+
+	var tmResources = tmSpec.GetResources()
+	var names = make(map[string]bool)
+	var resourceNames = make(map[corev1.ResourceName]bool)
+	for _, r := range externalResources {
+		if strings.TrimSpace(r.Name) == "" {
+			return fmt.Errorf("taskmanager external resource name is unspecified")
+		}
+		if names[r.Name] {
+			return fmt.Errorf("duplicate external resource name %q in taskmanager externalResources", r.Name)
+		}
+		names[r.Name] = true
+		if r.ResourceName == "" {
+			return fmt.Errorf("resourceName of taskmanager external resource %q is unspecified", r.Name)
+		}
+		if resourceNames[r.ResourceName] {
+			return fmt.Errorf("duplicate resourceName %v in taskmanager externalResources", r.ResourceName)
+		}
+		resourceNames[r.ResourceName] = true
+		if r.Amount < 1 {
+			return fmt.Errorf("amount of taskmanager external resource %q must be greater than 0", r.Name)
+		}
+		requested, ok := (*tmResources)[r.ResourceName]
+		if !ok || requested.Value() != r.Amount {
+			return fmt.Errorf("taskmanager external resource %q has amount %d but taskmanager resources request %v of %v",
+				r.Name, r.Amount, requested.String(), r.ResourceName)
+		}
+	}
+	for name := range *tmResources {
+		if IsGPUResourceName(name) && !resourceNames[name] {
+			return fmt.Errorf("taskmanager resources request %v but no taskmanager externalResources entry exposes it to Flink", name)
+		}
+	}
+	return nil
+}
+
+// validateLocalRecovery checks the task-local recovery is configured consistently with its
+// local storage. Local recovery enabled in flinkProperties without local storage would keep
+// the local state copy in the temporary directories of the container.
+func (v *Validator) validateLocalRecovery(cluster *FlinkCluster) error {
+	var tmSpec = cluster.Spec.TaskManager
+	if tmSpec == nil {
+		return nil
+	}
+	var config = cluster.ParsedFlinkConfig()
+	var localRecovery = tmSpec.LocalRecovery
+	enabled, key := config.LocalRecovery()
+	_, rootDirsSet := config.Get(flinkConfigLocalRootDirs)
+	if localRecovery == nil {
+		if enabled && !rootDirsSet {
+			return fmt.Errorf("%v is enabled in flinkProperties without local storage, "+
+				"use taskmanager localRecovery to provision it", key)
+		}
+		return nil
+	}
+
+	if key != "" && !enabled {
+		return fmt.Errorf("taskmanager localRecovery conflicts with %v: %v in flinkProperties", key, config[key])
+	}
+	if rootDirsSet {
+		return fmt.Errorf("taskmanager localRecovery cannot be used with %v in flinkProperties", flinkConfigLocalRootDirs)
+	}
+	if isBlank(localRecovery.VolumeName) {
+		for _, volume := range tmSpec.Volumes {
+			if volume.Name == LocalRecoveryVolumeName {
+				return fmt.Errorf("taskmanager volume name %q is reserved for the local recovery storage, "+
+					"set it as localRecovery volumeName to keep the local state in it", LocalRecoveryVolumeName)
+			}
+		}
+		return nil
+	}
+	if localRecovery.SizeLimit != nil {
+		return fmt.Errorf("taskmanager localRecovery sizeLimit only applies to the provisioned volume, it cannot be used with volumeName")
+	}
+	var volumeName = localRecovery.GetVolumeName()
+	for _, volume := range tmSpec.Volumes {
+		if volume.Name == volumeName {
+			return nil
+		}
+	}
+	for _, claim := range tmSpec.VolumeClaimTemplates {
+		if claim.Name == volumeName {
+			return nil
+		}
+	}
+	return fmt.Errorf("taskmanager localRecovery volumeName %q does not match any taskmanager volume or volumeClaimTemplate", volumeName)
+}
+
+// validateJobResultStore checks the job result store directory is distinct from the checkpoint
+// and savepoint directories, and durable when it is set by jobResultStore. Otherwise the job
+// results are removed together with the checkpoints or savepoints of the jobs, or lost with the
+// JobManager pod. A non-durable directory of job-result-store.storage-path in flinkProperties
+// is only warned about.
+func (v *Validator) validateJobResultStore(flinkVersion *version.Version, cluster *FlinkCluster) error {
+	var config = cluster.ParsedFlinkConfig()
+	if jrs := cluster.Spec.JobResultStore; jrs != nil {
+		for _, key := range []string{flinkConfigJobResultStorePath, flinkConfigJobResultStoreDeleteOnCommit} {
+			if _, ok := config.Get(key); ok {
+				return fmt.Errorf("jobResultStore cannot be used with %v in flinkProperties", key)
+			}
+		}
+		if flinkVersion == nil || flinkVersion.LessThan(v115) {
+			return fmt.Errorf("jobResultStore cannot be used with flinkVersion < 1.15")
+		}
+		if !cluster.IsHighAvailabilityEnabled() {
+			return fmt.Errorf("jobResultStore requires high availability to be enabled in flinkProperties")
+		}
+	}
+
+	var dir = cluster.JobResultStoreDir()
+	if dir == "" {
+		return nil
+	}
+	if cluster.Spec.JobResultStore != nil && !IsDurableStorageDir(dir) {
+		return fmt.Errorf("job result store directory %v is not durable, use a shared file system, e.g., gs:// or s3://", dir)
+	}
+	var dirs = []struct {
+		name string
+		dir  string
+	}{
+		{"checkpoint", config.CheckpointsDir()},
+		{"savepoint", cluster.SavepointsDir()},
+		{"coordinated savepoint", cluster.CoordinatedSavepointsDir()},
+	}
+	for _, d := range dirs {
+		if d.dir != "" && strings.TrimRight(dir, "/") == strings.TrimRight(d.dir, "/") {
+			return fmt.Errorf("job result store directory %v must differ from the %v directory", dir, d.name)
+		}
+	}
+	return nil
+}
+
+// validateAdaptiveScheduler checks the rescale settings of the adaptive scheduler apply, as
+// the other schedulers ignore them.
+func (v *Validator) validateAdaptiveScheduler(cluster *FlinkCluster) error {
+	var jmSpec = cluster.Spec.JobManager
+	if jmSpec == nil || jmSpec.AdaptiveScheduler == nil {
+		return nil
+	}
+	if !cluster.SupportsInPlaceRescale() {
+		return fmt.Errorf("jobmanager adaptiveScheduler requires the adaptive scheduler, "+
+			"set %v: adaptive or %v: reactive in flinkProperties", flinkConfigScheduler, flinkConfigSchedulerMode)
+	}
+	for key := range cluster.Spec.FlinkProperties {
+		if strings.HasPrefix(key, flinkConfigAdaptivePrefix) {
+			return fmt.Errorf("jobmanager adaptiveScheduler cannot be used with %v in flinkProperties", key)
+		}
+	}
+
+	var spec = jmSpec.AdaptiveScheduler
+	if spec.MinParallelismIncrease != nil &&
+		(*spec.MinParallelismIncrease < 1 || *spec.MinParallelismIncrease > UpperBoundMaxParallelism) {
+		return fmt.Errorf("jobmanager adaptiveScheduler minParallelismIncrease must be between 1 and %d", UpperBoundMaxParallelism)
+	}
+	if spec.ResourceWaitTimeoutSeconds != nil && *spec.ResourceWaitTimeoutSeconds < 1 {
+		return fmt.Errorf("jobmanager adaptiveScheduler resourceWaitTimeoutSeconds must be positive")
+	}
+	if spec.ResourceStabilizationTimeoutSeconds != nil {
+		if *spec.ResourceStabilizationTimeoutSeconds < 0 {
+			return fmt.Errorf("jobmanager adaptiveScheduler resourceStabilizationTimeoutSeconds must not be negative")
+		}
+		if spec.ResourceWaitTimeoutSeconds != nil && *spec.ResourceStabilizationTimeoutSeconds > *spec.ResourceWaitTimeoutSeconds {
+			return fmt.Errorf("jobmanager adaptiveScheduler resourceStabilizationTimeoutSeconds %d exceeds resourceWaitTimeoutSeconds %d",
+				*spec.ResourceStabilizationTimeoutSeconds, *spec.ResourceWaitTimeoutSeconds)
+		}
+	}
+	return nil
+}
+
+// validateFailoverStrategy checks the failover strategy is one Flink knows, whether it is
+// set typed or in flinkProperties, but not both.
+func (v *Validator) validateFailoverStrategy(cluster *FlinkCluster) error {
+	var raw, rawSet = cluster.ParsedFlinkConfig().Get(flinkConfigFailoverStrategy)
+	if jmSpec := cluster.Spec.JobManager; jmSpec != nil && jmSpec.FailoverStrategy != nil {
+		if rawSet {
+			return fmt.Errorf("jobmanager failoverStrategy cannot be used with %v in flinkProperties", flinkConfigFailoverStrategy)
+		}
+		switch *jmSpec.FailoverStrategy {
+		case FailoverStrategyRegion, FailoverStrategyFull:
+			return nil
+		}
+		return fmt.Errorf("jobmanager failoverStrategy must be %v or %v, got %v",
+			FailoverStrategyRegion, FailoverStrategyFull, *jmSpec.FailoverStrategy)
+	}
+	if rawSet && !strings.EqualFold(raw, string(FailoverStrategyRegion)) && !strings.EqualFold(raw, string(FailoverStrategyFull)) {
+		return fmt.Errorf("invalid %v in flinkProperties, must be %v or %v, got %v",
+			flinkConfigFailoverStrategy, FailoverStrategyRegion, FailoverStrategyFull, raw)
+	}
+	return nil
+}
+
+// validateClassloaderResolveOrder checks the classloader resolve order is one Flink knows,
+// whether it is set typed in the job or in flinkProperties, but not both.
+func (v *Validator) validateClassloaderResolveOrder(cluster *FlinkCluster) error {
+	var raw, rawSet = cluster.ParsedFlinkConfig().Get(flinkConfigResolveOrder)
+	if jobSpec := cluster.Spec.Job; jobSpec != nil && jobSpec.ClassloaderResolveOrder != nil {
+		if rawSet {
+			return fmt.Errorf("job classloaderResolveOrder cannot be used with %v in flinkProperties", flinkConfigResolveOrder)
+		}
+		switch *jobSpec.ClassloaderResolveOrder {
+		case ClassloaderResolveOrderChildFirst, ClassloaderResolveOrderParentFirst:
+			return nil
+		}
+		return fmt.Errorf("job classloaderResolveOrder must be %v or %v, got %v",
+			ClassloaderResolveOrderChildFirst, ClassloaderResolveOrderParentFirst, *jobSpec.ClassloaderResolveOrder)
+	}
+	if rawSet && !strings.EqualFold(raw, string(ClassloaderResolveOrderChildFirst)) &&
+		!strings.EqualFold(raw, string(ClassloaderResolveOrderParentFirst)) {
+		return fmt.Errorf("invalid %v in flinkProperties, must be %v or %v, got %v",
+			flinkConfigResolveOrder, ClassloaderResolveOrderChildFirst, ClassloaderResolveOrderParentFirst, raw)
+	}
+	return nil
+}
+
+// validateExecutionMode checks the job execution mode is one the operator knows, and is not
+// set together with the runtime mode in flinkProperties.
+func (v *Validator) validateExecutionMode(cluster *FlinkCluster) error {
+	var jobSpec = cluster.Spec.Job
+	if jobSpec == nil || jobSpec.ExecutionMode == nil {
+		return nil
+	}
+	if _, ok := cluster.ParsedFlinkConfig().Get(flinkConfigRuntimeMode); ok {
+		return fmt.Errorf("job executionMode cannot be used with %v in flinkProperties", flinkConfigRuntimeMode)
+	}
+	switch *jobSpec.ExecutionMode {
+	case JobExecutionModeStreaming, JobExecutionModeBatch:
+		return nil
+	}
+	return fmt.Errorf("job executionMode must be %v or %v, got %v",
+		JobExecutionModeStreaming, JobExecutionModeBatch, *jobSpec.ExecutionMode)
+}
+
+// validateOperatorUIDPolicy checks the operator UID policy is one the operator knows, and is
+// not set together with the generation of the UIDs in flinkProperties.
+func (v *Validator) validateOperatorUIDPolicy(cluster *FlinkCluster) error {
+	var jobSpec = cluster.Spec.Job
+	if jobSpec == nil || jobSpec.OperatorUIDPolicy == nil {
+		return nil
+	}
+	if _, ok := cluster.ParsedFlinkConfig().Get(flinkConfigAutoGenerateUIDs); ok {
+		return fmt.Errorf("job operatorUIDPolicy cannot be used with %v in flinkProperties", flinkConfigAutoGenerateUIDs)
+	}
+	switch *jobSpec.OperatorUIDPolicy {
+	case OperatorUIDPolicyGenerated, OperatorUIDPolicyRequired:
+		return nil
+	}
+	return fmt.Errorf("job operatorUIDPolicy must be %v or %v, got %v",
+		OperatorUIDPolicyGenerated, OperatorUIDPolicyRequired, *jobSpec.OperatorUIDPolicy)
+}
+
+// validateImageArchitecture checks the node selectors and the required node affinities of the
+// pods do not exclude the nodes of the image architecture, which would leave the pods pending.
+func (v *Validator) validateImageArchitecture(cluster *FlinkCluster) error {
+	var arch = cluster.NodeArchitecture()
+	if arch == "" {
+		return nil
+	}
+	type scheduling struct {
+		name         string
+		nodeSelector map[string]string
+		affinity     *corev1.Affinity
+	}
+	var pods []scheduling
+	if jm := cluster.Spec.JobManager; jm != nil {
+		pods = append(pods, scheduling{"jobManager", jm.NodeSelector, jm.Affinity})
+	}
+	if tm := cluster.Spec.TaskManager; tm != nil {
+		pods = append(pods, scheduling{"taskManager", tm.NodeSelector, tm.Affinity})
+	}
+	if job := cluster.Spec.Job; job != nil {
+		pods = append(pods, scheduling{"job", job.NodeSelector, job.Affinity})
+	}
+	for _, pod := range pods {
+		if selected, ok := pod.nodeSelector[corev1.LabelArchStable]; ok && selected != arch {
+			return fmt.Errorf("%v nodeSelector %v %q conflicts with image architecture %q",
+				pod.name, corev1.LabelArchStable, selected, arch)
+		}
+		if pod.affinity == nil || pod.affinity.NodeAffinity == nil ||
+			pod.affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+			continue
+		}
+		var terms = pod.affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+		var excluded = len(terms) > 0
+		for _, term := range terms {
+			if !excludesArchitecture(term, arch) {
+				excluded = false
+				break
+			}
+		}
+		if excluded {
+			return fmt.Errorf("%v affinity requires nodes whose %v is not the image architecture %q",
+				pod.name, corev1.LabelArchStable, arch)
+		}
+	}
+	return nil
+}
+
+// Returns true if the node selector term only matches nodes of other architectures than arch.
+func excludesArchitecture(term corev1.NodeSelectorTerm, arch string) bool {
+	for _, expr := range term.MatchExpressions {
+		if expr.Key != corev1.LabelArchStable {
+			continue
+		}
+		switch expr.Operator {
+		case corev1.NodeSelectorOpIn:
+			if !slices.Contains(expr.Values, arch) {
+				return true
+			}
+		case corev1.NodeSelectorOpNotIn:
+			if slices.Contains(expr.Values, arch) {
+				return true
+			}
+		case corev1.NodeSelectorOpDoesNotExist:
+			return true
+		}
+	}
+	return false
+}
+
+// validateWatermarkAlignment checks the watermark alignment is supported by the Flink version,
+// allowUnalignedSourceSplits is not configured both ways and the update interval fits in the
+// max drift.
+func (v *Validator) validateWatermarkAlignment(flinkVersion *version.Version, cluster *FlinkCluster) error {
+	var jobSpec = cluster.Spec.Job
+	if jobSpec == nil || jobSpec.WatermarkAlignment == nil {
+		return nil
+	}
+
+	var spec = jobSpec.WatermarkAlignment
+	if flinkVersion == nil || flinkVersion.LessThan(v115) {
+		return fmt.Errorf("job watermarkAlignment cannot be used with flinkVersion < 1.15")
+	}
+	if spec.AllowUnalignedSourceSplits != nil {
+		if _, ok := cluster.ParsedFlinkConfig().Get(flinkConfigUnalignedSplits); ok {
+			return fmt.Errorf("job watermarkAlignment allowUnalignedSourceSplits cannot be used with %v in flinkProperties",
+				flinkConfigUnalignedSplits)
+		}
+		if flinkVersion.LessThan(v117) {
+			return fmt.Errorf("job watermarkAlignment allowUnalignedSourceSplits cannot be used with flinkVersion < 1.17")
+		}
+	}
+	if strings.TrimSpace(spec.Group) == "" {
+		return fmt.Errorf("job watermarkAlignment group is unspecified")
+	}
+	if spec.MaxDriftSeconds < 1 {
+		return fmt.Errorf("job watermarkAlignment maxDriftSeconds must be positive")
+	}
+	if spec.UpdateIntervalSeconds != nil {
+		if *spec.UpdateIntervalSeconds < 1 {
+			return fmt.Errorf("job watermarkAlignment updateIntervalSeconds must be positive")
+		}
+		if *spec.UpdateIntervalSeconds > spec.MaxDriftSeconds {
+			return fmt.Errorf("job watermarkAlignment updateIntervalSeconds %d exceeds maxDriftSeconds %d",
+				*spec.UpdateIntervalSeconds, spec.MaxDriftSeconds)
+		}
+	}
+	return nil
+}
+
+// validateRESTJarUpload checks the job JAR uploaded through the REST API is one the submitter
+// can read and run detached, as the REST API neither blocks on the job nor takes a classpath.
+func (v *Validator) validateRESTJarUpload(jobSpec *JobSpec) error {
+	if jobSpec.RESTJarUpload == nil || !*jobSpec.RESTJarUpload {
+		return nil
+	}
+	if jobSpec.Mode != nil && *jobSpec.Mode != JobModeDetached {
+		return fmt.Errorf("job restJarUpload requires mode %v", JobModeDetached)
+	}
+	if isBlank(jobSpec.JarFile) {
+		return fmt.Errorf("job restJarUpload requires jarFile")
+	}
+	if scheme, _, found := strings.Cut(*jobSpec.JarFile, "://"); found &&
+		scheme != "http" && scheme != "https" && scheme != "file" {
+		return fmt.Errorf("job restJarUpload requires a local or http(s) jarFile, got %v", *jobSpec.JarFile)
+	}
+	if len(jobSpec.ClassPath) > 0 {
+		return fmt.Errorf("job restJarUpload cannot be used with classPath")
+	}
+	return nil
+}
+
+// validateRestartEscalation checks the restart strategy of Flink is not configured both ways
+// and the operator restarts the job once Flink gives up on it.
+func (v *Validator) validateRestartEscalation(cluster *FlinkCluster) error {
+	var jobSpec = cluster.Spec.Job
+	if jobSpec == nil || jobSpec.RestartEscalation == nil {
+		return nil
+	}
+	for key := range cluster.Spec.FlinkProperties {
+		if strings.HasPrefix(key, flinkConfigRestartStrategy) {
+			return fmt.Errorf("job restartEscalation cannot be used with %v in flinkProperties", key)
+		}
+	}
+	if jobSpec.RestartPolicy == nil || *jobSpec.RestartPolicy != JobRestartPolicyFromSavepointOnFailure {
+		return fmt.Errorf("job restartEscalation requires restartPolicy %v", JobRestartPolicyFromSavepointOnFailure)
+	}
+	var spec = jobSpec.RestartEscalation
+	if spec.FlinkRestartAttempts < 0 {
+		return fmt.Errorf("job restartEscalation flinkRestartAttempts must be >= 0")
+	}
+	if spec.FlinkRestartDelaySeconds != nil && *spec.FlinkRestartDelaySeconds < 0 {
+		return fmt.Errorf("job restartEscalation flinkRestartDelaySeconds must be >= 0")
+	}
+	return nil
+}
+
+// validateCheckpointTuning checks the checkpoint timeout and concurrency of the job are not set
+// both ways and are in sane ranges. The timeout must exceed the checkpoint interval and fit in
+// the maximum state age to restore, otherwise the latest checkpoint could already be too old
+// to restore from by the time it completes.
+func (v *Validator) validateCheckpointTuning(cluster *FlinkCluster) error {
+	var jobSpec = cluster.Spec.Job
+	if jobSpec == nil {
+		return nil
+	}
+	var config = cluster.ParsedFlinkConfig()
+	if jobSpec.MaxConcurrentCheckpoints != nil {
+		if _, ok := config.Get(flinkConfigMaxConcurrent); ok {
+			return fmt.Errorf("job maxConcurrentCheckpoints cannot be used with %v in flinkProperties", flinkConfigMaxConcurrent)
+		}
+		if *jobSpec.MaxConcurrentCheckpoints < 1 {
+			return fmt.Errorf("job maxConcurrentCheckpoints must be >= 1")
+		}
+	}
+	if jobSpec.CheckpointTimeoutSeconds == nil {
+		return nil
+	}
+	if _, ok := config.Get(flinkConfigCheckpointTimeout); ok {
+		return fmt.Errorf("job checkpointTimeoutSeconds cannot be used with %v in flinkProperties", flinkConfigCheckpointTimeout)
+	}
+	var timeoutSeconds = *jobSpec.CheckpointTimeoutSeconds
+	if timeoutSeconds < 1 {
+		return fmt.Errorf("job checkpointTimeoutSeconds must be positive")
+	}
+	if interval, ok := config.CheckpointInterval(); ok && cluster.CheckpointTimeout() <= interval {
+		return fmt.Errorf("job checkpointTimeoutSeconds %d must be greater than %v %v",
+			timeoutSeconds, flinkConfigCheckpointInterval, interval)
+	}
+	if maxStateAge := jobSpec.MaxStateAgeToRestoreSeconds; maxStateAge != nil && timeoutSeconds > *maxStateAge {
+		return fmt.Errorf("job checkpointTimeoutSeconds %d exceeds maxStateAgeToRestoreSeconds %d",
+			timeoutSeconds, *maxStateAge)
+	}
+	return nil
+}
+
+// validateRocksDBOptions checks the RocksDB options apply to the configured state backend, do
+// not configure the RocksDB memory both ways and fit in the TaskManager memory.
+func (v *Validator) validateRocksDBOptions(cluster *FlinkCluster) error {
+	var tmSpec = cluster.Spec.TaskManager
+	if tmSpec == nil || tmSpec.RocksDB == nil {
+		return nil
+	}
+	var config = cluster.ParsedFlinkConfig()
+	if !strings.Contains(config.StateBackend(), StateBackendRocksDB) {
+		return fmt.Errorf("taskmanager rocksDB requires the rocksdb state backend, "+
+			"set %v: %v in flinkProperties, got %v", flinkConfigStateBackend, StateBackendRocksDB, config.StateBackend())
+	}
+	for _, key := range []string{
+		flinkConfigRocksDBManagedMemory,
+		flinkConfigRocksDBFixedMemory,
+		flinkConfigRocksDBWriteBufferRatio,
+		flinkConfigRocksDBHighPrioPoolRatio,
+		flinkConfigRocksDBBlockCacheSize,
+		flinkConfigRocksDBWriteBufferSize,
+	} {
+		if _, ok := config.Get(key); ok {
+			return fmt.Errorf("taskmanager rocksDB cannot be used with %v in flinkProperties", key)
+		}
+	}
+
+	var spec = tmSpec.RocksDB
+	var managed = spec.ManagedMemory == nil || *spec.ManagedMemory
+	var sizes = []struct {
+		name string
+		size *resource.Quantity
+	}{
+		{"fixedMemoryPerSlot", spec.FixedMemoryPerSlot},
+		{"blockCacheSize", spec.BlockCacheSize},
+		{"writeBufferSize", spec.WriteBufferSize},
+	}
+	for _, s := range sizes {
+		if s.size != nil && s.size.Sign() <= 0 {
+			return fmt.Errorf("taskmanager rocksDB %v must be positive", s.name)
+		}
+	}
+	if spec.FixedMemoryPerSlot != nil && spec.ManagedMemory != nil && *spec.ManagedMemory {
+		return fmt.Errorf("taskmanager rocksDB fixedMemoryPerSlot cannot be used with managedMemory: true")
+	}
+	if (spec.BlockCacheSize != nil || spec.WriteBufferSize != nil) && (managed || spec.FixedMemoryPerSlot != nil) {
+		return fmt.Errorf("taskmanager rocksDB blockCacheSize and writeBufferSize only apply with managedMemory: false " +
+			"and without fixedMemoryPerSlot, the RocksDB memory is bounded otherwise")
+	}
+	if spec.WriteBufferRatio != nil && (*spec.WriteBufferRatio < 1 || *spec.WriteBufferRatio > 99) {
+		return fmt.Errorf("taskmanager rocksDB writeBufferRatio must be between 1 and 99")
+	}
+	if spec.HighPriorityPoolRatio != nil && (*spec.HighPriorityPoolRatio < 0 || *spec.HighPriorityPoolRatio > 99) {
+		return fmt.Errorf("taskmanager rocksDB highPriorityPoolRatio must be between 0 and 99")
+	}
+	var writeBufferRatio, highPriorityPoolRatio int32 = 50, 10
+	if spec.WriteBufferRatio != nil {
+		writeBufferRatio = *spec.WriteBufferRatio
+	}
+	if spec.HighPriorityPoolRatio != nil {
+		highPriorityPoolRatio = *spec.HighPriorityPoolRatio
+	}
+	if writeBufferRatio+highPriorityPoolRatio > 100 {
+		return fmt.Errorf("taskmanager rocksDB writeBufferRatio %d and highPriorityPoolRatio %d exceed 100 percent",
+			writeBufferRatio, highPriorityPoolRatio)
+	}
+
+	// The RocksDB memory is allocated outside the JVM heap of the TaskManager container.
+	var memory = tmSpec.GetResources().Memory()
+	if memory.IsZero() {
+		return nil
+	}
+	if spec.FixedMemoryPerSlot != nil {
+		if slots, err := cluster.GetTaskManagerTaskSlots(); err == nil && slots > 0 {
+			var total = spec.FixedMemoryPerSlot.Value() * int64(slots)
+			if total >= memory.Value() {
+				return fmt.Errorf("taskmanager rocksDB fixedMemoryPerSlot %v for %d slots exceeds the taskmanager memory %v",
+					spec.FixedMemoryPerSlot.String(), slots, memory.String())
+			}
+		}
+	}
+	for _, s := range sizes[1:] {
+		if s.size != nil && s.size.Cmp(*memory) >= 0 {
+			return fmt.Errorf("taskmanager rocksDB %v %v exceeds the taskmanager memory %v", s.name, s.size.String(), memory.String())
+		}
+	}
+	return nil
+}
+
+// validateMemoryFractions checks the memory fractions in flinkProperties are valid and that
+// the fractions of the total Flink memory of TaskManagers in effect leave room for the heap,
+// otherwise TaskManagers fail to start.
+func (v *Validator) validateMemoryFractions(config ParsedFlinkConfig) error {
+	if _, err := config.JVMOverheadFractions(); err != nil {
+		return err
+	}
+	fractions, err := config.TaskManagerMemoryFractions()
+	if err != nil {
+		return err
+	}
+
+	var sum float64
+	var anySet bool
+	var inEffect []string
+	for _, f := range fractions {
+		if !f.InEffect {
+			continue
+		}
+		sum += f.Value
+		anySet = anySet || f.Set
+		var desc = fmt.Sprintf("%s=%v", f.Key, f.Value)
+		if !f.Set {
+			desc += " (default)"
+		}
+		inEffect = append(inEffect, desc)
+	}
+	// Round to avoid floating point errors, e.g., 0.7 + 0.2 + 0.1.
+	var total = math.Round(sum*1000) / 1000
+	if anySet && total >= 1 {
+		return fmt.Errorf("taskmanager memory fractions in flinkProperties sum to %v of the total Flink memory, they must sum to less than 1: %s",
+			total, strings.Join(inEffect, ", "))
+	}
+	return nil
+}
+
+// validateNetworkPorts checks the ports Flink listens on after port settings in the Flink
+// properties are applied, which the per-component port checks do not see. A port set to a
+// port range is not checked, since Flink binds any free port of the range.
+func (v *Validator) validateNetworkPorts(cluster *FlinkCluster) error {
+	ports, err := cluster.NetworkPorts()
+	if err != nil {
+		return err
+	}
+	var namedPorts = func(keyedPorts []keyedNamedPort, extraPorts []NamedPort) []NamedPort {
+		var namedPorts []NamedPort
+		for _, p := range keyedPorts {
+			if !ports.IsPortRange(p.key) {
+				namedPorts = append(namedPorts, p.port)
+			}
+		}
+		return append(namedPorts, extraPorts...)
+	}
+	if jmSpec := cluster.Spec.JobManager; jmSpec != nil {
+		var jmPorts = []keyedNamedPort{
+			{flinkConfigJobManagerRPCPort, NamedPort{Name: "rpc", ContainerPort: ports.JobManagerRPC}},
+			{flinkConfigBlobServerPort, NamedPort{Name: "blob", ContainerPort: ports.Blob}},
+			{flinkConfigQueryServerPort, NamedPort{Name: "query", ContainerPort: ports.Query}},
+			{flinkConfigRestPort, NamedPort{Name: "ui", ContainerPort: ports.REST}},
+		}
+		if err := v.checkDupPorts(namedPorts(jmPorts, jmSpec.ExtraPorts), "jobmanager"); err != nil {
+			return err
+		}
+	}
+	if tmSpec := cluster.Spec.TaskManager; tmSpec != nil {
+		var tmPorts = []keyedNamedPort{
+			{flinkConfigTaskManagerRPCPort, NamedPort{Name: "rpc", ContainerPort: ports.TaskManagerRPC}},
+			{flinkConfigTaskManagerDataPort, NamedPort{Name: "data", ContainerPort: ports.TaskManagerData}},
+			{flinkConfigQueryServerPort, NamedPort{Name: "query", ContainerPort: ports.TaskManagerQuery}},
+		}
+		if err := v.checkDupPorts(namedPorts(tmPorts, tmSpec.ExtraPorts), "taskmanager"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// keyedNamedPort is a port of a component with the Flink property which sets it.
+type keyedNamedPort struct {
+	key  string
+	port NamedPort
+}
+
 // Check duplicate name and number in NamedPort array.
 func (v *Validator) checkDupPorts(ports []NamedPort, component string) error {
 	if len(ports) == 0 {
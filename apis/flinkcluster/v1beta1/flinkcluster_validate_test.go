@@ -19,10 +19,12 @@ package v1beta1
 import (
 	"encoding/json"
 	"fmt"
+	"maps"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/hashicorp/go-version"
 	"github.com/spotify/flink-on-k8s-operator/internal/util"
 	"k8s.io/apimachinery/pkg/api/resource"
 
@@ -215,6 +217,170 @@ func TestInvalidTaskManagerSpec(t *testing.T) {
 	assert.Equal(t, err.Error(), expectedErr)
 }
 
+func TestValidatePreStop(t *testing.T) {
+	var validator = &Validator{}
+	tests := []struct {
+		name        string
+		preStop     *corev1.LifecycleHandler
+		expectedErr string
+	}{
+		{
+			name: "default hook",
+		},
+		{
+			name:    "sleep action within the grace period",
+			preStop: &corev1.LifecycleHandler{Sleep: &corev1.SleepAction{Seconds: 60}},
+		},
+		{
+			name:    "sleep command within the grace period",
+			preStop: &corev1.LifecycleHandler{Exec: &corev1.ExecAction{Command: []string{"sleep", "45"}}},
+		},
+		{
+			name:    "hook of unknown duration",
+			preStop: &corev1.LifecycleHandler{Exec: &corev1.ExecAction{Command: []string{"/opt/flink/bin/drain.sh"}}},
+		},
+		{
+			name:        "sleep action over the grace period",
+			preStop:     &corev1.LifecycleHandler{Sleep: &corev1.SleepAction{Seconds: 90}},
+			expectedErr: "taskmanager preStop takes 90 seconds, which exceeds the termination grace period of 60 seconds",
+		},
+		{
+			name:        "sleep command over the grace period",
+			preStop:     &corev1.LifecycleHandler{Exec: &corev1.ExecAction{Command: []string{"sleep", "120"}}},
+			expectedErr: "taskmanager preStop takes 120 seconds, which exceeds the termination grace period of 60 seconds",
+		},
+		{
+			name:        "negative sleep",
+			preStop:     &corev1.LifecycleHandler{Sleep: &corev1.SleepAction{Seconds: -1}},
+			expectedErr: "taskmanager preStop sleep must not be negative",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.validatePreStop(tt.preStop, "taskmanager")
+			if tt.expectedErr == "" {
+				assert.NilError(t, err)
+			} else {
+				assert.Error(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestValidateFineGrainedResources(t *testing.T) {
+	var validator = &Validator{}
+	var rpcPort int32 = 6122
+	var dataPort int32 = 6121
+	var queryPort int32 = 6125
+	var twoSlots int32 = 2
+	var maxSlots int32 = 1
+	var memoryProcessRatio int32 = 80
+	var v115, _ = version.NewVersion("1.15")
+	var v113, _ = version.NewVersion("1.13")
+	var newTaskManagerSpec = func(fineGrainedResources *FineGrainedResourcesSpec) *TaskManagerSpec {
+		return &TaskManagerSpec{
+			Ports: TaskManagerPorts{RPC: &rpcPort, Data: &dataPort, Query: &queryPort},
+			Resources: corev1.ResourceRequirements{
+				Limits: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("2"),
+					corev1.ResourceMemory: resource.MustParse("4Gi"),
+				},
+			},
+			MemoryProcessRatio:   &memoryProcessRatio,
+			FineGrainedResources: fineGrainedResources,
+		}
+	}
+
+	tests := []struct {
+		name                 string
+		flinkVersion         *version.Version
+		fineGrainedResources *FineGrainedResourcesSpec
+		expectedErr          string
+	}{
+		{
+			name:         "profiles fit in taskmanager",
+			flinkVersion: v115,
+			fineGrainedResources: &FineGrainedResourcesSpec{SlotProfiles: []SlotResourceProfile{
+				{Name: "source", Slots: &twoSlots, CPU: resource.MustParse("500m"), Memory: resource.MustParse("512Mi")},
+				{Name: "sink", CPU: resource.MustParse("1"), Memory: resource.MustParse("1Gi")},
+			}},
+		},
+		{
+			// The process memory of 3276Mi less the metaspace, JVM overhead, network and
+			// framework memory leaves 2167Mi to the slots.
+			name:         "memory over the task memory",
+			flinkVersion: v115,
+			fineGrainedResources: &FineGrainedResourcesSpec{SlotProfiles: []SlotResourceProfile{
+				{Name: "source", Slots: &twoSlots, CPU: resource.MustParse("500m"), Memory: resource.MustParse("1Gi")},
+				{Name: "sink", CPU: resource.MustParse("1"), Memory: resource.MustParse("2Gi")},
+			}},
+			expectedErr: "total memory of slot profiles 4Gi exceeds the task memory 2167Mi of taskmanager memory 4Gi",
+		},
+		{
+			name:         "unsupported flink version",
+			flinkVersion: v113,
+			fineGrainedResources: &FineGrainedResourcesSpec{SlotProfiles: []SlotResourceProfile{
+				{Name: "default", CPU: resource.MustParse("1"), Memory: resource.MustParse("1Gi")},
+			}},
+			expectedErr: "fineGrainedResources config cannot be used with flinkVersion < 1.14",
+		},
+		{
+			name:                 "no profiles",
+			flinkVersion:         v115,
+			fineGrainedResources: &FineGrainedResourcesSpec{},
+			expectedErr:          "taskmanager fineGrainedResources.slotProfiles is unspecified",
+		},
+		{
+			name:         "duplicate profile name",
+			flinkVersion: v115,
+			fineGrainedResources: &FineGrainedResourcesSpec{SlotProfiles: []SlotResourceProfile{
+				{Name: "default", CPU: resource.MustParse("500m"), Memory: resource.MustParse("1Gi")},
+				{Name: "default", CPU: resource.MustParse("500m"), Memory: resource.MustParse("1Gi")},
+			}},
+			expectedErr: `duplicate slot profile name "default" in taskmanager fineGrainedResources`,
+		},
+		{
+			name:         "cpu over-subscribed",
+			flinkVersion: v115,
+			fineGrainedResources: &FineGrainedResourcesSpec{SlotProfiles: []SlotResourceProfile{
+				{Name: "source", Slots: &twoSlots, CPU: resource.MustParse("1"), Memory: resource.MustParse("1Gi")},
+				{Name: "sink", CPU: resource.MustParse("500m"), Memory: resource.MustParse("1Gi")},
+			}},
+			expectedErr: "total cpu of slot profiles 2500m exceeds taskmanager cpu 2",
+		},
+		{
+			name:         "memory over-subscribed",
+			flinkVersion: v115,
+			fineGrainedResources: &FineGrainedResourcesSpec{SlotProfiles: []SlotResourceProfile{
+				{Name: "default", Slots: &twoSlots, CPU: resource.MustParse("1"), Memory: resource.MustParse("3Gi")},
+			}},
+			expectedErr: "total memory of slot profiles 6Gi exceeds the task memory 2167Mi of taskmanager memory 4Gi",
+		},
+		{
+			name:         "max slots less than taskmanager slots",
+			flinkVersion: v115,
+			fineGrainedResources: &FineGrainedResourcesSpec{
+				MaxSlots: &maxSlots,
+				SlotProfiles: []SlotResourceProfile{
+					{Name: "default", Slots: &twoSlots, CPU: resource.MustParse("1"), Memory: resource.MustParse("1Gi")},
+				},
+			},
+			expectedErr: "taskmanager fineGrainedResources.maxSlots 1 is less than the slots of a TaskManager 2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.validateTaskManager(tt.flinkVersion, newTaskManagerSpec(tt.fineGrainedResources))
+			if tt.expectedErr == "" {
+				assert.NilError(t, err)
+			} else {
+				assert.Error(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
+
 func TestInvalidJobSpec(t *testing.T) {
 	var jmReplicas int32 = DefaultJobManagerReplicas
 	var tmReplicas int32 = DefaultTaskManagerReplicas
@@ -381,7 +547,7 @@ func TestUpdateJob(t *testing.T) {
 	var newCluster = getSimpleFlinkCluster()
 	newCluster.Spec.Job.SavepointsDir = nil
 	err := validator.ValidateUpdate(&oldCluster, &newCluster)
-	expectedErr := "removing savepointsDir is not allowed"
+	expectedErr := "removing savepointsDir or state.savepoints.dir is not allowed"
 	assert.Equal(t, err.Error(), expectedErr)
 
 	// cannot change cluster type
@@ -401,7 +567,7 @@ func TestUpdateJob(t *testing.T) {
 	newCluster.Spec.Job.SavepointsDir = nil
 	newCluster.Spec.Job.JarFile = &jarFileNew
 	err = validator.ValidateUpdate(&oldCluster, &newCluster)
-	expectedErr = "updating job is not allowed when spec.job.savepointsDir was not provided"
+	expectedErr = "updating job is not allowed when neither spec.job.savepointsDir nor state.savepoints.dir in flinkProperties was provided"
 	assert.Equal(t, err.Error(), expectedErr)
 
 	// cannot update when takeSavepointOnUpdate is false and stale savepoint
@@ -617,7 +783,7 @@ func TestUserControlSavepoint(t *testing.T) {
 
 	var oldCluster3 = FlinkCluster{Spec: FlinkClusterSpec{Job: &JobSpec{}}}
 	var err3 = validator.ValidateUpdate(&oldCluster3, &newCluster)
-	var expectedErr3 = "savepoint is not allowed without spec.job.savepointsDir, annotation: flinkclusters.flinkoperator.k8s.io/user-control"
+	var expectedErr3 = "savepoint is not allowed without spec.job.savepointsDir or state.savepoints.dir in flinkProperties, annotation: flinkclusters.flinkoperator.k8s.io/user-control"
 	assert.Equal(t, err3.Error(), expectedErr3)
 
 	var oldCluster4 = FlinkCluster{Spec: FlinkClusterSpec{Job: &JobSpec{SavepointsDir: &savepointsDir}}}
@@ -705,212 +871,1810 @@ func TestUserControlInvalid(t *testing.T) {
 	}
 	var oldCluster = FlinkCluster{}
 	var err = validator.ValidateUpdate(&oldCluster, &newCluster)
-	var expectedErr = "invalid value for annotation key: flinkclusters.flinkoperator.k8s.io/user-control, value: cancel, available values: savepoint, job-cancel"
+	var expectedErr = "invalid value for annotation key: flinkclusters.flinkoperator.k8s.io/user-control, value: cancel, available values: savepoint, job-cancel, coordinated-savepoint"
 	assert.Equal(t, err.Error(), expectedErr)
 }
 
-func TestDupPort(t *testing.T) {
-	var jmReplicas int32 = 1
-	var rpcPort int32 = 8001
-	var blobPort int32 = 8002
-	var queryPort int32 = 8003
-	var uiPort int32 = 8004
-	var flinkPorts = JobManagerPorts{
-		RPC:   &rpcPort,
-		Blob:  &blobPort,
-		Query: &queryPort,
-		UI:    &uiPort,
+func TestUserControlCoordinatedSavepoint(t *testing.T) {
+	var validator = &Validator{}
+	var newCluster = FlinkCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{
+				ControlAnnotation: ControlNameCoordinatedSavepoint,
+			},
+		},
 	}
-	var jm = &JobManagerSpec{Replicas: &jmReplicas, AccessScope: AccessScopeVPC, Ports: flinkPorts,
-		ExtraPorts: []NamedPort{
-			{Name: "rpc", ContainerPort: 9001}}}
-	var err = validator.validateJobManager(nil, jm)
-	var expectedErr = "duplicate port name rpc in jobmanager, each port name of ports and extraPorts must be unique"
-	assert.Equal(t, err.Error(), expectedErr)
-
-	jm = &JobManagerSpec{Replicas: &jmReplicas, AccessScope: AccessScopeVPC, Ports: flinkPorts,
-		ExtraPorts: []NamedPort{
-			{Name: "monitoring", ContainerPort: 9249},
-			{Name: "monitoring", ContainerPort: 9259}}}
-	err = validator.validateJobManager(nil, jm)
-	expectedErr = "duplicate port name monitoring in jobmanager, each port name of ports and extraPorts must be unique"
-	assert.Equal(t, err.Error(), expectedErr)
-
-	jm = &JobManagerSpec{Replicas: &jmReplicas, AccessScope: AccessScopeVPC, Ports: flinkPorts,
-		ExtraPorts: []NamedPort{
-			{Name: "rpc2", ContainerPort: 8001}}}
-	err = validator.validateJobManager(nil, jm)
-	expectedErr = "duplicate containerPort 8001 in jobmanager, each port number of ports and extraPorts must be unique"
-	assert.Equal(t, err.Error(), expectedErr)
+	var err = validator.ValidateUpdate(&FlinkCluster{}, &newCluster)
+	assert.Equal(t, err.Error(), "coordinated-savepoint is not allowed without spec.coordinatedSavepoint, annotation: flinkclusters.flinkoperator.k8s.io/user-control")
 
-	jm = &JobManagerSpec{Replicas: &jmReplicas, AccessScope: AccessScopeVPC, Ports: flinkPorts,
-		ExtraPorts: []NamedPort{
-			{Name: "monitoring", ContainerPort: 9249},
-			{Name: "prometheus", ContainerPort: 9249}}}
-	err = validator.validateJobManager(nil, jm)
-	expectedErr = "duplicate containerPort 9249 in jobmanager, each port number of ports and extraPorts must be unique"
-	assert.Equal(t, err.Error(), expectedErr)
+	var oldCluster = FlinkCluster{
+		Spec: FlinkClusterSpec{
+			CoordinatedSavepoint: &CoordinatedSavepointSpec{JobNames: []string{"source", "sink"}},
+		},
+	}
+	err = validator.checkControlAnnotations(&oldCluster, &newCluster)
+	assert.NilError(t, err)
 }
 
-func getSimpleFlinkCluster() FlinkCluster {
-	var jmReplicas int32 = DefaultJobManagerReplicas
-	var tmReplicas int32 = DefaultTaskManagerReplicas
-	var rpcPort int32 = 8001
-	var blobPort int32 = 8002
-	var queryPort int32 = 8003
-	var uiPort int32 = 8004
-	var dataPort int32 = 8005
-	var memoryOffHeapRatio int32 = 25
-	var memoryOffHeapMin = resource.MustParse("600M")
-	var jarFile = "gs://my-bucket/myjob.jar"
-	var parallelism int32 = 2
-	var maxStateAge = MaxStateAgeToRestore
-	var restartPolicy = JobRestartPolicyFromSavepointOnFailure
-	var savepointDir = "/savepoint_dir"
-	var jobMode = JobModeDetached
-	resources := DefaultResources
-	return FlinkCluster{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      "mycluster",
-			Namespace: "default",
+func TestValidateCoordinatedSavepoint(t *testing.T) {
+	var validator = &Validator{}
+	var savepointsDir = "gs://my-bucket/savepoints"
+	tests := []struct {
+		name        string
+		spec        FlinkClusterSpec
+		expectedErr string
+	}{
+		{
+			name: "valid",
+			spec: FlinkClusterSpec{CoordinatedSavepoint: &CoordinatedSavepointSpec{
+				JobNames: []string{"source", "sink"}, SavepointsDir: &savepointsDir}},
 		},
-		Spec: FlinkClusterSpec{
-			FlinkVersion: "1.8",
-			Image: ImageSpec{
-				Name:       "flink:1.8.1",
-				PullPolicy: corev1.PullPolicy("Always"),
-			},
-			JobManager: &JobManagerSpec{
-				Replicas:    &jmReplicas,
-				AccessScope: AccessScopeVPC,
-				Ports: JobManagerPorts{
-					RPC:   &rpcPort,
-					Blob:  &blobPort,
-					Query: &queryPort,
-					UI:    &uiPort,
-				},
-				MemoryOffHeapRatio: &memoryOffHeapRatio,
-				MemoryOffHeapMin:   memoryOffHeapMin,
-				Resources:          resources,
-			},
-			TaskManager: &TaskManagerSpec{
-				Replicas: &tmReplicas,
-				Ports: TaskManagerPorts{
-					RPC:   &rpcPort,
-					Data:  &dataPort,
-					Query: &queryPort,
-				},
-				MemoryOffHeapRatio: &memoryOffHeapRatio,
-				MemoryOffHeapMin:   memoryOffHeapMin,
-				Resources:          resources,
+		{
+			name: "savepoints dir from flink properties",
+			spec: FlinkClusterSpec{
+				FlinkProperties:      map[string]string{"state.savepoints.dir": savepointsDir},
+				CoordinatedSavepoint: &CoordinatedSavepointSpec{JobNames: []string{"source"}},
 			},
-			Job: &JobSpec{
-				JarFile:                     &jarFile,
-				Parallelism:                 &parallelism,
-				MaxStateAgeToRestoreSeconds: &maxStateAge,
-				RestartPolicy:               &restartPolicy,
-				SavepointsDir:               &savepointDir,
-				CleanupPolicy: &CleanupPolicy{
-					AfterJobSucceeds: CleanupActionKeepCluster,
-					AfterJobFails:    CleanupActionDeleteTaskManager,
-				},
-				Mode: &jobMode,
+		},
+		{
+			name: "job cluster",
+			spec: FlinkClusterSpec{
+				Job:                  &JobSpec{},
+				CoordinatedSavepoint: &CoordinatedSavepointSpec{JobNames: []string{"source"}, SavepointsDir: &savepointsDir},
 			},
+			expectedErr: "coordinatedSavepoint is only supported for session clusters",
+		},
+		{
+			name:        "no job names",
+			spec:        FlinkClusterSpec{CoordinatedSavepoint: &CoordinatedSavepointSpec{SavepointsDir: &savepointsDir}},
+			expectedErr: "coordinatedSavepoint.jobNames must not be empty",
+		},
+		{
+			name: "duplicate job names",
+			spec: FlinkClusterSpec{CoordinatedSavepoint: &CoordinatedSavepointSpec{
+				JobNames: []string{"source", "source"}, SavepointsDir: &savepointsDir}},
+			expectedErr: "duplicate job name \"source\" in coordinatedSavepoint.jobNames",
+		},
+		{
+			name:        "no savepoints dir",
+			spec:        FlinkClusterSpec{CoordinatedSavepoint: &CoordinatedSavepointSpec{JobNames: []string{"source"}}},
+			expectedErr: "coordinatedSavepoint requires coordinatedSavepoint.savepointsDir or state.savepoints.dir in flinkProperties",
 		},
 	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.validateCoordinatedSavepoint(&FlinkCluster{Spec: tt.spec})
+			if tt.expectedErr == "" {
+				assert.NilError(t, err)
+			} else {
+				assert.Error(t, err, tt.expectedErr)
+			}
+		})
+	}
 }
 
-func TestFlinkClusterValidation(t *testing.T) {
-	longName := strings.Repeat("a", 254)
-
-	invalidJobManagerAnnotations := func() *FlinkCluster {
-		cluster := getSimpleFlinkCluster()
-		cluster.Spec.JobManager.PodAnnotations = map[string]string{
-			longName: "bar",
-		}
-		return &cluster
-	}
-	invalidJobManagerLabels := func() *FlinkCluster {
-		cluster := getSimpleFlinkCluster()
-		cluster.Spec.JobManager.PodLabels = map[string]string{
-			longName: "bar",
-		}
-		return &cluster
-	}
-	invalidTaskManagerAnnotations := func() *FlinkCluster {
-		cluster := getSimpleFlinkCluster()
-		cluster.Spec.TaskManager.PodAnnotations = map[string]string{
-			longName: "bar",
-		}
-		return &cluster
-	}
-	invalidTaskManagerLabels := func() *FlinkCluster {
-		cluster := getSimpleFlinkCluster()
-		cluster.Spec.TaskManager.PodLabels = map[string]string{
-			longName: "bar",
-		}
-		return &cluster
-	}
-	invalidJobAnnotations := func() *FlinkCluster {
-		cluster := getSimpleFlinkCluster()
-		cluster.Spec.Job.PodAnnotations = map[string]string{
-			longName: "bar",
-		}
-		return &cluster
-	}
-	invalidJobLabels := func() *FlinkCluster {
-		cluster := getSimpleFlinkCluster()
-		cluster.Spec.Job.PodLabels = map[string]string{
-			longName: "bar",
-		}
-		return &cluster
-	}
-	invalidClusterName := func() *FlinkCluster {
-		cluster := getSimpleFlinkCluster()
-		cluster.Name = "1-invalid-name"
-		return &cluster
-	}
-	invalidLongClusterName := func() *FlinkCluster {
-		cluster := getSimpleFlinkCluster()
-		cluster.Name = longName
-		return &cluster
+func TestValidateConfigChangeRestartPolicy(t *testing.T) {
+	var validator = &Validator{}
+	for _, policy := range []ConfigChangeRestartPolicy{
+		ConfigChangeRestartPolicyAlways,
+		ConfigChangeRestartPolicySensitiveOnly,
+		ConfigChangeRestartPolicyNever,
+	} {
+		assert.NilError(t, validator.validateConfigChangeRestartPolicy(&policy))
 	}
+	assert.NilError(t, validator.validateConfigChangeRestartPolicy(nil))
 
-	data := []struct {
-		testName    string
-		run         func() *FlinkCluster
+	var invalid ConfigChangeRestartPolicy = "OnFailure"
+	assert.Error(t, validator.validateConfigChangeRestartPolicy(&invalid), "invalid configChangeRestartPolicy: OnFailure")
+}
+
+func TestValidateJobStopMode(t *testing.T) {
+	var validator = &Validator{}
+	var savepointsDir = "gs://my-bucket/savepoints"
+	var graceful = JobStopModeGraceful
+	var cancel = JobStopModeCancel
+	tests := []struct {
+		name        string
+		spec        FlinkClusterSpec
 		expectedErr string
 	}{
 		{
-			"invalid jm annotations",
-			invalidJobManagerAnnotations,
-			fmt.Sprintf("spec.jobManager.podAnnotations: Invalid value: \"%s\": name part must be no more than 63 bytes", longName),
+			name: "graceful with savepoints",
+			spec: FlinkClusterSpec{Job: &JobSpec{StopMode: &graceful, SavepointsDir: &savepointsDir}},
 		},
 		{
-			"invalid jm labels",
-			invalidJobManagerLabels,
-			fmt.Sprintf("spec.jobManager.podLabels: Invalid value: \"%s\": name part must be no more than 63 bytes", longName),
+			name: "graceful with savepoints dir from flink properties",
+			spec: FlinkClusterSpec{
+				FlinkProperties: map[string]string{"state.savepoints.dir": savepointsDir},
+				Job:             &JobSpec{StopMode: &graceful},
+			},
 		},
 		{
-			"invalid tm annotations",
-			invalidTaskManagerAnnotations,
-			fmt.Sprintf("spec.taskManager.podAnnotations: Invalid value: \"%s\": name part must be no more than 63 bytes", longName),
+			name:        "graceful without savepoints",
+			spec:        FlinkClusterSpec{Job: &JobSpec{StopMode: &graceful}},
+			expectedErr: "job stopMode Graceful requires spec.job.savepointsDir or state.savepoints.dir in flinkProperties, set stopMode to Cancel to stop the job without savepoint",
 		},
 		{
-			"invalid tm labels",
-			invalidTaskManagerLabels,
-			fmt.Sprintf("spec.taskManager.podLabels: Invalid value: \"%s\": name part must be no more than 63 bytes", longName),
+			name: "cancel without savepoints",
+			spec: FlinkClusterSpec{Job: &JobSpec{StopMode: &cancel}},
 		},
 		{
-			"invalid job annotations",
-			invalidJobAnnotations,
-			fmt.Sprintf("spec.job.podAnnotations: Invalid value: \"%s\": name part must be no more than 63 bytes", longName),
+			name: "cancel with savepoints",
+			spec: FlinkClusterSpec{Job: &JobSpec{StopMode: &cancel, SavepointsDir: &savepointsDir}},
 		},
-		{
-			"invalid job labels",
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.validateJobStopMode(&FlinkCluster{Spec: tt.spec})
+			if tt.expectedErr == "" {
+				assert.NilError(t, err)
+			} else {
+				assert.Error(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestValidateNetworkPorts(t *testing.T) {
+	var validator = &Validator{}
+	var rpcPort int32 = 6123
+	var blobPort int32 = 6124
+	var queryPort int32 = 6125
+	var uiPort int32 = 8081
+	var tmRPCPort int32 = 6122
+	var tmDataPort int32 = 6121
+	tests := []struct {
+		name            string
+		flinkProperties map[string]string
+		expectedErr     string
+	}{
+		{
+			name: "custom ports",
+			flinkProperties: map[string]string{
+				"jobmanager.rpc.port":   "7123",
+				"blob.server.port":      "7124",
+				"query.server.port":     "7125",
+				"rest.port":             "9081",
+				"taskmanager.rpc.port":  "7122",
+				"taskmanager.data.port": "7121",
+			},
+		},
+		{
+			name:            "blob port collides with rest port",
+			flinkProperties: map[string]string{"blob.server.port": "8081"},
+			expectedErr:     "duplicate containerPort 8081 in jobmanager, each port number of ports and extraPorts must be unique",
+		},
+		{
+			name:            "rpc port collides with extra port",
+			flinkProperties: map[string]string{"jobmanager.rpc.port": "9249"},
+			expectedErr:     "duplicate containerPort 9249 in jobmanager, each port number of ports and extraPorts must be unique",
+		},
+		{
+			name:            "taskmanager data port collides with rpc port",
+			flinkProperties: map[string]string{"taskmanager.data.port": "6122"},
+			expectedErr:     "duplicate containerPort 6122 in taskmanager, each port number of ports and extraPorts must be unique",
+		},
+		{
+			name:            "port range",
+			flinkProperties: map[string]string{"taskmanager.rpc.port": "50100-50200", "blob.server.port": "8081,50100"},
+		},
+		{
+			name:            "port range of a single port property",
+			flinkProperties: map[string]string{"taskmanager.data.port": "50100-50200"},
+			expectedErr:     `taskmanager.data.port in flinkProperties must be a single port between 1 and 65535, got "50100-50200"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cluster = &FlinkCluster{
+				Spec: FlinkClusterSpec{
+					FlinkProperties: tt.flinkProperties,
+					JobManager: &JobManagerSpec{
+						Ports:      JobManagerPorts{RPC: &rpcPort, Blob: &blobPort, Query: &queryPort, UI: &uiPort},
+						ExtraPorts: []NamedPort{{Name: "metrics", ContainerPort: 9249}},
+					},
+					TaskManager: &TaskManagerSpec{
+						Ports: TaskManagerPorts{RPC: &tmRPCPort, Data: &tmDataPort, Query: &queryPort},
+					},
+				},
+			}
+			err := validator.validateNetworkPorts(cluster)
+			if tt.expectedErr == "" {
+				assert.NilError(t, err)
+			} else {
+				assert.Error(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestValidateExternalResources(t *testing.T) {
+	var validator = &Validator{}
+	var gpu = ExternalResourceSpec{Name: "gpu", ResourceName: "nvidia.com/gpu", Amount: 2}
+	var newResources = func(gpus string) corev1.ResourceRequirements {
+		var limits = corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("2"),
+			corev1.ResourceMemory: resource.MustParse("4Gi"),
+		}
+		if gpus != "" {
+			limits["nvidia.com/gpu"] = resource.MustParse(gpus)
+		}
+		return corev1.ResourceRequirements{Limits: limits}
+	}
+
+	tests := []struct {
+		name              string
+		gpus              string
+		externalResources []ExternalResourceSpec
+		flinkProperties   map[string]string
+		expectedErr       string
+	}{
+		{
+			name:              "consistent gpu config",
+			gpus:              "2",
+			externalResources: []ExternalResourceSpec{gpu},
+		},
+		{
+			name: "no external resources",
+		},
+		{
+			name:        "gpu request without flink config",
+			gpus:        "1",
+			expectedErr: "taskmanager resources request nvidia.com/gpu but no taskmanager externalResources entry exposes it to Flink",
+		},
+		{
+			name:              "flink config without gpu request",
+			externalResources: []ExternalResourceSpec{gpu},
+			expectedErr:       `taskmanager external resource "gpu" has amount 2 but taskmanager resources request 0 of nvidia.com/gpu`,
+		},
+		{
+			name:              "amount mismatch",
+			gpus:              "1",
+			externalResources: []ExternalResourceSpec{gpu},
+			expectedErr:       `taskmanager external resource "gpu" has amount 2 but taskmanager resources request 1 of nvidia.com/gpu`,
+		},
+		{
+			name:              "duplicate name",
+			gpus:              "2",
+			externalResources: []ExternalResourceSpec{gpu, {Name: "gpu", ResourceName: "amd.com/gpu", Amount: 1}},
+			expectedErr:       `duplicate external resource name "gpu" in taskmanager externalResources`,
+		},
+		{
+			name:            "gpu request with manual flink config",
+			gpus:            "1",
+			flinkProperties: map[string]string{"external-resources": "gpu", "external-resource.gpu.amount": "1"},
+		},
+		{
+			name:              "typed and manual flink config",
+			gpus:              "2",
+			externalResources: []ExternalResourceSpec{gpu},
+			flinkProperties:   map[string]string{"external-resources": "gpu"},
+			expectedErr:       "taskmanager externalResources cannot be used with external-resources in flinkProperties",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cluster = &FlinkCluster{
+				Spec: FlinkClusterSpec{
+					FlinkProperties: tt.flinkProperties,
+					TaskManager: &TaskManagerSpec{
+						Resources:         newResources(tt.gpus),
+						ExternalResources: tt.externalResources,
+					},
+				},
+			}
+			err := validator.validateExternalResources(cluster)
+			if tt.expectedErr == "" {
+				assert.NilError(t, err)
+			} else {
+				assert.Error(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestValidateLocalRecovery(t *testing.T) {
+	var validator = &Validator{}
+	var stateVolume = "local-state"
+	var sizeLimit = resource.MustParse("20Gi")
+	var volumes = []corev1.Volume{{Name: "local-state", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}}}
+
+	tests := []struct {
+		name            string
+		localRecovery   *LocalRecoverySpec
+		volumes         []corev1.Volume
+		flinkProperties map[string]string
+		expectedErr     string
+	}{
+		{
+			name: "no local recovery",
+		},
+		{
+			name:          "provisioned volume",
+			localRecovery: &LocalRecoverySpec{SizeLimit: &sizeLimit},
+		},
+		{
+			name:          "taskmanager volume",
+			localRecovery: &LocalRecoverySpec{VolumeName: &stateVolume},
+			volumes:       volumes,
+		},
+		{
+			name:            "enabled in flink config without local storage",
+			flinkProperties: map[string]string{"state.backend.local-recovery": "true"},
+			expectedErr:     "state.backend.local-recovery is enabled in flinkProperties without local storage, use taskmanager localRecovery to provision it",
+		},
+		{
+			name: "enabled in flink config with manual local storage",
+			flinkProperties: map[string]string{
+				"execution.state-recovery.from-local": "true",
+				"taskmanager.state.local.root-dirs":   "/local-state",
+			},
+		},
+		{
+			name:            "disabled in flink config",
+			localRecovery:   &LocalRecoverySpec{},
+			flinkProperties: map[string]string{"state.backend.local-recovery": "false"},
+			expectedErr:     "taskmanager localRecovery conflicts with state.backend.local-recovery: false in flinkProperties",
+		},
+		{
+			name:            "manual local storage",
+			localRecovery:   &LocalRecoverySpec{},
+			flinkProperties: map[string]string{"taskmanager.state.local.root-dirs": "/local-state"},
+			expectedErr:     "taskmanager localRecovery cannot be used with taskmanager.state.local.root-dirs in flinkProperties",
+		},
+		{
+			name:          "missing volume",
+			localRecovery: &LocalRecoverySpec{VolumeName: &stateVolume},
+			expectedErr:   `taskmanager localRecovery volumeName "local-state" does not match any taskmanager volume or volumeClaimTemplate`,
+		},
+		{
+			name:          "size limit of taskmanager volume",
+			localRecovery: &LocalRecoverySpec{VolumeName: &stateVolume, SizeLimit: &sizeLimit},
+			volumes:       volumes,
+			expectedErr:   "taskmanager localRecovery sizeLimit only applies to the provisioned volume, it cannot be used with volumeName",
+		},
+		{
+			name:          "reserved volume name",
+			localRecovery: &LocalRecoverySpec{},
+			volumes:       []corev1.Volume{{Name: "local-recovery"}},
+			expectedErr:   `taskmanager volume name "local-recovery" is reserved for the local recovery storage, set it as localRecovery volumeName to keep the local state in it`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cluster = &FlinkCluster{
+				Spec: FlinkClusterSpec{
+					FlinkProperties: tt.flinkProperties,
+					TaskManager: &TaskManagerSpec{
+						Volumes:       tt.volumes,
+						LocalRecovery: tt.localRecovery,
+					},
+				},
+			}
+			err := validator.validateLocalRecovery(cluster)
+			if tt.expectedErr == "" {
+				assert.NilError(t, err)
+			} else {
+				assert.Error(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestValidateAdaptiveScheduler(t *testing.T) {
+	var validator = &Validator{}
+	var adaptive = map[string]string{"jobmanager.scheduler": "adaptive"}
+	var one int32 = 1
+	var thirty int32 = 30
+	var sixty int32 = 60
+	var zero int32 = 0
+
+	tests := []struct {
+		name              string
+		adaptiveScheduler *AdaptiveSchedulerSpec
+		flinkProperties   map[string]string
+		expectedErr       string
+	}{
+		{
+			name: "no adaptive scheduler settings",
+		},
+		{
+			name:              "adaptive scheduler",
+			adaptiveScheduler: &AdaptiveSchedulerSpec{MinParallelismIncrease: &one, ResourceWaitTimeoutSeconds: &sixty, ResourceStabilizationTimeoutSeconds: &thirty},
+			flinkProperties:   adaptive,
+		},
+		{
+			name:              "reactive mode",
+			adaptiveScheduler: &AdaptiveSchedulerSpec{ResourceStabilizationTimeoutSeconds: &zero},
+			flinkProperties:   map[string]string{"scheduler-mode": "reactive"},
+		},
+		{
+			name:              "default scheduler",
+			adaptiveScheduler: &AdaptiveSchedulerSpec{MinParallelismIncrease: &one},
+			expectedErr:       "jobmanager adaptiveScheduler requires the adaptive scheduler, set jobmanager.scheduler: adaptive or scheduler-mode: reactive in flinkProperties",
+		},
+		{
+			name:              "non-adaptive scheduler",
+			adaptiveScheduler: &AdaptiveSchedulerSpec{MinParallelismIncrease: &one},
+			flinkProperties:   map[string]string{"jobmanager.scheduler": "default"},
+			expectedErr:       "jobmanager adaptiveScheduler requires the adaptive scheduler, set jobmanager.scheduler: adaptive or scheduler-mode: reactive in flinkProperties",
+		},
+		{
+			name:              "raw properties",
+			adaptiveScheduler: &AdaptiveSchedulerSpec{MinParallelismIncrease: &one},
+			flinkProperties: map[string]string{
+				"jobmanager.scheduler":                                "adaptive",
+				"jobmanager.adaptive-scheduler.resource-wait-timeout": "1 min",
+			},
+			expectedErr: "jobmanager adaptiveScheduler cannot be used with jobmanager.adaptive-scheduler.resource-wait-timeout in flinkProperties",
+		},
+		{
+			name:              "zero min parallelism increase",
+			adaptiveScheduler: &AdaptiveSchedulerSpec{MinParallelismIncrease: &zero},
+			flinkProperties:   adaptive,
+			expectedErr:       "jobmanager adaptiveScheduler minParallelismIncrease must be between 1 and 32768",
+		},
+		{
+			name:              "zero resource wait timeout",
+			adaptiveScheduler: &AdaptiveSchedulerSpec{ResourceWaitTimeoutSeconds: &zero},
+			flinkProperties:   adaptive,
+			expectedErr:       "jobmanager adaptiveScheduler resourceWaitTimeoutSeconds must be positive",
+		},
+		{
+			name:              "stabilization longer than resource wait",
+			adaptiveScheduler: &AdaptiveSchedulerSpec{ResourceWaitTimeoutSeconds: &thirty, ResourceStabilizationTimeoutSeconds: &sixty},
+			flinkProperties:   adaptive,
+			expectedErr:       "jobmanager adaptiveScheduler resourceStabilizationTimeoutSeconds 60 exceeds resourceWaitTimeoutSeconds 30",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cluster = &FlinkCluster{
+				Spec: FlinkClusterSpec{
+					FlinkProperties: tt.flinkProperties,
+					JobManager:      &JobManagerSpec{AdaptiveScheduler: tt.adaptiveScheduler},
+				},
+			}
+			err := validator.validateAdaptiveScheduler(cluster)
+			if tt.expectedErr == "" {
+				assert.NilError(t, err)
+			} else {
+				assert.Error(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestValidateSavepointTimeouts(t *testing.T) {
+	var validator = &Validator{}
+	var zero int32 = 0
+	var hour int32 = 3600
+	var twoHours int32 = 7200
+
+	tests := []struct {
+		name        string
+		timeouts    *SavepointTimeoutsSpec
+		expectedErr string
+	}{
+		{
+			name: "no savepoint timeouts",
+		},
+		{
+			name:     "savepoint timeouts",
+			timeouts: &SavepointTimeoutsSpec{UpdateSeconds: &twoHours, DeleteSeconds: &hour},
+		},
+		{
+			name:        "zero scheduled timeout",
+			timeouts:    &SavepointTimeoutsSpec{ScheduledSeconds: &zero},
+			expectedErr: "job savepointTimeouts scheduledSeconds must be positive",
+		},
+		{
+			name:        "unbounded delete timeout",
+			timeouts:    &SavepointTimeoutsSpec{DeleteSeconds: &twoHours},
+			expectedErr: "job savepointTimeouts deleteSeconds 7200 exceeds the maximum 3600",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.validateSavepointTimeouts(tt.timeouts)
+			if tt.expectedErr == "" {
+				assert.NilError(t, err)
+			} else {
+				assert.Error(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestValidateSavepointValidationWebhook(t *testing.T) {
+	var validator = &Validator{}
+	var zero int32 = 0
+
+	tests := []struct {
+		name        string
+		webhook     *SavepointValidationWebhookSpec
+		expectedErr string
+	}{
+		{
+			name: "no webhook",
+		},
+		{
+			name:    "webhook",
+			webhook: &SavepointValidationWebhookSpec{URL: "https://savepoint-validator.governance:8443/validate", FailurePolicy: SavepointValidationFailOpen},
+		},
+		{
+			name:        "relative url",
+			webhook:     &SavepointValidationWebhookSpec{URL: "/validate"},
+			expectedErr: `job savepointValidationWebhook url must be an http(s) URL, got "/validate"`,
+		},
+		{
+			name:        "zero timeout",
+			webhook:     &SavepointValidationWebhookSpec{URL: "http://savepoint-validator/validate", TimeoutSeconds: &zero},
+			expectedErr: "job savepointValidationWebhook timeoutSeconds must be positive",
+		},
+		{
+			name:        "invalid failure policy",
+			webhook:     &SavepointValidationWebhookSpec{URL: "http://savepoint-validator/validate", FailurePolicy: "Ignore"},
+			expectedErr: "job savepointValidationWebhook failurePolicy must be FailClosed or FailOpen, got Ignore",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.validateSavepointValidationWebhook(tt.webhook)
+			if tt.expectedErr == "" {
+				assert.NilError(t, err)
+			} else {
+				assert.Error(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestValidateFailoverStrategy(t *testing.T) {
+	var validator = &Validator{}
+	var region = FailoverStrategyRegion
+	var invalid = FailoverStrategy("restart-pipelined-region")
+
+	tests := []struct {
+		name             string
+		failoverStrategy *FailoverStrategy
+		flinkProperties  map[string]string
+		expectedErr      string
+	}{
+		{
+			name: "default",
+		},
+		{
+			name:             "typed",
+			failoverStrategy: &region,
+		},
+		{
+			name:            "raw property",
+			flinkProperties: map[string]string{"jobmanager.execution.failover-strategy": "Full"},
+		},
+		{
+			name:             "invalid typed",
+			failoverStrategy: &invalid,
+			expectedErr:      "jobmanager failoverStrategy must be region or full, got restart-pipelined-region",
+		},
+		{
+			name:            "invalid raw property",
+			flinkProperties: map[string]string{"jobmanager.execution.failover-strategy": "individual"},
+			expectedErr:     "invalid jobmanager.execution.failover-strategy in flinkProperties, must be region or full, got individual",
+		},
+		{
+			name:             "typed and raw property",
+			failoverStrategy: &region,
+			flinkProperties:  map[string]string{"jobmanager.execution.failover-strategy": "full"},
+			expectedErr:      "jobmanager failoverStrategy cannot be used with jobmanager.execution.failover-strategy in flinkProperties",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cluster = &FlinkCluster{
+				Spec: FlinkClusterSpec{
+					FlinkProperties: tt.flinkProperties,
+					JobManager:      &JobManagerSpec{FailoverStrategy: tt.failoverStrategy},
+				},
+			}
+			err := validator.validateFailoverStrategy(cluster)
+			if tt.expectedErr == "" {
+				assert.NilError(t, err)
+			} else {
+				assert.Error(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestValidateClassloaderResolveOrder(t *testing.T) {
+	var validator = &Validator{}
+	var parentFirst = ClassloaderResolveOrderParentFirst
+	var invalid = ClassloaderResolveOrder("user-first")
+
+	tests := []struct {
+		name            string
+		resolveOrder    *ClassloaderResolveOrder
+		flinkProperties map[string]string
+		expectedErr     string
+	}{
+		{
+			name: "default",
+		},
+		{
+			name:         "typed",
+			resolveOrder: &parentFirst,
+		},
+		{
+			name:            "raw property",
+			flinkProperties: map[string]string{"classloader.resolve-order": "Child-First"},
+		},
+		{
+			name:         "invalid typed",
+			resolveOrder: &invalid,
+			expectedErr:  "job classloaderResolveOrder must be child-first or parent-first, got user-first",
+		},
+		{
+			name:            "invalid raw property",
+			flinkProperties: map[string]string{"classloader.resolve-order": "parent"},
+			expectedErr:     "invalid classloader.resolve-order in flinkProperties, must be child-first or parent-first, got parent",
+		},
+		{
+			name:            "typed and raw property",
+			resolveOrder:    &parentFirst,
+			flinkProperties: map[string]string{"classloader.resolve-order": "child-first"},
+			expectedErr:     "job classloaderResolveOrder cannot be used with classloader.resolve-order in flinkProperties",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cluster = &FlinkCluster{
+				Spec: FlinkClusterSpec{
+					FlinkProperties: tt.flinkProperties,
+					Job:             &JobSpec{ClassloaderResolveOrder: tt.resolveOrder},
+				},
+			}
+			err := validator.validateClassloaderResolveOrder(cluster)
+			if tt.expectedErr == "" {
+				assert.NilError(t, err)
+			} else {
+				assert.Error(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestValidateExecutionMode(t *testing.T) {
+	var validator = &Validator{}
+	var streaming = JobExecutionModeStreaming
+	var invalid = JobExecutionMode("Automatic")
+
+	tests := []struct {
+		name            string
+		executionMode   *JobExecutionMode
+		flinkProperties map[string]string
+		expectedErr     string
+	}{
+		{
+			name: "default",
+		},
+		{
+			name:          "typed",
+			executionMode: &streaming,
+		},
+		{
+			name:            "raw property",
+			flinkProperties: map[string]string{"execution.runtime-mode": "BATCH"},
+		},
+		{
+			name:          "invalid typed",
+			executionMode: &invalid,
+			expectedErr:   "job executionMode must be Streaming or Batch, got Automatic",
+		},
+		{
+			name:            "typed and raw property",
+			executionMode:   &streaming,
+			flinkProperties: map[string]string{"execution.runtime-mode": "STREAMING"},
+			expectedErr:     "job executionMode cannot be used with execution.runtime-mode in flinkProperties",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cluster = &FlinkCluster{
+				Spec: FlinkClusterSpec{
+					FlinkProperties: tt.flinkProperties,
+					Job:             &JobSpec{ExecutionMode: tt.executionMode},
+				},
+			}
+			err := validator.validateExecutionMode(cluster)
+			if tt.expectedErr == "" {
+				assert.NilError(t, err)
+			} else {
+				assert.Error(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestValidateImageArchitecture(t *testing.T) {
+	var validator = &Validator{}
+	var arm64 = ImageArchitectureARM64
+	var multiArch = ImageArchitectureMultiArch
+	var archAffinity = func(operator corev1.NodeSelectorOperator, values ...string) *corev1.Affinity {
+		return &corev1.Affinity{NodeAffinity: &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+				NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+					MatchExpressions: []corev1.NodeSelectorRequirement{
+						{Key: "kubernetes.io/arch", Operator: operator, Values: values},
+					},
+				}},
+			},
+		}}
+	}
+
+	tests := []struct {
+		name           string
+		architecture   *ImageArchitecture
+		tmNodeSelector map[string]string
+		jmAffinity     *corev1.Affinity
+		expectedErr    string
+	}{
+		{
+			name:           "not declared",
+			tmNodeSelector: map[string]string{"kubernetes.io/arch": "amd64"},
+		},
+		{
+			name:           "multi-arch",
+			architecture:   &multiArch,
+			tmNodeSelector: map[string]string{"kubernetes.io/arch": "amd64"},
+		},
+		{
+			name:           "matching node selector and affinity",
+			architecture:   &arm64,
+			tmNodeSelector: map[string]string{"kubernetes.io/arch": "arm64"},
+			jmAffinity:     archAffinity(corev1.NodeSelectorOpIn, "amd64", "arm64"),
+		},
+		{
+			name:           "conflicting node selector",
+			architecture:   &arm64,
+			tmNodeSelector: map[string]string{"kubernetes.io/arch": "amd64"},
+			expectedErr:    `taskManager nodeSelector kubernetes.io/arch "amd64" conflicts with image architecture "arm64"`,
+		},
+		{
+			name:         "conflicting affinity",
+			architecture: &arm64,
+			jmAffinity:   archAffinity(corev1.NodeSelectorOpNotIn, "arm64"),
+			expectedErr:  `jobManager affinity requires nodes whose kubernetes.io/arch is not the image architecture "arm64"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cluster = &FlinkCluster{
+				Spec: FlinkClusterSpec{
+					Image:       ImageSpec{Name: "flink:1.18", Architecture: tt.architecture},
+					JobManager:  &JobManagerSpec{Affinity: tt.jmAffinity},
+					TaskManager: &TaskManagerSpec{NodeSelector: tt.tmNodeSelector},
+				},
+			}
+			err := validator.validateImageArchitecture(cluster)
+			if tt.expectedErr == "" {
+				assert.NilError(t, err)
+			} else {
+				assert.Error(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestValidateWatermarkAlignment(t *testing.T) {
+	var validator = &Validator{}
+	var v114, _ = version.NewVersion("1.14")
+	var v116, _ = version.NewVersion("1.16")
+	var v117, _ = version.NewVersion("1.17")
+	var one, thirty int32 = 1, 30
+	var allowUnaligned = true
+
+	tests := []struct {
+		name            string
+		flinkVersion    *version.Version
+		alignment       *WatermarkAlignmentSpec
+		flinkProperties map[string]string
+		expectedErr     string
+	}{
+		{
+			name:         "unset",
+			flinkVersion: v114,
+		},
+		{
+			name:         "valid",
+			flinkVersion: v116,
+			alignment:    &WatermarkAlignmentSpec{Group: "sources", MaxDriftSeconds: 20, UpdateIntervalSeconds: &one},
+		},
+		{
+			name:         "unaligned source splits",
+			flinkVersion: v117,
+			alignment:    &WatermarkAlignmentSpec{Group: "sources", MaxDriftSeconds: 20, AllowUnalignedSourceSplits: &allowUnaligned},
+		},
+		{
+			name:        "unknown flink version",
+			alignment:   &WatermarkAlignmentSpec{Group: "sources", MaxDriftSeconds: 20},
+			expectedErr: "job watermarkAlignment cannot be used with flinkVersion < 1.15",
+		},
+		{
+			name:         "unsupported flink version",
+			flinkVersion: v114,
+			alignment:    &WatermarkAlignmentSpec{Group: "sources", MaxDriftSeconds: 20},
+			expectedErr:  "job watermarkAlignment cannot be used with flinkVersion < 1.15",
+		},
+		{
+			name:         "unaligned source splits unsupported",
+			flinkVersion: v116,
+			alignment:    &WatermarkAlignmentSpec{Group: "sources", MaxDriftSeconds: 20, AllowUnalignedSourceSplits: &allowUnaligned},
+			expectedErr:  "job watermarkAlignment allowUnalignedSourceSplits cannot be used with flinkVersion < 1.17",
+		},
+		{
+			name:         "no group",
+			flinkVersion: v116,
+			alignment:    &WatermarkAlignmentSpec{Group: " ", MaxDriftSeconds: 20},
+			expectedErr:  "job watermarkAlignment group is unspecified",
+		},
+		{
+			name:         "no max drift",
+			flinkVersion: v116,
+			alignment:    &WatermarkAlignmentSpec{Group: "sources"},
+			expectedErr:  "job watermarkAlignment maxDriftSeconds must be positive",
+		},
+		{
+			name:         "update interval exceeds max drift",
+			flinkVersion: v116,
+			alignment:    &WatermarkAlignmentSpec{Group: "sources", MaxDriftSeconds: 20, UpdateIntervalSeconds: &thirty},
+			expectedErr:  "job watermarkAlignment updateIntervalSeconds 30 exceeds maxDriftSeconds 20",
+		},
+		{
+			name:            "raw property",
+			flinkVersion:    v117,
+			alignment:       &WatermarkAlignmentSpec{Group: "sources", MaxDriftSeconds: 20, AllowUnalignedSourceSplits: &allowUnaligned},
+			flinkProperties: map[string]string{"pipeline.watermark-alignment.allow-unaligned-source-splits": "false"},
+			expectedErr: "job watermarkAlignment allowUnalignedSourceSplits cannot be used with " +
+				"pipeline.watermark-alignment.allow-unaligned-source-splits in flinkProperties",
+		},
+		{
+			name:            "raw property without allowUnalignedSourceSplits",
+			flinkVersion:    v117,
+			alignment:       &WatermarkAlignmentSpec{Group: "sources", MaxDriftSeconds: 20},
+			flinkProperties: map[string]string{"pipeline.watermark-alignment.allow-unaligned-source-splits": "true"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cluster = &FlinkCluster{
+				Spec: FlinkClusterSpec{
+					FlinkProperties: tt.flinkProperties,
+					Job:             &JobSpec{WatermarkAlignment: tt.alignment},
+				},
+			}
+			err := validator.validateWatermarkAlignment(tt.flinkVersion, cluster)
+			if tt.expectedErr == "" {
+				assert.NilError(t, err)
+			} else {
+				assert.Error(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestValidateCheckpointTuning(t *testing.T) {
+	var validator = &Validator{}
+	var zero, one, sixty, ninety int32 = 0, 1, 60, 90
+
+	tests := []struct {
+		name                     string
+		checkpointTimeoutSeconds *int32
+		maxConcurrentCheckpoints *int32
+		maxStateAgeSeconds       *int32
+		flinkProperties          map[string]string
+		expectedErr              string
+	}{
+		{
+			name: "unset",
+		},
+		{
+			name:                     "valid",
+			checkpointTimeoutSeconds: &sixty,
+			maxConcurrentCheckpoints: &one,
+			maxStateAgeSeconds:       &ninety,
+			flinkProperties:          map[string]string{"execution.checkpointing.interval": "30s"},
+		},
+		{
+			name:                     "no concurrency",
+			maxConcurrentCheckpoints: &zero,
+			expectedErr:              "job maxConcurrentCheckpoints must be >= 1",
+		},
+		{
+			name:                     "no timeout",
+			checkpointTimeoutSeconds: &zero,
+			expectedErr:              "job checkpointTimeoutSeconds must be positive",
+		},
+		{
+			name:                     "timeout not greater than interval",
+			checkpointTimeoutSeconds: &sixty,
+			flinkProperties:          map[string]string{"execution.checkpointing.interval": "1 min"},
+			expectedErr:              "job checkpointTimeoutSeconds 60 must be greater than execution.checkpointing.interval 1m0s",
+		},
+		{
+			name:                     "timeout exceeds max state age",
+			checkpointTimeoutSeconds: &ninety,
+			maxStateAgeSeconds:       &sixty,
+			expectedErr:              "job checkpointTimeoutSeconds 90 exceeds maxStateAgeToRestoreSeconds 60",
+		},
+		{
+			name:                     "typed and raw timeout",
+			checkpointTimeoutSeconds: &sixty,
+			flinkProperties:          map[string]string{"execution.checkpointing.timeout": "5min"},
+			expectedErr:              "job checkpointTimeoutSeconds cannot be used with execution.checkpointing.timeout in flinkProperties",
+		},
+		{
+			name:                     "typed and raw concurrency",
+			maxConcurrentCheckpoints: &one,
+			flinkProperties:          map[string]string{"execution.checkpointing.max-concurrent-checkpoints": "2"},
+			expectedErr:              "job maxConcurrentCheckpoints cannot be used with execution.checkpointing.max-concurrent-checkpoints in flinkProperties",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cluster = &FlinkCluster{
+				Spec: FlinkClusterSpec{
+					FlinkProperties: tt.flinkProperties,
+					Job: &JobSpec{
+						CheckpointTimeoutSeconds:    tt.checkpointTimeoutSeconds,
+						MaxConcurrentCheckpoints:    tt.maxConcurrentCheckpoints,
+						MaxStateAgeToRestoreSeconds: tt.maxStateAgeSeconds,
+					},
+				},
+			}
+			err := validator.validateCheckpointTuning(cluster)
+			if tt.expectedErr == "" {
+				assert.NilError(t, err)
+			} else {
+				assert.Error(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestValidateRESTJarUpload(t *testing.T) {
+	var validator = &Validator{}
+	var enabled = true
+	var detached = JobModeDetached
+	var blocking = JobModeBlocking
+	var localJar = "/opt/flink/job.jar"
+	var httpsJar = "https://artifacts.example.com/job.jar"
+	var gcsJar = "gs://my-bucket/job.jar"
+	var tests = []struct {
+		name        string
+		jobSpec     JobSpec
+		expectedErr string
+	}{
+		{
+			name:    "disabled",
+			jobSpec: JobSpec{JarFile: &gcsJar, Mode: &blocking},
+		},
+		{
+			name:    "local jar",
+			jobSpec: JobSpec{RESTJarUpload: &enabled, JarFile: &localJar, Mode: &detached},
+		},
+		{
+			name:    "https jar",
+			jobSpec: JobSpec{RESTJarUpload: &enabled, JarFile: &httpsJar},
+		},
+		{
+			name:        "blocking",
+			jobSpec:     JobSpec{RESTJarUpload: &enabled, JarFile: &localJar, Mode: &blocking},
+			expectedErr: "job restJarUpload requires mode Detached",
+		},
+		{
+			name:        "no jar",
+			jobSpec:     JobSpec{RESTJarUpload: &enabled, Mode: &detached},
+			expectedErr: "job restJarUpload requires jarFile",
+		},
+		{
+			name:        "remote jar the submitter cannot read",
+			jobSpec:     JobSpec{RESTJarUpload: &enabled, JarFile: &gcsJar},
+			expectedErr: "job restJarUpload requires a local or http(s) jarFile, got gs://my-bucket/job.jar",
+		},
+		{
+			name:        "classpath",
+			jobSpec:     JobSpec{RESTJarUpload: &enabled, JarFile: &localJar, ClassPath: []string{"file:///opt/lib.jar"}},
+			expectedErr: "job restJarUpload cannot be used with classPath",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.validateRESTJarUpload(&tt.jobSpec)
+			if tt.expectedErr == "" {
+				assert.NilError(t, err)
+			} else {
+				assert.Error(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestValidateRestartEscalation(t *testing.T) {
+	var validator = &Validator{}
+	var never = JobRestartPolicyNever
+	var fromSavepoint = JobRestartPolicyFromSavepointOnFailure
+	var negative = int32(-1)
+	var tests = []struct {
+		name            string
+		restartPolicy   *JobRestartPolicy
+		escalation      *RestartEscalationSpec
+		flinkProperties map[string]string
+		expectedErr     string
+	}{
+		{
+			name:          "unset",
+			restartPolicy: &never,
+		},
+		{
+			name:          "valid",
+			restartPolicy: &fromSavepoint,
+			escalation:    &RestartEscalationSpec{FlinkRestartAttempts: 3},
+		},
+		{
+			name:          "operator does not restart",
+			restartPolicy: &never,
+			escalation:    &RestartEscalationSpec{FlinkRestartAttempts: 3},
+			expectedErr:   "job restartEscalation requires restartPolicy FromSavepointOnFailure",
+		},
+		{
+			name:            "typed and raw restart strategy",
+			restartPolicy:   &fromSavepoint,
+			escalation:      &RestartEscalationSpec{FlinkRestartAttempts: 3},
+			flinkProperties: map[string]string{"restart-strategy.fixed-delay.attempts": "10"},
+			expectedErr:     "job restartEscalation cannot be used with restart-strategy.fixed-delay.attempts in flinkProperties",
+		},
+		{
+			name:          "negative delay",
+			restartPolicy: &fromSavepoint,
+			escalation:    &RestartEscalationSpec{FlinkRestartAttempts: 3, FlinkRestartDelaySeconds: &negative},
+			expectedErr:   "job restartEscalation flinkRestartDelaySeconds must be >= 0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cluster = &FlinkCluster{
+				Spec: FlinkClusterSpec{
+					FlinkProperties: tt.flinkProperties,
+					Job: &JobSpec{
+						RestartPolicy:     tt.restartPolicy,
+						RestartEscalation: tt.escalation,
+					},
+				},
+			}
+			err := validator.validateRestartEscalation(cluster)
+			if tt.expectedErr == "" {
+				assert.NilError(t, err)
+			} else {
+				assert.Error(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestValidateRocksDBOptions(t *testing.T) {
+	var validator = &Validator{}
+	var rocksdb = map[string]string{"state.backend.type": "rocksdb", "taskmanager.numberOfTaskSlots": "2"}
+	var enabled = true
+	var disabled = false
+	var ratio int32 = 60
+	var highPriorityRatio int32 = 50
+	var quantity = func(s string) *resource.Quantity {
+		q := resource.MustParse(s)
+		return &q
+	}
+
+	tests := []struct {
+		name            string
+		rocksDB         *RocksDBOptions
+		flinkProperties map[string]string
+		expectedErr     string
+	}{
+		{
+			name: "no rocksDB options",
+		},
+		{
+			name:            "managed memory",
+			rocksDB:         &RocksDBOptions{ManagedMemory: &enabled, WriteBufferRatio: &ratio},
+			flinkProperties: rocksdb,
+		},
+		{
+			name:            "fixed memory",
+			rocksDB:         &RocksDBOptions{FixedMemoryPerSlot: quantity("512Mi")},
+			flinkProperties: rocksdb,
+		},
+		{
+			name:            "unbounded memory",
+			rocksDB:         &RocksDBOptions{ManagedMemory: &disabled, BlockCacheSize: quantity("256Mi"), WriteBufferSize: quantity("64Mi")},
+			flinkProperties: rocksdb,
+		},
+		{
+			name:        "default state backend",
+			rocksDB:     &RocksDBOptions{ManagedMemory: &enabled},
+			expectedErr: "taskmanager rocksDB requires the rocksdb state backend, set state.backend.type: rocksdb in flinkProperties, got hashmap",
+		},
+		{
+			name:            "forst state backend",
+			rocksDB:         &RocksDBOptions{ManagedMemory: &enabled},
+			flinkProperties: map[string]string{"state.backend.type": "forst"},
+			expectedErr:     "taskmanager rocksDB requires the rocksdb state backend, set state.backend.type: rocksdb in flinkProperties, got forst",
+		},
+		{
+			name:    "raw properties",
+			rocksDB: &RocksDBOptions{ManagedMemory: &enabled},
+			flinkProperties: map[string]string{
+				"state.backend.type":                     "rocksdb",
+				"state.backend.rocksdb.block.cache-size": "64mb",
+			},
+			expectedErr: "taskmanager rocksDB cannot be used with state.backend.rocksdb.block.cache-size in flinkProperties",
+		},
+		{
+			name:            "fixed and managed memory",
+			rocksDB:         &RocksDBOptions{ManagedMemory: &enabled, FixedMemoryPerSlot: quantity("512Mi")},
+			flinkProperties: rocksdb,
+			expectedErr:     "taskmanager rocksDB fixedMemoryPerSlot cannot be used with managedMemory: true",
+		},
+		{
+			name:            "block cache with managed memory",
+			rocksDB:         &RocksDBOptions{BlockCacheSize: quantity("256Mi")},
+			flinkProperties: rocksdb,
+			expectedErr: "taskmanager rocksDB blockCacheSize and writeBufferSize only apply with managedMemory: false " +
+				"and without fixedMemoryPerSlot, the RocksDB memory is bounded otherwise",
+		},
+		{
+			name:            "write buffer with fixed memory",
+			rocksDB:         &RocksDBOptions{ManagedMemory: &disabled, FixedMemoryPerSlot: quantity("512Mi"), WriteBufferSize: quantity("64Mi")},
+			flinkProperties: rocksdb,
+			expectedErr: "taskmanager rocksDB blockCacheSize and writeBufferSize only apply with managedMemory: false " +
+				"and without fixedMemoryPerSlot, the RocksDB memory is bounded otherwise",
+		},
+		{
+			name:            "zero fixed memory",
+			rocksDB:         &RocksDBOptions{FixedMemoryPerSlot: quantity("0")},
+			flinkProperties: rocksdb,
+			expectedErr:     "taskmanager rocksDB fixedMemoryPerSlot must be positive",
+		},
+		{
+			name:            "ratios above 100 percent",
+			rocksDB:         &RocksDBOptions{WriteBufferRatio: &ratio, HighPriorityPoolRatio: &highPriorityRatio},
+			flinkProperties: rocksdb,
+			expectedErr:     "taskmanager rocksDB writeBufferRatio 60 and highPriorityPoolRatio 50 exceed 100 percent",
+		},
+		{
+			name:            "fixed memory above taskmanager memory",
+			rocksDB:         &RocksDBOptions{FixedMemoryPerSlot: quantity("1Gi")},
+			flinkProperties: rocksdb,
+			expectedErr:     "taskmanager rocksDB fixedMemoryPerSlot 1Gi for 2 slots exceeds the taskmanager memory 2Gi",
+		},
+		{
+			name:            "block cache above taskmanager memory",
+			rocksDB:         &RocksDBOptions{ManagedMemory: &disabled, BlockCacheSize: quantity("2Gi")},
+			flinkProperties: rocksdb,
+			expectedErr:     "taskmanager rocksDB blockCacheSize 2Gi exceeds the taskmanager memory 2Gi",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cluster = &FlinkCluster{
+				Spec: FlinkClusterSpec{
+					FlinkProperties: tt.flinkProperties,
+					TaskManager: &TaskManagerSpec{
+						RocksDB: tt.rocksDB,
+						Resources: corev1.ResourceRequirements{
+							Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("2Gi")},
+						},
+					},
+				},
+			}
+			err := validator.validateRocksDBOptions(cluster)
+			if tt.expectedErr == "" {
+				assert.NilError(t, err)
+			} else {
+				assert.Error(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestValidateMemoryFractions(t *testing.T) {
+	var validator = &Validator{}
+	tests := []struct {
+		name            string
+		flinkProperties map[string]string
+		expectedErr     string
+	}{
+		{
+			name: "defaults",
+		},
+		{
+			name: "valid fractions",
+			flinkProperties: map[string]string{
+				"taskmanager.memory.managed.fraction":      "0.6",
+				"taskmanager.memory.network.fraction":      "0.2",
+				"taskmanager.memory.jvm-overhead.fraction": "0.15",
+			},
+		},
+		{
+			name: "fractions over one hundred percent",
+			flinkProperties: map[string]string{
+				"taskmanager.memory.managed.fraction": "0.8",
+				"taskmanager.memory.network.fraction": "0.3",
+			},
+			expectedErr: "taskmanager memory fractions in flinkProperties sum to 1.1 of the total Flink memory, they must sum to less than 1: " +
+				"taskmanager.memory.managed.fraction=0.8, taskmanager.memory.network.fraction=0.3",
+		},
+		{
+			name:            "fraction with network default sums to one",
+			flinkProperties: map[string]string{"taskmanager.memory.managed.fraction": "0.9"},
+			expectedErr: "taskmanager memory fractions in flinkProperties sum to 1 of the total Flink memory, they must sum to less than 1: " +
+				"taskmanager.memory.managed.fraction=0.9, taskmanager.memory.network.fraction=0.1 (default)",
+		},
+		{
+			name: "absolute managed size overrides fraction",
+			flinkProperties: map[string]string{
+				"taskmanager.memory.managed.fraction": "0.8",
+				"taskmanager.memory.managed.size":     "1g",
+				"taskmanager.memory.network.fraction": "0.3",
+			},
+		},
+		{
+			name: "fixed network size overrides fraction",
+			flinkProperties: map[string]string{
+				"taskmanager.memory.managed.fraction": "0.8",
+				"taskmanager.memory.network.fraction": "0.3",
+				"taskmanager.memory.network.min":      "256mb",
+				"taskmanager.memory.network.max":      "256mb",
+			},
+		},
+		{
+			name: "network range keeps fraction in effect",
+			flinkProperties: map[string]string{
+				"taskmanager.memory.managed.fraction": "0.8",
+				"taskmanager.memory.network.fraction": "0.3",
+				"taskmanager.memory.network.min":      "64mb",
+				"taskmanager.memory.network.max":      "1gb",
+			},
+			expectedErr: "taskmanager memory fractions in flinkProperties sum to 1.1 of the total Flink memory, they must sum to less than 1: " +
+				"taskmanager.memory.managed.fraction=0.8, taskmanager.memory.network.fraction=0.3",
+		},
+		{
+			name:            "fraction out of range",
+			flinkProperties: map[string]string{"jobmanager.memory.jvm-overhead.fraction": "1.5"},
+			expectedErr:     "jobmanager.memory.jvm-overhead.fraction in flinkProperties must be at least 0 and less than 1, got 1.5",
+		},
+		{
+			name:            "fraction not a number",
+			flinkProperties: map[string]string{"taskmanager.memory.network.fraction": "10%"},
+			expectedErr:     `taskmanager.memory.network.fraction in flinkProperties must be a number, got "10%"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.validateMemoryFractions(ParsedFlinkConfig(tt.flinkProperties))
+			if tt.expectedErr == "" {
+				assert.NilError(t, err)
+			} else {
+				assert.Error(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestValidateMaxParallelism(t *testing.T) {
+	var validator = &Validator{}
+	var int32Ptr = func(v int32) *int32 { return &v }
+	tests := []struct {
+		name            string
+		parallelism     *int32
+		replicas        *int32
+		flinkProperties map[string]string
+		expectedErr     string
+	}{
+		{
+			name:            "in range",
+			parallelism:     int32Ptr(64),
+			flinkProperties: map[string]string{"pipeline.max-parallelism": "128"},
+		},
+		{
+			name:            "equal",
+			parallelism:     int32Ptr(128),
+			flinkProperties: map[string]string{"pipeline.max-parallelism": "128"},
+		},
+		{
+			name:            "over max",
+			parallelism:     int32Ptr(200),
+			flinkProperties: map[string]string{"pipeline.max-parallelism": "128"},
+			expectedErr:     "job parallelism 200 is greater than pipeline.max-parallelism 128 in flinkProperties",
+		},
+		{
+			name:            "derived parallelism over max",
+			replicas:        int32Ptr(100),
+			flinkProperties: map[string]string{"pipeline.max-parallelism": "128", "taskmanager.numberOfTaskSlots": "2"},
+			expectedErr:     "job parallelism derived from taskmanager replicas * task slots 200 is greater than pipeline.max-parallelism 128 in flinkProperties",
+		},
+		{
+			name:        "unset max parallelism is derived from the parallelism",
+			parallelism: int32Ptr(1000),
+		},
+		{
+			name:        "over the derived upper bound",
+			parallelism: int32Ptr(40000),
+			expectedErr: "job parallelism 40000 is greater than the max parallelism 32768 Flink derives when pipeline.max-parallelism is unset",
+		},
+		{
+			name:            "invalid max parallelism",
+			parallelism:     int32Ptr(1),
+			flinkProperties: map[string]string{"pipeline.max-parallelism": "many"},
+			expectedErr:     "invalid pipeline.max-parallelism: many in flinkProperties",
+		},
+		{
+			name:            "max parallelism over the upper bound",
+			parallelism:     int32Ptr(1),
+			flinkProperties: map[string]string{"pipeline.max-parallelism": "65536"},
+			expectedErr:     "pipeline.max-parallelism in flinkProperties must be between 1 and 32768, got 65536",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cluster = &FlinkCluster{
+				Spec: FlinkClusterSpec{
+					FlinkProperties: tt.flinkProperties,
+					Job:             &JobSpec{Parallelism: tt.parallelism},
+					TaskManager:     &TaskManagerSpec{Replicas: tt.replicas},
+				},
+			}
+			err := validator.validateMaxParallelism(cluster)
+			if tt.expectedErr == "" {
+				assert.NilError(t, err)
+			} else {
+				assert.Error(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestValidateJobResultStore(t *testing.T) {
+	var validator = &Validator{}
+	var haProperties = map[string]string{
+		"high-availability":            "kubernetes",
+		"kubernetes.cluster-id":        "my-cluster",
+		"high-availability.storageDir": "gs://my-bucket/ha",
+	}
+	var withProperties = func(props map[string]string) map[string]string {
+		var merged = maps.Clone(haProperties)
+		maps.Copy(merged, props)
+		return merged
+	}
+	var savepointsDir = "gs://my-bucket/savepoints/"
+
+	tests := []struct {
+		name            string
+		flinkVersion    string
+		jobResultStore  *JobResultStoreSpec
+		flinkProperties map[string]string
+		savepointsDir   *string
+		expectedErr     string
+	}{
+		{
+			name: "no job result store",
+		},
+		{
+			name:            "durable storage dir",
+			jobResultStore:  &JobResultStoreSpec{StorageDir: "gs://my-bucket/job-results"},
+			flinkProperties: withProperties(map[string]string{"state.checkpoints.dir": "gs://my-bucket/checkpoints"}),
+			savepointsDir:   &savepointsDir,
+		},
+		{
+			name:            "storage path in flink config",
+			flinkProperties: map[string]string{"job-result-store.storage-path": "s3://my-bucket/job-results"},
+		},
+		{
+			name:            "typed and flink config",
+			jobResultStore:  &JobResultStoreSpec{StorageDir: "gs://my-bucket/job-results"},
+			flinkProperties: withProperties(map[string]string{"job-result-store.delete-on-commit": "false"}),
+			expectedErr:     "jobResultStore cannot be used with job-result-store.delete-on-commit in flinkProperties",
+		},
+		{
+			name:            "unsupported flink version",
+			flinkVersion:    "1.14",
+			jobResultStore:  &JobResultStoreSpec{StorageDir: "gs://my-bucket/job-results"},
+			flinkProperties: haProperties,
+			expectedErr:     "jobResultStore cannot be used with flinkVersion < 1.15",
+		},
+		{
+			name:           "without high availability",
+			jobResultStore: &JobResultStoreSpec{StorageDir: "gs://my-bucket/job-results"},
+			expectedErr:    "jobResultStore requires high availability to be enabled in flinkProperties",
+		},
+		{
+			name:            "local path",
+			jobResultStore:  &JobResultStoreSpec{StorageDir: "/opt/flink/job-results"},
+			flinkProperties: haProperties,
+			expectedErr:     "job result store directory /opt/flink/job-results is not durable, use a shared file system, e.g., gs:// or s3://",
+		},
+		{
+			name:            "file uri in flink config",
+			flinkProperties: map[string]string{"job-result-store.storage-path": "file:///tmp/job-results"},
+		},
+		{
+			name:            "same dir as checkpoints",
+			jobResultStore:  &JobResultStoreSpec{StorageDir: "gs://my-bucket/checkpoints"},
+			flinkProperties: withProperties(map[string]string{"execution.checkpointing.dir": "gs://my-bucket/checkpoints/"}),
+			expectedErr:     "job result store directory gs://my-bucket/checkpoints must differ from the checkpoint directory",
+		},
+		{
+			name:            "same dir as savepoints",
+			jobResultStore:  &JobResultStoreSpec{StorageDir: "gs://my-bucket/savepoints"},
+			flinkProperties: haProperties,
+			savepointsDir:   &savepointsDir,
+			expectedErr:     "job result store directory gs://my-bucket/savepoints must differ from the savepoint directory",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cluster = &FlinkCluster{
+				Spec: FlinkClusterSpec{
+					FlinkProperties: tt.flinkProperties,
+					JobResultStore:  tt.jobResultStore,
+					Job:             &JobSpec{SavepointsDir: tt.savepointsDir},
+				},
+			}
+			var flinkVersion = version.Must(version.NewVersion("1.18"))
+			if tt.flinkVersion != "" {
+				flinkVersion = version.Must(version.NewVersion(tt.flinkVersion))
+			}
+			err := validator.validateJobResultStore(flinkVersion, cluster)
+			if tt.expectedErr == "" {
+				assert.NilError(t, err)
+			} else {
+				assert.Error(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestValidateApplicationHighAvailability(t *testing.T) {
+	var validator = &Validator{}
+	var applicationMode = JobModeApplication
+	var detachedMode = JobModeDetached
+	var haProperties = map[string]string{
+		"high-availability":            "kubernetes",
+		"kubernetes.cluster-id":        "my-cluster",
+		"high-availability.storageDir": "gs://my-bucket/ha",
+	}
+	var withProperties = func(props map[string]string) map[string]string {
+		var merged = maps.Clone(haProperties)
+		maps.Copy(merged, props)
+		return merged
+	}
+
+	tests := []struct {
+		name            string
+		flinkVersion    string
+		mode            *JobMode
+		jobResultStore  *JobResultStoreSpec
+		flinkProperties map[string]string
+		expectedErr     string
+	}{
+		{
+			name:            "application mode with job result store",
+			mode:            &applicationMode,
+			jobResultStore:  &JobResultStoreSpec{StorageDir: "gs://my-bucket/job-results"},
+			flinkProperties: haProperties,
+		},
+		{
+			name:            "application mode with storage path in flink config",
+			mode:            &applicationMode,
+			flinkProperties: withProperties(map[string]string{"job-result-store.storage-path": "gs://my-bucket/job-results"}),
+		},
+		{
+			name: "application mode without high availability",
+			mode: &applicationMode,
+		},
+		{
+			name:            "detached mode with high availability",
+			mode:            &detachedMode,
+			flinkProperties: haProperties,
+		},
+		{
+			name:            "application mode with job result store in ha storage dir",
+			mode:            &applicationMode,
+			flinkProperties: haProperties,
+		},
+		{
+			name:            "application mode with job result store disabled",
+			mode:            &applicationMode,
+			flinkProperties: withProperties(map[string]string{"job-result-store.storage-path": " "}),
+			expectedErr: "application mode with high availability requires a job result store, " +
+				"job-result-store.storage-path cannot be blank so that a completed job is not run again after a JobManager failover",
+		},
+		{
+			name:            "application mode with non-durable storage path",
+			mode:            &applicationMode,
+			flinkProperties: withProperties(map[string]string{"job-result-store.storage-path": "/tmp/job-results"}),
+			expectedErr: "application mode with high availability requires a durable job result store, " +
+				"directory /tmp/job-results is not durable, use a shared file system, e.g., gs:// or s3://",
+		},
+		{
+			name:            "application mode with non-durable ha storage dir",
+			mode:            &applicationMode,
+			flinkProperties: withProperties(map[string]string{"high-availability.storageDir": "file:///flink/ha"}),
+			expectedErr: "application mode with high availability requires a durable job result store, " +
+				"directory file:///flink/ha is not durable, use a shared file system, e.g., gs:// or s3://",
+		},
+		{
+			name:            "application mode with unsupported flink version",
+			flinkVersion:    "1.14",
+			mode:            &applicationMode,
+			flinkProperties: withProperties(map[string]string{"job-result-store.storage-path": "gs://my-bucket/job-results"}),
+			expectedErr: "application mode with high availability requires flinkVersion >= 1.15, " +
+				"older versions have no job result store and may run a completed job again after a JobManager failover",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cluster = &FlinkCluster{
+				Spec: FlinkClusterSpec{
+					FlinkProperties: tt.flinkProperties,
+					JobResultStore:  tt.jobResultStore,
+					Job:             &JobSpec{Mode: tt.mode},
+				},
+			}
+			var flinkVersion = version.Must(version.NewVersion("1.18"))
+			if tt.flinkVersion != "" {
+				flinkVersion = version.Must(version.NewVersion(tt.flinkVersion))
+			}
+			err := validator.validateApplicationHighAvailability(flinkVersion, cluster)
+			if tt.expectedErr == "" {
+				assert.NilError(t, err)
+			} else {
+				assert.Error(t, err, tt.expectedErr)
+			}
+		})
+	}
+
+	// The cluster-id of the HA data cannot change in application mode.
+	var savepointsDir = "gs://my-bucket/savepoints/"
+	var oldCluster = &FlinkCluster{
+		Spec: FlinkClusterSpec{
+			FlinkProperties: haProperties,
+			Job:             &JobSpec{Mode: &applicationMode, SavepointsDir: &savepointsDir},
+		},
+	}
+	var newCluster = oldCluster.DeepCopy()
+	newCluster.Spec.FlinkProperties = withProperties(map[string]string{"kubernetes.cluster-id": "my-new-cluster"})
+	err := validator.validateJobUpdate(oldCluster, newCluster)
+	assert.Error(t, err, "updating kubernetes.cluster-id is not allowed in application mode with high availability, "+
+		"the job would not recover from the HA data of the previous cluster-id")
+
+	newCluster.Spec.Job.Mode = &detachedMode
+	oldCluster.Spec.Job.Mode = &detachedMode
+	assert.NilError(t, validator.validateJobUpdate(oldCluster, newCluster))
+}
+
+func TestDupPort(t *testing.T) {
+	var jmReplicas int32 = 1
+	var rpcPort int32 = 8001
+	var blobPort int32 = 8002
+	var queryPort int32 = 8003
+	var uiPort int32 = 8004
+	var flinkPorts = JobManagerPorts{
+		RPC:   &rpcPort,
+		Blob:  &blobPort,
+		Query: &queryPort,
+		UI:    &uiPort,
+	}
+	var jm = &JobManagerSpec{Replicas: &jmReplicas, AccessScope: AccessScopeVPC, Ports: flinkPorts,
+		ExtraPorts: []NamedPort{
+			{Name: "rpc", ContainerPort: 9001}}}
+	var err = validator.validateJobManager(nil, jm)
+	var expectedErr = "duplicate port name rpc in jobmanager, each port name of ports and extraPorts must be unique"
+	assert.Equal(t, err.Error(), expectedErr)
+
+	jm = &JobManagerSpec{Replicas: &jmReplicas, AccessScope: AccessScopeVPC, Ports: flinkPorts,
+		ExtraPorts: []NamedPort{
+			{Name: "monitoring", ContainerPort: 9249},
+			{Name: "monitoring", ContainerPort: 9259}}}
+	err = validator.validateJobManager(nil, jm)
+	expectedErr = "duplicate port name monitoring in jobmanager, each port name of ports and extraPorts must be unique"
+	assert.Equal(t, err.Error(), expectedErr)
+
+	jm = &JobManagerSpec{Replicas: &jmReplicas, AccessScope: AccessScopeVPC, Ports: flinkPorts,
+		ExtraPorts: []NamedPort{
+			{Name: "rpc2", ContainerPort: 8001}}}
+	err = validator.validateJobManager(nil, jm)
+	expectedErr = "duplicate containerPort 8001 in jobmanager, each port number of ports and extraPorts must be unique"
+	assert.Equal(t, err.Error(), expectedErr)
+
+	jm = &JobManagerSpec{Replicas: &jmReplicas, AccessScope: AccessScopeVPC, Ports: flinkPorts,
+		ExtraPorts: []NamedPort{
+			{Name: "monitoring", ContainerPort: 9249},
+			{Name: "prometheus", ContainerPort: 9249}}}
+	err = validator.validateJobManager(nil, jm)
+	expectedErr = "duplicate containerPort 9249 in jobmanager, each port number of ports and extraPorts must be unique"
+	assert.Equal(t, err.Error(), expectedErr)
+}
+
+func getSimpleFlinkCluster() FlinkCluster {
+	var jmReplicas int32 = DefaultJobManagerReplicas
+	var tmReplicas int32 = DefaultTaskManagerReplicas
+	var rpcPort int32 = 8001
+	var blobPort int32 = 8002
+	var queryPort int32 = 8003
+	var uiPort int32 = 8004
+	var dataPort int32 = 8005
+	var memoryOffHeapRatio int32 = 25
+	var memoryOffHeapMin = resource.MustParse("600M")
+	var jarFile = "gs://my-bucket/myjob.jar"
+	var parallelism int32 = 2
+	var maxStateAge = MaxStateAgeToRestore
+	var restartPolicy = JobRestartPolicyFromSavepointOnFailure
+	var savepointDir = "/savepoint_dir"
+	var jobMode = JobModeDetached
+	resources := DefaultResources
+	return FlinkCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "mycluster",
+			Namespace: "default",
+		},
+		Spec: FlinkClusterSpec{
+			FlinkVersion: "1.8",
+			Image: ImageSpec{
+				Name:       "flink:1.8.1",
+				PullPolicy: corev1.PullPolicy("Always"),
+			},
+			JobManager: &JobManagerSpec{
+				Replicas:    &jmReplicas,
+				AccessScope: AccessScopeVPC,
+				Ports: JobManagerPorts{
+					RPC:   &rpcPort,
+					Blob:  &blobPort,
+					Query: &queryPort,
+					UI:    &uiPort,
+				},
+				MemoryOffHeapRatio: &memoryOffHeapRatio,
+				MemoryOffHeapMin:   memoryOffHeapMin,
+				Resources:          resources,
+			},
+			TaskManager: &TaskManagerSpec{
+				Replicas: &tmReplicas,
+				Ports: TaskManagerPorts{
+					RPC:   &rpcPort,
+					Data:  &dataPort,
+					Query: &queryPort,
+				},
+				MemoryOffHeapRatio: &memoryOffHeapRatio,
+				MemoryOffHeapMin:   memoryOffHeapMin,
+				Resources:          resources,
+			},
+			Job: &JobSpec{
+				JarFile:                     &jarFile,
+				Parallelism:                 &parallelism,
+				MaxStateAgeToRestoreSeconds: &maxStateAge,
+				RestartPolicy:               &restartPolicy,
+				SavepointsDir:               &savepointDir,
+				CleanupPolicy: &CleanupPolicy{
+					AfterJobSucceeds: CleanupActionKeepCluster,
+					AfterJobFails:    CleanupActionDeleteTaskManager,
+				},
+				Mode: &jobMode,
+			},
+		},
+	}
+}
+
+func TestFlinkClusterValidation(t *testing.T) {
+	longName := strings.Repeat("a", 254)
+
+	invalidJobManagerAnnotations := func() *FlinkCluster {
+		cluster := getSimpleFlinkCluster()
+		cluster.Spec.JobManager.PodAnnotations = map[string]string{
+			longName: "bar",
+		}
+		return &cluster
+	}
+	invalidJobManagerLabels := func() *FlinkCluster {
+		cluster := getSimpleFlinkCluster()
+		cluster.Spec.JobManager.PodLabels = map[string]string{
+			longName: "bar",
+		}
+		return &cluster
+	}
+	invalidTaskManagerAnnotations := func() *FlinkCluster {
+		cluster := getSimpleFlinkCluster()
+		cluster.Spec.TaskManager.PodAnnotations = map[string]string{
+			longName: "bar",
+		}
+		return &cluster
+	}
+	invalidTaskManagerLabels := func() *FlinkCluster {
+		cluster := getSimpleFlinkCluster()
+		cluster.Spec.TaskManager.PodLabels = map[string]string{
+			longName: "bar",
+		}
+		return &cluster
+	}
+	invalidJobAnnotations := func() *FlinkCluster {
+		cluster := getSimpleFlinkCluster()
+		cluster.Spec.Job.PodAnnotations = map[string]string{
+			longName: "bar",
+		}
+		return &cluster
+	}
+	invalidJobLabels := func() *FlinkCluster {
+		cluster := getSimpleFlinkCluster()
+		cluster.Spec.Job.PodLabels = map[string]string{
+			longName: "bar",
+		}
+		return &cluster
+	}
+	waitForSavepointWithoutFromSavepoint := func() *FlinkCluster {
+		cluster := getSimpleFlinkCluster()
+		cluster.Spec.Job.FromSavepoint = nil
+		cluster.Spec.Job.WaitForSavepoint = &WaitForSavepointSpec{}
+		return &cluster
+	}
+	invalidClusterName := func() *FlinkCluster {
+		cluster := getSimpleFlinkCluster()
+		cluster.Name = "1-invalid-name"
+		return &cluster
+	}
+	invalidLongClusterName := func() *FlinkCluster {
+		cluster := getSimpleFlinkCluster()
+		cluster.Name = longName
+		return &cluster
+	}
+
+	data := []struct {
+		testName    string
+		run         func() *FlinkCluster
+		expectedErr string
+	}{
+		{
+			"invalid jm annotations",
+			invalidJobManagerAnnotations,
+			fmt.Sprintf("spec.jobManager.podAnnotations: Invalid value: \"%s\": name part must be no more than 63 bytes", longName),
+		},
+		{
+			"invalid jm labels",
+			invalidJobManagerLabels,
+			fmt.Sprintf("spec.jobManager.podLabels: Invalid value: \"%s\": name part must be no more than 63 bytes", longName),
+		},
+		{
+			"invalid tm annotations",
+			invalidTaskManagerAnnotations,
+			fmt.Sprintf("spec.taskManager.podAnnotations: Invalid value: \"%s\": name part must be no more than 63 bytes", longName),
+		},
+		{
+			"invalid tm labels",
+			invalidTaskManagerLabels,
+			fmt.Sprintf("spec.taskManager.podLabels: Invalid value: \"%s\": name part must be no more than 63 bytes", longName),
+		},
+		{
+			"invalid job annotations",
+			invalidJobAnnotations,
+			fmt.Sprintf("spec.job.podAnnotations: Invalid value: \"%s\": name part must be no more than 63 bytes", longName),
+		},
+		{
+			"invalid job labels",
 			invalidJobLabels,
 			fmt.Sprintf("spec.job.podLabels: Invalid value: \"%s\": name part must be no more than 63 bytes", longName),
 		},
+		{
+			"wait for savepoint without fromSavepoint",
+			waitForSavepointWithoutFromSavepoint,
+			"job waitForSavepoint requires fromSavepoint to be specified",
+		},
 		{
 			"invalid cluster name",
 			invalidClusterName,
@@ -930,3 +2694,265 @@ func TestFlinkClusterValidation(t *testing.T) {
 		})
 	}
 }
+
+func TestIncrementalCheckpointsWarning(t *testing.T) {
+	var validator = &Validator{}
+	var hashmapWarning = "incremental checkpoints are enabled but the hashmap state backend does not support them, full checkpoints are taken instead; set state.backend.type to rocksdb or disable incremental checkpoints"
+	tests := []struct {
+		name            string
+		flinkProperties map[string]string
+		expected        []string
+	}{
+		{
+			name:            "rocksdb with incremental checkpoints",
+			flinkProperties: map[string]string{"state.backend.type": "rocksdb", "state.backend.incremental": "true"},
+		},
+		{
+			name:            "hashmap with incremental checkpoints",
+			flinkProperties: map[string]string{"state.backend.type": "hashmap", "state.backend.incremental": "true"},
+			expected:        []string{hashmapWarning},
+		},
+		{
+			name:            "default backend with incremental checkpoints",
+			flinkProperties: map[string]string{"execution.checkpointing.incremental": "TRUE"},
+			expected:        []string{hashmapWarning},
+		},
+		{
+			name:            "rocksdb without incremental checkpoints",
+			flinkProperties: map[string]string{"state.backend": "rocksdb"},
+		},
+		{
+			name:            "hashmap with incremental checkpoints disabled",
+			flinkProperties: map[string]string{"state.backend.type": "hashmap", "state.backend.incremental": "false"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cluster = &FlinkCluster{Spec: FlinkClusterSpec{FlinkProperties: tt.flinkProperties}}
+			assert.DeepEqual(t, validator.Warnings(cluster), tt.expected)
+		})
+	}
+}
+
+func TestCheckpointStorageWarning(t *testing.T) {
+	var validator = &Validator{}
+	var jobManagerWarning = "the rocksdb state backend is used for large state but the checkpoints are stored in the JobManager heap, " +
+		"which fails once the state outgrows it; set state.checkpoint-storage to filesystem with a checkpoint directory in state.checkpoints.dir"
+	tests := []struct {
+		name            string
+		flinkProperties map[string]string
+		expected        []string
+	}{
+		{
+			name:            "rocksdb with jobmanager storage",
+			flinkProperties: map[string]string{"state.backend.type": "rocksdb", "state.checkpoint-storage": "jobmanager"},
+			expected:        []string{jobManagerWarning},
+		},
+		{
+			name: "rocksdb with default storage without checkpoint dir",
+			flinkProperties: map[string]string{
+				"state.backend.type":               "rocksdb",
+				"execution.checkpointing.interval": "1 min",
+			},
+			expected: []string{jobManagerWarning},
+		},
+		{
+			name:            "rocksdb without periodic checkpoints",
+			flinkProperties: map[string]string{"state.backend.type": "rocksdb"},
+		},
+		{
+			name: "rocksdb with default storage and checkpoint dir",
+			flinkProperties: map[string]string{
+				"state.backend.type":               "rocksdb",
+				"execution.checkpointing.interval": "1 min",
+				"execution.checkpointing.dir":      "gs://my-bucket/checkpoints",
+			},
+		},
+		{
+			name: "rocksdb with filesystem storage",
+			flinkProperties: map[string]string{
+				"state.backend.type":              "rocksdb",
+				"execution.checkpointing.storage": "FileSystem",
+				"state.checkpoints.dir":           "gs://my-bucket/checkpoints",
+			},
+		},
+		{
+			name:            "hashmap with filesystem storage",
+			flinkProperties: map[string]string{"state.checkpoint-storage": "filesystem", "state.checkpoints.dir": "gs://my-bucket/checkpoints"},
+		},
+		{
+			name:            "hashmap with jobmanager storage",
+			flinkProperties: map[string]string{"state.checkpoint-storage": "jobmanager"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cluster = &FlinkCluster{Spec: FlinkClusterSpec{FlinkProperties: tt.flinkProperties}}
+			assert.DeepEqual(t, validator.Warnings(cluster), tt.expected)
+		})
+	}
+}
+
+func TestSavepointSpacingWarning(t *testing.T) {
+	var validator = &Validator{}
+	var int32Ptr = func(v int32) *int32 { return &v }
+	tests := []struct {
+		name                 string
+		checkpointInterval   string
+		autoSavepointSeconds *int32
+		expected             []string
+	}{
+		{
+			name:                 "savepoints as frequent as checkpoints",
+			checkpointInterval:   "1 min",
+			autoSavepointSeconds: int32Ptr(60),
+			expected: []string{"job autoSavepointSeconds 60 takes a savepoint every 1.0 checkpoints of execution.checkpointing.interval 1m0s, " +
+				"the savepoints and checkpoints contend for the JobManager; space the savepoints at least 3m0s apart"},
+		},
+		{
+			name:                 "savepoints every two checkpoints",
+			checkpointInterval:   "30s",
+			autoSavepointSeconds: int32Ptr(60),
+			expected: []string{"job autoSavepointSeconds 60 takes a savepoint every 2.0 checkpoints of execution.checkpointing.interval 30s, " +
+				"the savepoints and checkpoints contend for the JobManager; space the savepoints at least 1m30s apart"},
+		},
+		{
+			name:                 "savepoints spaced by several checkpoints",
+			checkpointInterval:   "1 min",
+			autoSavepointSeconds: int32Ptr(600),
+		},
+		{
+			name:                 "long checkpoint interval and infrequent savepoints",
+			checkpointInterval:   "30 min",
+			autoSavepointSeconds: int32Ptr(86400),
+		},
+		{
+			name:                 "without periodic checkpoints",
+			autoSavepointSeconds: int32Ptr(60),
+		},
+		{
+			name:               "without periodic savepoints",
+			checkpointInterval: "1 min",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var flinkProperties = map[string]string{}
+			if tt.checkpointInterval != "" {
+				flinkProperties["execution.checkpointing.interval"] = tt.checkpointInterval
+			}
+			var cluster = &FlinkCluster{
+				Spec: FlinkClusterSpec{
+					FlinkProperties: flinkProperties,
+					Job:             &JobSpec{AutoSavepointSeconds: tt.autoSavepointSeconds},
+				},
+			}
+			assert.DeepEqual(t, validator.Warnings(cluster), tt.expected)
+		})
+	}
+}
+
+func TestJobResultStoreWarning(t *testing.T) {
+	var validator = &Validator{}
+	var savepointsDir = "gs://my-bucket/savepoints"
+	tests := []struct {
+		name            string
+		jobResultStore  string
+		flinkProperties map[string]string
+		savepointsDir   *string
+		expected        []string
+	}{
+		{
+			name:           "separate dirs",
+			jobResultStore: "gs://my-bucket/job-results",
+			savepointsDir:  &savepointsDir,
+		},
+		{
+			name:           "nested in savepoints dir",
+			jobResultStore: "gs://my-bucket/savepoints/job-results",
+			savepointsDir:  &savepointsDir,
+			expected: []string{"job result store directory gs://my-bucket/savepoints/job-results is co-located with the savepoint directory gs://my-bucket/savepoints, " +
+				"cleaning up the savepoints can delete the job results"},
+		},
+		{
+			name:           "without savepoints dir",
+			jobResultStore: "gs://my-bucket/savepoints/job-results",
+		},
+		{
+			name:            "durable storage path in flink config",
+			flinkProperties: map[string]string{"job-result-store.storage-path": "s3://my-bucket/job-results"},
+		},
+		{
+			name:            "local storage path in flink config",
+			flinkProperties: map[string]string{"job-result-store.storage-path": "/opt/flink/job-results"},
+			expected: []string{"job result store directory /opt/flink/job-results of job-result-store.storage-path is not durable, " +
+				"the job results are lost with the JobManager pod; use a shared file system, e.g., gs:// or s3://"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cluster = &FlinkCluster{
+				Spec: FlinkClusterSpec{
+					FlinkProperties: tt.flinkProperties,
+					Job:             &JobSpec{SavepointsDir: tt.savepointsDir},
+				},
+			}
+			if tt.jobResultStore != "" {
+				cluster.Spec.JobResultStore = &JobResultStoreSpec{StorageDir: tt.jobResultStore}
+			}
+			assert.DeepEqual(t, validator.Warnings(cluster), tt.expected)
+		})
+	}
+}
+
+func TestValidateOperatorUIDPolicy(t *testing.T) {
+	var validator = &Validator{}
+	var required = OperatorUIDPolicyRequired
+	var invalid = OperatorUIDPolicy("Optional")
+
+	tests := []struct {
+		name              string
+		operatorUIDPolicy *OperatorUIDPolicy
+		flinkProperties   map[string]string
+		expectedErr       string
+	}{
+		{
+			name: "default",
+		},
+		{
+			name:              "typed",
+			operatorUIDPolicy: &required,
+		},
+		{
+			name:            "raw property",
+			flinkProperties: map[string]string{"pipeline.auto-generate-uids": "false"},
+		},
+		{
+			name:              "invalid typed",
+			operatorUIDPolicy: &invalid,
+			expectedErr:       "job operatorUIDPolicy must be Generated or Required, got Optional",
+		},
+		{
+			name:              "typed and raw property",
+			operatorUIDPolicy: &required,
+			flinkProperties:   map[string]string{"pipeline.auto-generate-uids": "false"},
+			expectedErr:       "job operatorUIDPolicy cannot be used with pipeline.auto-generate-uids in flinkProperties",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cluster = &FlinkCluster{
+				Spec: FlinkClusterSpec{
+					FlinkProperties: tt.flinkProperties,
+					Job:             &JobSpec{OperatorUIDPolicy: tt.operatorUIDPolicy},
+				},
+			}
+			err := validator.validateOperatorUIDPolicy(cluster)
+			if tt.expectedErr == "" {
+				assert.NilError(t, err)
+			} else {
+				assert.Error(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
@@ -30,17 +30,20 @@ import (
 var log = logf.Log.WithName("webhook")
 
 // flinkClusterDefaulter implements admission.CustomDefaulter for FlinkCluster.
-type flinkClusterDefaulter struct{}
+type flinkClusterDefaulter struct {
+	config DefaultingConfig
+}
 
 // flinkClusterValidator implements admission.CustomValidator for FlinkCluster.
 type flinkClusterValidator struct {
 	validator Validator
 }
 
-// SetupWebhookWithManager adds webhook for FlinkCluster.
-func (cluster *FlinkCluster) SetupWebhookWithManager(mgr ctrl.Manager) error {
+// SetupWebhookWithManager adds webhook for FlinkCluster, which applies the operator-wide
+// defaults in config.
+func (cluster *FlinkCluster) SetupWebhookWithManager(mgr ctrl.Manager, config DefaultingConfig) error {
 	return ctrl.NewWebhookManagedBy(mgr, cluster).
-		WithDefaulter(&flinkClusterDefaulter{}).
+		WithDefaulter(&flinkClusterDefaulter{config: config}).
 		WithValidator(&flinkClusterValidator{validator: Validator{}}).
 		Complete()
 }
@@ -57,6 +60,7 @@ The meaning of each marker can be found [here](/reference/markers/webhook.md).
 func (d *flinkClusterDefaulter) Default(ctx context.Context, cluster *FlinkCluster) error {
 	log.Info("default", "cluster", FlinkClusterLogSummary(cluster), "phase", "original")
 	_SetDefault(cluster)
+	_SetOperatorDefault(cluster, d.config)
 	log.Info("default", "cluster", FlinkClusterLogSummary(cluster), "phase", "augmented")
 	return nil
 }
@@ -72,13 +76,19 @@ var validator = Validator{}
 // ValidateCreate implements admission.Validator[*FlinkCluster].
 func (v *flinkClusterValidator) ValidateCreate(ctx context.Context, cluster *FlinkCluster) (admission.Warnings, error) {
 	log.Info("Validate create", "name", cluster.Name)
-	return nil, v.validator.ValidateCreate(cluster)
+	if err := v.validator.ValidateCreate(cluster); err != nil {
+		return nil, err
+	}
+	return v.validator.Warnings(cluster), nil
 }
 
 // ValidateUpdate implements admission.Validator[*FlinkCluster].
 func (v *flinkClusterValidator) ValidateUpdate(ctx context.Context, oldCluster, cluster *FlinkCluster) (admission.Warnings, error) {
 	log.Info("Validate update", "name", cluster.Name)
-	return nil, v.validator.ValidateUpdate(oldCluster, cluster)
+	if err := v.validator.ValidateUpdate(oldCluster, cluster); err != nil {
+		return nil, err
+	}
+	return v.validator.Warnings(cluster), nil
 }
 
 // ValidateDelete implements admission.Validator[*FlinkCluster].
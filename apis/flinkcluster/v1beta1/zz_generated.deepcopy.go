@@ -24,9 +24,40 @@ import (
 	"k8s.io/api/autoscaling/v2"
 	"k8s.io/api/core/v1"
 	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdaptiveSchedulerSpec) DeepCopyInto(out *AdaptiveSchedulerSpec) {
+	*out = *in
+	if in.MinParallelismIncrease != nil {
+		in, out := &in.MinParallelismIncrease, &out.MinParallelismIncrease
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ResourceWaitTimeoutSeconds != nil {
+		in, out := &in.ResourceWaitTimeoutSeconds, &out.ResourceWaitTimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ResourceStabilizationTimeoutSeconds != nil {
+		in, out := &in.ResourceStabilizationTimeoutSeconds, &out.ResourceStabilizationTimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdaptiveSchedulerSpec.
+func (in *AdaptiveSchedulerSpec) DeepCopy() *AdaptiveSchedulerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AdaptiveSchedulerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BatchSchedulerSpec) DeepCopyInto(out *BatchSchedulerSpec) {
 	*out = *in
@@ -42,6 +73,41 @@ func (in *BatchSchedulerSpec) DeepCopy() *BatchSchedulerSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CheckpointAlignmentSample) DeepCopyInto(out *CheckpointAlignmentSample) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CheckpointAlignmentSample.
+func (in *CheckpointAlignmentSample) DeepCopy() *CheckpointAlignmentSample {
+	if in == nil {
+		return nil
+	}
+	out := new(CheckpointAlignmentSample)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CheckpointAlignmentStatus) DeepCopyInto(out *CheckpointAlignmentStatus) {
+	*out = *in
+	if in.Samples != nil {
+		in, out := &in.Samples, &out.Samples
+		*out = make([]CheckpointAlignmentSample, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CheckpointAlignmentStatus.
+func (in *CheckpointAlignmentStatus) DeepCopy() *CheckpointAlignmentStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CheckpointAlignmentStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CleanupPolicy) DeepCopyInto(out *CleanupPolicy) {
 	*out = *in
@@ -72,6 +138,126 @@ func (in *ConfigMapStatus) DeepCopy() *ConfigMapStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CoordinatedSavepointJobStatus) DeepCopyInto(out *CoordinatedSavepointJobStatus) {
+	*out = *in
+	out.Savepoint = in.Savepoint
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CoordinatedSavepointJobStatus.
+func (in *CoordinatedSavepointJobStatus) DeepCopy() *CoordinatedSavepointJobStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CoordinatedSavepointJobStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CoordinatedSavepointSpec) DeepCopyInto(out *CoordinatedSavepointSpec) {
+	*out = *in
+	if in.JobNames != nil {
+		in, out := &in.JobNames, &out.JobNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SavepointsDir != nil {
+		in, out := &in.SavepointsDir, &out.SavepointsDir
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CoordinatedSavepointSpec.
+func (in *CoordinatedSavepointSpec) DeepCopy() *CoordinatedSavepointSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CoordinatedSavepointSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CoordinatedSavepointStatus) DeepCopyInto(out *CoordinatedSavepointStatus) {
+	*out = *in
+	if in.Jobs != nil {
+		in, out := &in.Jobs, &out.Jobs
+		*out = make([]CoordinatedSavepointJobStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.FailedJobs != nil {
+		in, out := &in.FailedJobs, &out.FailedJobs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CoordinatedSavepointStatus.
+func (in *CoordinatedSavepointStatus) DeepCopy() *CoordinatedSavepointStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CoordinatedSavepointStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalResourceSpec) DeepCopyInto(out *ExternalResourceSpec) {
+	*out = *in
+	if in.DriverFactoryClass != nil {
+		in, out := &in.DriverFactoryClass, &out.DriverFactoryClass
+		*out = new(string)
+		**out = **in
+	}
+	if in.DriverParams != nil {
+		in, out := &in.DriverParams, &out.DriverParams
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalResourceSpec.
+func (in *ExternalResourceSpec) DeepCopy() *ExternalResourceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalResourceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FineGrainedResourcesSpec) DeepCopyInto(out *FineGrainedResourcesSpec) {
+	*out = *in
+	if in.SlotProfiles != nil {
+		in, out := &in.SlotProfiles, &out.SlotProfiles
+		*out = make([]SlotResourceProfile, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MaxSlots != nil {
+		in, out := &in.MaxSlots, &out.MaxSlots
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FineGrainedResourcesSpec.
+func (in *FineGrainedResourcesSpec) DeepCopy() *FineGrainedResourcesSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FineGrainedResourcesSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FlinkCluster) DeepCopyInto(out *FlinkCluster) {
 	*out = *in
@@ -281,6 +467,31 @@ func (in *FlinkClusterSpec) DeepCopyInto(out *FlinkClusterSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.ConfigChangeRestartPolicy != nil {
+		in, out := &in.ConfigChangeRestartPolicy, &out.ConfigChangeRestartPolicy
+		*out = new(ConfigChangeRestartPolicy)
+		**out = **in
+	}
+	if in.ObservabilitySamplingSeconds != nil {
+		in, out := &in.ObservabilitySamplingSeconds, &out.ObservabilitySamplingSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.CoordinatedSavepoint != nil {
+		in, out := &in.CoordinatedSavepoint, &out.CoordinatedSavepoint
+		*out = new(CoordinatedSavepointSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.JobResultStore != nil {
+		in, out := &in.JobResultStore, &out.JobResultStore
+		*out = new(JobResultStoreSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GracefulShutdownTimeoutSeconds != nil {
+		in, out := &in.GracefulShutdownTimeoutSeconds, &out.GracefulShutdownTimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FlinkClusterSpec.
@@ -307,7 +518,41 @@ func (in *FlinkClusterStatus) DeepCopyInto(out *FlinkClusterStatus) {
 		*out = new(SavepointStatus)
 		**out = **in
 	}
+	if in.CoordinatedSavepoint != nil {
+		in, out := &in.CoordinatedSavepoint, &out.CoordinatedSavepoint
+		*out = new(CoordinatedSavepointStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SavepointInventory != nil {
+		in, out := &in.SavepointInventory, &out.SavepointInventory
+		*out = make([]SavepointRecord, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CheckpointAlignment != nil {
+		in, out := &in.CheckpointAlignment, &out.CheckpointAlignment
+		*out = new(CheckpointAlignmentStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SlotRegistration != nil {
+		in, out := &in.SlotRegistration, &out.SlotRegistration
+		*out = new(SlotRegistrationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.JobManagerLeader != nil {
+		in, out := &in.JobManagerLeader, &out.JobManagerLeader
+		*out = new(JobManagerLeaderStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	in.Revision.DeepCopyInto(&out.Revision)
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FlinkClusterStatus.
@@ -410,6 +655,11 @@ func (in *ImageSpec) DeepCopyInto(out *ImageSpec) {
 		*out = make([]v1.LocalObjectReference, len(*in))
 		copy(*out, *in)
 	}
+	if in.Architecture != nil {
+		in, out := &in.Architecture, &out.Architecture
+		*out = new(ImageArchitecture)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageSpec.
@@ -479,6 +729,26 @@ func (in *JobManagerIngressStatus) DeepCopy() *JobManagerIngressStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JobManagerLeaderStatus) DeepCopyInto(out *JobManagerLeaderStatus) {
+	*out = *in
+	if in.RecentChanges != nil {
+		in, out := &in.RecentChanges, &out.RecentChanges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobManagerLeaderStatus.
+func (in *JobManagerLeaderStatus) DeepCopy() *JobManagerLeaderStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(JobManagerLeaderStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *JobManagerPorts) DeepCopyInto(out *JobManagerPorts) {
 	*out = *in
@@ -544,6 +814,11 @@ func (in *JobManagerSpec) DeepCopyInto(out *JobManagerSpec) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.ReplicaDriftPolicy != nil {
+		in, out := &in.ReplicaDriftPolicy, &out.ReplicaDriftPolicy
+		*out = new(ReplicaDriftPolicy)
+		**out = **in
+	}
 	if in.ServiceAnnotations != nil {
 		in, out := &in.ServiceAnnotations, &out.ServiceAnnotations
 		*out = make(map[string]string, len(*in))
@@ -671,6 +946,21 @@ func (in *JobManagerSpec) DeepCopyInto(out *JobManagerSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.PreStop != nil {
+		in, out := &in.PreStop, &out.PreStop
+		*out = new(v1.LifecycleHandler)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AdaptiveScheduler != nil {
+		in, out := &in.AdaptiveScheduler, &out.AdaptiveScheduler
+		*out = new(AdaptiveSchedulerSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FailoverStrategy != nil {
+		in, out := &in.FailoverStrategy, &out.FailoverStrategy
+		*out = new(FailoverStrategy)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobManagerSpec.
@@ -698,6 +988,41 @@ func (in *JobManagerStatus) DeepCopy() *JobManagerStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JobRestartsStatus) DeepCopyInto(out *JobRestartsStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobRestartsStatus.
+func (in *JobRestartsStatus) DeepCopy() *JobRestartsStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(JobRestartsStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JobResultStoreSpec) DeepCopyInto(out *JobResultStoreSpec) {
+	*out = *in
+	if in.DeleteOnCommit != nil {
+		in, out := &in.DeleteOnCommit, &out.DeleteOnCommit
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobResultStoreSpec.
+func (in *JobResultStoreSpec) DeepCopy() *JobResultStoreSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(JobResultStoreSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *JobSpec) DeepCopyInto(out *JobSpec) {
 	*out = *in
@@ -706,11 +1031,31 @@ func (in *JobSpec) DeepCopyInto(out *JobSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.ClassloaderResolveOrder != nil {
+		in, out := &in.ClassloaderResolveOrder, &out.ClassloaderResolveOrder
+		*out = new(ClassloaderResolveOrder)
+		**out = **in
+	}
+	if in.ExecutionMode != nil {
+		in, out := &in.ExecutionMode, &out.ExecutionMode
+		*out = new(JobExecutionMode)
+		**out = **in
+	}
+	if in.OperatorUIDPolicy != nil {
+		in, out := &in.OperatorUIDPolicy, &out.OperatorUIDPolicy
+		*out = new(OperatorUIDPolicy)
+		**out = **in
+	}
 	if in.JarFile != nil {
 		in, out := &in.JarFile, &out.JarFile
 		*out = new(string)
 		**out = **in
 	}
+	if in.RESTJarUpload != nil {
+		in, out := &in.RESTJarUpload, &out.RESTJarUpload
+		*out = new(bool)
+		**out = **in
+	}
 	if in.ClassName != nil {
 		in, out := &in.ClassName, &out.ClassName
 		*out = new(string)
@@ -741,6 +1086,16 @@ func (in *JobSpec) DeepCopyInto(out *JobSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.WaitForSavepoint != nil {
+		in, out := &in.WaitForSavepoint, &out.WaitForSavepoint
+		*out = new(WaitForSavepointSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SavepointValidationWebhook != nil {
+		in, out := &in.SavepointValidationWebhook, &out.SavepointValidationWebhook
+		*out = new(SavepointValidationWebhookSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.AllowNonRestoredState != nil {
 		in, out := &in.AllowNonRestoredState, &out.AllowNonRestoredState
 		*out = new(bool)
@@ -761,6 +1116,16 @@ func (in *JobSpec) DeepCopyInto(out *JobSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.MaxUpdateDowntimeSeconds != nil {
+		in, out := &in.MaxUpdateDowntimeSeconds, &out.MaxUpdateDowntimeSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.StopMode != nil {
+		in, out := &in.StopMode, &out.StopMode
+		*out = new(JobStopMode)
+		**out = **in
+	}
 	if in.MaxStateAgeToRestoreSeconds != nil {
 		in, out := &in.MaxStateAgeToRestoreSeconds, &out.MaxStateAgeToRestoreSeconds
 		*out = new(int32)
@@ -771,11 +1136,31 @@ func (in *JobSpec) DeepCopyInto(out *JobSpec) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.SavepointTimeouts != nil {
+		in, out := &in.SavepointTimeouts, &out.SavepointTimeouts
+		*out = new(SavepointTimeoutsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CheckpointTimeoutSeconds != nil {
+		in, out := &in.CheckpointTimeoutSeconds, &out.CheckpointTimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxConcurrentCheckpoints != nil {
+		in, out := &in.MaxConcurrentCheckpoints, &out.MaxConcurrentCheckpoints
+		*out = new(int32)
+		**out = **in
+	}
 	if in.Parallelism != nil {
 		in, out := &in.Parallelism, &out.Parallelism
 		*out = new(int32)
 		**out = **in
 	}
+	if in.WatermarkAlignment != nil {
+		in, out := &in.WatermarkAlignment, &out.WatermarkAlignment
+		*out = new(WatermarkAlignmentSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.NoLoggingToStdout != nil {
 		in, out := &in.NoLoggingToStdout, &out.NoLoggingToStdout
 		*out = new(bool)
@@ -826,6 +1211,21 @@ func (in *JobSpec) DeepCopyInto(out *JobSpec) {
 		*out = new(JobRestartPolicy)
 		**out = **in
 	}
+	if in.MaxRestoreFailures != nil {
+		in, out := &in.MaxRestoreFailures, &out.MaxRestoreFailures
+		*out = new(int32)
+		**out = **in
+	}
+	if in.WarmStandby != nil {
+		in, out := &in.WarmStandby, &out.WarmStandby
+		*out = new(WarmStandbySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RestartEscalation != nil {
+		in, out := &in.RestartEscalation, &out.RestartEscalation
+		*out = new(RestartEscalationSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.CleanupPolicy != nil {
 		in, out := &in.CleanupPolicy, &out.CleanupPolicy
 		*out = new(CleanupPolicy)
@@ -881,34 +1281,109 @@ func (in *JobSpec) DeepCopy() *JobSpec {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *JobStatus) DeepCopyInto(out *JobStatus) {
+func (in *JobStateDuration) DeepCopyInto(out *JobStateDuration) {
 	*out = *in
-	if in.CompletionTime != nil {
-		in, out := &in.CompletionTime, &out.CompletionTime
-		*out = (*in).DeepCopy()
-	}
-	if in.FailureReasons != nil {
-		in, out := &in.FailureReasons, &out.FailureReasons
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobStatus.
-func (in *JobStatus) DeepCopy() *JobStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobStateDuration.
+func (in *JobStateDuration) DeepCopy() *JobStateDuration {
 	if in == nil {
 		return nil
 	}
-	out := new(JobStatus)
+	out := new(JobStateDuration)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NamedPort) DeepCopyInto(out *NamedPort) {
+func (in *JobStatus) DeepCopyInto(out *JobStatus) {
 	*out = *in
-}
-
+	if in.PrimedSavepoint != nil {
+		in, out := &in.PrimedSavepoint, &out.PrimedSavepoint
+		*out = new(PrimedSavepoint)
+		**out = **in
+	}
+	if in.RetainedCheckpoint != nil {
+		in, out := &in.RetainedCheckpoint, &out.RetainedCheckpoint
+		*out = new(RetainedCheckpoint)
+		**out = **in
+	}
+	if in.StateDurations != nil {
+		in, out := &in.StateDurations, &out.StateDurations
+		*out = make([]JobStateDuration, len(*in))
+		copy(*out, *in)
+	}
+	if in.Restarts != nil {
+		in, out := &in.Restarts, &out.Restarts
+		*out = new(JobRestartsStatus)
+		**out = **in
+	}
+	if in.PoisonSavepoints != nil {
+		in, out := &in.PoisonSavepoints, &out.PoisonSavepoints
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.FailureReasons != nil {
+		in, out := &in.FailureReasons, &out.FailureReasons
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.UploadedJar != nil {
+		in, out := &in.UploadedJar, &out.UploadedJar
+		*out = new(UploadedJarStatus)
+		**out = **in
+	}
+	if in.OperatorUIDs != nil {
+		in, out := &in.OperatorUIDs, &out.OperatorUIDs
+		*out = new(OperatorUIDsStatus)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobStatus.
+func (in *JobStatus) DeepCopy() *JobStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(JobStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocalRecoverySpec) DeepCopyInto(out *LocalRecoverySpec) {
+	*out = *in
+	if in.VolumeName != nil {
+		in, out := &in.VolumeName, &out.VolumeName
+		*out = new(string)
+		**out = **in
+	}
+	if in.SizeLimit != nil {
+		in, out := &in.SizeLimit, &out.SizeLimit
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalRecoverySpec.
+func (in *LocalRecoverySpec) DeepCopy() *LocalRecoverySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LocalRecoverySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamedPort) DeepCopyInto(out *NamedPort) {
+	*out = *in
+}
+
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamedPort.
 func (in *NamedPort) DeepCopy() *NamedPort {
 	if in == nil {
@@ -919,6 +1394,86 @@ func (in *NamedPort) DeepCopy() *NamedPort {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperatorUIDsStatus) DeepCopyInto(out *OperatorUIDsStatus) {
+	*out = *in
+	if in.Current != nil {
+		in, out := &in.Current, &out.Current
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Previous != nil {
+		in, out := &in.Previous, &out.Previous
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Removed != nil {
+		in, out := &in.Removed, &out.Removed
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperatorUIDsStatus.
+func (in *OperatorUIDsStatus) DeepCopy() *OperatorUIDsStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OperatorUIDsStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrimedSavepoint) DeepCopyInto(out *PrimedSavepoint) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrimedSavepoint.
+func (in *PrimedSavepoint) DeepCopy() *PrimedSavepoint {
+	if in == nil {
+		return nil
+	}
+	out := new(PrimedSavepoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestartEscalationSpec) DeepCopyInto(out *RestartEscalationSpec) {
+	*out = *in
+	if in.FlinkRestartDelaySeconds != nil {
+		in, out := &in.FlinkRestartDelaySeconds, &out.FlinkRestartDelaySeconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestartEscalationSpec.
+func (in *RestartEscalationSpec) DeepCopy() *RestartEscalationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RestartEscalationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RetainedCheckpoint) DeepCopyInto(out *RetainedCheckpoint) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetainedCheckpoint.
+func (in *RetainedCheckpoint) DeepCopy() *RetainedCheckpoint {
+	if in == nil {
+		return nil
+	}
+	out := new(RetainedCheckpoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RevisionStatus) DeepCopyInto(out *RevisionStatus) {
 	*out = *in
@@ -939,6 +1494,76 @@ func (in *RevisionStatus) DeepCopy() *RevisionStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RocksDBOptions) DeepCopyInto(out *RocksDBOptions) {
+	*out = *in
+	if in.ManagedMemory != nil {
+		in, out := &in.ManagedMemory, &out.ManagedMemory
+		*out = new(bool)
+		**out = **in
+	}
+	if in.FixedMemoryPerSlot != nil {
+		in, out := &in.FixedMemoryPerSlot, &out.FixedMemoryPerSlot
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.WriteBufferRatio != nil {
+		in, out := &in.WriteBufferRatio, &out.WriteBufferRatio
+		*out = new(int32)
+		**out = **in
+	}
+	if in.HighPriorityPoolRatio != nil {
+		in, out := &in.HighPriorityPoolRatio, &out.HighPriorityPoolRatio
+		*out = new(int32)
+		**out = **in
+	}
+	if in.BlockCacheSize != nil {
+		in, out := &in.BlockCacheSize, &out.BlockCacheSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.WriteBufferSize != nil {
+		in, out := &in.WriteBufferSize, &out.WriteBufferSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RocksDBOptions.
+func (in *RocksDBOptions) DeepCopy() *RocksDBOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(RocksDBOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SavepointRecord) DeepCopyInto(out *SavepointRecord) {
+	*out = *in
+	if in.SizeBytes != nil {
+		in, out := &in.SizeBytes, &out.SizeBytes
+		*out = new(int64)
+		**out = **in
+	}
+	if in.DurationSeconds != nil {
+		in, out := &in.DurationSeconds, &out.DurationSeconds
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SavepointRecord.
+func (in *SavepointRecord) DeepCopy() *SavepointRecord {
+	if in == nil {
+		return nil
+	}
+	out := new(SavepointRecord)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SavepointStatus) DeepCopyInto(out *SavepointStatus) {
 	*out = *in
@@ -954,6 +1579,133 @@ func (in *SavepointStatus) DeepCopy() *SavepointStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SavepointTimeoutsSpec) DeepCopyInto(out *SavepointTimeoutsSpec) {
+	*out = *in
+	if in.UpdateSeconds != nil {
+		in, out := &in.UpdateSeconds, &out.UpdateSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ScheduledSeconds != nil {
+		in, out := &in.ScheduledSeconds, &out.ScheduledSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.DeleteSeconds != nil {
+		in, out := &in.DeleteSeconds, &out.DeleteSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.UserRequestedSeconds != nil {
+		in, out := &in.UserRequestedSeconds, &out.UserRequestedSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.JobCancelSeconds != nil {
+		in, out := &in.JobCancelSeconds, &out.JobCancelSeconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SavepointTimeoutsSpec.
+func (in *SavepointTimeoutsSpec) DeepCopy() *SavepointTimeoutsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SavepointTimeoutsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SavepointValidationWebhookSpec) DeepCopyInto(out *SavepointValidationWebhookSpec) {
+	*out = *in
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SavepointValidationWebhookSpec.
+func (in *SavepointValidationWebhookSpec) DeepCopy() *SavepointValidationWebhookSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SavepointValidationWebhookSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SlotRegistrationStatus) DeepCopyInto(out *SlotRegistrationStatus) {
+	*out = *in
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]TaskManagerGroupSlots, len(*in))
+		copy(*out, *in)
+	}
+	if in.LaggingGroups != nil {
+		in, out := &in.LaggingGroups, &out.LaggingGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.UnhealthyGroups != nil {
+		in, out := &in.UnhealthyGroups, &out.UnhealthyGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlotRegistrationStatus.
+func (in *SlotRegistrationStatus) DeepCopy() *SlotRegistrationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SlotRegistrationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SlotResourceProfile) DeepCopyInto(out *SlotResourceProfile) {
+	*out = *in
+	if in.Slots != nil {
+		in, out := &in.Slots, &out.Slots
+		*out = new(int32)
+		**out = **in
+	}
+	out.CPU = in.CPU.DeepCopy()
+	out.Memory = in.Memory.DeepCopy()
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlotResourceProfile.
+func (in *SlotResourceProfile) DeepCopy() *SlotResourceProfile {
+	if in == nil {
+		return nil
+	}
+	out := new(SlotResourceProfile)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TaskManagerGroupSlots) DeepCopyInto(out *TaskManagerGroupSlots) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskManagerGroupSlots.
+func (in *TaskManagerGroupSlots) DeepCopy() *TaskManagerGroupSlots {
+	if in == nil {
+		return nil
+	}
+	out := new(TaskManagerGroupSlots)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TaskManagerPorts) DeepCopyInto(out *TaskManagerPorts) {
 	*out = *in
@@ -1100,11 +1852,43 @@ func (in *TaskManagerSpec) DeepCopyInto(out *TaskManagerSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.PreStop != nil {
+		in, out := &in.PreStop, &out.PreStop
+		*out = new(v1.LifecycleHandler)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.HorizontalPodAutoscaler != nil {
 		in, out := &in.HorizontalPodAutoscaler, &out.HorizontalPodAutoscaler
 		*out = new(HorizontalPodAutoscalerSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.FineGrainedResources != nil {
+		in, out := &in.FineGrainedResources, &out.FineGrainedResources
+		*out = new(FineGrainedResourcesSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExternalResources != nil {
+		in, out := &in.ExternalResources, &out.ExternalResources
+		*out = make([]ExternalResourceSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LocalRecovery != nil {
+		in, out := &in.LocalRecovery, &out.LocalRecovery
+		*out = new(LocalRecoverySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RocksDB != nil {
+		in, out := &in.RocksDB, &out.RocksDB
+		*out = new(RocksDBOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.WaitForJobManager != nil {
+		in, out := &in.WaitForJobManager, &out.WaitForJobManager
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskManagerSpec.
@@ -1132,6 +1916,21 @@ func (in *TaskManagerStatus) DeepCopy() *TaskManagerStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UploadedJarStatus) DeepCopyInto(out *UploadedJarStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UploadedJarStatus.
+func (in *UploadedJarStatus) DeepCopy() *UploadedJarStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(UploadedJarStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Validator) DeepCopyInto(out *Validator) {
 	*out = *in
@@ -1146,3 +1945,68 @@ func (in *Validator) DeepCopy() *Validator {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WaitForSavepointSpec) DeepCopyInto(out *WaitForSavepointSpec) {
+	*out = *in
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WaitForSavepointSpec.
+func (in *WaitForSavepointSpec) DeepCopy() *WaitForSavepointSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(WaitForSavepointSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WarmStandbySpec) DeepCopyInto(out *WarmStandbySpec) {
+	*out = *in
+	if in.MaxSavepointAgeSeconds != nil {
+		in, out := &in.MaxSavepointAgeSeconds, &out.MaxSavepointAgeSeconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WarmStandbySpec.
+func (in *WarmStandbySpec) DeepCopy() *WarmStandbySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(WarmStandbySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WatermarkAlignmentSpec) DeepCopyInto(out *WatermarkAlignmentSpec) {
+	*out = *in
+	if in.UpdateIntervalSeconds != nil {
+		in, out := &in.UpdateIntervalSeconds, &out.UpdateIntervalSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.AllowUnalignedSourceSplits != nil {
+		in, out := &in.AllowUnalignedSourceSplits, &out.AllowUnalignedSourceSplits
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WatermarkAlignmentSpec.
+func (in *WatermarkAlignmentSpec) DeepCopy() *WatermarkAlignmentSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(WatermarkAlignmentSpec)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -48,6 +48,9 @@ type FlinkClusterReconciler struct {
 	Clientset     *kubernetes.Clientset
 	EventRecorder record.EventRecorder
 	Sharder       *Sharder
+	// PriceList prices the resources of the clusters for their estimated cost, which is not
+	// estimated if it is zero.
+	PriceList v1beta1.ResourcePriceList
 }
 
 func NewReconciler(mgr manager.Manager) (*FlinkClusterReconciler, error) {
@@ -70,17 +73,19 @@ func NewReconciler(mgr manager.Manager) (*FlinkClusterReconciler, error) {
 
 // +kubebuilder:rbac:groups=flinkoperator.k8s.io,resources=flinkclusters,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=flinkoperator.k8s.io,resources=flinkclusters/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=flinkoperator.k8s.io,resources=flinkclusters/finalizers,verbs=update
 // +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=apps,resources=deployments/status,verbs=get
 // +kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=apps,resources=statefulsets/status,verbs=get
 // +kubebuilder:rbac:groups=core,resources=persistentvolumeclaims,verbs=get;list;watch;create;update;patch;delete
-// +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch;patch
 // +kubebuilder:rbac:groups=core,resources=pods/status,verbs=get
 // +kubebuilder:rbac:groups=core,resources=services,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,resources=services/status,verbs=get
 // +kubebuilder:rbac:groups=core,resources=events,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,resources=events/status,verbs=get
+// +kubebuilder:rbac:groups=core,resources=nodes,verbs=get;list;watch
 // +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=batch,resources=jobs/status,verbs=get
@@ -102,6 +107,7 @@ func (r *FlinkClusterReconciler) Reconcile(ctx context.Context,
 		flinkClient:   flink.NewDefaultClient(log),
 		request:       request,
 		eventRecorder: r.EventRecorder,
+		priceList:     r.PriceList,
 		observed:      ObservedClusterState{},
 	}
 
@@ -131,6 +137,7 @@ type FlinkClusterHandler struct {
 	flinkClient   *flink.Client
 	request       ctrl.Request
 	eventRecorder record.EventRecorder
+	priceList     v1beta1.ResourcePriceList
 	observed      ObservedClusterState
 	desired       model.DesiredClusterState
 }
@@ -163,6 +170,23 @@ func (handler *FlinkClusterHandler) reconcile(ctx context.Context,
 		return ctrl.Result{}, err
 	}
 
+	// The deleted cluster is torn down even if paused, otherwise the teardown finalizer
+	// would block the deletion until the cluster is resumed.
+	if observed.cluster != nil && observed.cluster.DeletionTimestamp == nil && observed.cluster.ReconcilePaused() {
+		log.Info("Reconciliation is paused, no action to take", "annotation", v1beta1.ReconcilePausedAnnotation)
+		var updater = ClusterStatusUpdater{
+			k8sClient: k8sClient,
+			recorder:  handler.eventRecorder,
+			observed:  handler.observed,
+		}
+		err = updater.updatePausedCondition(ctx)
+		if err != nil {
+			log.Error(err, "Failed to update the paused condition")
+		}
+		// The annotation change triggers the next reconcile when resumed.
+		return ctrl.Result{}, err
+	}
+
 	// Sync history and observe revision status
 	err = observer.syncRevisionStatus(ctx, observed)
 	if err != nil {
@@ -191,6 +215,10 @@ func (handler *FlinkClusterHandler) reconcile(ctx context.Context,
 			Requeue: true, RequeueAfter: 5 * time.Second,
 		}, nil
 	}
+	err = updater.updateEstimatedCostAnnotation(ctx, handler.priceList)
+	if err != nil {
+		log.Error(err, "Failed to update the estimated cost annotation")
+	}
 
 	log.Info("---------- 3. Compute the desired state ----------")
 
@@ -17,16 +17,20 @@ limitations under the License.
 package flinkcluster
 
 import (
+	"encoding/json"
 	"fmt"
 	"math"
 	"regexp"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"k8s.io/apimachinery/pkg/api/resource"
 
 	v1beta1 "github.com/spotify/flink-on-k8s-operator/apis/flinkcluster/v1beta1"
+	"github.com/spotify/flink-on-k8s-operator/internal/flink"
 	"github.com/spotify/flink-on-k8s-operator/internal/model"
 	"github.com/spotify/flink-on-k8s-operator/internal/util"
 
@@ -46,7 +50,7 @@ import (
 // underlying Kubernetes resource specs.
 
 const (
-	preStopSleepSeconds     = 30
+	preStopSleepSeconds     = v1beta1.DefaultPreStopSleepSeconds
 	flinkConfigMapPath      = "/opt/flink/conf"
 	flinkConfigMapVolume    = "flink-config-volume"
 	submitJobScriptPath     = "/opt/flink-operator/submit-job.sh"
@@ -56,22 +60,34 @@ const (
 	jobJarUriEnvVar         = "FLINK_JOB_JAR_URI"
 	jobPyFileUriEnvVar      = "FLINK_JOB_PY_FILE_URI"
 	jobPyFilesUriEnvVar     = "FLINK_JOB_PY_FILES_URI"
+	jarRunRequestEnvVar     = "FLINK_JAR_RUN_REQUEST"
+	cachedJarIDEnvVar       = "FLINK_CACHED_JAR_ID"
+	cachedJarHashEnvVar     = "FLINK_CACHED_JAR_HASH"
 	hadoopConfDirEnvVar     = "HADOOP_CONF_DIR"
 	gacEnvVar               = "GOOGLE_APPLICATION_CREDENTIALS"
+
+	watermarkAlignmentGroupEnvVar          = "FLINK_WATERMARK_ALIGNMENT_GROUP"
+	watermarkAlignmentMaxDriftEnvVar       = "FLINK_WATERMARK_ALIGNMENT_MAX_DRIFT_SECONDS"
+	watermarkAlignmentUpdateIntervalEnvVar = "FLINK_WATERMARK_ALIGNMENT_UPDATE_INTERVAL_SECONDS"
+	slotProfilesEnvVar                     = "FLINK_SLOT_PROFILES"
 )
 
 var (
 	backoffLimit                  int32 = 0
-	terminationGracePeriodSeconds int64 = 60
+	terminationGracePeriodSeconds int64 = v1beta1.TerminationGracePeriodSeconds
 	flinkSysProps                       = map[string]struct{}{
 		"jobmanager.rpc.address": {},
 		"jobmanager.rpc.port":    {},
 		"blob.server.port":       {},
 		"query.server.port":      {},
 		"rest.port":              {},
+		"taskmanager.rpc.port":   {},
+		"taskmanager.data.port":  {},
 	}
 	v10, _  = version.NewVersion("1.10")
+	v114, _ = version.NewVersion("1.14")
 	v115, _ = version.NewVersion("1.15")
+	v117, _ = version.NewVersion("1.17")
 	v20, _  = version.NewVersion("2.0")
 )
 
@@ -100,7 +116,7 @@ func getDesiredClusterState(observed *ObservedClusterState) *model.DesiredCluste
 	}
 
 	if !shouldCleanup(cluster, "JobManager") && !applicationMode {
-		state.JmStatefulSet = newJobManagerStatefulSet(cluster)
+		state.JmStatefulSet = newJobManagerStatefulSet(cluster, observed.observeTime)
 	}
 
 	if !shouldCleanup(cluster, "TaskManager") {
@@ -127,25 +143,26 @@ func getDesiredClusterState(observed *ObservedClusterState) *model.DesiredCluste
 		jobStatus := cluster.Status.Components.Job
 
 		keepJobState := (shouldStopJob(cluster) || jobStatus.IsStopped()) &&
-			(!shouldUpdateJob(observed) && !jobStatus.ShouldRestart(jobSpec)) &&
+			(!shouldUpdateJob(observed) && !jobStatus.ShouldRestart(jobSpec, observed.observeTime)) &&
 			shouldCleanup(cluster, "Job")
 
 		if !keepJobState {
-			state.Job = newJob(cluster)
+			state.Job = newJob(cluster, observed.observeTime)
 		}
 	}
 
 	return state
 }
 
-func newJobManagerContainer(flinkCluster *v1beta1.FlinkCluster) *corev1.Container {
+func newJobManagerContainer(flinkCluster *v1beta1.FlinkCluster, now time.Time) *corev1.Container {
 	var clusterSpec = flinkCluster.Spec
 	var imageSpec = clusterSpec.Image
 	var jobManagerSpec = clusterSpec.JobManager
-	var rpcPort = corev1.ContainerPort{Name: "rpc", ContainerPort: *jobManagerSpec.Ports.RPC}
-	var blobPort = corev1.ContainerPort{Name: "blob", ContainerPort: *jobManagerSpec.Ports.Blob}
-	var queryPort = corev1.ContainerPort{Name: "query", ContainerPort: *jobManagerSpec.Ports.Query}
-	var uiPort = corev1.ContainerPort{Name: "ui", ContainerPort: *jobManagerSpec.Ports.UI}
+	var networkPorts = getNetworkPorts(flinkCluster)
+	var rpcPort = corev1.ContainerPort{Name: "rpc", ContainerPort: networkPorts.JobManagerRPC}
+	var blobPort = corev1.ContainerPort{Name: "blob", ContainerPort: networkPorts.Blob}
+	var queryPort = corev1.ContainerPort{Name: "query", ContainerPort: networkPorts.Query}
+	var uiPort = corev1.ContainerPort{Name: "ui", ContainerPort: networkPorts.REST}
 	var ports = []corev1.ContainerPort{rpcPort, blobPort, queryPort, uiPort}
 	for _, port := range jobManagerSpec.ExtraPorts {
 		ports = append(ports, corev1.ContainerPort{Name: port.Name, ContainerPort: port.ContainerPort, Protocol: corev1.Protocol(port.Protocol)})
@@ -164,23 +181,18 @@ func newJobManagerContainer(flinkCluster *v1beta1.FlinkCluster) *corev1.Containe
 		EnvFrom:         flinkCluster.Spec.EnvFrom,
 		VolumeMounts:    jobManagerSpec.VolumeMounts,
 		Lifecycle: &corev1.Lifecycle{
-			PreStop: &corev1.LifecycleHandler{
-				Exec: &corev1.ExecAction{
-					Command: []string{"sleep", strconv.Itoa(preStopSleepSeconds)},
-				},
-			},
+			PreStop: getPreStopHandler(jobManagerSpec.PreStop),
 		},
 	}
 
 	if IsApplicationModeCluster(flinkCluster) {
 		jobSpec := flinkCluster.Spec.Job
-		status := flinkCluster.Status
 		args := []string{"standalone-job"}
-		if parallelism, err := calJobParallelism(flinkCluster); err == nil {
+		if parallelism, err := flinkCluster.GetJobParallelism(); err == nil {
 			args = append(args, fmt.Sprintf("-Dparallelism.default=%d", parallelism))
 		}
 
-		var fromSavepoint = convertFromSavepoint(jobSpec, status.Components.Job, &status.Revision)
+		var fromSavepoint = convertFromSavepoint(flinkCluster, now)
 		if fromSavepoint != nil {
 			args = append(args, "--fromSavepoint", *fromSavepoint)
 		}
@@ -197,6 +209,9 @@ func newJobManagerContainer(flinkCluster *v1beta1.FlinkCluster) *corev1.Containe
 
 		args = append(args, jobSpec.Args...)
 		container.Args = args
+
+		// The main method of the job runs in the JobManager.
+		container.Env = append(getJobMainEnvVars(flinkCluster), flinkCluster.Spec.EnvVars...)
 	}
 
 	return container
@@ -212,7 +227,7 @@ func newJobManagerPodSpec(mainContainer *corev1.Container, flinkCluster *v1beta1
 		InitContainers:                convertContainers(jobManagerSpec.InitContainers, []corev1.VolumeMount{}, clusterSpec.EnvVars),
 		Containers:                    []corev1.Container{*mainContainer},
 		Volumes:                       jobManagerSpec.Volumes,
-		Affinity:                      jobManagerSpec.Affinity,
+		Affinity:                      getArchitectureAffinity(jobManagerSpec.Affinity, flinkCluster.NodeArchitecture()),
 		NodeSelector:                  jobManagerSpec.NodeSelector,
 		Tolerations:                   jobManagerSpec.Tolerations,
 		ImagePullSecrets:              imageSpec.PullSecrets,
@@ -230,14 +245,14 @@ func newJobManagerPodSpec(mainContainer *corev1.Container, flinkCluster *v1beta1
 }
 
 // Gets the desired JobManager StatefulSet spec from the FlinkCluster spec.
-func newJobManagerStatefulSet(flinkCluster *v1beta1.FlinkCluster) *appsv1.StatefulSet {
+func newJobManagerStatefulSet(flinkCluster *v1beta1.FlinkCluster, now time.Time) *appsv1.StatefulSet {
 	var jobManagerSpec = flinkCluster.Spec.JobManager
 	var jobManagerStatefulSetName = getJobManagerName(flinkCluster.Name)
 	var podLabels = getComponentLabels(flinkCluster, "jobmanager")
 	podLabels = mergeLabels(podLabels, jobManagerSpec.PodLabels)
 	var statefulSetLabels = mergeLabels(podLabels, getRevisionHashLabels(&flinkCluster.Status.Revision))
 
-	mainContainer := newJobManagerContainer(flinkCluster)
+	mainContainer := newJobManagerContainer(flinkCluster, now)
 	podSpec := newJobManagerPodSpec(mainContainer, flinkCluster)
 
 	var pvcs []corev1.PersistentVolumeClaim
@@ -277,21 +292,22 @@ func newJobManagerService(flinkCluster *v1beta1.FlinkCluster) *corev1.Service {
 	var clusterNamespace = flinkCluster.Namespace
 	var clusterName = flinkCluster.Name
 	var jobManagerSpec = flinkCluster.Spec.JobManager
+	var networkPorts = getNetworkPorts(flinkCluster)
 	var rpcPort = corev1.ServicePort{
 		Name:       "rpc",
-		Port:       *jobManagerSpec.Ports.RPC,
+		Port:       networkPorts.JobManagerRPC,
 		TargetPort: intstr.FromString("rpc")}
 	var blobPort = corev1.ServicePort{
 		Name:       "blob",
-		Port:       *jobManagerSpec.Ports.Blob,
+		Port:       networkPorts.Blob,
 		TargetPort: intstr.FromString("blob")}
 	var queryPort = corev1.ServicePort{
 		Name:       "query",
-		Port:       *jobManagerSpec.Ports.Query,
+		Port:       networkPorts.Query,
 		TargetPort: intstr.FromString("query")}
 	var uiPort = corev1.ServicePort{
 		Name:       "ui",
-		Port:       *jobManagerSpec.Ports.UI,
+		Port:       networkPorts.REST,
 		TargetPort: intstr.FromString("ui")}
 	var jobManagerServiceName = getJobManagerServiceName(clusterName)
 	selectorLabels := getComponentLabels(flinkCluster, "jobmanager")
@@ -419,9 +435,10 @@ func newJobManagerIngress(
 func newTaskManagerContainer(flinkCluster *v1beta1.FlinkCluster) *corev1.Container {
 	var imageSpec = flinkCluster.Spec.Image
 	var taskManagerSpec = flinkCluster.Spec.TaskManager
-	var dataPort = corev1.ContainerPort{Name: "data", ContainerPort: *taskManagerSpec.Ports.Data}
-	var rpcPort = corev1.ContainerPort{Name: "rpc", ContainerPort: *taskManagerSpec.Ports.RPC}
-	var queryPort = corev1.ContainerPort{Name: "query", ContainerPort: *taskManagerSpec.Ports.Query}
+	var networkPorts = getNetworkPorts(flinkCluster)
+	var dataPort = corev1.ContainerPort{Name: "data", ContainerPort: networkPorts.TaskManagerData}
+	var rpcPort = corev1.ContainerPort{Name: "rpc", ContainerPort: networkPorts.TaskManagerRPC}
+	var queryPort = corev1.ContainerPort{Name: "query", ContainerPort: networkPorts.TaskManagerQuery}
 	var ports = []corev1.ContainerPort{dataPort, rpcPort, queryPort}
 	for _, port := range taskManagerSpec.ExtraPorts {
 		ports = append(ports, corev1.ContainerPort{Name: port.Name, ContainerPort: port.ContainerPort, Protocol: corev1.Protocol(port.Protocol)})
@@ -433,18 +450,61 @@ func newTaskManagerContainer(flinkCluster *v1beta1.FlinkCluster) *corev1.Contain
 		ImagePullPolicy: imageSpec.PullPolicy,
 		Args:            []string{"taskmanager"},
 		Ports:           ports,
-		LivenessProbe:   taskManagerSpec.LivenessProbe,
-		ReadinessProbe:  taskManagerSpec.ReadinessProbe,
-		Resources:       taskManagerSpec.Resources,
-		Env:             flinkCluster.Spec.EnvVars,
-		EnvFrom:         flinkCluster.Spec.EnvFrom,
-		VolumeMounts:    taskManagerSpec.VolumeMounts,
+		// The tail of the log is the termination message of a failed container, which the
+		// diagnosis of the RPC failures of the TaskManagers is based on.
+		TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+		LivenessProbe:            taskManagerSpec.LivenessProbe,
+		ReadinessProbe:           taskManagerSpec.ReadinessProbe,
+		Resources:                taskManagerSpec.Resources,
+		Env:                      flinkCluster.Spec.EnvVars,
+		EnvFrom:                  flinkCluster.Spec.EnvFrom,
+		VolumeMounts:             getTaskManagerVolumeMounts(taskManagerSpec),
 		Lifecycle: &corev1.Lifecycle{
-			PreStop: &corev1.LifecycleHandler{
-				Exec: &corev1.ExecAction{
-					Command: []string{"sleep", strconv.Itoa(preStopSleepSeconds)},
-				},
-			},
+			PreStop: getPreStopHandler(taskManagerSpec.PreStop),
+		},
+	}
+}
+
+// Gets the affinity of a pod running the Flink image, which also requires the nodes of the image
+// architecture when one is declared. The architecture is added to every required node selector
+// term, as a node matching any of the terms is selected.
+func getArchitectureAffinity(affinity *corev1.Affinity, arch string) *corev1.Affinity {
+	if arch == "" {
+		return affinity
+	}
+	var archAffinity = affinity.DeepCopy()
+	if archAffinity == nil {
+		archAffinity = &corev1.Affinity{}
+	}
+	if archAffinity.NodeAffinity == nil {
+		archAffinity.NodeAffinity = &corev1.NodeAffinity{}
+	}
+	var required = archAffinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+	if required == nil || len(required.NodeSelectorTerms) == 0 {
+		required = &corev1.NodeSelector{NodeSelectorTerms: []corev1.NodeSelectorTerm{{}}}
+		archAffinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = required
+	}
+	for i := range required.NodeSelectorTerms {
+		var term = &required.NodeSelectorTerms[i]
+		term.MatchExpressions = append(term.MatchExpressions, corev1.NodeSelectorRequirement{
+			Key:      corev1.LabelArchStable,
+			Operator: corev1.NodeSelectorOpIn,
+			Values:   []string{arch},
+		})
+	}
+	return archAffinity
+}
+
+// Gets the preStop hook of the JobManager or TaskManager container, by default sleeping
+// so that the container keeps running while it is removed from the endpoints and the job
+// is drained or cancelled.
+func getPreStopHandler(handler *corev1.LifecycleHandler) *corev1.LifecycleHandler {
+	if handler != nil {
+		return handler
+	}
+	return &corev1.LifecycleHandler{
+		Exec: &corev1.ExecAction{
+			Command: []string{"sleep", strconv.Itoa(preStopSleepSeconds)},
 		},
 	}
 }
@@ -459,7 +519,7 @@ func newTaskManagerPodSpec(mainContainer *corev1.Container, flinkCluster *v1beta
 		InitContainers:                convertContainers(taskManagerSpec.InitContainers, []corev1.VolumeMount{}, clusterSpec.EnvVars),
 		Containers:                    []corev1.Container{*mainContainer},
 		Volumes:                       taskManagerSpec.Volumes,
-		Affinity:                      taskManagerSpec.Affinity,
+		Affinity:                      getArchitectureAffinity(taskManagerSpec.Affinity, flinkCluster.NodeArchitecture()),
 		NodeSelector:                  taskManagerSpec.NodeSelector,
 		Tolerations:                   taskManagerSpec.Tolerations,
 		ImagePullSecrets:              imageSpec.PullSecrets,
@@ -469,6 +529,12 @@ func newTaskManagerPodSpec(mainContainer *corev1.Container, flinkCluster *v1beta
 		TerminationGracePeriodSeconds: &terminationGracePeriodSeconds,
 	}
 
+	if volume, _ := getLocalRecoveryStorage(taskManagerSpec); volume != nil {
+		podSpec.Volumes = appendVolumes(slices.Clone(podSpec.Volumes), *volume)
+	}
+	if taskManagerSpec.WaitForJobManager != nil && *taskManagerSpec.WaitForJobManager {
+		podSpec.SchedulingGates = []corev1.PodSchedulingGate{{Name: v1beta1.JobManagerReadySchedulingGate}}
+	}
 	setFlinkConfig(getConfigMapName(flinkCluster.Name), podSpec)
 	setHadoopConfig(flinkCluster.Spec.HadoopConfig, podSpec)
 	setGCPConfig(flinkCluster.Spec.GCPConfig, podSpec)
@@ -477,6 +543,264 @@ func newTaskManagerPodSpec(mainContainer *corev1.Container, flinkCluster *v1beta
 	return podSpec
 }
 
+// The mount path of the local recovery volume when it is not mounted in the TaskManager spec.
+const localRecoveryMountPath = "/flink-local-recovery"
+
+// Gets the volume mounts of the TaskManager container.
+func getTaskManagerVolumeMounts(taskManagerSpec *v1beta1.TaskManagerSpec) []corev1.VolumeMount {
+	if _, mount := getLocalRecoveryStorage(taskManagerSpec); mount != nil {
+		return appendVolumeMounts(slices.Clone(taskManagerSpec.VolumeMounts), *mount)
+	}
+	return taskManagerSpec.VolumeMounts
+}
+
+// Gets the local storage of the task-local recovery of TaskManagers: the volume to provision,
+// nil if it is a volume of the TaskManager spec, and its mount in the TaskManager container.
+func getLocalRecoveryStorage(taskManagerSpec *v1beta1.TaskManagerSpec) (*corev1.Volume, *corev1.VolumeMount) {
+	var localRecovery = taskManagerSpec.LocalRecovery
+	if localRecovery == nil {
+		return nil, nil
+	}
+
+	var volumeName = localRecovery.GetVolumeName()
+	var volume *corev1.Volume
+	if volumeName == v1beta1.LocalRecoveryVolumeName {
+		volume = &corev1.Volume{
+			Name:         volumeName,
+			VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{SizeLimit: localRecovery.SizeLimit}},
+		}
+	}
+	for _, mount := range taskManagerSpec.VolumeMounts {
+		if mount.Name == volumeName {
+			return volume, &mount
+		}
+	}
+	return volume, &corev1.VolumeMount{Name: volumeName, MountPath: localRecoveryMountPath}
+}
+
+// Gets the Flink properties enabling the task-local recovery with the local state copy
+// kept in the local recovery volume.
+func getLocalRecoveryProperties(cluster *v1beta1.FlinkCluster, appVersion *version.Version) map[string]string {
+	_, mount := getLocalRecoveryStorage(cluster.Spec.TaskManager)
+	if mount == nil {
+		return nil
+	}
+	var key = "state.backend.local-recovery"
+	if appVersion != nil && !appVersion.LessThan(v20) {
+		key = "execution.state-recovery.from-local"
+	}
+	return map[string]string{
+		key:                                 "true",
+		"taskmanager.state.local.root-dirs": mount.MountPath,
+	}
+}
+
+// Gets the Flink properties of the job result store.
+func getJobResultStoreProperties(cluster *v1beta1.FlinkCluster) map[string]string {
+	var jobResultStore = cluster.Spec.JobResultStore
+	if jobResultStore == nil {
+		return nil
+	}
+	var props = map[string]string{
+		"job-result-store.storage-path": strings.TrimSpace(jobResultStore.StorageDir),
+	}
+	if jobResultStore.DeleteOnCommit != nil {
+		props["job-result-store.delete-on-commit"] = strconv.FormatBool(*jobResultStore.DeleteOnCommit)
+	}
+	return props
+}
+
+// Gets the Flink properties of the adaptive scheduler rescale settings.
+func getAdaptiveSchedulerProperties(cluster *v1beta1.FlinkCluster) map[string]string {
+	if cluster.Spec.JobManager == nil || cluster.Spec.JobManager.AdaptiveScheduler == nil {
+		return nil
+	}
+	var spec = cluster.Spec.JobManager.AdaptiveScheduler
+	var props = map[string]string{}
+	if spec.MinParallelismIncrease != nil {
+		props["jobmanager.adaptive-scheduler.min-parallelism-increase"] = strconv.Itoa(int(*spec.MinParallelismIncrease))
+	}
+	if spec.ResourceWaitTimeoutSeconds != nil {
+		props["jobmanager.adaptive-scheduler.resource-wait-timeout"] = fmt.Sprintf("%ds", *spec.ResourceWaitTimeoutSeconds)
+	}
+	if spec.ResourceStabilizationTimeoutSeconds != nil {
+		props["jobmanager.adaptive-scheduler.resource-stabilization-timeout"] = fmt.Sprintf("%ds", *spec.ResourceStabilizationTimeoutSeconds)
+	}
+	return props
+}
+
+// Gets the Flink property of the failover strategy. The streaming jobs fail over by region
+// unless configured otherwise, so that a task failure does not restart the whole job.
+func getFailoverStrategyProperties(cluster *v1beta1.FlinkCluster) map[string]string {
+	const key = "jobmanager.execution.failover-strategy"
+	if jmSpec := cluster.Spec.JobManager; jmSpec != nil && jmSpec.FailoverStrategy != nil {
+		return map[string]string{key: string(*jmSpec.FailoverStrategy)}
+	}
+	if cluster.RuntimeMode() == v1beta1.RuntimeModeStreaming {
+		return map[string]string{key: string(v1beta1.FailoverStrategyRegion)}
+	}
+	return nil
+}
+
+// Gets the Flink properties of the fixed-delay restart strategy the failed job escalates from
+// to the operator. The strategy is typed by restart-strategy.type since Flink 1.17.
+func getRestartStrategyProperties(cluster *v1beta1.FlinkCluster, appVersion *version.Version) map[string]string {
+	if cluster.Spec.Job == nil || cluster.Spec.Job.RestartEscalation == nil {
+		return nil
+	}
+	var spec = cluster.Spec.Job.RestartEscalation
+	var key = "restart-strategy"
+	if appVersion != nil && !appVersion.LessThan(v117) {
+		key = "restart-strategy.type"
+	}
+	var props = map[string]string{
+		key:                                     "fixed-delay",
+		"restart-strategy.fixed-delay.attempts": strconv.Itoa(int(spec.FlinkRestartAttempts)),
+	}
+	if spec.FlinkRestartDelaySeconds != nil {
+		props["restart-strategy.fixed-delay.delay"] = fmt.Sprintf("%ds", *spec.FlinkRestartDelaySeconds)
+	}
+	return props
+}
+
+// Gets the Flink property of the classloader resolve order set typed in the job.
+func getClassloaderResolveOrderProperties(cluster *v1beta1.FlinkCluster) map[string]string {
+	if jobSpec := cluster.Spec.Job; jobSpec != nil && jobSpec.ClassloaderResolveOrder != nil {
+		return map[string]string{"classloader.resolve-order": string(*jobSpec.ClassloaderResolveOrder)}
+	}
+	return nil
+}
+
+// Gets the Flink property of the runtime mode set typed in the job execution mode.
+func getExecutionModeProperties(cluster *v1beta1.FlinkCluster) map[string]string {
+	if jobSpec := cluster.Spec.Job; jobSpec != nil && jobSpec.ExecutionMode != nil {
+		return map[string]string{"execution.runtime-mode": cluster.RuntimeMode()}
+	}
+	return nil
+}
+
+// Gets the Flink property of the generation of the missing operator UIDs set typed in the job.
+func getOperatorUIDProperties(cluster *v1beta1.FlinkCluster) map[string]string {
+	if jobSpec := cluster.Spec.Job; jobSpec != nil && jobSpec.OperatorUIDPolicy != nil {
+		var generated = *jobSpec.OperatorUIDPolicy != v1beta1.OperatorUIDPolicyRequired
+		return map[string]string{"pipeline.auto-generate-uids": strconv.FormatBool(generated)}
+	}
+	return nil
+}
+
+// Gets the Flink properties of the checkpoint timeout and concurrency set typed in the job.
+func getCheckpointTuningProperties(cluster *v1beta1.FlinkCluster) map[string]string {
+	if cluster.Spec.Job == nil {
+		return nil
+	}
+	var jobSpec = cluster.Spec.Job
+	var props = map[string]string{}
+	if jobSpec.CheckpointTimeoutSeconds != nil {
+		props["execution.checkpointing.timeout"] = fmt.Sprintf("%ds", *jobSpec.CheckpointTimeoutSeconds)
+	}
+	if jobSpec.MaxConcurrentCheckpoints != nil {
+		props["execution.checkpointing.max-concurrent-checkpoints"] = strconv.Itoa(int(*jobSpec.MaxConcurrentCheckpoints))
+	}
+	return props
+}
+
+// Gets the Flink property of the watermark alignment of the job sources. Flink has no option
+// for the group, the max drift and the update interval, which the job sets on its sources
+// from the environment variables of getWatermarkAlignmentEnvVars.
+func getWatermarkAlignmentProperties(cluster *v1beta1.FlinkCluster) map[string]string {
+	if cluster.Spec.Job == nil || cluster.Spec.Job.WatermarkAlignment == nil ||
+		cluster.Spec.Job.WatermarkAlignment.AllowUnalignedSourceSplits == nil {
+		return nil
+	}
+	var allowUnaligned = *cluster.Spec.Job.WatermarkAlignment.AllowUnalignedSourceSplits
+	return map[string]string{
+		"pipeline.watermark-alignment.allow-unaligned-source-splits": strconv.FormatBool(allowUnaligned),
+	}
+}
+
+// Gets the environment variables of the settings the main method of the job applies itself, as
+// Flink has no option for them.
+func getJobMainEnvVars(cluster *v1beta1.FlinkCluster) []corev1.EnvVar {
+	return append(getWatermarkAlignmentEnvVars(cluster.Spec.Job), getSlotProfilesEnvVars(cluster)...)
+}
+
+// slotProfile is a slot profile of the fine-grained resource management as passed to the job.
+type slotProfile struct {
+	Name        string  `json:"name"`
+	Slots       int32   `json:"slots"`
+	CPUCores    float64 `json:"cpuCores"`
+	MemoryBytes int64   `json:"memoryBytes"`
+}
+
+// Gets the environment variable of the slot profiles of the fine-grained resource management,
+// a JSON array which the job declares its slot sharing groups with, as the profiles of the slots
+// are requested by the slot sharing groups of the job.
+func getSlotProfilesEnvVars(cluster *v1beta1.FlinkCluster) []corev1.EnvVar {
+	if cluster.Spec.TaskManager == nil || cluster.Spec.TaskManager.FineGrainedResources == nil {
+		return nil
+	}
+	var profiles []slotProfile
+	for _, profile := range cluster.Spec.TaskManager.FineGrainedResources.SlotProfiles {
+		profiles = append(profiles, slotProfile{
+			Name:        profile.Name,
+			Slots:       profile.GetSlots(),
+			CPUCores:    profile.CPU.AsApproximateFloat64(),
+			MemoryBytes: profile.Memory.Value(),
+		})
+	}
+	var value, _ = json.Marshal(profiles)
+	return []corev1.EnvVar{{Name: slotProfilesEnvVar, Value: string(value)}}
+}
+
+// Gets the environment variables of the watermark alignment group, max drift and update interval,
+// which the main method of the job passes to `WatermarkStrategy.withWatermarkAlignment`.
+func getWatermarkAlignmentEnvVars(jobSpec *v1beta1.JobSpec) []corev1.EnvVar {
+	if jobSpec == nil || jobSpec.WatermarkAlignment == nil {
+		return nil
+	}
+	var spec = jobSpec.WatermarkAlignment
+	var envVars = []corev1.EnvVar{
+		{Name: watermarkAlignmentGroupEnvVar, Value: spec.Group},
+		{Name: watermarkAlignmentMaxDriftEnvVar, Value: strconv.Itoa(int(spec.MaxDriftSeconds))},
+	}
+	if spec.UpdateIntervalSeconds != nil {
+		envVars = append(envVars, corev1.EnvVar{
+			Name:  watermarkAlignmentUpdateIntervalEnvVar,
+			Value: strconv.Itoa(int(*spec.UpdateIntervalSeconds)),
+		})
+	}
+	return envVars
+}
+
+// Gets the Flink properties of the RocksDB options. The memory sizes are in bytes and the
+// percentages are converted to the ratios of Flink.
+func getRocksDBProperties(cluster *v1beta1.FlinkCluster) map[string]string {
+	if cluster.Spec.TaskManager == nil || cluster.Spec.TaskManager.RocksDB == nil {
+		return nil
+	}
+	var spec = cluster.Spec.TaskManager.RocksDB
+	var props = map[string]string{}
+	if spec.ManagedMemory != nil {
+		props["state.backend.rocksdb.memory.managed"] = strconv.FormatBool(*spec.ManagedMemory)
+	}
+	if spec.FixedMemoryPerSlot != nil {
+		props["state.backend.rocksdb.memory.fixed-per-slot"] = strconv.FormatInt(spec.FixedMemoryPerSlot.Value(), 10) + "b"
+	}
+	if spec.WriteBufferRatio != nil {
+		props["state.backend.rocksdb.memory.write-buffer-ratio"] = fmt.Sprintf("%.2f", float64(*spec.WriteBufferRatio)/100)
+	}
+	if spec.HighPriorityPoolRatio != nil {
+		props["state.backend.rocksdb.memory.high-prio-pool-ratio"] = fmt.Sprintf("%.2f", float64(*spec.HighPriorityPoolRatio)/100)
+	}
+	if spec.BlockCacheSize != nil {
+		props["state.backend.rocksdb.block.cache-size"] = strconv.FormatInt(spec.BlockCacheSize.Value(), 10) + "b"
+	}
+	if spec.WriteBufferSize != nil {
+		props["state.backend.rocksdb.writebuffer.size"] = strconv.FormatInt(spec.WriteBufferSize.Value(), 10) + "b"
+	}
+	return props
+}
+
 // Gets the desired TaskManager StatefulSet spec from a cluster spec.
 func newTaskManagerStatefulSet(flinkCluster *v1beta1.FlinkCluster) *appsv1.StatefulSet {
 	var taskManagerSpec = flinkCluster.Spec.TaskManager
@@ -656,19 +980,20 @@ func newTaskManagerService(flinkCluster *v1beta1.FlinkCluster) *corev1.Service {
 	var tmSvcName = getTaskManagerName(clusterName)
 	selectorLabels := getComponentLabels(flinkCluster, "taskmanager")
 	serviceLabels := mergeLabels(selectorLabels, getRevisionHashLabels(&flinkCluster.Status.Revision))
+	var networkPorts = getNetworkPorts(flinkCluster)
 
 	var tmSvcPorts = []corev1.ServicePort{
 		{
 			Name: "data",
-			Port: *tmSpec.Ports.Data,
+			Port: networkPorts.TaskManagerData,
 		},
 		{
 			Name: "rpc",
-			Port: *tmSpec.Ports.RPC,
+			Port: networkPorts.TaskManagerRPC,
 		},
 		{
 			Name: "query",
-			Port: *tmSpec.Ports.Query,
+			Port: networkPorts.TaskManagerQuery,
 		},
 	}
 
@@ -695,8 +1020,7 @@ func newConfigMap(flinkCluster *v1beta1.FlinkCluster) *corev1.ConfigMap {
 	var clusterNamespace = flinkCluster.Namespace
 	var clusterName = flinkCluster.Name
 	var flinkProperties = flinkCluster.Spec.FlinkProperties
-	var jmPorts = flinkCluster.Spec.JobManager.Ports
-	var tmPorts = flinkCluster.Spec.TaskManager.Ports
+	var networkPorts = getNetworkPorts(flinkCluster)
 	var configMapName = getConfigMapName(clusterName)
 	var labels = mergeLabels(
 		getClusterLabels(flinkCluster),
@@ -704,11 +1028,12 @@ func newConfigMap(flinkCluster *v1beta1.FlinkCluster) *corev1.ConfigMap {
 	// Properties which should be provided from real deployed environment.
 	var flinkProps = map[string]string{
 		"jobmanager.rpc.address": getJobManagerServiceName(clusterName),
-		"jobmanager.rpc.port":    strconv.FormatInt(int64(*jmPorts.RPC), 10),
-		"blob.server.port":       strconv.FormatInt(int64(*jmPorts.Blob), 10),
-		"query.server.port":      strconv.FormatInt(int64(*jmPorts.Query), 10),
-		"rest.port":              strconv.FormatInt(int64(*jmPorts.UI), 10),
-		"taskmanager.rpc.port":   strconv.FormatInt(int64(*tmPorts.RPC), 10),
+		"jobmanager.rpc.port":    strconv.FormatInt(int64(networkPorts.JobManagerRPC), 10),
+		"blob.server.port":       strconv.FormatInt(int64(networkPorts.Blob), 10),
+		"query.server.port":      strconv.FormatInt(int64(networkPorts.Query), 10),
+		"rest.port":              strconv.FormatInt(int64(networkPorts.REST), 10),
+		"taskmanager.rpc.port":   strconv.FormatInt(int64(networkPorts.TaskManagerRPC), 10),
+		"taskmanager.data.port":  strconv.FormatInt(int64(networkPorts.TaskManagerData), 10),
 	}
 
 	if appVersion == nil || appVersion.LessThan(v10) {
@@ -729,10 +1054,52 @@ func newConfigMap(flinkCluster *v1beta1.FlinkCluster) *corev1.ConfigMap {
 		}
 	}
 
-	if taskSlots, err := calTaskManagerTaskSlots(flinkCluster); err == nil {
+	if taskSlots, err := flinkCluster.GetTaskManagerTaskSlots(); err == nil {
 		flinkProps["taskmanager.numberOfTaskSlots"] = strconv.Itoa(int(taskSlots))
 	}
 
+	if appVersion != nil && !appVersion.LessThan(v114) {
+		for k, v := range getFineGrainedResourceProperties(flinkCluster) {
+			flinkProps[k] = v
+		}
+	}
+	for k, v := range getExternalResourceProperties(flinkCluster) {
+		flinkProps[k] = v
+	}
+	for k, v := range getLocalRecoveryProperties(flinkCluster, appVersion) {
+		flinkProps[k] = v
+	}
+	for k, v := range getJobResultStoreProperties(flinkCluster) {
+		flinkProps[k] = v
+	}
+	for k, v := range getAdaptiveSchedulerProperties(flinkCluster) {
+		flinkProps[k] = v
+	}
+	for k, v := range getRocksDBProperties(flinkCluster) {
+		flinkProps[k] = v
+	}
+	for k, v := range getFailoverStrategyProperties(flinkCluster) {
+		flinkProps[k] = v
+	}
+	for k, v := range getRestartStrategyProperties(flinkCluster, appVersion) {
+		flinkProps[k] = v
+	}
+	for k, v := range getClassloaderResolveOrderProperties(flinkCluster) {
+		flinkProps[k] = v
+	}
+	for k, v := range getExecutionModeProperties(flinkCluster) {
+		flinkProps[k] = v
+	}
+	for k, v := range getOperatorUIDProperties(flinkCluster) {
+		flinkProps[k] = v
+	}
+	for k, v := range getWatermarkAlignmentProperties(flinkCluster) {
+		flinkProps[k] = v
+	}
+	for k, v := range getCheckpointTuningProperties(flinkCluster) {
+		flinkProps[k] = v
+	}
+
 	// Add custom Flink properties.
 	for k, v := range flinkProperties {
 		// Do not allow to override properties from real deployment.
@@ -761,21 +1128,19 @@ func newConfigMap(flinkCluster *v1beta1.FlinkCluster) *corev1.ConfigMap {
 	return configMap
 }
 
-func newJobSubmitterPodSpec(flinkCluster *v1beta1.FlinkCluster) *corev1.PodSpec {
+func newJobSubmitterPodSpec(flinkCluster *v1beta1.FlinkCluster, now time.Time) *corev1.PodSpec {
 	var jobSpec = flinkCluster.Spec.Job
 	if jobSpec == nil {
 		return nil
 	}
 
-	var status = flinkCluster.Status
 	var clusterSpec = flinkCluster.Spec
 	var imageSpec = clusterSpec.Image
 	var serviceAccount = clusterSpec.ServiceAccountName
-	var jobManagerSpec = clusterSpec.JobManager
 	var clusterName = flinkCluster.Name
 	var jobManagerServiceName = getJobManagerServiceName(clusterName)
 	var jobManagerAddress = fmt.Sprintf(
-		"%s:%d", jobManagerServiceName, *jobManagerSpec.Ports.UI)
+		"%s:%d", jobManagerServiceName, getNetworkPorts(flinkCluster).REST)
 
 	var jobArgs = []string{"bash", submitJobScriptPath}
 	jobArgs = append(jobArgs, "--jobmanager", jobManagerAddress)
@@ -783,7 +1148,7 @@ func newJobSubmitterPodSpec(flinkCluster *v1beta1.FlinkCluster) *corev1.PodSpec
 		jobArgs = append(jobArgs, "--class", *jobSpec.ClassName)
 	}
 
-	var fromSavepoint = convertFromSavepoint(jobSpec, status.Components.Job, &status.Revision)
+	var fromSavepoint = convertFromSavepoint(flinkCluster, now)
 	if fromSavepoint != nil {
 		jobArgs = append(jobArgs, "--fromSavepoint", *fromSavepoint)
 	}
@@ -793,7 +1158,7 @@ func newJobSubmitterPodSpec(flinkCluster *v1beta1.FlinkCluster) *corev1.PodSpec
 		jobArgs = append(jobArgs, "--allowNonRestoredState")
 	}
 
-	if parallelism, err := calJobParallelism(flinkCluster); err == nil {
+	if parallelism, err := flinkCluster.GetJobParallelism(); err == nil {
 		jobArgs = append(jobArgs, "--parallelism", fmt.Sprint(parallelism))
 	}
 
@@ -816,6 +1181,10 @@ func newJobSubmitterPodSpec(flinkCluster *v1beta1.FlinkCluster) *corev1.PodSpec
 		Name:  jobManagerAddrEnvVar,
 		Value: jobManagerAddress,
 	}}
+	// The main method of the job runs in the submitter, unless the JAR is run through the REST API.
+	if !isRESTJarUpload(flinkCluster) {
+		envVars = append(envVars, getJobMainEnvVars(flinkCluster)...)
+	}
 	envVars = append(envVars, flinkCluster.Spec.EnvVars...)
 
 	var volumes []corev1.Volume
@@ -846,6 +1215,12 @@ func newJobSubmitterPodSpec(flinkCluster *v1beta1.FlinkCluster) *corev1.PodSpec
 
 	jobArgs = append(jobArgs, jobSpec.Args...)
 
+	// The submitter uploads the JAR through the REST API and runs it by the request instead.
+	if isRESTJarUpload(flinkCluster) {
+		jobArgs = []string{"bash", submitJobScriptPath, *jobSpec.JarFile}
+		envVars = append(envVars, getRESTJarUploadEnvVars(flinkCluster, fromSavepoint)...)
+	}
+
 	podSpec := &corev1.PodSpec{
 		InitContainers: convertContainers(jobSpec.InitContainers, volumeMounts, envVars),
 		Containers: []corev1.Container{
@@ -866,7 +1241,7 @@ func newJobSubmitterPodSpec(flinkCluster *v1beta1.FlinkCluster) *corev1.PodSpec
 		SecurityContext:    jobSpec.SecurityContext,
 		HostAliases:        jobSpec.HostAliases,
 		ServiceAccountName: getServiceAccountName(serviceAccount),
-		Affinity:           jobSpec.Affinity,
+		Affinity:           getArchitectureAffinity(jobSpec.Affinity, flinkCluster.NodeArchitecture()),
 		NodeSelector:       jobSpec.NodeSelector,
 		Tolerations:        jobSpec.Tolerations,
 	}
@@ -878,7 +1253,34 @@ func newJobSubmitterPodSpec(flinkCluster *v1beta1.FlinkCluster) *corev1.PodSpec
 	return podSpec
 }
 
-func newJob(flinkCluster *v1beta1.FlinkCluster) *batchv1.Job {
+// Gets the environment variables of the job submitter which uploads the JAR through the REST
+// API: the request to run the JAR, and the JAR uploaded by the previous submission, if any, which
+// the submitter runs again when the content hash of the JAR is the same.
+func getRESTJarUploadEnvVars(flinkCluster *v1beta1.FlinkCluster, fromSavepoint *string) []corev1.EnvVar {
+	var jobSpec = flinkCluster.Spec.Job
+	var request = flink.JarRunRequest{ProgramArgsList: jobSpec.Args}
+	if jobSpec.ClassName != nil {
+		request.EntryClass = *jobSpec.ClassName
+	}
+	if parallelism, err := flinkCluster.GetJobParallelism(); err == nil {
+		request.Parallelism = &parallelism
+	}
+	if fromSavepoint != nil {
+		request.SavepointPath = *fromSavepoint
+	}
+	request.AllowNonRestoredState = jobSpec.AllowNonRestoredState != nil && *jobSpec.AllowNonRestoredState
+	var body, _ = json.Marshal(request)
+
+	var envVars = []corev1.EnvVar{{Name: jarRunRequestEnvVar, Value: string(body)}}
+	if job := flinkCluster.Status.Components.Job; job != nil && job.UploadedJar != nil {
+		envVars = append(envVars,
+			corev1.EnvVar{Name: cachedJarIDEnvVar, Value: job.UploadedJar.ID},
+			corev1.EnvVar{Name: cachedJarHashEnvVar, Value: job.UploadedJar.Hash})
+	}
+	return envVars
+}
+
+func newJob(flinkCluster *v1beta1.FlinkCluster, now time.Time) *batchv1.Job {
 	jobSpec := flinkCluster.Spec.Job
 	if jobSpec == nil {
 		return nil
@@ -900,13 +1302,13 @@ func newJob(flinkCluster *v1beta1.FlinkCluster) *batchv1.Job {
 		labels = mergeLabels(labels, map[string]string{JobIdLabel: jobId})
 		jobName = getJobManagerJobName(flinkCluster.Name)
 		annotations = jobManagerSpec.PodAnnotations
-		mainContainer := newJobManagerContainer(flinkCluster)
+		mainContainer := newJobManagerContainer(flinkCluster, now)
 		podSpec = newJobManagerPodSpec(mainContainer, flinkCluster)
 	} else {
 		jobName = getSubmitterJobName(flinkCluster.Name)
 		labels = mergeLabels(labels, jobSpec.PodLabels)
 		annotations = jobSpec.PodAnnotations
-		podSpec = newJobSubmitterPodSpec(flinkCluster)
+		podSpec = newJobSubmitterPodSpec(flinkCluster, now)
 	}
 
 	// Disable the retry mechanism of k8s Job, all retries should be initiated
@@ -943,22 +1345,29 @@ func newJob(flinkCluster *v1beta1.FlinkCluster) *batchv1.Job {
 // case 1) Restore job from the user provided savepoint
 // When FlinkCluster is created or updated, if spec.job.fromSavepoint is specified, Flink job will be restored from it.
 //
-// case 2) Restore Flink job from the latest savepoint.
+// case 2) Restore Flink job from the best restore source.
 // When FlinkCluster is updated with no spec.job.fromSavepoint, or job is restarted from the failed state,
-// Flink job will be restored from the latest savepoint created by the operator.
+// Flink job will be restored from the best restore source at now, chosen among the latest savepoint created
+// by the operator, the primed savepoint a job with warm standby fails over to, the savepoint inventory and
+// the retained checkpoint of the job, skipping the poison ones.
 //
-// case 3) When latest created savepoint is unavailable, use the savepoint from which current job was restored.
-func convertFromSavepoint(jobSpec *v1beta1.JobSpec, jobStatus *v1beta1.JobStatus, revision *v1beta1.RevisionStatus) *string {
+// case 3) When all the restore sources are poison, use the latest savepoint or the savepoint from which
+// current job was restored, the job is not restarted from it.
+func convertFromSavepoint(cluster *v1beta1.FlinkCluster, now time.Time) *string {
+	var jobSpec = cluster.Spec.Job
+	var jobStatus = cluster.Status.Components.Job
 	switch {
 	// Updating with FromSavepoint provided
-	case revision.IsUpdateTriggered() && !util.IsBlank(jobSpec.FromSavepoint):
+	case cluster.Status.Revision.IsUpdateTriggered() && !util.IsBlank(jobSpec.FromSavepoint):
 		return jobSpec.FromSavepoint
-	// Latest savepoint
-	case jobStatus != nil && jobStatus.SavepointLocation != "":
-		return &jobStatus.SavepointLocation
-	// The savepoint from which current job was restored
-	case jobStatus != nil && jobStatus.FromSavepoint != "":
-		return &jobStatus.FromSavepoint
+	// The best restore source
+	case jobStatus != nil:
+		if path, _, _ := cluster.BestRestoreSource(now); path != "" {
+			return &path
+		}
+		if savepoint := jobStatus.RestoreSavepoint(); savepoint != "" {
+			return &savepoint
+		}
 	}
 	// Creating for the first time or other situation
 	if !util.IsBlank(jobSpec.FromSavepoint) {
@@ -1065,6 +1474,20 @@ func getFlinkProperties(properties map[string]string) string {
 	return builder.String()
 }
 
+// Parses Flink properties rendered by getFlinkProperties.
+func parseFlinkProperties(conf string) map[string]string {
+	var properties = make(map[string]string)
+	for _, line := range strings.Split(conf, "\n") {
+		key, value, found := strings.Cut(line, ":")
+		key = strings.TrimSpace(key)
+		if !found || key == "" || strings.HasPrefix(key, "#") {
+			continue
+		}
+		properties[key] = strings.TrimSpace(value)
+	}
+	return properties
+}
+
 var jobManagerIngressHostRegex = regexp.MustCompile(`{{\s*[$]clusterName\s*}}`)
 
 func getJobManagerIngressHost(ingressHostFormat string, clusterName string) string {
@@ -1089,6 +1512,10 @@ func shouldCleanup(cluster *v1beta1.FlinkCluster, component string) bool {
 	switch jobStatus.State {
 	case v1beta1.JobStateSucceeded:
 		action = cluster.Spec.Job.CleanupPolicy.AfterJobSucceeds
+		// The streaming job which completed unexpectedly is cleaned up like a failed job.
+		if jobStatus.IsUnexpectedCompletion(cluster.Spec.Job) {
+			action = cluster.Spec.Job.CleanupPolicy.AfterJobFails
+		}
 	case v1beta1.JobStateFailed, v1beta1.JobStateLost, v1beta1.JobStateDeployFailed:
 		action = cluster.Spec.Job.CleanupPolicy.AfterJobFails
 	case v1beta1.JobStateCancelled:
@@ -1107,35 +1534,48 @@ func shouldCleanup(cluster *v1beta1.FlinkCluster, component string) bool {
 	return false
 }
 
-func calJobParallelism(cluster *v1beta1.FlinkCluster) (int32, error) {
-	if cluster.Spec.Job.Parallelism != nil {
-		return *cluster.Spec.Job.Parallelism, nil
+// Gets the Flink properties for fine-grained resource management of TaskManagers.
+// The TaskManager CPU is set explicitly so that Flink can fit the slot profiles into it.
+func getFineGrainedResourceProperties(cluster *v1beta1.FlinkCluster) map[string]string {
+	var fineGrainedResources = cluster.Spec.TaskManager.FineGrainedResources
+	if fineGrainedResources == nil {
+		return nil
 	}
 
-	value, err := calTaskManagerTaskSlots(cluster)
-	if err != nil {
-		return 0, err
+	var props = map[string]string{
+		"cluster.fine-grained-resource-management.enabled": "true",
+		"taskmanager.cpu.cores": strconv.FormatFloat(
+			cluster.Spec.TaskManager.GetResources().Cpu().AsApproximateFloat64(), 'f', -1, 64),
 	}
-
-	parallelism := *cluster.Spec.TaskManager.Replicas * value
-	return parallelism, nil
+	if fineGrainedResources.MaxSlots != nil {
+		props["slotmanager.number-of-slots.max"] = strconv.Itoa(int(*fineGrainedResources.MaxSlots))
+	}
+	return props
 }
 
-func calTaskManagerTaskSlots(cluster *v1beta1.FlinkCluster) (int32, error) {
-	if ts, ok := cluster.Spec.FlinkProperties["taskmanager.numberOfTaskSlots"]; ok {
-		parsed, err := strconv.ParseInt(ts, 10, 32)
-		if err != nil {
-			return 0, err
-		}
-		return int32(parsed), nil
+// Gets the Flink properties which expose the external resources of TaskManagers to the
+// operators through the Flink external resource framework.
+func getExternalResourceProperties(cluster *v1beta1.FlinkCluster) map[string]string {
+	var externalResources = cluster.Spec.TaskManager.ExternalResources
+	if len(externalResources) == 0 {
+		return nil
 	}
 
-	resources := cluster.Spec.TaskManager.GetResources()
-	slots := int32(resources.Cpu().Value()) / 2
-	if slots == 0 {
-		return 1, nil
+	var props = make(map[string]string)
+	var names []string
+	for _, r := range externalResources {
+		names = append(names, r.Name)
+		var prefix = "external-resource." + r.Name
+		props[prefix+".amount"] = strconv.FormatInt(r.Amount, 10)
+		if driverFactoryClass := r.GetDriverFactoryClass(); driverFactoryClass != "" {
+			props[prefix+".driver-factory.class"] = driverFactoryClass
+		}
+		for k, v := range r.DriverParams {
+			props[prefix+".param."+k] = v
+		}
 	}
-	return slots, nil
+	props["external-resources"] = strings.Join(names, ";")
+	return props
 }
 
 func calFlinkHeapSize(cluster *v1beta1.FlinkCluster) map[string]string {
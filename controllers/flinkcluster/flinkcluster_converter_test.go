@@ -18,10 +18,14 @@ package flinkcluster
 
 import (
 	"reflect"
+	"slices"
 	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/hashicorp/go-version"
 	v1beta1 "github.com/spotify/flink-on-k8s-operator/apis/flinkcluster/v1beta1"
 	"gotest.tools/v3/assert"
 	appsv1 "k8s.io/api/apps/v1"
@@ -688,8 +692,9 @@ func TestGetDesiredClusterState(t *testing.T) {
 								{Name: "rpc", ContainerPort: 6122},
 								{Name: "query", ContainerPort: 6125},
 							},
-							LivenessProbe:  &tmLivenessProbe,
-							ReadinessProbe: &tmReadinessProbe,
+							TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+							LivenessProbe:            &tmLivenessProbe,
+							ReadinessProbe:           &tmReadinessProbe,
 							Env: []corev1.EnvVar{
 								{
 									Name:  "FOO",
@@ -986,10 +991,12 @@ func TestGetDesiredClusterState(t *testing.T) {
 
 	// ConfigMap
 	var flinkConfYaml = `blob.server.port: 6124
+jobmanager.execution.failover-strategy: region
 jobmanager.rpc.address: fjc-jobmanager
 jobmanager.rpc.port: 6123
 query.server.port: 6125
 rest.port: 8081
+taskmanager.data.port: 6121
 taskmanager.heap.size: 452m
 taskmanager.numberOfTaskSlots: 1
 taskmanager.rpc.port: 6122
@@ -1089,8 +1096,9 @@ func TestTmDeploymentTypeDeployment(t *testing.T) {
 								{Name: "rpc", ContainerPort: 6122},
 								{Name: "query", ContainerPort: 6125},
 							},
-							LivenessProbe:  &tmLivenessProbe,
-							ReadinessProbe: &tmReadinessProbe,
+							TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+							LivenessProbe:            &tmLivenessProbe,
+							ReadinessProbe:           &tmReadinessProbe,
 							Env: []corev1.EnvVar{
 								{
 									Name:  "FOO",
@@ -1635,3 +1643,805 @@ func TestClassPath(t *testing.T) {
 
 	assert.DeepEqual(t, args, expectedArgs)
 }
+
+func TestFineGrainedResourceProperties(t *testing.T) {
+	var jmRPCPort int32 = 6123
+	var jmBlobPort int32 = 6124
+	var jmQueryPort int32 = 6125
+	var jmUIPort int32 = 8081
+	var tmDataPort int32 = 6121
+	var tmRPCPort int32 = 6122
+	var tmQueryPort int32 = 6125
+	var twoSlots int32 = 2
+	var maxSlots int32 = 12
+	var memoryProcessRatio int32 = 80
+
+	var cluster = &v1beta1.FlinkCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "fgc",
+			Namespace: "default",
+		},
+		Spec: v1beta1.FlinkClusterSpec{
+			FlinkVersion: "1.15",
+			JobManager: &v1beta1.JobManagerSpec{
+				Ports: v1beta1.JobManagerPorts{
+					RPC:   &jmRPCPort,
+					Blob:  &jmBlobPort,
+					Query: &jmQueryPort,
+					UI:    &jmUIPort,
+				},
+				MemoryProcessRatio: &memoryProcessRatio,
+			},
+			TaskManager: &v1beta1.TaskManagerSpec{
+				Ports: v1beta1.TaskManagerPorts{
+					Data:  &tmDataPort,
+					RPC:   &tmRPCPort,
+					Query: &tmQueryPort,
+				},
+				MemoryProcessRatio: &memoryProcessRatio,
+				Resources: corev1.ResourceRequirements{
+					Limits: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("2500m"),
+						corev1.ResourceMemory: resource.MustParse("4Gi"),
+					},
+				},
+				FineGrainedResources: &v1beta1.FineGrainedResourcesSpec{
+					MaxSlots: &maxSlots,
+					SlotProfiles: []v1beta1.SlotResourceProfile{
+						{Name: "source", Slots: &twoSlots, CPU: resource.MustParse("500m"), Memory: resource.MustParse("1Gi")},
+						{Name: "sink", CPU: resource.MustParse("1"), Memory: resource.MustParse("1Gi")},
+					},
+				},
+			},
+		},
+		Status: v1beta1.FlinkClusterStatus{
+			Revision: v1beta1.RevisionStatus{NextRevision: "fgc-85dc8f749-1"},
+		},
+	}
+
+	var flinkConf = newConfigMap(cluster).Data["flink-conf.yaml"]
+	for _, expected := range []string{
+		"cluster.fine-grained-resource-management.enabled: true\n",
+		"slotmanager.number-of-slots.max: 12\n",
+		"taskmanager.cpu.cores: 2.5\n",
+		"taskmanager.numberOfTaskSlots: 3\n",
+	} {
+		assert.Assert(t, strings.Contains(flinkConf, expected), "expected %q in %q", expected, flinkConf)
+	}
+
+	// The job declares its slot sharing groups with the slot profiles.
+	assert.DeepEqual(t, getSlotProfilesEnvVars(cluster), []corev1.EnvVar{{
+		Name: "FLINK_SLOT_PROFILES",
+		Value: `[{"name":"source","slots":2,"cpuCores":0.5,"memoryBytes":1073741824},` +
+			`{"name":"sink","slots":1,"cpuCores":1,"memoryBytes":1073741824}]`,
+	}})
+
+	// Fine-grained resource management is not supported before Flink 1.14.
+	cluster.Spec.FlinkVersion = "1.13"
+	flinkConf = newConfigMap(cluster).Data["flink-conf.yaml"]
+	assert.Assert(t, !strings.Contains(flinkConf, "cluster.fine-grained-resource-management.enabled"))
+}
+
+func TestExternalResourceProperties(t *testing.T) {
+	var driverFactoryClass = "com.example.FPGADriverFactory"
+	var cluster = &v1beta1.FlinkCluster{
+		Spec: v1beta1.FlinkClusterSpec{
+			TaskManager: &v1beta1.TaskManagerSpec{
+				ExternalResources: []v1beta1.ExternalResourceSpec{
+					{
+						Name:         "gpu",
+						ResourceName: "nvidia.com/gpu",
+						Amount:       2,
+						DriverParams: map[string]string{"discovery-script.path": "/opt/flink/plugins/external-resource-gpu/nvidia-gpu-discovery.sh"},
+					},
+					{
+						Name:               "fpga",
+						ResourceName:       "example.com/fpga",
+						Amount:             1,
+						DriverFactoryClass: &driverFactoryClass,
+					},
+				},
+			},
+		},
+	}
+
+	assert.DeepEqual(t, getExternalResourceProperties(cluster), map[string]string{
+		"external-resources":                                "gpu;fpga",
+		"external-resource.gpu.amount":                      "2",
+		"external-resource.gpu.driver-factory.class":        "org.apache.flink.externalresource.gpu.GPUDriverFactory",
+		"external-resource.gpu.param.discovery-script.path": "/opt/flink/plugins/external-resource-gpu/nvidia-gpu-discovery.sh",
+		"external-resource.fpga.amount":                     "1",
+		"external-resource.fpga.driver-factory.class":       "com.example.FPGADriverFactory",
+	})
+
+	cluster.Spec.TaskManager.ExternalResources = nil
+	assert.Assert(t, getExternalResourceProperties(cluster) == nil)
+}
+
+func TestLocalRecovery(t *testing.T) {
+	var sizeLimit = resource.MustParse("20Gi")
+	var cluster = &v1beta1.FlinkCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "flinkjobcluster-sample", Namespace: "default"},
+		Spec: v1beta1.FlinkClusterSpec{
+			FlinkVersion: "1.18",
+			Image:        v1beta1.ImageSpec{Name: "flink:1.18.1"},
+			JobManager:   &v1beta1.JobManagerSpec{},
+			TaskManager: &v1beta1.TaskManagerSpec{
+				VolumeMounts:  []corev1.VolumeMount{{Name: "cache", MountPath: "/cache"}},
+				LocalRecovery: &v1beta1.LocalRecoverySpec{SizeLimit: &sizeLimit},
+			},
+		},
+		Status: v1beta1.FlinkClusterStatus{Revision: v1beta1.RevisionStatus{NextRevision: "flinkjobcluster-sample-85dc8f749-1"}},
+	}
+
+	// The provisioned volume is mounted and configured as the local state root.
+	var podSpec = newTaskManagerStatefulSet(cluster).Spec.Template.Spec
+	assert.Assert(t, slices.ContainsFunc(podSpec.Volumes, func(v corev1.Volume) bool {
+		return v.Name == "local-recovery" && v.EmptyDir != nil && v.EmptyDir.SizeLimit.Equal(sizeLimit)
+	}))
+	assert.DeepEqual(t, podSpec.Containers[0].VolumeMounts[:2], []corev1.VolumeMount{
+		{Name: "cache", MountPath: "/cache"},
+		{Name: "local-recovery", MountPath: "/flink-local-recovery"},
+	})
+	assert.DeepEqual(t, getLocalRecoveryProperties(cluster, version.Must(version.NewVersion("1.18"))), map[string]string{
+		"state.backend.local-recovery":      "true",
+		"taskmanager.state.local.root-dirs": "/flink-local-recovery",
+	})
+	assert.Equal(t, len(cluster.Spec.TaskManager.VolumeMounts), 1)
+
+	// A volume of the TaskManager spec is used at its mount.
+	var volumeName = "cache"
+	cluster.Spec.TaskManager.LocalRecovery = &v1beta1.LocalRecoverySpec{VolumeName: &volumeName}
+	podSpec = newTaskManagerStatefulSet(cluster).Spec.Template.Spec
+	assert.Assert(t, !slices.ContainsFunc(podSpec.Volumes, func(v corev1.Volume) bool { return v.Name == "local-recovery" }))
+	assert.DeepEqual(t, getLocalRecoveryProperties(cluster, version.Must(version.NewVersion("2.0"))), map[string]string{
+		"execution.state-recovery.from-local": "true",
+		"taskmanager.state.local.root-dirs":   "/cache",
+	})
+
+	cluster.Spec.TaskManager.LocalRecovery = nil
+	assert.Assert(t, getLocalRecoveryProperties(cluster, nil) == nil)
+}
+
+func TestJobResultStoreProperties(t *testing.T) {
+	var deleteOnCommit = false
+	var cluster = &v1beta1.FlinkCluster{
+		Spec: v1beta1.FlinkClusterSpec{
+			JobResultStore: &v1beta1.JobResultStoreSpec{StorageDir: " gs://my-bucket/job-results "},
+		},
+	}
+	assert.DeepEqual(t, getJobResultStoreProperties(cluster), map[string]string{
+		"job-result-store.storage-path": "gs://my-bucket/job-results",
+	})
+
+	cluster.Spec.JobResultStore.DeleteOnCommit = &deleteOnCommit
+	assert.DeepEqual(t, getJobResultStoreProperties(cluster), map[string]string{
+		"job-result-store.storage-path":     "gs://my-bucket/job-results",
+		"job-result-store.delete-on-commit": "false",
+	})
+
+	cluster.Spec.JobResultStore = nil
+	assert.Assert(t, getJobResultStoreProperties(cluster) == nil)
+}
+
+func TestAdaptiveSchedulerProperties(t *testing.T) {
+	var minParallelismIncrease int32 = 2
+	var resourceWaitTimeout int32 = 300
+	var resourceStabilizationTimeout int32 = 10
+	var cluster = &v1beta1.FlinkCluster{
+		Spec: v1beta1.FlinkClusterSpec{
+			FlinkProperties: map[string]string{"jobmanager.scheduler": "adaptive"},
+			JobManager: &v1beta1.JobManagerSpec{
+				AdaptiveScheduler: &v1beta1.AdaptiveSchedulerSpec{
+					MinParallelismIncrease:              &minParallelismIncrease,
+					ResourceWaitTimeoutSeconds:          &resourceWaitTimeout,
+					ResourceStabilizationTimeoutSeconds: &resourceStabilizationTimeout,
+				},
+			},
+		},
+	}
+	assert.DeepEqual(t, getAdaptiveSchedulerProperties(cluster), map[string]string{
+		"jobmanager.adaptive-scheduler.min-parallelism-increase":       "2",
+		"jobmanager.adaptive-scheduler.resource-wait-timeout":          "300s",
+		"jobmanager.adaptive-scheduler.resource-stabilization-timeout": "10s",
+	})
+
+	// Unset settings keep the Flink defaults.
+	cluster.Spec.JobManager.AdaptiveScheduler = &v1beta1.AdaptiveSchedulerSpec{MinParallelismIncrease: &minParallelismIncrease}
+	assert.DeepEqual(t, getAdaptiveSchedulerProperties(cluster), map[string]string{
+		"jobmanager.adaptive-scheduler.min-parallelism-increase": "2",
+	})
+
+	cluster.Spec.JobManager.AdaptiveScheduler = nil
+	assert.Assert(t, getAdaptiveSchedulerProperties(cluster) == nil)
+}
+
+func TestFailoverStrategyProperties(t *testing.T) {
+	var full = v1beta1.FailoverStrategyFull
+	tests := []struct {
+		name             string
+		failoverStrategy *v1beta1.FailoverStrategy
+		flinkProperties  map[string]string
+		expected         map[string]string
+	}{
+		{
+			name:     "streaming by default",
+			expected: map[string]string{"jobmanager.execution.failover-strategy": "region"},
+		},
+		{
+			name:            "streaming",
+			flinkProperties: map[string]string{"execution.runtime-mode": "streaming"},
+			expected:        map[string]string{"jobmanager.execution.failover-strategy": "region"},
+		},
+		{
+			name:            "batch",
+			flinkProperties: map[string]string{"execution.runtime-mode": "BATCH"},
+		},
+		{
+			name:            "automatic",
+			flinkProperties: map[string]string{"execution.runtime-mode": "AUTOMATIC"},
+		},
+		{
+			name:             "typed",
+			failoverStrategy: &full,
+			flinkProperties:  map[string]string{"execution.runtime-mode": "BATCH"},
+			expected:         map[string]string{"jobmanager.execution.failover-strategy": "full"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cluster = &v1beta1.FlinkCluster{
+				Spec: v1beta1.FlinkClusterSpec{
+					FlinkProperties: tt.flinkProperties,
+					JobManager:      &v1beta1.JobManagerSpec{FailoverStrategy: tt.failoverStrategy},
+				},
+			}
+			assert.DeepEqual(t, getFailoverStrategyProperties(cluster), tt.expected)
+		})
+	}
+
+	// The default of the streaming jobs is overridden by flinkProperties.
+	var rpcPort, blobPort, queryPort, uiPort, dataPort int32 = 6123, 6124, 6125, 8081, 6121
+	var memoryProcessRatio int32 = 80
+	var cluster = &v1beta1.FlinkCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "fsc", Namespace: "default"},
+		Spec: v1beta1.FlinkClusterSpec{
+			FlinkVersion:    "1.15",
+			FlinkProperties: map[string]string{"jobmanager.execution.failover-strategy": "full"},
+			JobManager: &v1beta1.JobManagerSpec{
+				Ports:              v1beta1.JobManagerPorts{RPC: &rpcPort, Blob: &blobPort, Query: &queryPort, UI: &uiPort},
+				MemoryProcessRatio: &memoryProcessRatio,
+			},
+			TaskManager: &v1beta1.TaskManagerSpec{
+				Ports:              v1beta1.TaskManagerPorts{RPC: &rpcPort, Data: &dataPort, Query: &queryPort},
+				MemoryProcessRatio: &memoryProcessRatio,
+			},
+		},
+		Status: v1beta1.FlinkClusterStatus{
+			Revision: v1beta1.RevisionStatus{NextRevision: "fsc-85dc8f749-1"},
+		},
+	}
+	var flinkConf = newConfigMap(cluster).Data["flink-conf.yaml"]
+	assert.Assert(t, strings.Contains(flinkConf, "jobmanager.execution.failover-strategy: full\n"), flinkConf)
+}
+
+func TestExecutionModeProperties(t *testing.T) {
+	var batch = v1beta1.JobExecutionModeBatch
+	var cluster = &v1beta1.FlinkCluster{
+		Spec: v1beta1.FlinkClusterSpec{
+			Job: &v1beta1.JobSpec{ExecutionMode: &batch},
+		},
+	}
+	assert.DeepEqual(t, getExecutionModeProperties(cluster),
+		map[string]string{"execution.runtime-mode": "BATCH"})
+	// The batch jobs do not fail over by region by default.
+	assert.Assert(t, getFailoverStrategyProperties(cluster) == nil)
+
+	cluster.Spec.Job.ExecutionMode = nil
+	assert.Assert(t, getExecutionModeProperties(cluster) == nil)
+}
+
+func TestOperatorUIDProperties(t *testing.T) {
+	var required = v1beta1.OperatorUIDPolicyRequired
+	var cluster = &v1beta1.FlinkCluster{
+		Spec: v1beta1.FlinkClusterSpec{
+			Job: &v1beta1.JobSpec{OperatorUIDPolicy: &required},
+		},
+	}
+	assert.DeepEqual(t, getOperatorUIDProperties(cluster),
+		map[string]string{"pipeline.auto-generate-uids": "false"})
+
+	var generated = v1beta1.OperatorUIDPolicyGenerated
+	cluster.Spec.Job.OperatorUIDPolicy = &generated
+	assert.DeepEqual(t, getOperatorUIDProperties(cluster),
+		map[string]string{"pipeline.auto-generate-uids": "true"})
+
+	cluster.Spec.Job.OperatorUIDPolicy = nil
+	assert.Assert(t, getOperatorUIDProperties(cluster) == nil)
+}
+
+func TestShouldCleanupUnexpectedCompletion(t *testing.T) {
+	var streaming = v1beta1.JobExecutionModeStreaming
+	var cluster = &v1beta1.FlinkCluster{
+		Spec: v1beta1.FlinkClusterSpec{
+			Job: &v1beta1.JobSpec{
+				CleanupPolicy: &v1beta1.CleanupPolicy{
+					AfterJobSucceeds: v1beta1.CleanupActionDeleteCluster,
+					AfterJobFails:    v1beta1.CleanupActionKeepCluster,
+				},
+			},
+		},
+		Status: v1beta1.FlinkClusterStatus{
+			Components: v1beta1.FlinkClusterComponentsStatus{
+				Job: &v1beta1.JobStatus{State: v1beta1.JobStateSucceeded},
+			},
+		},
+	}
+	// The completed batch job is a success.
+	assert.Equal(t, shouldCleanup(cluster, "JobManager"), true)
+
+	// The completed streaming job is kept like a failed job.
+	cluster.Spec.Job.ExecutionMode = &streaming
+	assert.Equal(t, shouldCleanup(cluster, "JobManager"), false)
+}
+
+func TestClassloaderResolveOrderProperties(t *testing.T) {
+	var parentFirst = v1beta1.ClassloaderResolveOrderParentFirst
+	var cluster = &v1beta1.FlinkCluster{
+		Spec: v1beta1.FlinkClusterSpec{
+			Job: &v1beta1.JobSpec{ClassloaderResolveOrder: &parentFirst},
+		},
+	}
+	assert.DeepEqual(t, getClassloaderResolveOrderProperties(cluster),
+		map[string]string{"classloader.resolve-order": "parent-first"})
+
+	cluster.Spec.Job.ClassloaderResolveOrder = nil
+	assert.Assert(t, getClassloaderResolveOrderProperties(cluster) == nil)
+	cluster.Spec.Job = nil
+	assert.Assert(t, getClassloaderResolveOrderProperties(cluster) == nil)
+}
+
+func TestCheckpointTuningProperties(t *testing.T) {
+	var timeout, concurrency int32 = 120, 2
+	var cluster = &v1beta1.FlinkCluster{
+		Spec: v1beta1.FlinkClusterSpec{
+			Job: &v1beta1.JobSpec{CheckpointTimeoutSeconds: &timeout, MaxConcurrentCheckpoints: &concurrency},
+		},
+	}
+	assert.DeepEqual(t, getCheckpointTuningProperties(cluster), map[string]string{
+		"execution.checkpointing.timeout":                    "120s",
+		"execution.checkpointing.max-concurrent-checkpoints": "2",
+	})
+	assert.Equal(t, cluster.CheckpointTimeout(), 2*time.Minute)
+
+	cluster.Spec.Job = &v1beta1.JobSpec{}
+	assert.DeepEqual(t, getCheckpointTuningProperties(cluster), map[string]string{})
+	assert.Equal(t, cluster.CheckpointTimeout(), v1beta1.DefaultCheckpointTimeout)
+}
+
+func TestRestartStrategyProperties(t *testing.T) {
+	var delay int32 = 30
+	var cluster = &v1beta1.FlinkCluster{
+		Spec: v1beta1.FlinkClusterSpec{
+			Job: &v1beta1.JobSpec{
+				RestartEscalation: &v1beta1.RestartEscalationSpec{FlinkRestartAttempts: 3, FlinkRestartDelaySeconds: &delay},
+			},
+		},
+	}
+	var v116, _ = version.NewVersion("1.16")
+	assert.DeepEqual(t, getRestartStrategyProperties(cluster, v116), map[string]string{
+		"restart-strategy":                      "fixed-delay",
+		"restart-strategy.fixed-delay.attempts": "3",
+		"restart-strategy.fixed-delay.delay":    "30s",
+	})
+
+	cluster.Spec.Job.RestartEscalation.FlinkRestartDelaySeconds = nil
+	assert.DeepEqual(t, getRestartStrategyProperties(cluster, v117), map[string]string{
+		"restart-strategy.type":                 "fixed-delay",
+		"restart-strategy.fixed-delay.attempts": "3",
+	})
+
+	cluster.Spec.Job.RestartEscalation = nil
+	assert.Assert(t, getRestartStrategyProperties(cluster, v117) == nil)
+}
+
+func TestRESTJarUploadSubmitter(t *testing.T) {
+	var enabled = true
+	var allowNonRestoredState = true
+	var jarFile = "/opt/flink/job.jar"
+	var className = "org.example.Job"
+	var parallelism int32 = 4
+	var uiPort int32 = 8081
+	var cluster = &v1beta1.FlinkCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "fjc"},
+		Spec: v1beta1.FlinkClusterSpec{
+			Image:      v1beta1.ImageSpec{Name: "flink:1.17"},
+			JobManager: &v1beta1.JobManagerSpec{Ports: v1beta1.JobManagerPorts{UI: &uiPort}},
+			Job: &v1beta1.JobSpec{
+				JarFile:               &jarFile,
+				ClassName:             &className,
+				Parallelism:           &parallelism,
+				Args:                  []string{"--input", "./README.txt"},
+				AllowNonRestoredState: &allowNonRestoredState,
+				RESTJarUpload:         &enabled,
+			},
+		},
+	}
+	var envValue = func(podSpec *corev1.PodSpec, name string) (string, bool) {
+		for _, env := range podSpec.Containers[0].Env {
+			if env.Name == name {
+				return env.Value, true
+			}
+		}
+		return "", false
+	}
+
+	// Cache miss, nothing uploaded yet.
+	var podSpec = newJobSubmitterPodSpec(cluster, time.Now())
+	assert.DeepEqual(t, podSpec.Containers[0].Args, []string{"bash", "/opt/flink-operator/submit-job.sh", jarFile})
+	var request, _ = envValue(podSpec, "FLINK_JAR_RUN_REQUEST")
+	assert.Equal(t, request, `{"entryClass":"org.example.Job","programArgsList":["--input","./README.txt"],`+
+		`"parallelism":4,"allowNonRestoredState":true}`)
+	var _, cached = envValue(podSpec, "FLINK_CACHED_JAR_ID")
+	assert.Equal(t, cached, false)
+
+	// Cache hit, the submitter runs the recorded jar if its hash is the same.
+	cluster.Status.Components.Job = &v1beta1.JobStatus{
+		UploadedJar: &v1beta1.UploadedJarStatus{ID: "6077eca7_job.jar", Hash: "a9c2f3d7"},
+	}
+	podSpec = newJobSubmitterPodSpec(cluster, time.Now())
+	var jarID, _ = envValue(podSpec, "FLINK_CACHED_JAR_ID")
+	var jarHash, _ = envValue(podSpec, "FLINK_CACHED_JAR_HASH")
+	assert.Equal(t, jarID, "6077eca7_job.jar")
+	assert.Equal(t, jarHash, "a9c2f3d7")
+}
+
+func TestWatermarkAlignmentProperties(t *testing.T) {
+	var updateInterval int32 = 2
+	var allowUnaligned = true
+	var cluster = &v1beta1.FlinkCluster{
+		Spec: v1beta1.FlinkClusterSpec{
+			Job: &v1beta1.JobSpec{
+				WatermarkAlignment: &v1beta1.WatermarkAlignmentSpec{
+					Group: "sources", MaxDriftSeconds: 20, UpdateIntervalSeconds: &updateInterval,
+				},
+			},
+		},
+	}
+	// The group, the max drift and the update interval are not Flink options.
+	assert.Assert(t, getWatermarkAlignmentProperties(cluster) == nil)
+
+	cluster.Spec.Job.WatermarkAlignment.AllowUnalignedSourceSplits = &allowUnaligned
+	assert.DeepEqual(t, getWatermarkAlignmentProperties(cluster), map[string]string{
+		"pipeline.watermark-alignment.allow-unaligned-source-splits": "true",
+	})
+
+	cluster.Spec.Job.WatermarkAlignment = nil
+	assert.Assert(t, getWatermarkAlignmentProperties(cluster) == nil)
+}
+
+func TestWatermarkAlignmentEnvVars(t *testing.T) {
+	var jarFile = "/opt/flink/job.jar"
+	var className = "org.example.Job"
+	var updateInterval int32 = 2
+	var parallelism int32 = 4
+	var uiPort int32 = 8081
+	var cluster = &v1beta1.FlinkCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "fjc"},
+		Spec: v1beta1.FlinkClusterSpec{
+			Image:      v1beta1.ImageSpec{Name: "flink:1.17"},
+			JobManager: &v1beta1.JobManagerSpec{Ports: v1beta1.JobManagerPorts{UI: &uiPort}},
+			Job: &v1beta1.JobSpec{
+				JarFile:     &jarFile,
+				ClassName:   &className,
+				Parallelism: &parallelism,
+				WatermarkAlignment: &v1beta1.WatermarkAlignmentSpec{
+					Group: "sources", MaxDriftSeconds: 20, UpdateIntervalSeconds: &updateInterval,
+				},
+			},
+			EnvVars: []corev1.EnvVar{{Name: "FOO", Value: "bar"}},
+		},
+	}
+	var expectedEnvVars = []corev1.EnvVar{
+		{Name: "FLINK_WATERMARK_ALIGNMENT_GROUP", Value: "sources"},
+		{Name: "FLINK_WATERMARK_ALIGNMENT_MAX_DRIFT_SECONDS", Value: "20"},
+		{Name: "FLINK_WATERMARK_ALIGNMENT_UPDATE_INTERVAL_SECONDS", Value: "2"},
+	}
+	assert.DeepEqual(t, getWatermarkAlignmentEnvVars(cluster.Spec.Job), expectedEnvVars)
+
+	// The job submitter runs the main method of the job.
+	var podSpec = newJobSubmitterPodSpec(cluster, time.Now())
+	assert.DeepEqual(t, podSpec.Containers[0].Env, append(append(
+		[]corev1.EnvVar{{Name: "FLINK_JM_ADDR", Value: "fjc-jobmanager:8081"}},
+		expectedEnvVars...), corev1.EnvVar{Name: "FOO", Value: "bar"}))
+
+	// The JobManager runs the main method of the job in application mode.
+	var applicationMode = v1beta1.JobModeApplication
+	cluster.Spec.Job.Mode = &applicationMode
+	assert.DeepEqual(t, newJobManagerContainer(cluster, time.Now()).Env,
+		append(expectedEnvVars, corev1.EnvVar{Name: "FOO", Value: "bar"}))
+	assert.DeepEqual(t, cluster.Spec.EnvVars, []corev1.EnvVar{{Name: "FOO", Value: "bar"}})
+
+	// The update interval is optional.
+	cluster.Spec.Job.WatermarkAlignment.UpdateIntervalSeconds = nil
+	assert.DeepEqual(t, getWatermarkAlignmentEnvVars(cluster.Spec.Job), expectedEnvVars[:2])
+
+	cluster.Spec.Job.WatermarkAlignment = nil
+	assert.Assert(t, getWatermarkAlignmentEnvVars(cluster.Spec.Job) == nil)
+}
+
+func TestRocksDBProperties(t *testing.T) {
+	var disabled = false
+	var writeBufferRatio int32 = 40
+	var highPriorityPoolRatio int32 = 5
+	var fixedMemory = resource.MustParse("512Mi")
+	var blockCache = resource.MustParse("256Mi")
+	var writeBuffer = resource.MustParse("64Mi")
+	var cluster = &v1beta1.FlinkCluster{
+		Spec: v1beta1.FlinkClusterSpec{
+			FlinkProperties: map[string]string{"state.backend.type": "rocksdb"},
+			TaskManager: &v1beta1.TaskManagerSpec{
+				RocksDB: &v1beta1.RocksDBOptions{
+					FixedMemoryPerSlot:    &fixedMemory,
+					WriteBufferRatio:      &writeBufferRatio,
+					HighPriorityPoolRatio: &highPriorityPoolRatio,
+				},
+			},
+		},
+	}
+	assert.DeepEqual(t, getRocksDBProperties(cluster), map[string]string{
+		"state.backend.rocksdb.memory.fixed-per-slot":       "536870912b",
+		"state.backend.rocksdb.memory.write-buffer-ratio":   "0.40",
+		"state.backend.rocksdb.memory.high-prio-pool-ratio": "0.05",
+	})
+
+	cluster.Spec.TaskManager.RocksDB = &v1beta1.RocksDBOptions{
+		ManagedMemory:   &disabled,
+		BlockCacheSize:  &blockCache,
+		WriteBufferSize: &writeBuffer,
+	}
+	assert.DeepEqual(t, getRocksDBProperties(cluster), map[string]string{
+		"state.backend.rocksdb.memory.managed":   "false",
+		"state.backend.rocksdb.block.cache-size": "268435456b",
+		"state.backend.rocksdb.writebuffer.size": "67108864b",
+	})
+
+	cluster.Spec.TaskManager.RocksDB = nil
+	assert.Assert(t, getRocksDBProperties(cluster) == nil)
+}
+
+func TestTaskManagerPreStop(t *testing.T) {
+	var dataPort int32 = 6121
+	var rpcPort int32 = 6122
+	var queryPort int32 = 6125
+	var cluster = &v1beta1.FlinkCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "mycluster", Namespace: "default"},
+		Spec: v1beta1.FlinkClusterSpec{
+			TaskManager: &v1beta1.TaskManagerSpec{
+				Ports: v1beta1.TaskManagerPorts{Data: &dataPort, RPC: &rpcPort, Query: &queryPort},
+			},
+		},
+	}
+	var getPodSpec = func() *corev1.PodSpec {
+		return newTaskManagerPodSpec(newTaskManagerContainer(cluster), cluster)
+	}
+
+	// Default hook.
+	var podSpec = getPodSpec()
+	assert.DeepEqual(t, podSpec.Containers[0].Lifecycle.PreStop, &corev1.LifecycleHandler{
+		Exec: &corev1.ExecAction{Command: []string{"sleep", strconv.Itoa(preStopSleepSeconds)}},
+	})
+	seconds, _ := v1beta1.GetPreStopSeconds(podSpec.Containers[0].Lifecycle.PreStop)
+	assert.Assert(t, seconds < *podSpec.TerminationGracePeriodSeconds)
+
+	// Custom hook.
+	var preStop = &corev1.LifecycleHandler{Sleep: &corev1.SleepAction{Seconds: 45}}
+	cluster.Spec.TaskManager.PreStop = preStop
+	podSpec = getPodSpec()
+	assert.DeepEqual(t, podSpec.Containers[0].Lifecycle.PreStop, preStop)
+	seconds, _ = v1beta1.GetPreStopSeconds(podSpec.Containers[0].Lifecycle.PreStop)
+	assert.Assert(t, seconds <= *podSpec.TerminationGracePeriodSeconds)
+}
+
+func TestTaskManagerSchedulingGate(t *testing.T) {
+	var waitForJobManager = true
+	var cluster = &v1beta1.FlinkCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "mycluster", Namespace: "default"},
+		Spec: v1beta1.FlinkClusterSpec{
+			TaskManager: &v1beta1.TaskManagerSpec{},
+		},
+	}
+	var podSpec = newTaskManagerPodSpec(newTaskManagerContainer(cluster), cluster)
+	assert.Assert(t, podSpec.SchedulingGates == nil)
+
+	cluster.Spec.TaskManager.WaitForJobManager = &waitForJobManager
+	podSpec = newTaskManagerPodSpec(newTaskManagerContainer(cluster), cluster)
+	assert.DeepEqual(t, podSpec.SchedulingGates, []corev1.PodSchedulingGate{{Name: v1beta1.JobManagerReadySchedulingGate}})
+}
+
+func TestTaskManagerArchitectureAffinity(t *testing.T) {
+	var arm64 = v1beta1.ImageArchitectureARM64
+	var multiArch = v1beta1.ImageArchitectureMultiArch
+	var archRequirement = corev1.NodeSelectorRequirement{
+		Key:      "kubernetes.io/arch",
+		Operator: corev1.NodeSelectorOpIn,
+		Values:   []string{"arm64"},
+	}
+	var poolRequirement = corev1.NodeSelectorRequirement{
+		Key:      "cloud.google.com/gke-nodepool",
+		Operator: corev1.NodeSelectorOpIn,
+		Values:   []string{"flink"},
+	}
+	var zoneRequirement = corev1.NodeSelectorRequirement{
+		Key:      "topology.kubernetes.io/zone",
+		Operator: corev1.NodeSelectorOpIn,
+		Values:   []string{"europe-west1-b"},
+	}
+	var podAntiAffinity = &corev1.PodAntiAffinity{
+		PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{{
+			Weight:          100,
+			PodAffinityTerm: corev1.PodAffinityTerm{TopologyKey: "kubernetes.io/hostname"},
+		}},
+	}
+
+	for _, test := range []struct {
+		name             string
+		architecture     *v1beta1.ImageArchitecture
+		affinity         *corev1.Affinity
+		expectedAffinity *corev1.Affinity
+	}{
+		{
+			name: "not declared",
+		},
+		{
+			name:         "multi-arch",
+			architecture: &multiArch,
+			affinity:     &corev1.Affinity{PodAntiAffinity: podAntiAffinity},
+			expectedAffinity: &corev1.Affinity{
+				PodAntiAffinity: podAntiAffinity,
+			},
+		},
+		{
+			name:         "without affinity",
+			architecture: &arm64,
+			expectedAffinity: &corev1.Affinity{NodeAffinity: &corev1.NodeAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+					NodeSelectorTerms: []corev1.NodeSelectorTerm{{MatchExpressions: []corev1.NodeSelectorRequirement{archRequirement}}},
+				},
+			}},
+		},
+		{
+			name:         "with node affinity terms",
+			architecture: &arm64,
+			affinity: &corev1.Affinity{
+				PodAntiAffinity: podAntiAffinity,
+				NodeAffinity: &corev1.NodeAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+						NodeSelectorTerms: []corev1.NodeSelectorTerm{
+							{MatchExpressions: []corev1.NodeSelectorRequirement{poolRequirement}},
+							{MatchExpressions: []corev1.NodeSelectorRequirement{zoneRequirement}},
+						},
+					},
+				},
+			},
+			expectedAffinity: &corev1.Affinity{
+				PodAntiAffinity: podAntiAffinity,
+				NodeAffinity: &corev1.NodeAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+						NodeSelectorTerms: []corev1.NodeSelectorTerm{
+							{MatchExpressions: []corev1.NodeSelectorRequirement{poolRequirement, archRequirement}},
+							{MatchExpressions: []corev1.NodeSelectorRequirement{zoneRequirement, archRequirement}},
+						},
+					},
+				},
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			var cluster = &v1beta1.FlinkCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "mycluster", Namespace: "default"},
+				Spec: v1beta1.FlinkClusterSpec{
+					Image:       v1beta1.ImageSpec{Name: "flink:1.18", Architecture: test.architecture},
+					TaskManager: &v1beta1.TaskManagerSpec{Affinity: test.affinity.DeepCopy()},
+				},
+			}
+			var podSpec = newTaskManagerPodSpec(newTaskManagerContainer(cluster), cluster)
+
+			assert.DeepEqual(t, podSpec.Affinity, test.expectedAffinity)
+			// The spec is not modified.
+			assert.DeepEqual(t, cluster.Spec.TaskManager.Affinity, test.affinity)
+		})
+	}
+}
+
+func TestNetworkPortsFromFlinkProperties(t *testing.T) {
+	var jmRPCPort int32 = 6123
+	var jmBlobPort int32 = 6124
+	var jmQueryPort int32 = 6125
+	var jmUIPort int32 = 8081
+	var tmDataPort int32 = 6121
+	var tmRPCPort int32 = 6122
+	var tmQueryPort int32 = 6125
+	var memoryProcessRatio int32 = 80
+
+	var cluster = &v1beta1.FlinkCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "fpc",
+			Namespace: "default",
+		},
+		Spec: v1beta1.FlinkClusterSpec{
+			FlinkVersion: "1.15",
+			FlinkProperties: map[string]string{
+				"jobmanager.rpc.port":   "7123",
+				"blob.server.port":      "7124",
+				"query.server.port":     "7125",
+				"rest.port":             "9081",
+				"taskmanager.rpc.port":  "7122",
+				"taskmanager.data.port": "7121",
+			},
+			JobManager: &v1beta1.JobManagerSpec{
+				AccessScope: v1beta1.AccessScopeCluster,
+				Ports: v1beta1.JobManagerPorts{
+					RPC:   &jmRPCPort,
+					Blob:  &jmBlobPort,
+					Query: &jmQueryPort,
+					UI:    &jmUIPort,
+				},
+				MemoryProcessRatio: &memoryProcessRatio,
+			},
+			TaskManager: &v1beta1.TaskManagerSpec{
+				Ports: v1beta1.TaskManagerPorts{
+					Data:  &tmDataPort,
+					RPC:   &tmRPCPort,
+					Query: &tmQueryPort,
+				},
+				MemoryProcessRatio: &memoryProcessRatio,
+			},
+		},
+		Status: v1beta1.FlinkClusterStatus{
+			Revision: v1beta1.RevisionStatus{NextRevision: "fpc-85dc8f749-1"},
+		},
+	}
+
+	// Container ports
+	var containerPorts = func(container *corev1.Container) map[string]int32 {
+		var ports = map[string]int32{}
+		for _, port := range container.Ports {
+			ports[port.Name] = port.ContainerPort
+		}
+		return ports
+	}
+	assert.DeepEqual(t, containerPorts(newJobManagerContainer(cluster, time.Now())),
+		map[string]int32{"rpc": 7123, "blob": 7124, "query": 7125, "ui": 9081})
+	assert.DeepEqual(t, containerPorts(newTaskManagerContainer(cluster)),
+		map[string]int32{"rpc": 7122, "data": 7121, "query": 7125})
+
+	// Service ports
+	var servicePorts = func(service *corev1.Service) map[string]int32 {
+		var ports = map[string]int32{}
+		for _, port := range service.Spec.Ports {
+			ports[port.Name] = port.Port
+		}
+		return ports
+	}
+	assert.DeepEqual(t, servicePorts(newJobManagerService(cluster)),
+		map[string]int32{"rpc": 7123, "blob": 7124, "query": 7125, "ui": 9081})
+	assert.DeepEqual(t, servicePorts(newTaskManagerService(cluster)),
+		map[string]int32{"rpc": 7122, "data": 7121, "query": 7125})
+
+	// Flink config
+	var flinkConf = newConfigMap(cluster).Data["flink-conf.yaml"]
+	for _, expected := range []string{
+		"jobmanager.rpc.port: 7123\n",
+		"blob.server.port: 7124\n",
+		"query.server.port: 7125\n",
+		"rest.port: 9081\n",
+		"taskmanager.rpc.port: 7122\n",
+		"taskmanager.data.port: 7121\n",
+	} {
+		assert.Assert(t, strings.Contains(flinkConf, expected), "expected %q in %q", expected, flinkConf)
+	}
+
+	// REST API
+	assert.Equal(t, getFlinkAPIBaseURL(cluster), "http://fpc-jobmanager.default.svc.cluster.local:9081")
+}
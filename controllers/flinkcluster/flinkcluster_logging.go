@@ -70,6 +70,11 @@ func logObservedClusterStateSummary(observed *ObservedClusterState) map[string]a
 	} else {
 		summary["persistentVolumeClaimCount"] = logNilValue
 	}
+	if observed.pods != nil {
+		summary["podCount"] = len(observed.pods.Items)
+	} else {
+		summary["podCount"] = logNilValue
+	}
 	return summary
 }
 
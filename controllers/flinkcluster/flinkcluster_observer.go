@@ -18,6 +18,9 @@ package flinkcluster
 
 import (
 	"context"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -68,9 +71,21 @@ type ObservedClusterState struct {
 	podDisruptionBudget     *policyv1.PodDisruptionBudget
 	horizontalPodAutoscaler *autoscalingv2.HorizontalPodAutoscaler
 	persistentVolumeClaims  *corev1.PersistentVolumeClaimList
+	pods                    *corev1.PodList
+	nodeArchitectures       []string
 	flinkJob                FlinkJob
+	flinkConfig             map[string]string
+	checkpointAlignment     *v1beta1.CheckpointAlignmentSample
+	retainedCheckpoint      *v1beta1.RetainedCheckpoint
+	flinkInternalRestarts   *int32
+	uploadedJars            *flink.JarsList
+	slotRegistration        *v1beta1.SlotRegistrationStatus
+	staleSavepoint          bool
+	savepointAvailable      bool
+	observabilityPollDue    bool
 	flinkJobSubmitter       FlinkJobSubmitter
 	savepoint               Savepoint
+	coordinatedSavepoints   map[string]Savepoint
 	revision                Revision
 	observeTime             time.Time
 	updateState             UpdateState
@@ -81,6 +96,11 @@ type FlinkJob struct {
 	list       *flink.JobsOverview
 	exceptions *flink.JobExceptions
 	unexpected []string
+	// The plan of the job, only observed once per job ID.
+	plan *flink.JobPlan
+	// The checkpointing statistics of the running job, only observed when the
+	// observability poll is due.
+	checkpointingStats *flink.CheckpointingStatistics
 }
 
 type FlinkJobSubmitter struct {
@@ -92,11 +112,15 @@ type FlinkJobSubmitter struct {
 type SubmitterLog struct {
 	jobID   string
 	message string
+	// The JAR the submitter ran the job from with the REST JAR upload.
+	jar *v1beta1.UploadedJarStatus
 }
 
 type Savepoint struct {
 	status *flink.SavepointStatus
 	error  error
+	// The size of the successful savepoint, nil if not reported by Flink.
+	size *int64
 }
 
 type Revision struct {
@@ -141,6 +165,11 @@ func (observer *ClusterStateObserver) observe(ctx context.Context, observed *Obs
 		observed.cluster = nil
 	}
 
+	// Leave the components and the Flink jobs alone while paused.
+	if observed.cluster != nil && observed.cluster.ReconcilePaused() {
+		return nil
+	}
+
 	if observed.cluster != nil {
 		// Revisions.
 		if err := observer.observeRevisions(observed); err != nil {
@@ -209,16 +238,59 @@ func (observer *ClusterStateObserver) observe(ctx context.Context, observed *Obs
 			log.Error(err, "Failed to get Flink job savepoint status")
 		}
 
+		// (Optional) Coordinated savepoint of session jobs.
+		observer.observeCoordinatedSavepoints(ctx, observed)
+
 		if err := observer.observePersistentVolumeClaims(ctx, observed); err != nil {
 			log.Error(err, "Failed to get persistent volume claim list")
 			return err
 		}
 
+		if err := observer.observePods(ctx, observed); err != nil {
+			log.Error(err, "Failed to get pod list")
+			return err
+		}
+
+		// (Optional) Architectures of the nodes, when the image architecture is declared.
+		observer.observeNodeArchitectures(ctx, observed)
+
+		observed.observabilityPollDue = isObservabilityPollDue(observed.cluster, time.Now())
+
 		// (Optional) job.
 		if err := observer.observeJob(ctx, observed); err != nil {
 			log.Error(err, "Failed to get Flink job status")
 			return err
 		}
+
+		// (Optional) Flink config of the running JobManager.
+		observer.observeFlinkConfig(ctx, observed)
+
+		// (Optional) Checkpointing statistics of the running job.
+		observer.observeCheckpointingStatistics(ctx, observed)
+
+		// (Optional) Checkpoint alignment of the running job.
+		observer.observeCheckpointAlignment(ctx, observed)
+
+		// (Optional) Latest checkpoint of the running job retained in the checkpoint storage.
+		observed.retainedCheckpoint = getRetainedCheckpoint(observed.flinkJob.checkpointingStats)
+
+		// (Optional) Restarts of the job tasks within Flink.
+		observer.observeFlinkInternalRestarts(ctx, observed)
+
+		// (Optional) JARs uploaded to the JobManager by the job submitter.
+		observer.observeUploadedJars(ctx, observed)
+
+		// (Optional) Slot registration of the TaskManagers.
+		observer.observeSlotRegistration(ctx, observed)
+
+		// (Optional) Whether the recorded savepoint belongs to the current run of the job.
+		observer.observeSavepointBaseline(observed)
+
+		// (Optional) Savepoint the job submission waits for.
+		if err := observer.observeAwaitedSavepoint(ctx, observed); err != nil {
+			log.Error(err, "Failed to get the savepoint the job waits for")
+			return err
+		}
 	}
 
 	observed.observeTime = time.Now()
@@ -378,6 +450,9 @@ func (observer *ClusterStateObserver) observeFlinkJobStatus(ctx context.Context,
 
 	if flinkJobID == "" {
 		log.Info("No flinkJobID given. Skipping get exceptions")
+	} else if !observed.observabilityPollDue && flinkJobStatus != nil && flinkJobStatus.State == "RUNNING" {
+		// The exceptions of a running job are only sampled.
+		log.Info("Observability poll is not due. Skipping get exceptions")
 	} else {
 		flinkJobExceptions, err := observer.flinkClient.GetJobExceptions(flinkAPIBaseURL, flinkJobID)
 		if err != nil {
@@ -389,6 +464,238 @@ func (observer *ClusterStateObserver) observeFlinkJobStatus(ctx context.Context,
 		}
 	}
 
+	// The job graph does not change while the job runs, so its plan is observed once.
+	var recordedUIDs *v1beta1.OperatorUIDsStatus
+	if recordedJob := observed.cluster.Status.Components.Job; recordedJob != nil {
+		recordedUIDs = recordedJob.OperatorUIDs
+	}
+	if flinkJobStatus != nil && (recordedUIDs == nil || recordedUIDs.JobID != flinkJobID) {
+		flinkJobPlan, err := observer.flinkClient.GetJobPlan(flinkAPIBaseURL, flinkJobID)
+		if err != nil {
+			// It is normal in many cases, not an error.
+			log.Info("Failed to get Flink job plan.", "error", err)
+		} else {
+			flinkJob.plan = flinkJobPlan
+		}
+	}
+}
+
+// Observes the effective Flink config of the running JobManager through Flink API.
+func (observer *ClusterStateObserver) observeFlinkConfig(ctx context.Context, observed *ObservedClusterState) {
+	var log = logr.FromContextOrDiscard(ctx)
+	var jmReady bool
+	if IsApplicationModeCluster(observed.cluster) {
+		// The Flink API answered the job observation.
+		jmReady = observed.flinkJob.list != nil
+	} else {
+		jmReady = observed.jmStatefulSet != nil &&
+			getStatefulSetState(observed.jmStatefulSet) == v1beta1.ComponentStateReady
+	}
+	if !jmReady || !observed.observabilityPollDue {
+		return
+	}
+
+	flinkConfig, err := observer.flinkClient.GetJobManagerConfig(getFlinkAPIBaseURL(observed.cluster))
+	if err != nil {
+		// It is normal in many cases, not an error.
+		log.Info("Failed to get Flink config.", "error", err)
+		return
+	}
+	observed.flinkConfig = flinkConfig
+}
+
+// Observes the checkpointing statistics of the running job through Flink API, once per
+// observability poll for the observers which inspect them.
+func (observer *ClusterStateObserver) observeCheckpointingStatistics(ctx context.Context, observed *ObservedClusterState) {
+	var log = logr.FromContextOrDiscard(ctx)
+	var flinkJobStatus = observed.flinkJob.status
+	if !observed.observabilityPollDue || flinkJobStatus == nil || flinkJobStatus.State != "RUNNING" {
+		return
+	}
+
+	stats, err := observer.flinkClient.GetCheckpointingStatistics(getFlinkAPIBaseURL(observed.cluster), flinkJobStatus.Id)
+	if err != nil {
+		// It is normal in many cases, not an error.
+		log.Info("Failed to get Flink checkpointing statistics.", "error", err)
+		return
+	}
+	observed.flinkJob.checkpointingStats = stats
+}
+
+// Observes the alignment of the latest completed checkpoint of the running job through
+// Flink API. A checkpoint is only sampled once.
+func (observer *ClusterStateObserver) observeCheckpointAlignment(ctx context.Context, observed *ObservedClusterState) {
+	var log = logr.FromContextOrDiscard(ctx)
+	var stats = observed.flinkJob.checkpointingStats
+	if stats == nil || !observed.cluster.Status.Components.Job.IsActive() {
+		return
+	}
+
+	var flinkAPIBaseURL = getFlinkAPIBaseURL(observed.cluster)
+	var jobID = observed.flinkJob.status.Id
+	var checkpoint = getLatestCompletedCheckpoint(stats)
+	if checkpoint == nil {
+		return
+	}
+	if recorded := observed.cluster.Status.CheckpointAlignment; recorded != nil && len(recorded.Samples) > 0 &&
+		recorded.Samples[len(recorded.Samples)-1].CheckpointID == checkpoint.ID {
+		return
+	}
+
+	details, err := observer.flinkClient.GetCheckpointDetails(flinkAPIBaseURL, jobID, checkpoint.ID)
+	if err != nil {
+		log.Info("Failed to get Flink checkpoint details.", "error", err)
+		return
+	}
+	var sample = &v1beta1.CheckpointAlignmentSample{
+		CheckpointID: checkpoint.ID,
+		Unaligned:    checkpoint.CheckpointType == "UNALIGNED_CHECKPOINT",
+	}
+	for vertexID := range details.Tasks {
+		task, err := observer.flinkClient.GetTaskCheckpointDetails(flinkAPIBaseURL, jobID, checkpoint.ID, vertexID)
+		if err != nil {
+			log.Info("Failed to get Flink task checkpoint details.", "error", err)
+			return
+		}
+		sample.AlignmentDurationMillis = max(sample.AlignmentDurationMillis, task.Summary.Alignment.Duration.Max)
+	}
+	util.SetTimestamp(&sample.Time)
+	observed.checkpointAlignment = sample
+}
+
+// Observes the numRestarts metric of the active job, the restarts of its tasks by Flink
+// within the job run.
+func (observer *ClusterStateObserver) observeFlinkInternalRestarts(ctx context.Context, observed *ObservedClusterState) {
+	var log = logr.FromContextOrDiscard(ctx)
+	var flinkJobStatus = observed.flinkJob.status
+	if !observed.observabilityPollDue || flinkJobStatus == nil ||
+		!observed.cluster.Status.Components.Job.IsActive() {
+		return
+	}
+
+	metrics, err := observer.flinkClient.GetJobMetrics(getFlinkAPIBaseURL(observed.cluster), flinkJobStatus.Id, "numRestarts")
+	if err != nil {
+		// It is normal in many cases, not an error.
+		log.Info("Failed to get Flink job metrics.", "error", err)
+		return
+	}
+	restarts, err := strconv.ParseInt(metrics["numRestarts"], 10, 32)
+	if err != nil {
+		log.Info("Failed to parse the numRestarts metric of the Flink job.", "error", err)
+		return
+	}
+	var count = int32(restarts)
+	observed.flinkInternalRestarts = &count
+}
+
+// Observes the JARs uploaded to the JobManager while the job waits for its next submission,
+// whether the JAR recorded in the job status can still be run or the JobManager lost it, e.g.,
+// because it restarted.
+func (observer *ClusterStateObserver) observeUploadedJars(ctx context.Context, observed *ObservedClusterState) {
+	var log = logr.FromContextOrDiscard(ctx)
+	var job = observed.cluster.Status.Components.Job
+	if !isRESTJarUpload(observed.cluster) || job == nil || job.UploadedJar == nil || job.IsActive() {
+		return
+	}
+
+	jars, err := observer.flinkClient.GetJars(getFlinkAPIBaseURL(observed.cluster))
+	if err != nil {
+		// It is normal in many cases, not an error.
+		log.Info("Failed to get the JARs uploaded to the JobManager.", "error", err)
+		return
+	}
+	observed.uploadedJars = jars
+}
+
+// Gets the latest completed checkpoint with an external path in the checkpointing statistics.
+// Flink reports a placeholder path for the checkpoints which are not retained.
+func getRetainedCheckpoint(stats *flink.CheckpointingStatistics) *v1beta1.RetainedCheckpoint {
+	if stats == nil {
+		return nil
+	}
+	var checkpoint = getLatestCompletedCheckpoint(stats)
+	if checkpoint == nil || checkpoint.ExternalPath == "" || strings.HasPrefix(checkpoint.ExternalPath, "<") {
+		return nil
+	}
+	var tc = &util.TimeConverter{}
+	return &v1beta1.RetainedCheckpoint{
+		ID:       checkpoint.ID,
+		Location: checkpoint.ExternalPath,
+		Time:     tc.ToString(time.UnixMilli(checkpoint.LatestAckTimestamp)),
+	}
+}
+
+// Cross-checks the savepoint recorded in the job status with the running Flink job. The
+// savepoint is stale when the checkpointing statistics show the job was restored from a
+// later savepoint and did not take the recorded one.
+func (observer *ClusterStateObserver) observeSavepointBaseline(observed *ObservedClusterState) {
+	var stats = observed.flinkJob.checkpointingStats
+	var job = observed.cluster.Status.Components.Job
+	if stats == nil || job == nil || job.SavepointLocation == "" || job.SavepointLocation == job.FromSavepoint {
+		return
+	}
+	observed.staleSavepoint = isSavepointSuperseded(job, stats)
+}
+
+// Probes whether the savepoint the job submission waits for is available, i.e., a FlinkCluster
+// in the namespace of the cluster recorded it as completed, e.g., the cluster the job is migrated
+// from. The clusters of other namespaces are not looked up.
+func (observer *ClusterStateObserver) observeAwaitedSavepoint(ctx context.Context, observed *ObservedClusterState) error {
+	var location = getAwaitedSavepoint(observed.cluster, observed.observeTime)
+	if location == nil {
+		return nil
+	}
+	var clusters v1beta1.FlinkClusterList
+	if err := observer.k8sClient.List(ctx, &clusters, client.InNamespace(observed.cluster.Namespace)); err != nil {
+		return err
+	}
+	observed.savepointAvailable = isSavepointRecorded(clusters.Items, *location)
+	return nil
+}
+
+// Observes the slots the TaskManager groups registered with the JobManager through Flink API.
+func (observer *ClusterStateObserver) observeSlotRegistration(ctx context.Context, observed *ObservedClusterState) {
+	var log = logr.FromContextOrDiscard(ctx)
+	if !observed.observabilityPollDue || observed.pods == nil ||
+		observed.cluster.Status.State != v1beta1.ClusterStateRunning {
+		return
+	}
+	slots, err := observed.cluster.GetTaskManagerTaskSlots()
+	if err != nil {
+		return
+	}
+
+	taskManagers, err := observer.flinkClient.GetTaskManagers(getFlinkAPIBaseURL(observed.cluster))
+	if err != nil {
+		// It is normal in many cases, not an error.
+		log.Info("Failed to get Flink TaskManagers.", "error", err)
+		return
+	}
+	var desiredReplicas = make(map[string]int32)
+	if sts := observed.tmStatefulSet; sts != nil && sts.Spec.Replicas != nil {
+		desiredReplicas[sts.Name] = *sts.Spec.Replicas
+	}
+	if deploy := observed.tmDeployment; deploy != nil && deploy.Spec.Replicas != nil {
+		desiredReplicas[deploy.Name] = *deploy.Spec.Replicas
+	}
+	observed.slotRegistration = &v1beta1.SlotRegistrationStatus{
+		Groups: getTaskManagerGroupSlots(observed.pods, taskManagers.TaskManagers, slots, desiredReplicas),
+	}
+}
+
+// Finds the latest completed checkpoint in the checkpointing statistics, savepoints are
+// not taken into account.
+func getLatestCompletedCheckpoint(stats *flink.CheckpointingStatistics) *flink.CheckpointStatistics {
+	var latest *flink.CheckpointStatistics
+	for i, c := range stats.History {
+		if c.IsSavepoint || c.Status != "COMPLETED" {
+			continue
+		}
+		if latest == nil || c.ID > latest.ID {
+			latest = &stats.History[i]
+		}
+	}
+	return latest
 }
 
 func (observer *ClusterStateObserver) observeSavepoint(cluster *v1beta1.FlinkCluster, savepoint *Savepoint) error {
@@ -408,9 +715,49 @@ func (observer *ClusterStateObserver) observeSavepoint(cluster *v1beta1.FlinkClu
 	savepoint.status = savepointStatus
 	savepoint.error = err
 
+	// (Optional) The size of the successful savepoint for the savepoint inventory.
+	if err == nil && savepointStatus.IsSuccessful() {
+		if stats, err := observer.flinkClient.GetCheckpointingStatistics(flinkAPIBaseURL, jobID); err == nil {
+			savepoint.size = getSavepointSize(stats, savepointStatus.Location)
+		}
+	}
+
 	return err
 }
 
+// Finds the size of the savepoint at the location in the checkpointing statistics.
+func getSavepointSize(stats *flink.CheckpointingStatistics, location string) *int64 {
+	for _, c := range stats.History {
+		if c.IsSavepoint && c.ExternalPath == location {
+			var size = c.StateSize
+			return &size
+		}
+	}
+	return nil
+}
+
+// Observes the savepoints in progress of the coordinated savepoint, keyed by Flink job ID.
+func (observer *ClusterStateObserver) observeCoordinatedSavepoints(ctx context.Context, observed *ObservedClusterState) {
+	var log = logr.FromContextOrDiscard(ctx)
+	var recorded = observed.cluster.Status.CoordinatedSavepoint
+	if !recorded.HasPendingSavepoints() {
+		return
+	}
+
+	var flinkAPIBaseURL = getFlinkAPIBaseURL(observed.cluster)
+	observed.coordinatedSavepoints = make(map[string]Savepoint)
+	for _, job := range recorded.Jobs {
+		if job.Savepoint.State != v1beta1.SavepointStateInProgress {
+			continue
+		}
+		status, err := observer.flinkClient.GetSavepointStatus(flinkAPIBaseURL, job.Savepoint.JobID, job.Savepoint.TriggerID)
+		if err != nil {
+			log.Error(err, "Failed to get coordinated savepoint status", "jobName", job.JobName)
+		}
+		observed.coordinatedSavepoints[job.Savepoint.JobID] = Savepoint{status: status, error: err}
+	}
+}
+
 func (observer *ClusterStateObserver) observeCluster(ctx context.Context, cluster *v1beta1.FlinkCluster) error {
 	return observer.k8sClient.Get(ctx, observer.request.NamespacedName, cluster)
 }
@@ -632,6 +979,50 @@ func (observer *ClusterStateObserver) observePersistentVolumeClaims(
 	return nil
 }
 
+// observePods observes the JobManager and TaskManager pods of the cluster.
+func (observer *ClusterStateObserver) observePods(
+	ctx context.Context,
+	observed *ObservedClusterState) error {
+	var clusterNamespace = observer.request.Namespace
+	var selector = labels.SelectorFromSet(map[string]string{
+		"cluster": observer.request.Name,
+		"app":     "flink",
+	})
+
+	observed.pods = new(corev1.PodList)
+	err := observer.k8sClient.List(
+		ctx,
+		observed.pods,
+		client.InNamespace(clusterNamespace),
+		client.MatchingLabelsSelector{Selector: selector})
+	if client.IgnoreNotFound(err) != nil {
+		return err
+	}
+
+	return nil
+}
+
+// observeNodeArchitectures observes the `kubernetes.io/arch` of the nodes of the cluster, which
+// the declared image architecture is checked against.
+func (observer *ClusterStateObserver) observeNodeArchitectures(ctx context.Context, observed *ObservedClusterState) {
+	var log = logr.FromContextOrDiscard(ctx)
+	if observed.cluster.NodeArchitecture() == "" {
+		return
+	}
+	var nodes corev1.NodeList
+	if err := observer.k8sClient.List(ctx, &nodes); err != nil {
+		log.Info("Failed to get the nodes", "error", err)
+		return
+	}
+	observed.nodeArchitectures = []string{}
+	for _, node := range nodes.Items {
+		if arch := node.Labels[corev1.LabelArchStable]; arch != "" && !slices.Contains(observed.nodeArchitectures, arch) {
+			observed.nodeArchitectures = append(observed.nodeArchitectures, arch)
+		}
+	}
+	slices.Sort(observed.nodeArchitectures)
+}
+
 // syncRevisionStatus synchronizes current FlinkCluster resource and its child ControllerRevision resources.
 // When FlinkCluster resource is edited, the operator creates new child ControllerRevision for it
 // and updates nextRevision in FlinkClusterStatus to the name of the new ControllerRevision.
@@ -672,6 +1063,17 @@ func (observer *ClusterStateObserver) syncRevisionStatus(ctx context.Context, ob
 	// find any equivalent revisions
 	equalRevisions := history.FindEqualRevisions(revisions, nextRevision)
 	equalCount := len(equalRevisions)
+	if equalCount == 0 && revisionCount > 0 {
+		// The hash of an unchanged spec can churn when the serialization differs, e.g., a field
+		// defaulted to an empty value; keep the latest revision instead of triggering an update.
+		var latestRevision = revisions[revisionCount-1]
+		if fields, unstable := revisionHashChurn(latestRevision.Data.Raw, nextRevision.Data.Raw); unstable {
+			log.Info("Revision hash is unstable across reconciles, keeping the latest revision",
+				"revision", latestRevision.Name, "churnFields", fields)
+			equalRevisions = []*appsv1.ControllerRevision{latestRevision}
+			equalCount = 1
+		}
+	}
 	if equalCount > 0 && history.EqualRevision(revisions[revisionCount-1], equalRevisions[equalCount-1]) {
 		// if the equivalent revision is immediately prior the next revision has not changed
 		nextRevision = revisions[revisionCount-1]
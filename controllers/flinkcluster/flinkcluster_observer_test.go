@@ -3,11 +3,17 @@ package flinkcluster
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/go-logr/logr"
 	v1beta1 "github.com/spotify/flink-on-k8s-operator/apis/flinkcluster/v1beta1"
 	"github.com/spotify/flink-on-k8s-operator/internal/controllers/history"
+	"github.com/spotify/flink-on-k8s-operator/internal/flink"
 	"github.com/spotify/flink-on-k8s-operator/internal/util"
+	"gotest.tools/v3/assert"
 	appsv1 "k8s.io/api/apps/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -157,6 +163,35 @@ func TestSyncRevisionStatus_CollisionCountStableAcrossReconciliations(t *testing
 	}
 }
 
+func TestSyncRevisionStatus_KeepsRevisionWhenHashIsUnstable(t *testing.T) {
+	cluster := newTestCluster()
+	cluster.Spec.TaskManager = &v1beta1.TaskManagerSpec{}
+	fake := &fakeHistory{}
+	observer := &ClusterStateObserver{history: fake}
+	observed := &ObservedClusterState{cluster: cluster}
+
+	// First reconciliation.
+	if err := observer.syncRevisionStatus(context.Background(), observed); err != nil {
+		t.Fatalf("first sync: %v", err)
+	}
+	originalName := observed.revision.nextRevision.Name
+	persistRevisionStatus(cluster, observed.revision)
+
+	// An empty value serializes differently but does not change the spec semantically.
+	cluster.Spec.TaskManager.FineGrainedResources = &v1beta1.FineGrainedResourcesSpec{}
+	observed.revisions = fake.revisions
+	if err := observer.syncRevisionStatus(context.Background(), observed); err != nil {
+		t.Fatalf("second sync: %v", err)
+	}
+
+	if observed.revision.nextRevision.Name != originalName {
+		t.Errorf("nextRevision name changed: %s -> %s", originalName, observed.revision.nextRevision.Name)
+	}
+	if len(fake.revisions) != 1 {
+		t.Errorf("expected no new revision, got %d revisions", len(fake.revisions))
+	}
+}
+
 func TestSyncRevisionStatus_DeletedRevisionProducesStableNames(t *testing.T) {
 	cluster := newTestCluster()
 	fake := &fakeHistory{}
@@ -333,3 +368,137 @@ func TestSyncRevisionStatus_RealCollisionIncrementsCount(t *testing.T) {
 	}
 	_ = fmt.Sprintf("collisionCount after real collision: %d", observed.revision.collisionCount)
 }
+
+func TestObserveFlinkConfigSampling(t *testing.T) {
+	var polls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		polls++
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[{"key":"parallelism.default","value":"2"}]`))
+	}))
+	defer server.Close()
+
+	var replicas int32 = 1
+	var uiPort int32 = 8081
+	var interval int32 = 60
+	var tc = &util.TimeConverter{}
+	var now = time.Now()
+	var observer = &ClusterStateObserver{flinkClient: flink.NewClient(logr.Discard(), newRedirectingHTTPClient(server.URL))}
+
+	for _, test := range []struct {
+		name          string
+		lastPollTime  string
+		expectedPolls int
+	}{
+		{name: "never polled", expectedPolls: 1},
+		{name: "within the interval", lastPollTime: tc.ToString(now.Add(-30 * time.Second)), expectedPolls: 0},
+		{name: "interval elapsed", lastPollTime: tc.ToString(now.Add(-90 * time.Second)), expectedPolls: 1},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			// given
+			polls = 0
+			var observed = &ObservedClusterState{
+				cluster: &v1beta1.FlinkCluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "mycluster", Namespace: "default"},
+					Spec: v1beta1.FlinkClusterSpec{
+						JobManager:                   &v1beta1.JobManagerSpec{Ports: v1beta1.JobManagerPorts{UI: &uiPort}},
+						ObservabilitySamplingSeconds: &interval,
+					},
+					Status: v1beta1.FlinkClusterStatus{LastObservabilityPollTime: test.lastPollTime},
+				},
+				jmStatefulSet: &appsv1.StatefulSet{
+					Spec:   appsv1.StatefulSetSpec{Replicas: &replicas},
+					Status: appsv1.StatefulSetStatus{ReadyReplicas: 1},
+				},
+			}
+			observed.observabilityPollDue = isObservabilityPollDue(observed.cluster, now)
+
+			// when
+			observer.observeFlinkConfig(context.Background(), observed)
+
+			// then
+			assert.Equal(t, polls, test.expectedPolls)
+			assert.Equal(t, observed.flinkConfig != nil, test.expectedPolls > 0)
+		})
+	}
+}
+
+func TestGetRetainedCheckpoint(t *testing.T) {
+	var tc = &util.TimeConverter{}
+	var ackTime = time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	var stats = &flink.CheckpointingStatistics{History: []flink.CheckpointStatistics{
+		{ID: 9, Status: "IN_PROGRESS", ExternalPath: "gs://bucket/chk-9"},
+		{ID: 8, Status: "COMPLETED", IsSavepoint: true, ExternalPath: "gs://bucket/savepoint-8"},
+		{ID: 7, Status: "COMPLETED", ExternalPath: "gs://bucket/chk-7", LatestAckTimestamp: ackTime.UnixMilli()},
+		{ID: 6, Status: "COMPLETED", ExternalPath: "gs://bucket/chk-6"},
+	}}
+	assert.DeepEqual(t, getRetainedCheckpoint(stats), &v1beta1.RetainedCheckpoint{
+		ID:       7,
+		Location: "gs://bucket/chk-7",
+		Time:     tc.ToString(ackTime),
+	})
+
+	// Not retained.
+	stats.History[2].ExternalPath = "<checkpoint-not-externally-addressable>"
+	assert.Assert(t, getRetainedCheckpoint(stats) == nil)
+
+	// No completed checkpoint.
+	assert.Assert(t, getRetainedCheckpoint(&flink.CheckpointingStatistics{}) == nil)
+}
+
+func TestObserveFlinkInternalRestarts(t *testing.T) {
+	var response string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, r.URL.Path, "/jobs/job-1/metrics")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(response))
+	}))
+	defer server.Close()
+
+	var uiPort int32 = 8081
+	var observer = &ClusterStateObserver{flinkClient: flink.NewClient(logr.Discard(), newRedirectingHTTPClient(server.URL))}
+	var restarts int32 = 3
+	for _, test := range []struct {
+		name     string
+		state    v1beta1.JobState
+		response string
+		expected *int32
+	}{
+		{name: "running job", state: v1beta1.JobStateRunning, response: `[{"id":"numRestarts","value":"3"}]`, expected: &restarts},
+		{name: "metric not registered", state: v1beta1.JobStateRunning, response: `[]`},
+		{name: "inactive job", state: v1beta1.JobStateFailed, response: `[{"id":"numRestarts","value":"3"}]`},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			// given
+			response = test.response
+			var observed = &ObservedClusterState{
+				cluster: &v1beta1.FlinkCluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "mycluster", Namespace: "default"},
+					Spec: v1beta1.FlinkClusterSpec{
+						JobManager: &v1beta1.JobManagerSpec{Ports: v1beta1.JobManagerPorts{UI: &uiPort}},
+					},
+					Status: v1beta1.FlinkClusterStatus{
+						Components: v1beta1.FlinkClusterComponentsStatus{
+							Job: &v1beta1.JobStatus{ID: "job-1", State: test.state, RestartCount: 2},
+						},
+					},
+				},
+				flinkJob:             FlinkJob{status: &flink.Job{Id: "job-1", State: "RUNNING"}},
+				observabilityPollDue: true,
+			}
+
+			// when
+			observer.observeFlinkInternalRestarts(context.Background(), observed)
+			var job = observed.cluster.Status.Components.Job
+			var jobRestarts = deriveJobRestarts(job, job, observed.flinkInternalRestarts)
+
+			// then: the Flink restarts are reported alongside the operator restarts
+			assert.DeepEqual(t, observed.flinkInternalRestarts, test.expected)
+			if test.expected != nil {
+				assert.DeepEqual(t, jobRestarts, &v1beta1.JobRestartsStatus{
+					OperatorRestarts: 2, FlinkInternalRestarts: 3, TotalRestarts: 5,
+				})
+			}
+		})
+	}
+}
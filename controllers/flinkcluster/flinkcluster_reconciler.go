@@ -20,9 +20,12 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"slices"
+	"strings"
 	"time"
 
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
@@ -42,6 +45,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
 // ClusterReconciler takes actions to drive the observed state towards the
@@ -70,6 +74,20 @@ func (reconciler *ClusterReconciler) reconcile(ctx context.Context) (ctrl.Result
 		return ctrl.Result{}, nil
 	}
 
+	if reconciler.observed.cluster.DeletionTimestamp != nil {
+		return reconciler.reconcileTeardown(ctx)
+	}
+
+	err = reconciler.reconcileFinalizer(ctx)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if reconciler.observed.cluster.GracefulShutdownRequested() {
+		return reconciler.reconcileGracefulShutdown(ctx)
+	}
+	reconciler.clearJobsDrainedCondition(ctx)
+
 	if shouldUpdateCluster(&reconciler.observed) {
 		log.Info("The cluster update is in progress")
 	}
@@ -119,6 +137,16 @@ func (reconciler *ClusterReconciler) reconcile(ctx context.Context) (ctrl.Result
 		return ctrl.Result{}, err
 	}
 
+	err = reconciler.reconcileTaskManagerSchedulingGates(ctx)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	err = reconciler.reconcileTaskManagerOrdinals(ctx)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
 	err = reconciler.reconcileHorizontalPodAutoscaler(ctx)
 	if err != nil {
 		return ctrl.Result{}, err
@@ -134,14 +162,200 @@ func (reconciler *ClusterReconciler) reconcile(ctx context.Context) (ctrl.Result
 		return ctrl.Result{}, err
 	}
 
+	err = reconciler.reconcileCoordinatedSavepoint(ctx)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
 	result, err := reconciler.reconcileJob(ctx)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
 
+	// Keep polling the savepoints of the coordinated savepoint in progress.
+	if result.IsZero() && reconciler.observed.cluster.Status.CoordinatedSavepoint.HasPendingSavepoints() {
+		result = requeueResult
+	}
+
+	// Pods are not watched, keep checking unschedulable pods until they are scheduled.
+	if result.IsZero() && hasUnschedulablePods(reconciler.observed.pods) {
+		result = requeueResult
+	}
+
+	// Keep checking the JobManager until the gated TaskManager pods are released.
+	if result.IsZero() && len(getGatedTaskManagerPods(reconciler.observed.pods)) > 0 {
+		result = requeueResult
+	}
+
 	return result, nil
 }
 
+// Adds the teardown finalizer to the cluster, so that the cluster is torn down in order
+// when deleted.
+func (reconciler *ClusterReconciler) reconcileFinalizer(ctx context.Context) error {
+	var cluster = reconciler.observed.cluster
+	if controllerutil.ContainsFinalizer(cluster, v1beta1.TeardownFinalizer) {
+		return nil
+	}
+	return reconciler.patchFinalizer(ctx, func(c *v1beta1.FlinkCluster) bool {
+		return controllerutil.AddFinalizer(c, v1beta1.TeardownFinalizer)
+	})
+}
+
+// Tears down the deleted cluster one phase per reconcile, then removes the teardown
+// finalizer to let K8S reclaim the remaining child resources.
+func (reconciler *ClusterReconciler) reconcileTeardown(ctx context.Context) (ctrl.Result, error) {
+	log := logr.FromContextOrDiscard(ctx)
+	var observed = reconciler.observed
+	var cluster = observed.cluster
+	var err error
+
+	if !controllerutil.ContainsFinalizer(cluster, v1beta1.TeardownFinalizer) {
+		log.Info("The cluster is being deleted, no action to take")
+		return ctrl.Result{}, nil
+	}
+
+	var phase = getTeardownPhase(&observed)
+	log.Info("Tearing down the deleted cluster", "phase", phase)
+	switch phase {
+	case teardownPhaseDrainJob:
+		var takeSavepoint = shouldDrainJobWithSavepoint(cluster)
+		if !takeSavepoint && shouldStopWithSavepoint(cluster) {
+			reconciler.recorder.Event(cluster, corev1.EventTypeWarning, "TeardownSavepointFailed",
+				"final savepoint failed, cancelling the job without savepoint")
+		}
+		err = reconciler.cancelRunningJobs(ctx, takeSavepoint)
+	case teardownPhaseFinalSavepoint:
+		log.Info("Wait until the final savepoint is completed and the job stops")
+	case teardownPhaseDeletePods:
+		err = reconciler.deleteWorkloads(ctx)
+	case teardownPhaseCleanupHA:
+		err = reconciler.deleteComponent(ctx, observed.haConfigMap, "HA ConfigMap")
+	case teardownPhaseCleanupStorage:
+		for i := range observed.persistentVolumeClaims.Items {
+			err = reconciler.deleteComponent(ctx, &observed.persistentVolumeClaims.Items[i], "PersistentVolumeClaim")
+			if err != nil {
+				break
+			}
+		}
+	case teardownPhaseDone:
+		reconciler.recorder.Event(cluster, corev1.EventTypeNormal, "TeardownCompleted", "cluster torn down")
+		return ctrl.Result{}, reconciler.patchFinalizer(ctx, func(c *v1beta1.FlinkCluster) bool {
+			return controllerutil.RemoveFinalizer(c, v1beta1.TeardownFinalizer)
+		})
+	}
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// Pods are not watched, keep checking until the phase is completed.
+	return requeueResult, nil
+}
+
+// Drains the cluster for the graceful shutdown requested with the graceful-shutdown annotation.
+// The running jobs are stopped one by one with a savepoint, which is recorded in the savepoint
+// inventory. The wait for each savepoint ends at the graceful shutdown deadline at the latest.
+// Once the deadline has passed, the jobs still running are cancelled without a savepoint so
+// that the shutdown does not hang.
+func (reconciler *ClusterReconciler) reconcileGracefulShutdown(ctx context.Context) (ctrl.Result, error) {
+	log := logr.FromContextOrDiscard(ctx)
+	var cluster = reconciler.observed.cluster
+	var runningJobs = getRunningFlinkJobIDs(&reconciler.observed)
+
+	condition, deadline := deriveJobsDrainedCondition(cluster, runningJobs, reconciler.observed.observeTime)
+	reconciler.setJobsDrainedCondition(ctx, condition)
+	if len(runningJobs) == 0 {
+		log.Info("The jobs are drained for the graceful shutdown, no action to take")
+		return ctrl.Result{}, nil
+	}
+
+	for _, jobID := range runningJobs {
+		var takeSavepoint = shouldStopWithSavepoint(cluster) && time.Now().Before(deadline)
+		log.Info("Stopping job for the graceful shutdown", "jobID", jobID, "takeSavepoint", takeSavepoint)
+		if err := reconciler.cancelFlinkJobBefore(ctx, jobID, takeSavepoint, deadline); err != nil {
+			// Keep draining the other jobs, the job is stopped again on requeue.
+			log.Error(err, "Failed to stop job for the graceful shutdown", "jobID", jobID)
+		}
+	}
+
+	// Keep checking until the jobs are stopped.
+	return requeueResult, nil
+}
+
+// Records the JobsDrained condition of the graceful shutdown when it changed.
+func (reconciler *ClusterReconciler) setJobsDrainedCondition(ctx context.Context, condition *metav1.Condition) {
+	var cluster = reconciler.observed.cluster
+	var recorded = meta.FindStatusCondition(cluster.Status.Conditions, condition.Type)
+	if recorded != nil && recorded.Status == condition.Status &&
+		recorded.Reason == condition.Reason && recorded.Message == condition.Message {
+		return
+	}
+	var eventType = corev1.EventTypeNormal
+	if condition.Reason == v1beta1.JobsDrainedReasonTimedOut {
+		eventType = corev1.EventTypeWarning
+	}
+	reconciler.recorder.Event(cluster, eventType, condition.Reason, condition.Message)
+	var nilSS *v1beta1.SavepointStatus
+	var nilCS *v1beta1.FlinkClusterControlStatus
+	reconciler.updateStatusWith(ctx, &nilSS, &nilCS, func(status *v1beta1.FlinkClusterStatus) {
+		meta.SetStatusCondition(&status.Conditions, *condition)
+	})
+}
+
+// Removes the JobsDrained condition once the graceful shutdown is no longer requested, so that
+// the timeout of the next graceful shutdown starts anew.
+func (reconciler *ClusterReconciler) clearJobsDrainedCondition(ctx context.Context) {
+	var cluster = reconciler.observed.cluster
+	if meta.FindStatusCondition(cluster.Status.Conditions, v1beta1.ClusterConditionJobsDrained) == nil {
+		return
+	}
+	var nilSS *v1beta1.SavepointStatus
+	var nilCS *v1beta1.FlinkClusterControlStatus
+	reconciler.updateStatusWith(ctx, &nilSS, &nilCS, func(status *v1beta1.FlinkClusterStatus) {
+		meta.RemoveStatusCondition(&status.Conditions, v1beta1.ClusterConditionJobsDrained)
+	})
+}
+
+// Deletes the workloads running the pods of the cluster.
+func (reconciler *ClusterReconciler) deleteWorkloads(ctx context.Context) error {
+	var observed = reconciler.observed
+	if observed.jmStatefulSet != nil {
+		if err := reconciler.deleteComponent(ctx, observed.jmStatefulSet, "JobManagerStatefulSet"); err != nil {
+			return err
+		}
+	}
+	if observed.tmStatefulSet != nil {
+		if err := reconciler.deleteComponent(ctx, observed.tmStatefulSet, "TaskManagerStatefulSet"); err != nil {
+			return err
+		}
+	}
+	if observed.tmDeployment != nil {
+		if err := reconciler.deleteComponent(ctx, observed.tmDeployment, "TaskManagerDeployment"); err != nil {
+			return err
+		}
+	}
+	if observed.flinkJobSubmitter.job != nil {
+		return reconciler.deleteJob(ctx, observed.flinkJobSubmitter.job)
+	}
+	return nil
+}
+
+// Patches the finalizers of the cluster with the given change, if any.
+func (reconciler *ClusterReconciler) patchFinalizer(ctx context.Context, change func(*v1beta1.FlinkCluster) bool) error {
+	log := logr.FromContextOrDiscard(ctx)
+	var cluster = reconciler.observed.cluster
+	var patch = client.MergeFrom(cluster.DeepCopy())
+	if !change(cluster) {
+		return nil
+	}
+	if err := reconciler.k8sClient.Patch(ctx, cluster, patch); err != nil {
+		log.Error(err, "Failed to patch the finalizers", "finalizers", cluster.Finalizers)
+		return err
+	}
+	log.Info("Patched the finalizers", "finalizers", cluster.Finalizers)
+	return nil
+}
+
 func (reconciler *ClusterReconciler) reconcileBatchScheduler() error {
 	cluster := reconciler.observed.cluster
 	schedulerSpec := cluster.Spec.BatchScheduler
@@ -170,6 +384,9 @@ func (reconciler *ClusterReconciler) reconcileBatchScheduler() error {
 }
 
 func (reconciler *ClusterReconciler) reconcileJobManagerStatefulSet(ctx context.Context) error {
+	if err := reconciler.reconcileJobManagerReplicaDrift(ctx); err != nil {
+		return err
+	}
 	return reconciler.reconcileComponent(
 		ctx,
 		"JobManager",
@@ -177,6 +394,31 @@ func (reconciler *ClusterReconciler) reconcileJobManagerStatefulSet(ctx context.
 		reconciler.observed.jmStatefulSet)
 }
 
+// Scales the JobManager StatefulSet back to the replicas of the spec when it was scaled to
+// other replicas. With the Warn replica drift policy, the drift is left as is and reported by
+// the JobManagerReplicaDrift condition instead.
+func (reconciler *ClusterReconciler) reconcileJobManagerReplicaDrift(ctx context.Context) error {
+	var log = logr.FromContextOrDiscard(ctx)
+	var observed = &reconciler.observed
+	desired, actual, drifted := getJobManagerReplicaDrift(observed)
+	if !drifted {
+		return nil
+	}
+
+	var sts = observed.jmStatefulSet
+	if !shouldRevertJobManagerReplicaDrift(observed.cluster) {
+		log.Info("JobManager replicas drifted from the spec, leaving them as is", "desired", desired, "actual", actual)
+		return nil
+	}
+
+	log.Info("JobManager replicas drifted from the spec, scaling them back", "desired", desired, "actual", actual)
+	reconciler.recorder.Event(observed.cluster, corev1.EventTypeWarning, "JobManagerReplicaDrift",
+		fmt.Sprintf("JobManager StatefulSet %s was scaled to %d replicas, scaling it back to %d", sts.Name, actual, desired))
+	var scaled = sts.DeepCopy()
+	scaled.Spec.Replicas = &desired
+	return reconciler.updateComponent(ctx, scaled, "JobManager")
+}
+
 func (reconciler *ClusterReconciler) reconcileTaskManagerStatefulSet(ctx context.Context) error {
 	return reconciler.reconcileComponent(
 		ctx,
@@ -212,6 +454,17 @@ func (reconciler *ClusterReconciler) reconcileComponent(
 			var err error
 			if shouldRecreateOnUpdate(&reconciler.observed) {
 				err = reconciler.deleteComponent(ctx, desiredObj, component)
+			} else if order := reconciler.getTaskManagerUpdateOrder(component); order != nil {
+				staged, wait := getTaskManagerUpdateStage(observedObj, desiredObj, order[0])
+				switch {
+				case staged != nil:
+					log.Info("Updating TaskManager in steps", "order", order)
+					err = reconciler.updateComponent(ctx, staged, component)
+				case wait:
+					log.Info("Waiting for the TaskManager update step to roll out", "step", order[0])
+				default:
+					err = reconciler.updateComponent(ctx, desiredObj, component)
+				}
 			} else {
 				err = reconciler.updateComponent(ctx, desiredObj, component)
 			}
@@ -231,6 +484,87 @@ func (reconciler *ClusterReconciler) reconcileComponent(
 	return nil
 }
 
+// Returns the order of the steps to update the TaskManagers when the update changes both
+// the image and the replicas, nil if the update is applied at once.
+func (reconciler *ClusterReconciler) getTaskManagerUpdateOrder(component string) []updateStep {
+	if component != "TaskManager" {
+		return nil
+	}
+	return getScaleAndImageUpdateOrder(reconciler.observed.revisions, reconciler.observed.cluster, reconciler.observed.observeTime)
+}
+
+// Removes the scheduling gate of the TaskManager pods once they can connect to the JobManager.
+func (reconciler *ClusterReconciler) reconcileTaskManagerSchedulingGates(ctx context.Context) error {
+	var log = logr.FromContextOrDiscard(ctx)
+	var gated = getGatedTaskManagerPods(reconciler.observed.pods)
+	if len(gated) == 0 {
+		return nil
+	}
+	if !shouldRemoveTaskManagerSchedulingGates(&reconciler.observed) {
+		log.Info("TaskManager pods are gated until the JobManager is ready", "pods", len(gated))
+		return nil
+	}
+
+	for _, pod := range gated {
+		var patch = client.MergeFrom(pod.DeepCopy())
+		pod.Spec.SchedulingGates = slices.DeleteFunc(pod.Spec.SchedulingGates, func(gate corev1.PodSchedulingGate) bool {
+			return gate.Name == v1beta1.JobManagerReadySchedulingGate
+		})
+		if err := reconciler.k8sClient.Patch(ctx, pod, patch); client.IgnoreNotFound(err) != nil {
+			log.Error(err, "Failed to remove the scheduling gate of the TaskManager pod", "pod", pod.Name)
+			return err
+		}
+		log.Info("Removed the scheduling gate of the TaskManager pod", "pod", pod.Name)
+	}
+	return nil
+}
+
+// Keeps the TaskManager pods of the StatefulSet on the contiguous ordinals [0, replicas)
+// once a scale is applied. Pods left out of the replicas are drained highest ordinal first,
+// and the gaps below the replicas are left to the StatefulSet to recreate.
+func (reconciler *ClusterReconciler) reconcileTaskManagerOrdinals(ctx context.Context) error {
+	var log = logr.FromContextOrDiscard(ctx)
+	var observed = reconciler.observed
+	var observedSts = observed.tmStatefulSet
+	var desiredSts = reconciler.desired.TmStatefulSet
+	if observedSts == nil || desiredSts == nil || observed.pods == nil {
+		return nil
+	}
+	// Wait until the StatefulSet is updated to the desired replicas.
+	var replicas = getReplicas(desiredSts.Spec.Replicas)
+	if getReplicas(observedSts.Spec.Replicas) != replicas {
+		return nil
+	}
+
+	var clusterName = observed.cluster.Name
+	var podsByOrdinal = make(map[int32]*corev1.Pod)
+	var ordinals []int32
+	for i := range observed.pods.Items {
+		var pod = &observed.pods.Items[i]
+		if pod.Labels["component"] != "taskmanager" || pod.DeletionTimestamp != nil {
+			continue
+		}
+		if ordinal, ok := getTaskManagerPodOrdinal(clusterName, pod.Name); ok {
+			podsByOrdinal[ordinal] = pod
+			ordinals = append(ordinals, ordinal)
+		}
+	}
+
+	var toRemove, toCreate = getTaskManagerOrdinalChanges(ordinals, replicas)
+	for _, ordinal := range toRemove {
+		var pod = podsByOrdinal[ordinal]
+		if err := reconciler.k8sClient.Delete(ctx, pod); client.IgnoreNotFound(err) != nil {
+			log.Error(err, "Failed to drain the TaskManager pod out of the replicas", "pod", pod.Name)
+			return err
+		}
+		log.Info("Drained the TaskManager pod out of the replicas", "pod", pod.Name, "replicas", replicas)
+	}
+	for _, ordinal := range toCreate {
+		log.Info("Waiting for the TaskManager pod to be recreated", "pod", getTaskManagerPodName(clusterName, ordinal))
+	}
+	return nil
+}
+
 func (reconciler *ClusterReconciler) reconcileHorizontalPodAutoscaler(ctx context.Context) error {
 	return reconciler.reconcileComponent(
 		ctx,
@@ -268,6 +602,13 @@ func (reconciler *ClusterReconciler) updateComponent(ctx context.Context, desire
 	log := logr.FromContextOrDiscard(ctx).WithValues("component", component)
 	var k8sClient = reconciler.k8sClient
 
+	// Pace the roll of the TaskManager pods by the slots the job requires.
+	if component == "TaskManager" {
+		var concurrency = reconciler.observed.cluster.MaxConcurrentTMRoll()
+		setTaskManagerRollConcurrency(desired, concurrency)
+		log.Info("Rolling TaskManagers", "maxConcurrent", concurrency)
+	}
+
 	if err := k8sClient.Update(ctx, desired); err != nil {
 		log.Error(err, "Failed to update component for update", "object", logObjectSummary(desired))
 		return err
@@ -415,7 +756,7 @@ func (reconciler *ClusterReconciler) reconcileJob(ctx context.Context) (ctrl.Res
 
 	observedSubmitter := observed.flinkJobSubmitter.job
 
-	if desiredJob != nil && job.IsTerminated(jobSpec) {
+	if desiredJob != nil && job.IsTerminated(jobSpec, observed.observeTime) {
 		// When the job was cancelled as part of an update (savepoint trigger
 		// reason is "update"), don't treat it as terminally done. The operator
 		// needs to proceed with creating a new job submitter to restart the
@@ -438,7 +779,7 @@ func (reconciler *ClusterReconciler) reconcileJob(ctx context.Context) (ctrl.Res
 		}
 		// cancel all running jobs
 		if job.IsActive() {
-			if err := reconciler.cancelRunningJobs(ctx, true /* takeSavepoint */); err != nil && !errors.IsResourceExpired(err) {
+			if err := reconciler.cancelRunningJobs(ctx, shouldStopWithSavepoint(observed.cluster)); err != nil && !errors.IsResourceExpired(err) {
 				return requeueResult, err
 			}
 		}
@@ -467,6 +808,21 @@ func (reconciler *ClusterReconciler) reconcileJob(ctx context.Context) (ctrl.Res
 			return ctrl.Result{}, nil
 		}
 
+		// Wait for the savepoint to restore the job from.
+		switch getSavepointWaitState(&observed) {
+		case savepointWaitInProgress:
+			log.Info("Waiting for the savepoint to be available", "savepoint", *getAwaitedSavepoint(observed.cluster, observed.observeTime))
+			return requeueResult, nil
+		case savepointWaitTimedOut:
+			log.Info("Timed out waiting for the savepoint, not submitting the job", "savepoint", *getAwaitedSavepoint(observed.cluster, observed.observeTime))
+			return ctrl.Result{}, nil
+		}
+
+		// Gate the restore on the savepoint validation webhook.
+		if !reconciler.validateRestoreSavepoint(ctx) {
+			return requeueResult, nil
+		}
+
 		// Create Flink job submitter
 		log.Info("Updating job status to proceed creating new job submitter")
 		// Job status must be updated before creating a job submitter to ensure the observed job is the job submitted by the operator.
@@ -476,6 +832,10 @@ func (reconciler *ClusterReconciler) reconcileJob(ctx context.Context) (ctrl.Res
 			return requeueResult, err
 		}
 
+		if shouldFailOverToPrimedSavepoint(observed.cluster, observed.observeTime) {
+			log.Info("Failing over to the primed savepoint", "savepoint", job.PrimedSavepoint.Location)
+		}
+
 		if observedSubmitter != nil {
 			var shouldDeleteForRestart bool
 			if !recorded.Revision.IsUpdateTriggered() && recorded.Revision.CurrentRevision != "" {
@@ -512,13 +872,30 @@ func (reconciler *ClusterReconciler) reconcileJob(ctx context.Context) (ctrl.Res
 		}
 
 		// Suspend or stop job to proceed update.
-		if recorded.Revision.IsUpdateTriggered() && !isScaleUpdate(observed.revisions, observed.cluster) {
+		if recorded.Revision.IsUpdateTriggered() && !isInPlaceUpdate(observed.revisions, observed.cluster) {
 			log.Info("Preparing job update")
-			var takeSavepoint = jobSpec.TakeSavepointOnUpdate == nil || *jobSpec.TakeSavepointOnUpdate
-			var shouldSuspend = takeSavepoint && util.IsBlank(jobSpec.FromSavepoint)
+			var skipReason, _ = getSavepointSkipReason(observed.cluster, observed.observeTime)
+			var shouldSuspend = skipReason == "" && util.IsBlank(jobSpec.FromSavepoint)
 			if shouldSuspend {
-				newSavepointStatus, err = reconciler.trySuspendJob(ctx)
+				newSavepointStatus, err = reconciler.trySuspendJob(ctx, v1beta1.SavepointReasonUpdate)
 			} else if shouldUpdateJob(&observed) {
+				if skipReason == v1beta1.SavepointSkippedReasonBudgetExceeded {
+					log.Info("Updating job without savepoint to honor maxUpdateDowntimeSeconds")
+					reconciler.recorder.Event(observed.cluster, corev1.EventTypeWarning, "UpdateDowntimeBudgetExceeded",
+						fmt.Sprintf("the savepoint before the update does not fit in maxUpdateDowntimeSeconds %d, updating without it",
+							*jobSpec.MaxUpdateDowntimeSeconds))
+				}
+				err = reconciler.cancelJob(ctx)
+			}
+			return requeueResult, err
+		}
+
+		// Restart job for the restart trigger, which waits for the update above to finish.
+		if len(jobID) > 0 && isJobRestartTriggered(observed.cluster) {
+			log.Info("Restarting job for the restart trigger", "nonce", observed.cluster.PendingRestartTriggerNonce())
+			if shouldRestartWithSavepoint(observed.cluster) {
+				newSavepointStatus, err = reconciler.trySuspendJob(ctx, v1beta1.SavepointReasonRestart)
+			} else {
 				err = reconciler.cancelJob(ctx)
 			}
 			return requeueResult, err
@@ -552,7 +929,7 @@ func (reconciler *ClusterReconciler) reconcileJob(ctx context.Context) (ctrl.Res
 			}
 
 			log.Info("Stopping job", "jobID", jobID)
-			if err := reconciler.cancelRunningJobs(ctx, true /* takeSavepoint */); err != nil {
+			if err := reconciler.cancelRunningJobs(ctx, shouldStopWithSavepoint(observed.cluster)); err != nil {
 				return requeueResult, err
 			}
 		} else if job.IsStopped() && observedSubmitter != nil {
@@ -576,6 +953,87 @@ func (reconciler *ClusterReconciler) reconcileJob(ctx context.Context) (ctrl.Res
 	return ctrl.Result{}, nil
 }
 
+// Triggers the savepoints of the coordinated savepoint group when requested by the user.
+// The job IDs are resolved first so that the savepoints are triggered as close together as
+// possible, no savepoint is triggered when any job of the group is not running.
+func (reconciler *ClusterReconciler) reconcileCoordinatedSavepoint(ctx context.Context) error {
+	log := logr.FromContextOrDiscard(ctx)
+	var cluster = reconciler.observed.cluster
+	var spec = cluster.Spec.CoordinatedSavepoint
+	if spec == nil ||
+		getNewControlRequest(cluster) != v1beta1.ControlNameCoordinatedSavepoint ||
+		cluster.Status.CoordinatedSavepoint.IsInProgress() ||
+		cluster.Status.CoordinatedSavepoint.HasPendingSavepoints() {
+		return nil
+	}
+
+	var apiBaseURL = getFlinkAPIBaseURL(cluster)
+	jobsOverview, err := reconciler.flinkClient.GetJobsOverview(apiBaseURL)
+	if err != nil {
+		log.Info("Flink API is not ready, postponing the coordinated savepoint", "error", err)
+		return nil
+	}
+
+	var now string
+	util.SetTimestamp(&now)
+	var group = &v1beta1.CoordinatedSavepointStatus{TriggerTime: now, UpdateTime: now}
+	var allRunning = true
+	for _, name := range spec.JobNames {
+		var job = v1beta1.CoordinatedSavepointJobStatus{
+			JobName: name,
+			Savepoint: v1beta1.SavepointStatus{
+				JobID:         getRunningFlinkJobID(jobsOverview, name),
+				TriggerReason: v1beta1.SavepointReasonUserRequested,
+				TriggerTime:   now,
+				UpdateTime:    now,
+			},
+		}
+		if job.Savepoint.JobID == "" {
+			job.Savepoint.State = v1beta1.SavepointStateTriggerFailed
+			job.Savepoint.Message = "Flink job is not running"
+			allRunning = false
+		}
+		group.Jobs = append(group.Jobs, job)
+	}
+
+	if allRunning {
+		var savepointsDir = cluster.CoordinatedSavepointsDir()
+		for i := range group.Jobs {
+			var job = &group.Jobs[i]
+			triggerID, err := reconciler.flinkClient.TriggerSavepoint(apiBaseURL, job.Savepoint.JobID, savepointsDir, false, "")
+			if err != nil {
+				job.Savepoint.State = v1beta1.SavepointStateTriggerFailed
+				if job.Savepoint.Message = err.Error(); len(job.Savepoint.Message) > 1024 {
+					job.Savepoint.Message = job.Savepoint.Message[:1024] + "..."
+				}
+				log.Info("Failed to trigger coordinated savepoint", "jobName", job.JobName, "jobID", job.Savepoint.JobID, "error", err)
+				continue
+			}
+			job.Savepoint.State = v1beta1.SavepointStateInProgress
+			job.Savepoint.TriggerID = triggerID.RequestID
+			log.Info("Successfully coordinated savepoint triggered", "jobName", job.JobName, "jobID", job.Savepoint.JobID, "triggerID", triggerID.RequestID)
+		}
+	}
+	group.Aggregate()
+
+	var nilSS *v1beta1.SavepointStatus
+	var controlStatus = getControlStatus(v1beta1.ControlNameCoordinatedSavepoint, v1beta1.ControlStateInProgress)
+	reconciler.updateStatusWith(ctx, &nilSS, &controlStatus, func(status *v1beta1.FlinkClusterStatus) {
+		status.CoordinatedSavepoint = group
+	})
+	return nil
+}
+
+// Returns the ID of the latest running Flink job with the name.
+func getRunningFlinkJobID(jobsOverview *flink.JobsOverview, name string) string {
+	for _, job := range jobsOverview.Jobs {
+		if job.Name == name && getFlinkJobDeploymentState(job.State) == v1beta1.JobStateRunning {
+			return job.Id
+		}
+	}
+	return ""
+}
+
 func (reconciler *ClusterReconciler) createJob(ctx context.Context, job *batchv1.Job) error {
 	log := logr.FromContextOrDiscard(ctx)
 	var k8sClient = reconciler.k8sClient
@@ -617,10 +1075,17 @@ func (reconciler *ClusterReconciler) getFlinkJobID() string {
 	return ""
 }
 
-func (reconciler *ClusterReconciler) trySuspendJob(ctx context.Context) (*v1beta1.SavepointStatus, error) {
+func (reconciler *ClusterReconciler) trySuspendJob(ctx context.Context, reason v1beta1.SavepointReason) (*v1beta1.SavepointStatus, error) {
 	log := logr.FromContextOrDiscard(ctx)
 	var recorded = reconciler.observed.cluster.Status
 
+	if !reconciler.observed.cluster.SavepointsConfigured() {
+		var action = "update"
+		if reason == v1beta1.SavepointReasonRestart {
+			action = "restart"
+		}
+		return nil, fmt.Errorf("cannot suspend job with savepoint for %s: neither spec.job.savepointsDir nor state.savepoints.dir is configured, set spec.job.takeSavepointOnUpdate to false to %s without savepoint", reason, action)
+	}
 	if !canTakeSavepoint(reconciler.observed.cluster) {
 		return nil, nil
 	}
@@ -631,7 +1096,7 @@ func (reconciler *ClusterReconciler) trySuspendJob(ctx context.Context) (*v1beta
 	var canSuspend = reconciler.canSuspendJob(ctx, jobID, recorded.Savepoint)
 	if canSuspend {
 		log.Info("Triggering savepoint for suspending job")
-		var newSavepointStatus, err = reconciler.triggerSavepoint(ctx, jobID, v1beta1.SavepointReasonUpdate, true)
+		var newSavepointStatus, err = reconciler.triggerSavepoint(ctx, jobID, reason, true)
 		if err != nil {
 			log.Info("Failed to trigger savepoint", "jobID", jobID, "triggerID", newSavepointStatus.TriggerID, "error", err)
 		} else {
@@ -672,12 +1137,7 @@ func (reconciler *ClusterReconciler) cancelJob(ctx context.Context) error {
 func (reconciler *ClusterReconciler) cancelRunningJobs(
 	ctx context.Context,
 	takeSavepoint bool) error {
-	var runningJobs = reconciler.observed.flinkJob.unexpected
-	var flinkJob = reconciler.observed.flinkJob.status
-	if flinkJob != nil && flinkJob.Id != "" &&
-		getFlinkJobDeploymentState(flinkJob.State) == v1beta1.JobStateRunning {
-		runningJobs = append(runningJobs, flinkJob.Id)
-	}
+	var runningJobs = getRunningFlinkJobIDs(&reconciler.observed)
 	if len(runningJobs) == 0 {
 		return errors.NewResourceExpired("no running Flink jobs to stop")
 	}
@@ -705,6 +1165,12 @@ func (reconciler *ClusterReconciler) cancelJobs(
 // endpoint to atomically create a savepoint and stop the job. Otherwise, cancels the job
 // immediately without a savepoint.
 func (reconciler *ClusterReconciler) cancelFlinkJob(ctx context.Context, jobID string, takeSavepoint bool) error {
+	return reconciler.cancelFlinkJobBefore(ctx, jobID, takeSavepoint, time.Time{})
+}
+
+// Stops a Flink job like cancelFlinkJob, waiting for the savepoint until the deadline at the
+// latest if it is set, even if the timeout of the savepoint is longer.
+func (reconciler *ClusterReconciler) cancelFlinkJobBefore(ctx context.Context, jobID string, takeSavepoint bool, deadline time.Time) error {
 	log := logr.FromContextOrDiscard(ctx)
 	var apiBaseURL = getFlinkAPIBaseURL(reconciler.observed.cluster)
 
@@ -712,40 +1178,43 @@ func (reconciler *ClusterReconciler) cancelFlinkJob(ctx context.Context, jobID s
 		log.Info("Stopping job with savepoint", "jobID", jobID)
 		formatType := savepointFormatType(reconciler.observed.cluster)
 		triggerID, err := reconciler.flinkClient.StopJobWithSavepoint(
-			apiBaseURL, jobID, *reconciler.observed.cluster.Spec.Job.SavepointsDir, string(formatType))
+			apiBaseURL, jobID, reconciler.observed.cluster.SavepointsDir(), string(formatType))
 		if err != nil {
 			return err
 		}
-		newSavepointStatus := reconciler.getNewSavepointStatus(triggerID.RequestID, v1beta1.SavepointReasonJobCancel, "", true, formatType)
+		// The final savepoint of the deleted cluster is recorded as such to apply its timeout.
+		var reason = v1beta1.SavepointReasonJobCancel
+		if reconciler.observed.cluster.DeletionTimestamp != nil {
+			reason = v1beta1.SavepointReasonDelete
+		}
+		newSavepointStatus := reconciler.getNewSavepointStatus(triggerID.RequestID, reason, "", true, formatType)
+		// The job may be another running job than the job of the cluster, e.g., in the graceful shutdown.
+		newSavepointStatus.JobID = jobID
 		var newControlStatus *v1beta1.FlinkClusterControlStatus
 		reconciler.updateStatus(ctx, &newSavepointStatus, &newControlStatus)
-		location, err := reconciler.waitForSavepointCompleted(ctx, apiBaseURL, jobID, triggerID.RequestID)
+		location, err := reconciler.waitForSavepointCompleted(ctx, apiBaseURL, jobID, triggerID.RequestID, reason, deadline)
 		reconciler.updateFinalSavepointStatus(ctx, newSavepointStatus, location, err)
 		return err
 	}
 
-	log.Info("Cancelling job", "jobID", jobID)
+	if takeSavepoint && !reconciler.observed.cluster.SavepointsConfigured() {
+		log.Info("Savepoints are not configured, cancelling job without savepoint", "jobID", jobID)
+	} else {
+		log.Info("Cancelling job", "jobID", jobID)
+	}
 	return reconciler.flinkClient.StopJob(apiBaseURL, jobID)
 }
 
 // waitForSavepointCompleted polls the savepoint status until it succeeds, fails, or times out.
-// On success, it returns the savepoint location.
-func (reconciler *ClusterReconciler) waitForSavepointCompleted(ctx context.Context, apiBaseURL string, jobID string, triggerID string) (string, error) {
+// The wait ends at notAfter at the latest if it is set. On success, it returns the savepoint location.
+func (reconciler *ClusterReconciler) waitForSavepointCompleted(ctx context.Context, apiBaseURL string, jobID string, triggerID string, reason v1beta1.SavepointReason, notAfter time.Time) (string, error) {
 	log := logr.FromContextOrDiscard(ctx)
 	log.Info("Polling savepoint status", "jobID", jobID, "triggerID", triggerID)
 	var delay = 100 * time.Millisecond
 	const maxDelay = 5 * time.Second
 	const backoffMultiplier = 2
 
-	// Extract savepoint timeout from job configuration.
-	maxWait := 10 * time.Minute
-	if props := reconciler.observed.cluster.Spec.FlinkProperties; props != nil {
-		if v, ok := props["execution.checkpointing.timeout"]; ok {
-			if d, err := parseFlinkDuration(v); err == nil {
-				maxWait = d
-			}
-		}
-	}
+	maxWait := getSavepointTimeout(reconciler.observed.cluster, reason)
 
 	// When using stop-with-savepoint, it may appear that a race condition could occur between
 	// the JobManager shutting down after the savepoint completes and the client polling for the
@@ -754,10 +1223,17 @@ func (reconciler *ClusterReconciler) waitForSavepointCompleted(ctx context.Conte
 	// for asynchronous results for this purpose, configurable via the rest.async.store-duration
 	// timeout setting (5 minutes).
 	deadline := time.Now().Add(maxWait)
+	var bounded = !notAfter.IsZero() && notAfter.Before(deadline)
+	if bounded {
+		deadline = notAfter
+	}
 	for {
-		time.Sleep(delay)
-		if time.Now().After(deadline) {
-			return "", fmt.Errorf("timed out stopping job %s with savepoint, please configure a larger timeout via 'execution.checkpointing.timeout'", jobID)
+		time.Sleep(min(delay, time.Until(deadline)))
+		if !time.Now().Before(deadline) {
+			if bounded {
+				return "", fmt.Errorf("timed out stopping job %s with savepoint within the graceful shutdown timeout", jobID)
+			}
+			return "", fmt.Errorf("timed out stopping job %s with savepoint, please configure a larger timeout via 'spec.job.savepointTimeouts' or 'execution.checkpointing.timeout'", jobID)
 		}
 		status, err := reconciler.flinkClient.GetSavepointStatus(apiBaseURL, jobID, triggerID)
 		if err != nil {
@@ -875,7 +1351,7 @@ func (reconciler *ClusterReconciler) triggerSavepoint(
 	var formatType = savepointFormatType(cluster)
 	var err error
 	log.Info(fmt.Sprintf("Trigger savepoint for %s", triggerReason), "jobID", jobID)
-	savepointTriggerID, err = reconciler.flinkClient.TriggerSavepoint(apiBaseURL, jobID, *cluster.Spec.Job.SavepointsDir, cancel, string(formatType))
+	savepointTriggerID, err = reconciler.flinkClient.TriggerSavepoint(apiBaseURL, jobID, cluster.SavepointsDir(), cancel, string(formatType))
 	if err != nil {
 		// limit message size to 1KiB
 		if message = err.Error(); len(message) > 1024 {
@@ -906,6 +1382,42 @@ func savepointFormatType(cluster *v1beta1.FlinkCluster) v1beta1.SavepointFormatT
 	return *cluster.Spec.Job.SavepointFormatType
 }
 
+// Asks the savepoint validation webhook to approve the savepoint the job is restored from, and
+// records the answer in the SavepointValidated condition. Returns false if the job must not be
+// submitted until the webhook approves the savepoint, which it is asked again for on requeue.
+func (reconciler *ClusterReconciler) validateRestoreSavepoint(ctx context.Context) bool {
+	var log = logr.FromContextOrDiscard(ctx)
+	var cluster = reconciler.observed.cluster
+	var location = getSavepointToValidate(cluster, reconciler.observed.observeTime)
+	if location == nil {
+		return true
+	}
+
+	var webhook = cluster.Spec.Job.SavepointValidationWebhook
+	response, err := reconciler.flinkClient.ValidateSavepoint(webhook.URL, flink.SavepointValidationRequest{
+		Namespace: cluster.Namespace,
+		Cluster:   cluster.Name,
+		Savepoint: *location,
+	}, time.Duration(webhook.GetTimeoutSeconds())*time.Second)
+	condition, approved := deriveSavepointValidatedCondition(cluster, *location, response, err)
+	log.Info("Validated the savepoint to restore the job from", "savepoint", *location, "approved", approved, "reason", condition.Reason)
+
+	var recorded = meta.FindStatusCondition(cluster.Status.Conditions, condition.Type)
+	if recorded != nil && recorded.Status == condition.Status &&
+		recorded.Reason == condition.Reason && recorded.Message == condition.Message {
+		return approved
+	}
+	if condition.Reason != v1beta1.SavepointValidatedReasonApproved {
+		reconciler.recorder.Event(cluster, corev1.EventTypeWarning, condition.Reason, condition.Message)
+	}
+	var nilSS *v1beta1.SavepointStatus
+	var nilCS *v1beta1.FlinkClusterControlStatus
+	reconciler.updateStatusWith(ctx, &nilSS, &nilCS, func(status *v1beta1.FlinkClusterStatus) {
+		meta.SetStatusCondition(&status.Conditions, *condition)
+	})
+	return approved
+}
+
 func (reconciler *ClusterReconciler) updateStatus(
 	ctx context.Context, ss **v1beta1.SavepointStatus, cs **v1beta1.FlinkClusterControlStatus) {
 	reconciler.updateStatusWithJob(ctx, ss, cs, nil)
@@ -916,12 +1428,27 @@ func (reconciler *ClusterReconciler) updateStatus(
 // is recorded.
 func (reconciler *ClusterReconciler) updateStatusWithJob(
 	ctx context.Context, ss **v1beta1.SavepointStatus, cs **v1beta1.FlinkClusterControlStatus, jobUpdate func(*v1beta1.JobStatus)) {
+	var statusUpdate func(*v1beta1.FlinkClusterStatus)
+	if jobUpdate != nil {
+		statusUpdate = func(status *v1beta1.FlinkClusterStatus) {
+			if status.Components.Job != nil {
+				jobUpdate(status.Components.Job)
+			}
+		}
+	}
+	reconciler.updateStatusWith(ctx, ss, cs, statusUpdate)
+}
+
+// updateStatusWith behaves like updateStatus, and additionally applies statusUpdate to the
+// recorded status as part of the same status update, when statusUpdate is non-nil.
+func (reconciler *ClusterReconciler) updateStatusWith(
+	ctx context.Context, ss **v1beta1.SavepointStatus, cs **v1beta1.FlinkClusterControlStatus, statusUpdate func(*v1beta1.FlinkClusterStatus)) {
 	log := logr.FromContextOrDiscard(ctx)
 
 	var savepointStatus = *ss
 	var controlStatus = *cs
 
-	if savepointStatus == nil && controlStatus == nil && jobUpdate == nil {
+	if savepointStatus == nil && controlStatus == nil && statusUpdate == nil {
 		return
 	}
 
@@ -946,8 +1473,8 @@ func (reconciler *ClusterReconciler) updateStatusWithJob(
 		if controlStatus != nil {
 			newStatus.Control = controlStatus
 		}
-		if jobUpdate != nil && newStatus.Components.Job != nil {
-			jobUpdate(newStatus.Components.Job)
+		if statusUpdate != nil {
+			statusUpdate(newStatus)
 		}
 		util.SetTimestamp(&newStatus.LastUpdateTime)
 		log.Info(
@@ -998,11 +1525,17 @@ func (reconciler *ClusterReconciler) updateJobDeployStatus(ctx context.Context)
 
 		// Latest savepoint location should be fromSavepoint.
 		var fromSavepoint = getFromSavepoint(desiredJobSubmitter.Spec)
+		if newJob.FromSavepoint != fromSavepoint {
+			newJob.RestoreFailureCount = 0
+		}
 		newJob.FromSavepoint = fromSavepoint
 		if newJob.SavepointLocation != "" {
 			newJob.SavepointLocation = fromSavepoint
 		}
 
+		// The restart trigger is served by any submission of the job.
+		newJob.RestartTriggerNonce = strings.TrimSpace(cluster.Annotations[v1beta1.RestartTriggerAnnotation])
+
 		return reconciler.k8sClient.Status().Update(ctx, &cluster)
 	})
 	if err != nil {
@@ -1053,16 +1586,27 @@ func (reconciler *ClusterReconciler) updateFinalSavepointStatus(ctx context.Cont
 		finalStatus.State = v1beta1.SavepointStateSucceeded
 	}
 	var nilCS *v1beta1.FlinkClusterControlStatus
-	var jobUpdate func(*v1beta1.JobStatus)
+	var statusUpdate func(*v1beta1.FlinkClusterStatus)
 	if savepointErr == nil {
-		jobUpdate = func(job *v1beta1.JobStatus) {
-			job.SavepointGeneration++
-			job.SavepointLocation = location
-			job.FinalSavepoint = true
-			util.SetTimestamp(&job.SavepointTime)
+		statusUpdate = func(status *v1beta1.FlinkClusterStatus) {
+			// The savepoint of another session job is only recorded in the inventory.
+			if job := status.Components.Job; job != nil && job.ID == finalStatus.JobID {
+				job.SavepointGeneration++
+				job.SavepointLocation = location
+				job.FinalSavepoint = true
+				util.SetTimestamp(&job.SavepointTime)
+			}
+			var record = v1beta1.SavepointRecord{
+				Location:      location,
+				JobID:         finalStatus.JobID,
+				TriggerReason: finalStatus.TriggerReason,
+				FlinkVersion:  reconciler.observed.cluster.Spec.FlinkVersion,
+			}
+			util.SetTimestamp(&record.Time)
+			status.SavepointInventory = v1beta1.AddSavepointRecord(status.SavepointInventory, record)
 		}
 	}
-	reconciler.updateStatusWithJob(ctx, &finalStatus, &nilCS, jobUpdate)
+	reconciler.updateStatusWith(ctx, &finalStatus, &nilCS, statusUpdate)
 }
 
 // Convert raw time to object and add `addedSeconds` to it,
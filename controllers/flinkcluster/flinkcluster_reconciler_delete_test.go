@@ -19,11 +19,24 @@ package flinkcluster
 import (
 	"context"
 	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 
+	"github.com/go-logr/logr"
+	v1beta1 "github.com/spotify/flink-on-k8s-operator/apis/flinkcluster/v1beta1"
+	"github.com/spotify/flink-on-k8s-operator/internal/flink"
+	"gotest.tools/v3/assert"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
@@ -86,3 +99,203 @@ func testDeleteConfigMap() *corev1.ConfigMap {
 		},
 	}
 }
+
+func TestReconcileTeardown(t *testing.T) {
+	// given: Flink REST API that stops the job with a savepoint
+	var stopped atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/jobs/job-123/stop":
+			stopped.Store(true)
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, `{"request-id": "trigger-abc"}`)
+
+		case r.Method == http.MethodGet && r.URL.Path == "/jobs/job-123/savepoints/trigger-abc":
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, `{"status":{"id":"COMPLETED"},"operation":{"location":"s3://bucket/sp-1"}}`)
+
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			http.Error(w, "not found", http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	// and: a deleted cluster running a job, with its workloads, HA ConfigMap and volumes
+	cluster := newTestTeardownCluster()
+	reconciler := newTestTeardownReconciler(t, server.URL, append(newTestTeardownObjects(), cluster)...)
+
+	// when: reconciled until the cluster is gone
+	var phases []teardownPhase
+	for i := 0; i < 10; i++ {
+		observeTestTeardown(t, reconciler, !stopped.Load())
+		if reconciler.observed.cluster == nil {
+			break
+		}
+		phases = append(phases, getTeardownPhase(&reconciler.observed))
+		_, err := reconciler.reconcileTeardown(context.Background())
+		assert.NilError(t, err)
+	}
+
+	// then: the cluster is torn down in order, after the final savepoint
+	assert.DeepEqual(t, phases, []teardownPhase{
+		teardownPhaseDrainJob,
+		teardownPhaseDeletePods,
+		teardownPhaseCleanupHA,
+		teardownPhaseCleanupStorage,
+		teardownPhaseDone,
+	})
+	assert.Assert(t, stopped.Load())
+	assert.Assert(t, reconciler.observed.cluster == nil)
+	var haConfigMap corev1.ConfigMap
+	err := reconciler.k8sClient.Get(context.Background(),
+		types.NamespacedName{Name: "test-cluster-cluster-config-map", Namespace: "default"}, &haConfigMap)
+	assert.Assert(t, apierrors.IsNotFound(err))
+}
+
+func TestReconcileTeardown_ResumesAfterRestart(t *testing.T) {
+	t.Run("waits for the final savepoint in progress", func(t *testing.T) {
+		// given: the operator restarted while the job was being stopped with a savepoint
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			http.Error(w, "not found", http.StatusNotFound)
+		}))
+		defer server.Close()
+		cluster := newTestTeardownCluster()
+		cluster.Status.Savepoint = &v1beta1.SavepointStatus{
+			JobID:         "job-123",
+			TriggerID:     "trigger-abc",
+			TriggerReason: v1beta1.SavepointReasonJobCancel,
+			State:         v1beta1.SavepointStateInProgress,
+		}
+		reconciler := newTestTeardownReconciler(t, server.URL, append(newTestTeardownObjects(), cluster)...)
+		observeTestTeardown(t, reconciler, true)
+
+		// when
+		result, err := reconciler.reconcileTeardown(context.Background())
+
+		// then: the job is not stopped again and the workloads are kept
+		assert.NilError(t, err)
+		assert.Equal(t, getTeardownPhase(&reconciler.observed), teardownPhaseFinalSavepoint)
+		assert.DeepEqual(t, result, requeueResult)
+		observeTestTeardown(t, reconciler, true)
+		assert.Assert(t, reconciler.observed.jmStatefulSet != nil)
+	})
+
+	t.Run("resumes after the pods are gone", func(t *testing.T) {
+		// given: the operator restarted after the workloads were deleted
+		cluster := newTestTeardownCluster()
+		cluster.Status.Components.Job.State = v1beta1.JobStateCancelled
+		var objects []client.Object
+		for _, obj := range newTestTeardownObjects() {
+			if _, ok := obj.(*appsv1.StatefulSet); !ok {
+				objects = append(objects, obj)
+			}
+		}
+		reconciler := newTestTeardownReconciler(t, "http://localhost", append(objects, cluster)...)
+
+		// when
+		var phases []teardownPhase
+		for i := 0; i < 10; i++ {
+			observeTestTeardown(t, reconciler, false)
+			if reconciler.observed.cluster == nil {
+				break
+			}
+			phases = append(phases, getTeardownPhase(&reconciler.observed))
+			_, err := reconciler.reconcileTeardown(context.Background())
+			assert.NilError(t, err)
+		}
+
+		// then: the teardown resumes with the HA cleanup
+		assert.DeepEqual(t, phases, []teardownPhase{
+			teardownPhaseCleanupHA,
+			teardownPhaseCleanupStorage,
+			teardownPhaseDone,
+		})
+	})
+}
+
+func TestReconcileFinalizer(t *testing.T) {
+	// given
+	cluster := newTestClusterWithJob(nil, nil)
+	reconciler := newTestTeardownReconciler(t, "http://localhost", cluster)
+	reconciler.observed.cluster = cluster
+
+	// when
+	err := reconciler.reconcileFinalizer(context.Background())
+
+	// then
+	assert.NilError(t, err)
+	var updated v1beta1.FlinkCluster
+	assert.NilError(t, reconciler.k8sClient.Get(context.Background(),
+		types.NamespacedName{Name: cluster.Name, Namespace: cluster.Namespace}, &updated))
+	assert.DeepEqual(t, updated.Finalizers, []string{v1beta1.TeardownFinalizer})
+}
+
+func newTestTeardownCluster() *v1beta1.FlinkCluster {
+	savepointsDir := "s3://bucket/savepoints"
+	cluster := newTestClusterWithJob(&savepointsDir, map[string]string{
+		"high-availability":            "kubernetes",
+		"kubernetes.cluster-id":        "test-cluster",
+		"high-availability.storageDir": "s3://bucket/ha",
+	})
+	cluster.Spec.TaskManager = &v1beta1.TaskManagerSpec{}
+	deletionTimestamp := metav1.Now()
+	cluster.DeletionTimestamp = &deletionTimestamp
+	cluster.Finalizers = []string{v1beta1.TeardownFinalizer}
+	return cluster
+}
+
+func newTestTeardownObjects() []client.Object {
+	var objectMeta = func(name string) metav1.ObjectMeta {
+		return metav1.ObjectMeta{Name: name, Namespace: "default", Labels: map[string]string{"cluster": "test-cluster"}}
+	}
+	return []client.Object{
+		&appsv1.StatefulSet{ObjectMeta: objectMeta("test-cluster-jobmanager")},
+		&appsv1.StatefulSet{ObjectMeta: objectMeta("test-cluster-taskmanager")},
+		&corev1.ConfigMap{ObjectMeta: objectMeta("test-cluster-cluster-config-map")},
+		&corev1.PersistentVolumeClaim{ObjectMeta: objectMeta("pvc-test-cluster-taskmanager-0")},
+	}
+}
+
+func newTestTeardownReconciler(t *testing.T, serverURL string, objects ...client.Object) *ClusterReconciler {
+	scheme := runtime.NewScheme()
+	assert.NilError(t, v1beta1.AddToScheme(scheme))
+	assert.NilError(t, appsv1.AddToScheme(scheme))
+	assert.NilError(t, corev1.AddToScheme(scheme))
+	k8sClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithStatusSubresource(&v1beta1.FlinkCluster{}).
+		WithObjects(objects...).
+		Build()
+	return &ClusterReconciler{
+		k8sClient:   k8sClient,
+		flinkClient: flink.NewClient(logr.Discard(), newRedirectingHTTPClient(serverURL)),
+		recorder:    record.NewFakeRecorder(16),
+	}
+}
+
+// Observes the state of the deleted cluster relevant to the teardown.
+func observeTestTeardown(t *testing.T, reconciler *ClusterReconciler, jobRunning bool) {
+	t.Helper()
+	ctx := context.Background()
+	observer := ClusterStateObserver{
+		k8sClient: reconciler.k8sClient,
+		request:   ctrl.Request{NamespacedName: types.NamespacedName{Name: "test-cluster", Namespace: "default"}},
+	}
+	observed := ObservedClusterState{cluster: new(v1beta1.FlinkCluster)}
+	if err := observer.observeCluster(ctx, observed.cluster); err != nil {
+		assert.Assert(t, apierrors.IsNotFound(err))
+		reconciler.observed = ObservedClusterState{}
+		return
+	}
+	assert.NilError(t, observer.observeHAConfigMap(ctx, &observed))
+	assert.NilError(t, observer.observeJobManager(ctx, &observed))
+	assert.NilError(t, observer.observeTaskManager(ctx, &observed))
+	assert.NilError(t, observer.observePersistentVolumeClaims(ctx, &observed))
+	assert.NilError(t, observer.observePods(ctx, &observed))
+	if jobRunning {
+		observed.flinkJob.status = &flink.Job{Id: "job-123", State: "RUNNING"}
+	}
+	reconciler.observed = observed
+}
@@ -11,20 +11,25 @@ import (
 	"strings"
 	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/go-logr/logr"
 	v1beta1 "github.com/spotify/flink-on-k8s-operator/apis/flinkcluster/v1beta1"
 	"github.com/spotify/flink-on-k8s-operator/internal/flink"
 	"github.com/spotify/flink-on-k8s-operator/internal/model"
 	"gotest.tools/v3/assert"
+	appsv1 "k8s.io/api/apps/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
@@ -309,6 +314,226 @@ func TestReconcileJobKeepsSubmitterAtNextRevision(t *testing.T) {
 	))
 }
 
+func newTestSavepointWait(t *testing.T, location string, objects ...client.Object) (*ClusterReconciler, client.Client) {
+	var scheme = runtime.NewScheme()
+	assert.NilError(t, v1beta1.AddToScheme(scheme))
+	assert.NilError(t, batchv1.AddToScheme(scheme))
+
+	var cluster = &v1beta1.FlinkCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster", Namespace: "default", Generation: 1},
+		Spec: v1beta1.FlinkClusterSpec{
+			Job: &v1beta1.JobSpec{
+				FromSavepoint:    &location,
+				WaitForSavepoint: &v1beta1.WaitForSavepointSpec{},
+			},
+		},
+		Status: v1beta1.FlinkClusterStatus{
+			Components: v1beta1.FlinkClusterComponentsStatus{
+				Job: &v1beta1.JobStatus{State: v1beta1.JobStatePending},
+			},
+		},
+	}
+	var desiredJob = &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster-job-submitter", Namespace: "default"},
+		Spec: batchv1.JobSpec{Template: corev1.PodTemplateSpec{
+			Spec: corev1.PodSpec{Containers: []corev1.Container{{Args: []string{"--fromSavepoint", location}}}},
+		}},
+	}
+	var fakeClient = fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithStatusSubresource(&v1beta1.FlinkCluster{}).
+		WithObjects(append(objects, cluster)...).
+		Build()
+	return &ClusterReconciler{
+		k8sClient: fakeClient,
+		observed:  ObservedClusterState{cluster: cluster},
+		desired:   model.DesiredClusterState{Job: desiredJob},
+		recorder:  record.NewFakeRecorder(16),
+	}, fakeClient
+}
+
+// Observes the awaited savepoint and records the SavepointAvailable condition as the
+// status updater does before the reconciler acts.
+func observeTestSavepointWait(t *testing.T, reconciler *ClusterReconciler, now time.Time) {
+	var observed = &reconciler.observed
+	var observer = &ClusterStateObserver{k8sClient: reconciler.k8sClient}
+	assert.NilError(t, observer.observeAwaitedSavepoint(context.Background(), observed))
+	observed.observeTime = now
+	observed.cluster.Status.Conditions = deriveConditions(observed, observed.cluster.Status.Conditions)
+}
+
+func TestReconcileJobWaitsForSavepoint(t *testing.T) {
+	var location = "gs://bucket/savepoints/savepoint-1a2b3c"
+	var source = &v1beta1.FlinkCluster{ObjectMeta: metav1.ObjectMeta{Name: "source", Namespace: "default"}}
+	// A cluster of another namespace is not looked up.
+	var other = &v1beta1.FlinkCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "source", Namespace: "other"},
+		Status:     v1beta1.FlinkClusterStatus{SavepointInventory: []v1beta1.SavepointRecord{{Location: location}}},
+	}
+	reconciler, fakeClient := newTestSavepointWait(t, location, source, other)
+	var submitterKey = types.NamespacedName{Name: "cluster-job-submitter", Namespace: "default"}
+	var now = time.Now()
+
+	// The source cluster is still taking the savepoint.
+	observeTestSavepointWait(t, reconciler, now)
+	var condition = meta.FindStatusCondition(reconciler.observed.cluster.Status.Conditions, v1beta1.ClusterConditionSavepointAvailable)
+	assert.Equal(t, condition.Reason, v1beta1.SavepointAvailableReasonWaiting)
+
+	result, err := reconciler.reconcileJob(context.Background())
+	assert.NilError(t, err)
+	assert.Equal(t, result, requeueResult)
+	assert.Assert(t, apierrors.IsNotFound(fakeClient.Get(context.Background(), submitterKey, &batchv1.Job{})))
+
+	// The savepoint completes on the source cluster a minute later.
+	source.Status.SavepointInventory = []v1beta1.SavepointRecord{{Location: location}}
+	assert.NilError(t, fakeClient.Status().Update(context.Background(), source))
+	observeTestSavepointWait(t, reconciler, now.Add(time.Minute))
+	condition = meta.FindStatusCondition(reconciler.observed.cluster.Status.Conditions, v1beta1.ClusterConditionSavepointAvailable)
+	assert.Equal(t, condition.Status, metav1.ConditionTrue)
+	assert.Equal(t, condition.Reason, v1beta1.SavepointAvailableReasonFound)
+
+	_, err = reconciler.reconcileJob(context.Background())
+	assert.NilError(t, err)
+	assert.NilError(t, fakeClient.Get(context.Background(), submitterKey, &batchv1.Job{}))
+
+	// The job is submitted from the savepoint and does not wait for it anymore.
+	var recorded v1beta1.FlinkCluster
+	assert.NilError(t, fakeClient.Get(context.Background(), client.ObjectKeyFromObject(reconciler.observed.cluster), &recorded))
+	assert.Equal(t, recorded.Status.Components.Job.FromSavepoint, location)
+	assert.Assert(t, getAwaitedSavepoint(&recorded, time.Now()) == nil)
+}
+
+func TestReconcileJobWaitForSavepointTimesOut(t *testing.T) {
+	var location = "gs://bucket/savepoints/savepoint-1a2b3c"
+	reconciler, fakeClient := newTestSavepointWait(t, location)
+	var timeout int32 = 600
+	reconciler.observed.cluster.Spec.Job.WaitForSavepoint.TimeoutSeconds = &timeout
+	var now = time.Now()
+
+	observeTestSavepointWait(t, reconciler, now)
+	observeTestSavepointWait(t, reconciler, now.Add(10*time.Minute))
+	var condition = meta.FindStatusCondition(reconciler.observed.cluster.Status.Conditions, v1beta1.ClusterConditionSavepointAvailable)
+	assert.Equal(t, condition.Status, metav1.ConditionFalse)
+	assert.Equal(t, condition.Reason, v1beta1.SavepointAvailableReasonTimedOut)
+	assert.Equal(t, condition.Message,
+		"Savepoint gs://bucket/savepoints/savepoint-1a2b3c was not available within 600 seconds, the job is not submitted")
+
+	result, err := reconciler.reconcileJob(context.Background())
+	assert.NilError(t, err)
+	assert.Equal(t, result, ctrl.Result{})
+	assert.Assert(t, apierrors.IsNotFound(fakeClient.Get(context.Background(),
+		types.NamespacedName{Name: "cluster-job-submitter", Namespace: "default"}, &batchv1.Job{})))
+
+	// The wait restarts when the spec is changed.
+	reconciler.observed.cluster.Generation = 2
+	observeTestSavepointWait(t, reconciler, now.Add(11*time.Minute))
+	condition = meta.FindStatusCondition(reconciler.observed.cluster.Status.Conditions, v1beta1.ClusterConditionSavepointAvailable)
+	assert.Equal(t, condition.Reason, v1beta1.SavepointAvailableReasonWaiting)
+	assert.Equal(t, condition.ObservedGeneration, int64(2))
+}
+
+func TestReconcileJobValidatesSavepoint(t *testing.T) {
+	var location = "gs://bucket/savepoints/savepoint-1a2b3c"
+	for _, test := range []struct {
+		name            string
+		response        string
+		statusCode      int
+		failurePolicy   v1beta1.SavepointValidationFailurePolicy
+		expectSubmitted bool
+		expectedStatus  metav1.ConditionStatus
+		expectedReason  string
+		expectedMessage string
+	}{
+		{
+			name:            "approved",
+			response:        `{"approved":true}`,
+			expectSubmitted: true,
+			expectedStatus:  metav1.ConditionTrue,
+			expectedReason:  v1beta1.SavepointValidatedReasonApproved,
+			expectedMessage: "Savepoint gs://bucket/savepoints/savepoint-1a2b3c is approved by the validation webhook",
+		},
+		{
+			name:            "rejected",
+			response:        `{"approved":false,"reason":"lineage not approved"}`,
+			expectedStatus:  metav1.ConditionFalse,
+			expectedReason:  v1beta1.SavepointValidatedReasonRejected,
+			expectedMessage: "Savepoint gs://bucket/savepoints/savepoint-1a2b3c is rejected by the validation webhook: lineage not approved, the job is not submitted until it is approved",
+		},
+		{
+			name:            "webhook error, fail closed",
+			statusCode:      http.StatusServiceUnavailable,
+			expectedStatus:  metav1.ConditionFalse,
+			expectedReason:  v1beta1.SavepointValidatedReasonFailed,
+			expectedMessage: "The validation webhook failed for savepoint gs://bucket/savepoints/savepoint-1a2b3c: Post \"%s/validate\": 503 Service Unavailable, the job is not submitted until it is approved",
+		},
+		{
+			name:            "webhook error, fail open",
+			statusCode:      http.StatusServiceUnavailable,
+			failurePolicy:   v1beta1.SavepointValidationFailOpen,
+			expectSubmitted: true,
+			expectedStatus:  metav1.ConditionTrue,
+			expectedReason:  v1beta1.SavepointValidatedReasonFailedOpen,
+			expectedMessage: "The validation webhook failed for savepoint gs://bucket/savepoints/savepoint-1a2b3c: Post \"%s/validate\": 503 Service Unavailable, restoring from it as the failurePolicy is FailOpen",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var request flink.SavepointValidationRequest
+				assert.NilError(t, json.NewDecoder(r.Body).Decode(&request))
+				assert.DeepEqual(t, request, flink.SavepointValidationRequest{Namespace: "default", Cluster: "cluster", Savepoint: location})
+				if test.statusCode != 0 {
+					w.WriteHeader(test.statusCode)
+					return
+				}
+				w.Header().Set("Content-Type", "application/json")
+				fmt.Fprint(w, test.response)
+			}))
+			defer server.Close()
+
+			reconciler, fakeClient := newTestSavepointWait(t, location)
+			var jobSpec = reconciler.observed.cluster.Spec.Job
+			jobSpec.WaitForSavepoint = nil
+			jobSpec.SavepointValidationWebhook = &v1beta1.SavepointValidationWebhookSpec{
+				URL:           server.URL + "/validate",
+				FailurePolicy: test.failurePolicy,
+			}
+			reconciler.flinkClient = flink.NewClient(logr.Discard(), server.Client())
+
+			// The message of a failed call names the URL of the test server.
+			var expectedMessage = test.expectedMessage
+			if strings.Contains(expectedMessage, "%s") {
+				expectedMessage = fmt.Sprintf(expectedMessage, server.URL)
+			}
+
+			result, err := reconciler.reconcileJob(context.Background())
+			assert.NilError(t, err)
+			var submitterErr = fakeClient.Get(context.Background(),
+				types.NamespacedName{Name: "cluster-job-submitter", Namespace: "default"}, &batchv1.Job{})
+			if test.expectSubmitted {
+				assert.NilError(t, submitterErr)
+			} else {
+				assert.Equal(t, result, requeueResult)
+				assert.Assert(t, apierrors.IsNotFound(submitterErr))
+			}
+
+			var recorded v1beta1.FlinkCluster
+			assert.NilError(t, fakeClient.Get(context.Background(), client.ObjectKeyFromObject(reconciler.observed.cluster), &recorded))
+			var condition = meta.FindStatusCondition(recorded.Status.Conditions, v1beta1.ClusterConditionSavepointValidated)
+			assert.Assert(t, condition != nil)
+			assert.Equal(t, condition.Status, test.expectedStatus)
+			assert.Equal(t, condition.Reason, test.expectedReason)
+			assert.Equal(t, condition.Message, expectedMessage)
+
+			var events = reconciler.recorder.(*record.FakeRecorder).Events
+			if test.expectedReason == v1beta1.SavepointValidatedReasonApproved {
+				assert.Equal(t, len(events), 0)
+			} else {
+				assert.Equal(t, <-events, "Warning "+test.expectedReason+" "+expectedMessage)
+			}
+		})
+	}
+}
+
 func TestCancelFlinkJob_StopWithSavepoint_Success(t *testing.T) {
 	// given: Flink REST API that completes savepoint after 2 in-progress polls
 	var pollCount atomic.Int32
@@ -515,6 +740,40 @@ func TestCancelFlinkJob_NoSavepointsDir_FallsBackToCancel(t *testing.T) {
 	assertNoSavepointStatus(t, reconciler, cluster)
 }
 
+func TestCancelRunningJobs_StopModeCancel(t *testing.T) {
+	// given: Flink REST API that accepts cancel requests only
+	var cancelCalled atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPatch && r.URL.Path == "/jobs/job-123":
+			cancelCalled.Store(true)
+			w.WriteHeader(http.StatusAccepted)
+
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			http.Error(w, "not found", http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	// and: a running cluster with savepoints configured and the Cancel stop mode
+	savepointsDir := "s3://bucket/savepoints"
+	stopMode := v1beta1.JobStopModeCancel
+	cluster := newTestClusterWithJob(&savepointsDir, nil)
+	cluster.Spec.Job.StopMode = &stopMode
+	reconciler := newTestReconciler(cluster, newRedirectingHTTPClient(server.URL))
+	reconciler.observed.flinkJob.status = &flink.Job{Id: "job-123", State: "RUNNING"}
+
+	// when: the running jobs are cancelled
+	err := reconciler.cancelRunningJobs(context.Background(), shouldStopWithSavepoint(cluster))
+
+	// then: no error is returned
+	requireNoError(t, err)
+	// and: the job was cancelled without savepoint
+	assertCancelCalled(t, &cancelCalled)
+	assertNoSavepointStatus(t, reconciler, cluster)
+}
+
 func TestCancelFlinkJob_StopWithSavepoint_Timeout(t *testing.T) {
 	// given: Flink REST API that always reports savepoint in progress
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -554,6 +813,206 @@ func TestCancelFlinkJob_StopWithSavepoint_Timeout(t *testing.T) {
 	}
 }
 
+func TestCancelFlinkJob_SavepointsDirFromFlinkProperties(t *testing.T) {
+	// given: Flink REST API that completes stop-with-savepoint immediately
+	var stopBody map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/jobs/job-123/stop":
+			body, err := io.ReadAll(r.Body)
+			assert.NilError(t, err)
+			assert.NilError(t, json.Unmarshal(body, &stopBody))
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, `{"request-id": "trigger-abc"}`)
+
+		case r.Method == http.MethodGet && r.URL.Path == "/jobs/job-123/savepoints/trigger-abc":
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, `{"status":{"id":"COMPLETED"},"operation":{"location":"s3://bucket/sp-1"}}`)
+
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			http.Error(w, "not found", http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	// and: a running cluster with savepoints configured only through Flink properties
+	cluster := newTestClusterWithJob(nil, map[string]string{
+		"state.savepoints.dir": "s3://bucket/savepoints",
+	})
+	reconciler := newTestReconciler(cluster, newRedirectingHTTPClient(server.URL))
+
+	// when: cancelFlinkJob is called with takeSavepoint=true
+	err := reconciler.cancelFlinkJob(context.Background(), "job-123", true)
+
+	// then: the job is stopped with a savepoint to the configured directory
+	requireNoError(t, err)
+	assert.DeepEqual(t, stopBody, map[string]interface{}{
+		"targetDirectory": "s3://bucket/savepoints",
+		"drain":           false,
+	})
+	sp := requireSavepointStatus(t, reconciler, cluster)
+	if sp.State != v1beta1.SavepointStateSucceeded {
+		t.Errorf("expected savepoint state %q, got %q", v1beta1.SavepointStateSucceeded, sp.State)
+	}
+}
+
+func TestTrySuspendJob_SavepointsNotConfigured(t *testing.T) {
+	// given: Flink REST API that must not receive any request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		http.Error(w, "not found", http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	// and: a running cluster without savepoints configured
+	cluster := newTestClusterWithJob(nil, nil)
+	reconciler := newTestReconciler(cluster, newRedirectingHTTPClient(server.URL))
+
+	// when: the job is suspended for update
+	savepoint, err := reconciler.trySuspendJob(context.Background(), v1beta1.SavepointReasonUpdate)
+
+	// then: no savepoint is triggered and a clear error is returned
+	requireError(t, err, "spec.job.savepointsDir", "state.savepoints.dir", "to update without savepoint")
+	assert.Assert(t, savepoint == nil)
+	assertNoSavepointStatus(t, reconciler, cluster)
+
+	// when: the job is suspended for the restart trigger
+	_, err = reconciler.trySuspendJob(context.Background(), v1beta1.SavepointReasonRestart)
+
+	// then: the error refers to the restart
+	requireError(t, err, "to restart without savepoint")
+}
+
+func TestShouldTakeSavepoint_SavepointsNotConfigured(t *testing.T) {
+	// given: a running cluster with scheduled savepoints that are due
+	var autoSavepointSeconds int32 = 60
+	cluster := newTestClusterWithJob(nil, nil)
+	cluster.Spec.Job.AutoSavepointSeconds = &autoSavepointSeconds
+	cluster.Status.Components.Job.StartTime = time.Now().Add(-time.Hour).Format(time.RFC3339)
+	reconciler := newTestReconciler(cluster, http.DefaultClient)
+
+	// then: no savepoint is triggered while savepoints are not configured
+	assert.Equal(t, reconciler.shouldTakeSavepoint(), v1beta1.SavepointReason(""))
+
+	// and: the scheduled savepoint is triggered once the Flink properties configure it
+	cluster.Spec.FlinkProperties = map[string]string{"state.savepoints.dir": "s3://bucket/savepoints"}
+	assert.Equal(t, reconciler.shouldTakeSavepoint(), v1beta1.SavepointReasonScheduled)
+}
+
+func TestReconcileJobRestartTrigger(t *testing.T) {
+	// given: Flink REST API that accepts savepoint triggers
+	var triggerBodies []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/jobs/job-123/savepoints" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+		var body map[string]interface{}
+		assert.NilError(t, json.NewDecoder(r.Body).Decode(&body))
+		triggerBodies = append(triggerBodies, body)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"request-id": "trigger-abc"}`)
+	}))
+	defer server.Close()
+
+	// and: a running cluster with a new nonce of the restart trigger
+	scheme := runtime.NewScheme()
+	assert.NilError(t, v1beta1.AddToScheme(scheme))
+	assert.NilError(t, batchv1.AddToScheme(scheme))
+	var savepointsDir = "s3://bucket/savepoints"
+	cluster := newTestClusterWithJob(&savepointsDir, nil)
+	cluster.Annotations = map[string]string{v1beta1.RestartTriggerAnnotation: "1"}
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithStatusSubresource(cluster).
+		WithObjects(cluster).
+		Build()
+	var desiredJob = &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-cluster-job-submitter", Namespace: "default"},
+		Spec: batchv1.JobSpec{Template: corev1.PodTemplateSpec{
+			Spec: corev1.PodSpec{Containers: []corev1.Container{{
+				Args: []string{"standalone-job", "--fromSavepoint", "s3://bucket/savepoints/savepoint-1"},
+			}}},
+		}},
+	}
+	reconciler := &ClusterReconciler{
+		k8sClient:   fakeClient,
+		flinkClient: flink.NewClient(logr.Discard(), newRedirectingHTTPClient(server.URL)),
+		observed:    ObservedClusterState{cluster: cluster},
+		desired:     model.DesiredClusterState{Job: desiredJob},
+		recorder:    record.NewFakeRecorder(16),
+	}
+
+	// when: the job is reconciled
+	_, err := reconciler.reconcileJob(context.Background())
+
+	// then: the job is cancelled with a savepoint for the restart
+	requireNoError(t, err)
+	assert.Equal(t, len(triggerBodies), 1)
+	assert.Equal(t, triggerBodies[0]["cancel-job"], true)
+	sp := requireSavepointStatus(t, reconciler, cluster)
+	assert.Equal(t, sp.TriggerReason, v1beta1.SavepointReasonRestart)
+
+	// when: the cancelled job is restarting from the savepoint
+	var recorded v1beta1.FlinkCluster
+	assert.NilError(t, fakeClient.Get(context.Background(), client.ObjectKeyFromObject(cluster), &recorded))
+	recorded.Status.Components.Job.State = v1beta1.JobStateRestarting
+	recorded.Status.Components.Job.SavepointLocation = "s3://bucket/savepoints/savepoint-1"
+	recorded.Status.Savepoint.State = v1beta1.SavepointStateSucceeded
+	assert.NilError(t, fakeClient.Status().Update(context.Background(), &recorded))
+	reconciler.observed.cluster = recorded.DeepCopy()
+	_, err = reconciler.reconcileJob(context.Background())
+
+	// then: the job is submitted again and the nonce is recorded
+	requireNoError(t, err)
+	assert.NilError(t, fakeClient.Get(context.Background(),
+		types.NamespacedName{Name: desiredJob.Name, Namespace: desiredJob.Namespace}, &batchv1.Job{}))
+	job := requireJobStatus(t, reconciler, cluster)
+	assert.Equal(t, job.RestartTriggerNonce, "1")
+	assert.Equal(t, job.FromSavepoint, "s3://bucket/savepoints/savepoint-1")
+
+	// when: the restarted job is running with the same nonce
+	assert.NilError(t, fakeClient.Get(context.Background(), client.ObjectKeyFromObject(cluster), &recorded))
+	recorded.Status.Components.Job.State = v1beta1.JobStateRunning
+	reconciler.observed.cluster = recorded.DeepCopy()
+	reconciler.observed.flinkJobSubmitter = FlinkJobSubmitter{}
+	_, err = reconciler.reconcileJob(context.Background())
+
+	// then: the job is not restarted again
+	requireNoError(t, err)
+	assert.Equal(t, len(triggerBodies), 1)
+}
+
+func TestReconcileJobRestartTriggerWaitsForUpdate(t *testing.T) {
+	// given: Flink REST API that accepts savepoint triggers
+	var triggered atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		triggered.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"request-id": "trigger-abc"}`)
+	}))
+	defer server.Close()
+
+	// and: a running cluster being updated with a new nonce of the restart trigger
+	var savepointsDir = "s3://bucket/savepoints"
+	cluster := newTestClusterWithJob(&savepointsDir, nil)
+	cluster.Annotations = map[string]string{v1beta1.RestartTriggerAnnotation: "1"}
+	cluster.Status.Revision = v1beta1.RevisionStatus{CurrentRevision: "test-cluster-1", NextRevision: "test-cluster-2"}
+	reconciler := newTestReconciler(cluster, newRedirectingHTTPClient(server.URL))
+	reconciler.desired = model.DesiredClusterState{Job: &batchv1.Job{}}
+
+	// when: the job is reconciled
+	_, err := reconciler.reconcileJob(context.Background())
+
+	// then: the job is suspended for the update, the restart is left for later
+	requireNoError(t, err)
+	assert.Equal(t, triggered.Load(), int32(1))
+	sp := requireSavepointStatus(t, reconciler, cluster)
+	assert.Equal(t, sp.TriggerReason, v1beta1.SavepointReasonUpdate)
+}
+
 // --- Test helpers ---
 
 // redirectTransport rewrites every request to target the httptest server,
@@ -686,3 +1145,598 @@ func assertNoSavepointStatus(t *testing.T, reconciler *ClusterReconciler, cluste
 		t.Errorf("expected no savepoint status, got %+v", updated.Status.Savepoint)
 	}
 }
+
+func newTestSessionClusterWithCoordinatedSavepoint(jobNames ...string) *v1beta1.FlinkCluster {
+	var uiPort int32 = 8081
+	return &v1beta1.FlinkCluster{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "FlinkCluster",
+			APIVersion: "flinkoperator.k8s.io/v1beta1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test-cluster",
+			Namespace:   "default",
+			Annotations: map[string]string{v1beta1.ControlAnnotation: v1beta1.ControlNameCoordinatedSavepoint},
+		},
+		Spec: v1beta1.FlinkClusterSpec{
+			JobManager: &v1beta1.JobManagerSpec{
+				Ports: v1beta1.JobManagerPorts{UI: &uiPort},
+			},
+			FlinkProperties:      map[string]string{"state.savepoints.dir": "s3://bucket/savepoints"},
+			CoordinatedSavepoint: &v1beta1.CoordinatedSavepointSpec{JobNames: jobNames},
+		},
+		Status: v1beta1.FlinkClusterStatus{
+			Control: &v1beta1.FlinkClusterControlStatus{
+				Name:  v1beta1.ControlNameCoordinatedSavepoint,
+				State: v1beta1.ControlStateRequested,
+			},
+		},
+	}
+}
+
+func newCoordinatedSavepointTestServer(t *testing.T, triggered *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/jobs/overview":
+			fmt.Fprint(w, `{"jobs":[`+
+				`{"jid":"job-source","name":"source","state":"RUNNING","start-time":2},`+
+				`{"jid":"job-sink","name":"sink","state":"RUNNING","start-time":1},`+
+				`{"jid":"job-old","name":"sink","state":"CANCELED","start-time":0}]}`)
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/savepoints"):
+			var jobID = strings.Split(r.URL.Path, "/")[2]
+			*triggered = append(*triggered, jobID)
+			fmt.Fprintf(w, `{"request-id": "trigger-%s"}`, jobID)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			http.Error(w, "not found", http.StatusNotFound)
+		}
+	}))
+}
+
+func getTestClusterStatus(t *testing.T, reconciler *ClusterReconciler, cluster *v1beta1.FlinkCluster) *v1beta1.FlinkClusterStatus {
+	t.Helper()
+	updated := &v1beta1.FlinkCluster{}
+	if err := reconciler.k8sClient.Get(context.Background(),
+		types.NamespacedName{Name: cluster.Name, Namespace: cluster.Namespace}, updated); err != nil {
+		t.Fatalf("failed to get cluster: %v", err)
+	}
+	return &updated.Status
+}
+
+func TestReconcileCoordinatedSavepoint_TriggersAllJobs(t *testing.T) {
+	// given: a session cluster running all jobs of the group
+	var triggered []string
+	server := newCoordinatedSavepointTestServer(t, &triggered)
+	defer server.Close()
+	cluster := newTestSessionClusterWithCoordinatedSavepoint("source", "sink")
+	reconciler := newTestReconciler(cluster, newRedirectingHTTPClient(server.URL))
+
+	// when: the coordinated savepoint is reconciled
+	err := reconciler.reconcileCoordinatedSavepoint(context.Background())
+
+	// then: savepoints of all jobs are triggered and tracked as a group
+	requireNoError(t, err)
+	assert.DeepEqual(t, triggered, []string{"job-source", "job-sink"})
+	status := getTestClusterStatus(t, reconciler, cluster)
+	group := status.CoordinatedSavepoint
+	assert.Assert(t, group != nil)
+	assert.Equal(t, group.State, v1beta1.SavepointStateInProgress)
+	assert.Equal(t, len(group.Jobs), 2)
+	for _, job := range group.Jobs {
+		assert.Equal(t, job.Savepoint.State, v1beta1.SavepointStateInProgress)
+		assert.Equal(t, job.Savepoint.TriggerID, "trigger-"+job.Savepoint.JobID)
+	}
+	assert.Equal(t, status.Control.State, v1beta1.ControlStateInProgress)
+}
+
+func TestReconcileCoordinatedSavepoint_JobNotRunning(t *testing.T) {
+	// given: a session cluster where a job of the group is not running
+	var triggered []string
+	server := newCoordinatedSavepointTestServer(t, &triggered)
+	defer server.Close()
+	cluster := newTestSessionClusterWithCoordinatedSavepoint("source", "enrich")
+	reconciler := newTestReconciler(cluster, newRedirectingHTTPClient(server.URL))
+
+	// when: the coordinated savepoint is reconciled
+	err := reconciler.reconcileCoordinatedSavepoint(context.Background())
+
+	// then: no savepoint is triggered and the group fails with the missing job
+	requireNoError(t, err)
+	assert.Equal(t, len(triggered), 0)
+	group := getTestClusterStatus(t, reconciler, cluster).CoordinatedSavepoint
+	assert.Assert(t, group != nil)
+	assert.Equal(t, group.State, v1beta1.SavepointStateFailed)
+	assert.DeepEqual(t, group.FailedJobs, []string{"enrich"})
+}
+
+// newGracefulShutdownTestServer serves the Flink REST API stopping the jobs, recording the jobs
+// stopped with a savepoint and cancelled without one in order. The savepoints are reported in
+// the given state.
+func newGracefulShutdownTestServer(t *testing.T, savepointState string, stopped *[]string, cancelled *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var jobID = strings.Split(r.URL.Path, "/")[2]
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/stop"):
+			*stopped = append(*stopped, jobID)
+			fmt.Fprintf(w, `{"request-id": "trigger-%s"}`, jobID)
+		case r.Method == http.MethodGet && strings.Contains(r.URL.Path, "/savepoints/"):
+			if savepointState != "COMPLETED" {
+				fmt.Fprintf(w, `{"status":{"id":"%s"}}`, savepointState)
+				return
+			}
+			fmt.Fprintf(w, `{"status":{"id":"COMPLETED"},"operation":{"location":"s3://bucket/sp-%s"}}`, jobID)
+		case r.Method == http.MethodPatch:
+			*cancelled = append(*cancelled, jobID)
+			w.WriteHeader(http.StatusAccepted)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			http.Error(w, "not found", http.StatusNotFound)
+		}
+	}))
+}
+
+func newTestGracefulShutdown(t *testing.T, serverURL string, cluster *v1beta1.FlinkCluster) *ClusterReconciler {
+	cluster.Annotations = map[string]string{v1beta1.GracefulShutdownAnnotation: "true"}
+	cluster.Finalizers = []string{v1beta1.TeardownFinalizer}
+	reconciler := newTestReconciler(cluster, newRedirectingHTTPClient(serverURL))
+	reconciler.observed.observeTime = time.Now()
+	reconciler.observed.flinkJob.status = &flink.Job{Id: "job-123", State: "RUNNING"}
+	reconciler.observed.flinkJob.unexpected = []string{"job-456"}
+	return reconciler
+}
+
+func TestReconcileGracefulShutdown(t *testing.T) {
+	// given: a cluster running two jobs, requested to shut down gracefully
+	var stopped, cancelled []string
+	server := newGracefulShutdownTestServer(t, "COMPLETED", &stopped, &cancelled)
+	defer server.Close()
+	savepointsDir := "s3://bucket/savepoints"
+	cluster := newTestClusterWithJob(&savepointsDir, nil)
+	reconciler := newTestGracefulShutdown(t, server.URL, cluster)
+
+	// when
+	result, err := reconciler.reconcile(context.Background())
+
+	// then: the jobs are stopped with a savepoint one by one, which is recorded in the inventory
+	requireNoError(t, err)
+	assert.Equal(t, result, requeueResult)
+	assert.DeepEqual(t, stopped, []string{"job-456", "job-123"})
+	assert.Equal(t, len(cancelled), 0)
+	status := getTestClusterStatus(t, reconciler, cluster)
+	assert.Equal(t, len(status.SavepointInventory), 2)
+	for i, jobID := range stopped {
+		assert.Equal(t, status.SavepointInventory[i].JobID, jobID)
+		assert.Equal(t, status.SavepointInventory[i].Location, "s3://bucket/sp-"+jobID)
+	}
+	assert.Equal(t, status.Components.Job.SavepointLocation, "s3://bucket/sp-job-123")
+	condition := meta.FindStatusCondition(status.Conditions, v1beta1.ClusterConditionJobsDrained)
+	assert.Assert(t, condition != nil)
+	assert.Equal(t, condition.Status, metav1.ConditionFalse)
+	assert.Equal(t, condition.Reason, v1beta1.JobsDrainedReasonDraining)
+
+	// when: reconciled again once the jobs are stopped
+	cluster.Status = *status
+	reconciler.observed.flinkJob.status = &flink.Job{Id: "job-123", State: "FINISHED"}
+	reconciler.observed.flinkJob.unexpected = nil
+	result, err = reconciler.reconcile(context.Background())
+
+	// then: the cluster is drained and no job is stopped again
+	requireNoError(t, err)
+	assert.Assert(t, result.IsZero())
+	assert.Equal(t, len(stopped), 2)
+	status = getTestClusterStatus(t, reconciler, cluster)
+	assert.Assert(t, meta.IsStatusConditionTrue(status.Conditions, v1beta1.ClusterConditionJobsDrained))
+	condition = meta.FindStatusCondition(status.Conditions, v1beta1.ClusterConditionJobsDrained)
+	assert.Equal(t, condition.Reason, v1beta1.JobsDrainedReasonDrained)
+}
+
+func TestUpdateFinalSavepointStatusOfAnotherJob(t *testing.T) {
+	// given: a session cluster whose job is not recorded yet
+	savepointsDir := "s3://bucket/savepoints"
+	cluster := newTestClusterWithJob(&savepointsDir, nil)
+	cluster.Status.Components.Job.ID = ""
+	reconciler := newTestReconciler(cluster, http.DefaultClient)
+	inProgress := reconciler.getNewSavepointStatus("trigger-456", v1beta1.SavepointReasonJobCancel, "", true, "")
+	inProgress.JobID = "job-456"
+
+	// when: the final savepoint of another job is completed
+	reconciler.updateFinalSavepointStatus(context.Background(), inProgress, "s3://bucket/sp-job-456", nil)
+
+	// then: it is only recorded in the inventory
+	status := getTestClusterStatus(t, reconciler, cluster)
+	assert.Equal(t, status.Components.Job.SavepointLocation, "")
+	assert.Equal(t, status.Components.Job.FinalSavepoint, false)
+	assert.Equal(t, len(status.SavepointInventory), 1)
+	assert.Equal(t, status.SavepointInventory[0].JobID, "job-456")
+	assert.Equal(t, status.SavepointInventory[0].Location, "s3://bucket/sp-job-456")
+}
+
+func TestReconcileGracefulShutdownTimesOut(t *testing.T) {
+	// given: a cluster whose graceful shutdown started longer than its timeout ago
+	var stopped, cancelled []string
+	server := newGracefulShutdownTestServer(t, "COMPLETED", &stopped, &cancelled)
+	defer server.Close()
+	savepointsDir := "s3://bucket/savepoints"
+	var timeoutSeconds int32 = 60
+	cluster := newTestClusterWithJob(&savepointsDir, nil)
+	cluster.Spec.GracefulShutdownTimeoutSeconds = &timeoutSeconds
+	cluster.Status.Conditions = []metav1.Condition{{
+		Type:               v1beta1.ClusterConditionJobsDrained,
+		Status:             metav1.ConditionFalse,
+		Reason:             v1beta1.JobsDrainedReasonDraining,
+		Message:            "Stopping jobs job-456, job-123 with a savepoint",
+		LastTransitionTime: metav1.NewTime(time.Now().Add(-time.Hour)),
+	}}
+	reconciler := newTestGracefulShutdown(t, server.URL, cluster)
+
+	// when
+	result, err := reconciler.reconcile(context.Background())
+
+	// then: the jobs are cancelled without a savepoint
+	requireNoError(t, err)
+	assert.Equal(t, result, requeueResult)
+	assert.Equal(t, len(stopped), 0)
+	assert.DeepEqual(t, cancelled, []string{"job-456", "job-123"})
+	status := getTestClusterStatus(t, reconciler, cluster)
+	assert.Equal(t, len(status.SavepointInventory), 0)
+	condition := meta.FindStatusCondition(status.Conditions, v1beta1.ClusterConditionJobsDrained)
+	assert.Assert(t, condition != nil)
+	assert.Equal(t, condition.Status, metav1.ConditionFalse)
+	assert.Equal(t, condition.Reason, v1beta1.JobsDrainedReasonTimedOut)
+	event := <-reconciler.recorder.(*record.FakeRecorder).Events
+	assert.Assert(t, strings.HasPrefix(event, "Warning DrainTimedOut"), event)
+
+	// when: reconciled again once the jobs are cancelled
+	cluster.Status = *status
+	reconciler.observed.flinkJob.status = nil
+	reconciler.observed.flinkJob.unexpected = nil
+	result, err = reconciler.reconcile(context.Background())
+
+	// then: the cluster is drained, reporting the timeout
+	requireNoError(t, err)
+	assert.Assert(t, result.IsZero())
+	status = getTestClusterStatus(t, reconciler, cluster)
+	condition = meta.FindStatusCondition(status.Conditions, v1beta1.ClusterConditionJobsDrained)
+	assert.Equal(t, condition.Status, metav1.ConditionTrue)
+	assert.Equal(t, condition.Reason, v1beta1.JobsDrainedReasonTimedOut)
+}
+
+func TestReconcileGracefulShutdownBoundsSavepointWait(t *testing.T) {
+	// given: a cluster whose graceful shutdown times out in a second, with a savepoint which
+	// does not complete
+	var stopped, cancelled []string
+	server := newGracefulShutdownTestServer(t, "IN_PROGRESS", &stopped, &cancelled)
+	defer server.Close()
+	savepointsDir := "s3://bucket/savepoints"
+	var timeoutSeconds int32 = 60
+	cluster := newTestClusterWithJob(&savepointsDir, nil)
+	cluster.Spec.GracefulShutdownTimeoutSeconds = &timeoutSeconds
+	cluster.Status.Conditions = []metav1.Condition{{
+		Type:               v1beta1.ClusterConditionJobsDrained,
+		Status:             metav1.ConditionFalse,
+		Reason:             v1beta1.JobsDrainedReasonDraining,
+		Message:            "Stopping jobs job-456, job-123 with a savepoint",
+		LastTransitionTime: metav1.NewTime(time.Now().Add(-59 * time.Second)),
+	}}
+	reconciler := newTestGracefulShutdown(t, server.URL, cluster)
+
+	// when
+	var start = time.Now()
+	result, err := reconciler.reconcile(context.Background())
+
+	// then: the wait for the savepoint ends at the deadline, not after the savepoint timeout,
+	// and the next job is cancelled without a savepoint
+	requireNoError(t, err)
+	assert.Equal(t, result, requeueResult)
+	assert.Assert(t, time.Since(start) < 10*time.Second)
+	assert.DeepEqual(t, stopped, []string{"job-456"})
+	assert.DeepEqual(t, cancelled, []string{"job-123"})
+}
+
+func TestReconcilePaused(t *testing.T) {
+	var scheme = runtime.NewScheme()
+	assert.NilError(t, v1beta1.AddToScheme(scheme))
+
+	var cluster = &v1beta1.FlinkCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "cluster",
+			Namespace:   "default",
+			Annotations: map[string]string{v1beta1.ReconcilePausedAnnotation: "true"},
+		},
+		Spec: v1beta1.FlinkClusterSpec{Job: &v1beta1.JobSpec{}},
+		Status: v1beta1.FlinkClusterStatus{
+			State: v1beta1.ClusterStateRunning,
+			Components: v1beta1.FlinkClusterComponentsStatus{
+				Job: &v1beta1.JobStatus{State: v1beta1.JobStateFailed},
+			},
+		},
+	}
+	var fakeClient = fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithStatusSubresource(cluster).
+		WithObjects(cluster).
+		Build()
+
+	// given: any mutation other than the status update fails the test
+	var mutations atomic.Int32
+	var interceptedClient = interceptor.NewClient(fakeClient, interceptor.Funcs{
+		Create: func(context.Context, client.WithWatch, client.Object, ...client.CreateOption) error {
+			mutations.Add(1)
+			return errors.New("unexpected create")
+		},
+		Update: func(context.Context, client.WithWatch, client.Object, ...client.UpdateOption) error {
+			mutations.Add(1)
+			return errors.New("unexpected update")
+		},
+		Patch: func(context.Context, client.WithWatch, client.Object, client.Patch, ...client.PatchOption) error {
+			mutations.Add(1)
+			return errors.New("unexpected patch")
+		},
+		Delete: func(context.Context, client.WithWatch, client.Object, ...client.DeleteOption) error {
+			mutations.Add(1)
+			return errors.New("unexpected delete")
+		},
+	})
+	var request = ctrl.Request{NamespacedName: types.NamespacedName{Name: cluster.Name, Namespace: cluster.Namespace}}
+	var handler = FlinkClusterHandler{
+		k8sClient:     interceptedClient,
+		request:       request,
+		eventRecorder: record.NewFakeRecorder(10),
+	}
+
+	// when
+	result, err := handler.reconcile(context.Background(), request)
+
+	// then: only the Paused condition is set, the failed job is left as is
+	assert.NilError(t, err)
+	assert.Assert(t, result.IsZero())
+	assert.Equal(t, mutations.Load(), int32(0))
+	var updated v1beta1.FlinkCluster
+	assert.NilError(t, fakeClient.Get(context.Background(), request.NamespacedName, &updated))
+	assert.Assert(t, meta.IsStatusConditionTrue(updated.Status.Conditions, v1beta1.ClusterConditionPaused))
+	assert.Equal(t, updated.Status.State, v1beta1.ClusterStateRunning)
+	assert.Equal(t, updated.Status.Components.Job.State, v1beta1.JobStateFailed)
+	var resourceVersion = updated.ResourceVersion
+
+	// when: reconciled again while paused
+	handler.observed = ObservedClusterState{}
+	_, err = handler.reconcile(context.Background(), request)
+
+	// then: the status is not updated again
+	assert.NilError(t, err)
+	assert.NilError(t, fakeClient.Get(context.Background(), request.NamespacedName, &updated))
+	assert.Equal(t, updated.ResourceVersion, resourceVersion)
+
+	// when: resumed
+	updated.Annotations = nil
+	var conditions = deriveConditions(&ObservedClusterState{cluster: &updated}, updated.Status.Conditions)
+
+	// then
+	assert.Assert(t, meta.IsStatusConditionFalse(conditions, v1beta1.ClusterConditionPaused))
+}
+
+func TestReconcilePausedClusterDeleted(t *testing.T) {
+	var scheme = runtime.NewScheme()
+	assert.NilError(t, clientgoscheme.AddToScheme(scheme))
+	assert.NilError(t, v1beta1.AddToScheme(scheme))
+
+	var cluster = &v1beta1.FlinkCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "cluster",
+			Namespace:         "default",
+			Annotations:       map[string]string{v1beta1.ReconcilePausedAnnotation: "true"},
+			Finalizers:        []string{v1beta1.TeardownFinalizer},
+			DeletionTimestamp: &metav1.Time{Time: time.Now()},
+		},
+		Spec: v1beta1.FlinkClusterSpec{
+			JobManager:  &v1beta1.JobManagerSpec{AccessScope: v1beta1.AccessScopeCluster},
+			TaskManager: &v1beta1.TaskManagerSpec{DeploymentType: v1beta1.DeploymentTypeStatefulSet},
+		},
+		Status: v1beta1.FlinkClusterStatus{State: v1beta1.ClusterStateRunning},
+	}
+	var fakeClient = fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithStatusSubresource(cluster).
+		WithObjects(cluster).
+		Build()
+	var request = ctrl.Request{NamespacedName: types.NamespacedName{Name: cluster.Name, Namespace: cluster.Namespace}}
+	var handler = FlinkClusterHandler{
+		k8sClient:     fakeClient,
+		request:       request,
+		eventRecorder: record.NewFakeRecorder(10),
+	}
+
+	// when: reconciled until the teardown is completed
+	var err error
+	for i := 0; i < 5 && err == nil; i++ {
+		handler.observed = ObservedClusterState{}
+		_, err = handler.reconcile(context.Background(), request)
+		err = client.IgnoreNotFound(err)
+		if apierrors.IsNotFound(fakeClient.Get(context.Background(), request.NamespacedName, &v1beta1.FlinkCluster{})) {
+			break
+		}
+	}
+
+	// then: the teardown finalizer is removed and the cluster is deleted
+	assert.NilError(t, err)
+	assert.Assert(t, apierrors.IsNotFound(fakeClient.Get(context.Background(), request.NamespacedName, &v1beta1.FlinkCluster{})))
+}
+
+func TestReconcileTaskManagerSchedulingGates(t *testing.T) {
+	var scheme = runtime.NewScheme()
+	assert.NilError(t, corev1.AddToScheme(scheme))
+	var pod = &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "cluster-taskmanager-0", Namespace: "default", Labels: map[string]string{"component": "taskmanager"},
+		},
+		Spec: corev1.PodSpec{SchedulingGates: []corev1.PodSchedulingGate{
+			{Name: "example.com/other"},
+			{Name: v1beta1.JobManagerReadySchedulingGate},
+		}},
+	}
+	var fakeClient = fake.NewClientBuilder().WithScheme(scheme).WithObjects(pod).Build()
+	var getPods = func() *corev1.PodList {
+		var pods = new(corev1.PodList)
+		assert.NilError(t, fakeClient.List(context.Background(), pods))
+		return pods
+	}
+	var reconciler = &ClusterReconciler{
+		k8sClient: fakeClient,
+		observed: ObservedClusterState{
+			cluster:       &v1beta1.FlinkCluster{ObjectMeta: metav1.ObjectMeta{Name: "cluster", Namespace: "default"}},
+			jmStatefulSet: &appsv1.StatefulSet{},
+			pods:          getPods(),
+		},
+	}
+
+	// The gate is kept until the JobManager is ready.
+	assert.NilError(t, reconciler.reconcileTaskManagerSchedulingGates(context.Background()))
+	assert.Equal(t, len(getGatedTaskManagerPods(getPods())), 1)
+
+	reconciler.observed.jmStatefulSet.Status.ReadyReplicas = 1
+	assert.NilError(t, reconciler.reconcileTaskManagerSchedulingGates(context.Background()))
+	var pods = getPods()
+	assert.Equal(t, len(getGatedTaskManagerPods(pods)), 0)
+	assert.DeepEqual(t, pods.Items[0].Spec.SchedulingGates, []corev1.PodSchedulingGate{{Name: "example.com/other"}})
+}
+
+func TestReconcileTaskManagerOrdinals(t *testing.T) {
+	var scheme = runtime.NewScheme()
+	assert.NilError(t, corev1.AddToScheme(scheme))
+	tests := []struct {
+		name              string
+		ordinals          []int32
+		observedReplicas  int32
+		desiredReplicas   int32
+		expectedRemaining []string
+	}{
+		{
+			name:              "scale down drains the highest ordinals",
+			ordinals:          []int32{0, 1, 2, 3, 4},
+			observedReplicas:  2,
+			desiredReplicas:   2,
+			expectedRemaining: []string{"cluster-taskmanager-0", "cluster-taskmanager-1"},
+		},
+		{
+			name:              "scale down with gaps keeps the ordinals below the replicas",
+			ordinals:          []int32{0, 2, 5},
+			observedReplicas:  3,
+			desiredReplicas:   3,
+			expectedRemaining: []string{"cluster-taskmanager-0", "cluster-taskmanager-2"},
+		},
+		{
+			name:              "scale up keeps the pods",
+			ordinals:          []int32{0, 1},
+			observedReplicas:  4,
+			desiredReplicas:   4,
+			expectedRemaining: []string{"cluster-taskmanager-0", "cluster-taskmanager-1"},
+		},
+		{
+			name:              "scale down is not applied yet",
+			ordinals:          []int32{0, 1, 2},
+			observedReplicas:  3,
+			desiredReplicas:   1,
+			expectedRemaining: []string{"cluster-taskmanager-0", "cluster-taskmanager-1", "cluster-taskmanager-2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var builder = fake.NewClientBuilder().WithScheme(scheme)
+			for _, ordinal := range tt.ordinals {
+				builder.WithObjects(&corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+					Name:      getTaskManagerPodName("cluster", ordinal),
+					Namespace: "default",
+					Labels:    map[string]string{"component": "taskmanager"},
+				}})
+			}
+			var fakeClient = builder.Build()
+			var getPodNames = func() []string {
+				var pods corev1.PodList
+				assert.NilError(t, fakeClient.List(context.Background(), &pods))
+				var names []string
+				for _, pod := range pods.Items {
+					names = append(names, pod.Name)
+				}
+				return names
+			}
+			var pods corev1.PodList
+			assert.NilError(t, fakeClient.List(context.Background(), &pods))
+			var reconciler = &ClusterReconciler{
+				k8sClient: fakeClient,
+				observed: ObservedClusterState{
+					cluster:       &v1beta1.FlinkCluster{ObjectMeta: metav1.ObjectMeta{Name: "cluster", Namespace: "default"}},
+					tmStatefulSet: &appsv1.StatefulSet{Spec: appsv1.StatefulSetSpec{Replicas: &tt.observedReplicas}},
+					pods:          &pods,
+				},
+				desired: model.DesiredClusterState{
+					TmStatefulSet: &appsv1.StatefulSet{Spec: appsv1.StatefulSetSpec{Replicas: &tt.desiredReplicas}},
+				},
+			}
+
+			assert.NilError(t, reconciler.reconcileTaskManagerOrdinals(context.Background()))
+			assert.DeepEqual(t, getPodNames(), tt.expectedRemaining)
+		})
+	}
+}
+
+func TestReconcileJobManagerReplicaDrift(t *testing.T) {
+	var scheme = runtime.NewScheme()
+	assert.NilError(t, appsv1.AddToScheme(scheme))
+	var warn = v1beta1.ReplicaDriftPolicyWarn
+	tests := []struct {
+		name             string
+		policy           *v1beta1.ReplicaDriftPolicy
+		expectedReplicas int32
+		expectedEvent    string
+	}{
+		{
+			name:             "scaled back",
+			expectedReplicas: 1,
+			expectedEvent:    "Warning JobManagerReplicaDrift JobManager StatefulSet cluster-jobmanager was scaled to 0 replicas, scaling it back to 1",
+		},
+		{
+			// The drift is reported by the condition of the status updater.
+			name:             "warn only",
+			policy:           &warn,
+			expectedReplicas: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var replicas int32 = 1
+			var scaledDown int32 = 0
+			var cluster = &v1beta1.FlinkCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster", Namespace: "default"},
+				Spec: v1beta1.FlinkClusterSpec{
+					JobManager: &v1beta1.JobManagerSpec{Replicas: &replicas, ReplicaDriftPolicy: tt.policy},
+				},
+			}
+			var sts = &appsv1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster-jobmanager", Namespace: "default"},
+				Spec:       appsv1.StatefulSetSpec{Replicas: &scaledDown},
+			}
+			var fakeClient = fake.NewClientBuilder().WithScheme(scheme).WithObjects(sts).Build()
+			var observedSts appsv1.StatefulSet
+			assert.NilError(t, fakeClient.Get(context.Background(), client.ObjectKeyFromObject(sts), &observedSts))
+			var recorder = record.NewFakeRecorder(10)
+			var reconciler = &ClusterReconciler{
+				k8sClient: fakeClient,
+				observed:  ObservedClusterState{cluster: cluster, jmStatefulSet: &observedSts},
+				recorder:  recorder,
+			}
+
+			assert.NilError(t, reconciler.reconcileJobManagerReplicaDrift(context.Background()))
+
+			var updated appsv1.StatefulSet
+			assert.NilError(t, fakeClient.Get(context.Background(), client.ObjectKeyFromObject(sts), &updated))
+			assert.Equal(t, *updated.Spec.Replicas, tt.expectedReplicas)
+			if tt.expectedEvent == "" {
+				assert.Equal(t, len(recorder.Events), 0)
+			} else {
+				assert.Equal(t, <-recorder.Events, tt.expectedEvent)
+			}
+		})
+	}
+}
@@ -161,6 +161,76 @@ function submit_job() {
     return 0
 }
 
+# Uploads the jar through the REST API of the JobManager and prints its ID, the base name of the uploaded file.
+function upload_jar() {
+    local -r jar_file="$1"
+
+    echo "curl -sS -X POST -F \"jarfile=@${jar_file}\" \"http://${FLINK_JM_ADDR}/jars/upload\"" | tee -a submit_log >&2
+    local -r response=$(curl -sS -X POST -H "Expect:" -F "jarfile=@${jar_file}" "http://${FLINK_JM_ADDR}/jars/upload" 2>&1)
+    echo "${response}" | tee -a submit_log >&2
+    echo "${response}" | grep -o '"filename":"[^"]*"' | sed -e 's/^"filename":"//' -e 's/"$//' -e 's|.*/||'
+}
+
+# Runs the uploaded jar by the request of the operator and prints the ID of the job.
+function run_jar() {
+    local -r jar_id="$1"
+
+    echo "curl -sS -X POST -d '${FLINK_JAR_RUN_REQUEST}' \"http://${FLINK_JM_ADDR}/jars/${jar_id}/run\"" | tee -a submit_log >&2
+    local -r response=$(curl -sS -X POST -H "Content-Type: application/json" -d "${FLINK_JAR_RUN_REQUEST}" \
+        "http://${FLINK_JM_ADDR}/jars/${jar_id}/run" 2>&1)
+    echo "${response}" | tee -a submit_log >&2
+    echo "${response}" | grep -o '"jobid":"[^"]*"' | sed -e 's/^"jobid":"//' -e 's/"$//'
+}
+
+# Submits the job by running the jar uploaded through the REST API. The jar uploaded by the previous
+# submission is run again if its content hash is the same, and uploaded again when it changed or
+# the JobManager no longer has it, e.g., after it restarted.
+function submit_job_rest() {
+    local jar_file="$1"
+    local jar_id=""
+    local job_id=""
+
+    case "${jar_file}" in
+    http://* | https://*)
+        local -r download="/tmp/$(basename "${jar_file}")"
+        echo "curl -sSfL -o ${download} ${jar_file}" | tee -a submit_log
+        if ! curl -sSfL -o "${download}" "${jar_file}" 2>&1 | tee -a submit_log; then
+            write_term_log_msg "Failed to download the jar." "submit_log"
+            return 1
+        fi
+        jar_file="${download}"
+        ;;
+    file://*)
+        jar_file="${jar_file#file://}"
+        ;;
+    esac
+
+    local -r jar_hash=$(sha256sum "${jar_file}" | awk '{print $1}')
+    if [[ -n ${FLINK_CACHED_JAR_ID:-} && ${FLINK_CACHED_JAR_HASH:-} == "${jar_hash}" ]]; then
+        echo "Running the jar uploaded before, ${FLINK_CACHED_JAR_ID}" | tee -a submit_log
+        jar_id="${FLINK_CACHED_JAR_ID}"
+        job_id=$(run_jar "${jar_id}")
+    fi
+    if [[ -z ${job_id} ]]; then
+        jar_id=$(upload_jar "${jar_file}")
+        if [[ -z ${jar_id} ]]; then
+            write_term_log_msg "Failed to upload the jar." "submit_log"
+            return 1
+        fi
+        job_id=$(run_jar "${jar_id}")
+    fi
+    if [[ -z ${job_id} ]]; then
+        write_term_log_msg "Failed to submit." "submit_log"
+        return 1
+    fi
+
+    echo "Running the jar with JarID ${jar_id} JarHash ${jar_hash}" | tee -a submit_log
+    echo "Job has been submitted with JobID ${job_id}" | tee -a submit_log
+    write_term_log "jobID: ${job_id}"
+    write_term_log_msg "Successfully submitted!" "submit_log"
+    return 0
+}
+
 function main() {
     echo -e "---------- Checking job manager status ----------"
     if ! check_jm_ready; then
@@ -169,7 +239,11 @@ function main() {
 
     echo -e "\n---------- Submitting job ----------"
     set +e
-    submit_job "$@"
+    if [[ -n ${FLINK_JAR_RUN_REQUEST:-} ]]; then
+        submit_job_rest "$@"
+    else
+        submit_job "$@"
+    fi
     submit_job_result=$?
     set -e
     exit $submit_job_result
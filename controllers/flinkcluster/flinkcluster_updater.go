@@ -24,17 +24,22 @@ import (
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	batchv1 "k8s.io/api/batch/v1"
 
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 
 	"github.com/go-logr/logr"
 	v1beta1 "github.com/spotify/flink-on-k8s-operator/apis/flinkcluster/v1beta1"
+	"github.com/spotify/flink-on-k8s-operator/internal/flink"
 	"github.com/spotify/flink-on-k8s-operator/internal/util"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -118,6 +123,18 @@ func (updater *ClusterStatusUpdater) createStatusChangeEvents(
 			newStatus.Components.JobManager.State)
 	}
 
+	// JobManager QoS class.
+	if jm := newStatus.Components.JobManager; jm != nil && jm.QoSClass == corev1.PodQOSBestEffort &&
+		(oldStatus.Components.JobManager == nil || oldStatus.Components.JobManager.QoSClass != jm.QoSClass) &&
+		!updater.observed.cluster.IsHighAvailabilityEnabled() {
+		updater.recorder.Event(
+			updater.observed.cluster,
+			"Warning",
+			"JobManagerBestEffort",
+			"JobManager pods have BestEffort QoS without high availability, they are the first to be evicted "+
+				"under node pressure and the cluster is lost with them; set JobManager resources")
+	}
+
 	// ConfigMap.
 	if oldStatus.Components.ConfigMap != nil &&
 		newStatus.Components.ConfigMap != nil &&
@@ -186,6 +203,131 @@ func (updater *ClusterStatusUpdater) createStatusChangeEvents(
 		updater.recorder.Event(updater.observed.cluster, eventType, eventReason, eventMessage)
 	}
 
+	// Stale savepoint.
+	if oldJob, newJob := oldStatus.Components.Job, newStatus.Components.Job; updater.observed.staleSavepoint &&
+		oldJob != nil && newJob != nil && oldJob.SavepointLocation != "" && newJob.SavepointLocation == "" {
+		updater.recorder.Event(
+			updater.observed.cluster,
+			"Warning",
+			"StaleSavepointCleared",
+			fmt.Sprintf("Savepoint %s was recorded at %s before the job was restored from another savepoint "+
+				"and doesn't belong to the current job run, cleared it from the job status",
+				oldJob.SavepointLocation, oldJob.SavepointTime))
+	}
+
+	// Poison savepoint.
+	if oldJob, newJob := oldStatus.Components.Job, newStatus.Components.Job; oldJob != nil && newJob != nil &&
+		len(newJob.PoisonSavepoints) > 0 && !slices.Equal(oldJob.PoisonSavepoints, newJob.PoisonSavepoints) {
+		var poison = newJob.PoisonSavepoints[len(newJob.PoisonSavepoints)-1]
+		var message = fmt.Sprintf("The job failed %d times in a row after restoring from savepoint %s, marked it poison",
+			newJob.RestoreFailureCount, poison)
+		if restoreSavepoint := newJob.RestoreSavepoint(); !slices.Contains(newJob.PoisonSavepoints, restoreSavepoint) {
+			message += fmt.Sprintf(", the job is restarted from savepoint %s", restoreSavepoint)
+		} else {
+			message += ", there is no other savepoint in the savepoint inventory to restore from and the job is not restarted"
+		}
+		updater.recorder.Event(updater.observed.cluster, "Warning", "PoisonSavepoint", message)
+	}
+
+	// Savepoint wait.
+	if timedOut := meta.FindStatusCondition(newStatus.Conditions, v1beta1.ClusterConditionSavepointAvailable); timedOut != nil &&
+		timedOut.Reason == v1beta1.SavepointAvailableReasonTimedOut &&
+		!reflect.DeepEqual(meta.FindStatusCondition(oldStatus.Conditions, timedOut.Type), timedOut) {
+		updater.recorder.Event(updater.observed.cluster, "Warning", "SavepointWaitTimedOut", timedOut.Message)
+	}
+
+	// Image architecture.
+	if conflict := meta.FindStatusCondition(newStatus.Conditions, v1beta1.ClusterConditionImageArchitectureConflict); conflict != nil &&
+		conflict.Status == metav1.ConditionTrue &&
+		!meta.IsStatusConditionTrue(oldStatus.Conditions, conflict.Type) {
+		updater.recorder.Event(updater.observed.cluster, "Warning", "ImageArchitectureConflict", conflict.Message)
+	}
+
+	// Unexpected completion of the streaming job.
+	if completion := meta.FindStatusCondition(newStatus.Conditions, v1beta1.ClusterConditionUnexpectedJobCompletion); completion != nil &&
+		completion.Status == metav1.ConditionTrue &&
+		!meta.IsStatusConditionTrue(oldStatus.Conditions, completion.Type) {
+		updater.recorder.Event(updater.observed.cluster, "Warning", "UnexpectedJobCompletion", completion.Message)
+	}
+
+	// JobManager replicas drifted from the spec and left as is.
+	if drift := meta.FindStatusCondition(newStatus.Conditions, v1beta1.ClusterConditionJobManagerReplicaDrift); drift != nil &&
+		drift.Status == metav1.ConditionTrue &&
+		!meta.IsStatusConditionTrue(oldStatus.Conditions, drift.Type) {
+		updater.recorder.Event(updater.observed.cluster, "Warning", "JobManagerReplicaDrift", drift.Message)
+	}
+
+	// Operator UIDs removed from the restored job. Every restored job run with removed UIDs is reported.
+	if uidsChanged := meta.FindStatusCondition(newStatus.Conditions, v1beta1.ClusterConditionOperatorUIDsChanged); uidsChanged != nil &&
+		uidsChanged.Status == metav1.ConditionTrue {
+		if oldUIDsChanged := meta.FindStatusCondition(oldStatus.Conditions, uidsChanged.Type); oldUIDsChanged == nil ||
+			oldUIDsChanged.Status != metav1.ConditionTrue || oldUIDsChanged.Message != uidsChanged.Message {
+			updater.recorder.Event(updater.observed.cluster, "Warning", "OperatorUIDsChanged", uidsChanged.Message)
+		}
+	}
+
+	// JobManager leader.
+	if leader, oldLeader := newStatus.JobManagerLeader, oldStatus.JobManagerLeader; leader != nil && oldLeader != nil {
+		var now = updater.observed.observeTime
+		if leader.Identity != oldLeader.Identity {
+			updater.recorder.Event(
+				updater.observed.cluster,
+				"Normal",
+				"JobManagerLeaderChanged",
+				fmt.Sprintf("JobManager leader changed from %s to %s", leaderName(oldLeader), leaderName(leader)))
+		}
+		if leader.IsFlapping(now) && !oldLeader.IsFlapping(now) {
+			updater.recorder.Event(
+				updater.observed.cluster,
+				"Warning",
+				"JobManagerLeaderFlapping",
+				fmt.Sprintf("JobManager leader changed %d times within %v, the JobManagers repeatedly lose the leadership; "+
+					"check the JobManager restarts, the API server latency and high-availability.kubernetes.leader-election.lease-duration",
+					v1beta1.JobManagerLeaderChurnThreshold, v1beta1.JobManagerLeaderChurnWindow))
+		}
+	}
+
+	// Checkpoint alignment.
+	var wasAlignmentHigh = oldStatus.CheckpointAlignment != nil && oldStatus.CheckpointAlignment.High
+	if alignment := newStatus.CheckpointAlignment; alignment != nil && alignment.High && !wasAlignmentHigh {
+		var threshold = getCheckpointAlignmentThreshold(updater.observed.cluster)
+		updater.recorder.Event(
+			updater.observed.cluster,
+			"Warning",
+			"CheckpointAlignmentHigh",
+			fmt.Sprintf("Checkpoint alignment took %v or longer for the last %d checkpoints, "+
+				"the job is likely backpressured and checkpoints may start to time out",
+				threshold, highCheckpointAlignmentSamples))
+	}
+
+	// Slot registration.
+	if registration := newStatus.SlotRegistration; registration != nil {
+		var wasLagging, wasUnhealthy []string
+		if oldStatus.SlotRegistration != nil {
+			wasLagging = oldStatus.SlotRegistration.LaggingGroups
+			wasUnhealthy = oldStatus.SlotRegistration.UnhealthyGroups
+		}
+		for _, group := range registration.Groups {
+			if slices.Contains(registration.LaggingGroups, group.Name) && !slices.Contains(wasLagging, group.Name) {
+				updater.recorder.Event(
+					updater.observed.cluster,
+					"Warning",
+					"SlotRegistrationLagging",
+					fmt.Sprintf("TaskManager group %s registered %d of %d slots for over %d seconds, "+
+						"the job may not be fully deployable",
+						group.Name, group.RegisteredSlots, group.ExpectedSlots, slotRegistrationGracePeriodSeconds))
+			}
+			if !group.Healthy && !slices.Contains(wasUnhealthy, group.Name) {
+				var message = fmt.Sprintf("TaskManager group %s is unhealthy, %d of %d pods ready",
+					group.Name, group.ReadyReplicas, group.Replicas)
+				if group.LastError != "" {
+					message += ", last error: " + group.LastError
+				}
+				updater.recorder.Event(updater.observed.cluster, "Warning", "TaskManagerGroupUnhealthy", message)
+			}
+		}
+	}
+
 	// Control.
 	if newStatus.Control != nil && !reflect.DeepEqual(oldStatus.Control, newStatus.Control) {
 		eventType, eventReason, eventMessage := getControlEvent(*newStatus.Control)
@@ -250,6 +392,8 @@ func (updater *ClusterStatusUpdater) deriveClusterStatus(
 		}
 	}
 
+	var jmQoS, tmQoS = cluster.EffectiveQoSClass()
+
 	// JobManager StatefulSet.
 	var observedJmStatefulSet = observed.jmStatefulSet
 	jmStatus := &status.Components.JobManager
@@ -265,6 +409,7 @@ func (updater *ClusterStatusUpdater) deriveClusterStatus(
 				Replicas:      observedJmStatefulSet.Status.Replicas,
 				ReadyReplicas: observedJmStatefulSet.Status.ReadyReplicas,
 				Ready:         fmt.Sprintf("%d/%d", observedJmStatefulSet.Status.ReadyReplicas, observedJmStatefulSet.Status.Replicas),
+				QoSClass:      jmQoS,
 			}
 			if (*jmStatus).State == v1beta1.ComponentStateReady {
 				runningComponents++
@@ -426,6 +571,7 @@ func (updater *ClusterStatusUpdater) deriveClusterStatus(
 				ReadyReplicas: observedTmStatefulSet.Status.ReadyReplicas,
 				Ready:         fmt.Sprintf("%d/%d", observedTmStatefulSet.Status.ReadyReplicas, observedTmStatefulSet.Status.Replicas),
 				Selector:      labelSelector.String(),
+				QoSClass:      tmQoS,
 			}
 			if (*tmStatus).State == v1beta1.ComponentStateReady {
 				runningComponents++
@@ -452,6 +598,7 @@ func (updater *ClusterStatusUpdater) deriveClusterStatus(
 				ReadyReplicas: observedTmDeployment.Status.ReadyReplicas,
 				Ready:         fmt.Sprintf("%d/%d", observedTmDeployment.Status.ReadyReplicas, observedTmDeployment.Status.Replicas),
 				Selector:      labelSelector.String(),
+				QoSClass:      tmQoS,
 			}
 			if (*tmStatus).State == v1beta1.ComponentStateReady {
 				runningComponents++
@@ -471,10 +618,10 @@ func (updater *ClusterStatusUpdater) deriveClusterStatus(
 			status.State = v1beta1.ClusterStateCreating
 			if jobStatus.IsStopped() {
 				var policy = observed.cluster.Spec.Job.CleanupPolicy
-				if jobStatus.State == v1beta1.JobStateSucceeded &&
+				if jobStatus.State == v1beta1.JobStateSucceeded && !jobStatus.IsUnexpectedCompletion(observed.cluster.Spec.Job) &&
 					policy.AfterJobSucceeds != v1beta1.CleanupActionKeepCluster {
 					status.State = v1beta1.ClusterStateStopping
-				} else if jobStatus.IsFailed() &&
+				} else if (jobStatus.IsFailed() || jobStatus.IsUnexpectedCompletion(observed.cluster.Spec.Job)) &&
 					policy.AfterJobFails != v1beta1.CleanupActionKeepCluster {
 					status.State = v1beta1.ClusterStateStopping
 				} else if jobStatus.State == v1beta1.JobStateCancelled &&
@@ -503,10 +650,10 @@ func (updater *ClusterStatusUpdater) deriveClusterStatus(
 			status.State = v1beta1.ClusterStateUpdating
 		} else if !recorded.Revision.IsUpdateTriggered() && jobStatus.IsStopped() {
 			var policy = observed.cluster.Spec.Job.CleanupPolicy
-			if jobStatus.State == v1beta1.JobStateSucceeded &&
+			if jobStatus.State == v1beta1.JobStateSucceeded && !jobStatus.IsUnexpectedCompletion(observed.cluster.Spec.Job) &&
 				policy.AfterJobSucceeds != v1beta1.CleanupActionKeepCluster {
 				status.State = v1beta1.ClusterStateStopping
-			} else if jobStatus.IsFailed() &&
+			} else if (jobStatus.IsFailed() || jobStatus.IsUnexpectedCompletion(observed.cluster.Spec.Job)) &&
 				policy.AfterJobFails != v1beta1.CleanupActionKeepCluster {
 				status.State = v1beta1.ClusterStateStopping
 			} else if jobStatus.State == v1beta1.JobStateCancelled &&
@@ -553,16 +700,49 @@ func (updater *ClusterStatusUpdater) deriveClusterStatus(
 	// Update savepoint status if it is in progress or requested.
 	var newJobStatus = status.Components.Job
 	status.Savepoint = updater.deriveSavepointStatus(
-		&observed.savepoint,
+		observed,
 		recorded.Savepoint,
 		newJobStatus,
 		updater.getFlinkJobID())
 
+	// (Optional) Savepoint inventory.
+	status.SavepointInventory = deriveSavepointInventory(
+		observed.cluster,
+		&observed.savepoint,
+		recorded.Savepoint,
+		recorded.SavepointInventory)
+
+	// (Optional) Checkpoint alignment.
+	status.CheckpointAlignment = deriveCheckpointAlignment(
+		observed.cluster,
+		observed.checkpointAlignment,
+		recorded.CheckpointAlignment)
+
+	// (Optional) Slot registration of the TaskManagers.
+	status.SlotRegistration = deriveSlotRegistration(
+		observed.slotRegistration,
+		recorded.SlotRegistration,
+		observed.observeTime)
+
+	// (Optional) Leader JobManager with the Kubernetes high availability.
+	status.JobManagerLeader = deriveJobManagerLeader(observed, recorded.JobManagerLeader)
+
+	// (Optional) Coordinated savepoint of session jobs.
+	status.CoordinatedSavepoint = deriveCoordinatedSavepointStatus(
+		observed.coordinatedSavepoints,
+		recorded.CoordinatedSavepoint)
+	status.SavepointInventory = addCoordinatedSavepointRecords(
+		observed.cluster,
+		recorded.CoordinatedSavepoint,
+		status.CoordinatedSavepoint,
+		status.SavepointInventory)
+
 	// (Optional) Control.
 	// Update user requested control status.
 	status.Control = deriveControlStatus(
 		observed.cluster,
 		status.Savepoint,
+		status.CoordinatedSavepoint,
 		status.Components.Job,
 		recorded.Control)
 
@@ -573,9 +753,610 @@ func (updater *ClusterStatusUpdater) deriveClusterStatus(
 		&observed.revision,
 		&recorded.Revision)
 
+	// Update conditions.
+	status.Conditions = deriveConditions(observed, recorded.Conditions)
+	if unexpectedCompletion := deriveUnexpectedJobCompletionCondition(observed, status.Components.Job, status.Conditions); unexpectedCompletion != nil {
+		meta.SetStatusCondition(&status.Conditions, *unexpectedCompletion)
+	}
+	if uidsChanged := deriveOperatorUIDsChangedCondition(observed, status.Components.Job, status.Conditions); uidsChanged != nil {
+		meta.SetStatusCondition(&status.Conditions, *uidsChanged)
+	}
+	if replicaDrift := deriveJobManagerReplicaDriftCondition(observed, status.Conditions); replicaDrift != nil {
+		meta.SetStatusCondition(&status.Conditions, *replicaDrift)
+	}
+	meta.SetStatusCondition(&status.Conditions, derivePendingActionCondition(observed, &status))
+
+	status.LastObservabilityPollTime = deriveLastObservabilityPollTime(observed, recorded.LastObservabilityPollTime)
+
 	return status
 }
 
+// Adds the observed successful savepoint to the recorded savepoint inventory.
+func deriveSavepointInventory(
+	cluster *v1beta1.FlinkCluster,
+	observedSavepoint *Savepoint,
+	recordedSavepoint *v1beta1.SavepointStatus,
+	recorded []v1beta1.SavepointRecord) []v1beta1.SavepointRecord {
+	var inventory []v1beta1.SavepointRecord
+	for _, r := range recorded {
+		inventory = append(inventory, *r.DeepCopy())
+	}
+	if observedSavepoint.status == nil || !observedSavepoint.status.IsSuccessful() || recordedSavepoint == nil {
+		return inventory
+	}
+	var record = v1beta1.SavepointRecord{
+		Location:      observedSavepoint.status.Location,
+		JobID:         recordedSavepoint.JobID,
+		TriggerReason: recordedSavepoint.TriggerReason,
+		FlinkVersion:  cluster.Spec.FlinkVersion,
+		SizeBytes:     observedSavepoint.size,
+	}
+	util.SetTimestamp(&record.Time)
+	if recordedSavepoint.TriggerTime != "" {
+		var duration = int64(util.GetTime(record.Time).Sub(util.GetTime(recordedSavepoint.TriggerTime)).Seconds())
+		record.DurationSeconds = &duration
+	}
+	return v1beta1.AddSavepointRecord(inventory, record)
+}
+
+// Tracks how long the slot registration of each TaskManager group has been lagging, and how
+// long each group has been unhealthy, and reports the groups lagging or unhealthy past the
+// grace period, so that the transient lag of a normal startup or rolling update is not reported.
+func deriveSlotRegistration(
+	observed *v1beta1.SlotRegistrationStatus,
+	recorded *v1beta1.SlotRegistrationStatus,
+	now time.Time) *v1beta1.SlotRegistrationStatus {
+	if observed == nil {
+		return recorded.DeepCopy()
+	}
+	if len(observed.Groups) == 0 {
+		return nil
+	}
+
+	var laggingSince = make(map[string]string)
+	var unhealthySince = make(map[string]string)
+	if recorded != nil {
+		for _, g := range recorded.Groups {
+			laggingSince[g.Name] = g.LaggingSince
+			unhealthySince[g.Name] = g.UnhealthySince
+		}
+	}
+	var tc = &util.TimeConverter{}
+	var registration = &v1beta1.SlotRegistrationStatus{}
+	for _, g := range observed.Groups {
+		var group = g
+		var lagging = isTaskManagerGroupLagging(group)
+		group.LaggingSince = ""
+		if lagging {
+			group.LaggingSince = laggingSince[group.Name]
+			if group.LaggingSince == "" {
+				group.LaggingSince = tc.ToString(now)
+			}
+			if util.HasTimeElapsed(group.LaggingSince, now, slotRegistrationGracePeriodSeconds) {
+				registration.LaggingGroups = append(registration.LaggingGroups, group.Name)
+			}
+		}
+		group.UnhealthySince = ""
+		group.Healthy = true
+		if !isTaskManagerGroupHealthy(group, lagging) {
+			group.UnhealthySince = unhealthySince[group.Name]
+			if group.UnhealthySince == "" {
+				group.UnhealthySince = tc.ToString(now)
+			}
+			group.Healthy = !util.HasTimeElapsed(group.UnhealthySince, now, taskManagerGroupUnhealthyGracePeriodSeconds)
+		}
+		registration.Groups = append(registration.Groups, group)
+	}
+	_, registration.UnhealthyGroups = getTaskManagerHealth(registration.Groups)
+	return registration
+}
+
+// Derives the leader JobManager from the leader election in the HA ConfigMap. While no
+// JobManager is leader, e.g., in the middle of a failover, the last leader is kept, so that
+// the failover is recorded as a single change once the next leader is elected.
+func deriveJobManagerLeader(observed *ObservedClusterState, recorded *v1beta1.JobManagerLeaderStatus) *v1beta1.JobManagerLeaderStatus {
+	if !observed.cluster.IsKubernetesHighAvailability() {
+		return nil
+	}
+	var identity = getHALeaderIdentity(observed.haConfigMap)
+	if identity == "" {
+		return recorded.DeepCopy()
+	}
+	var leader = &v1beta1.JobManagerLeaderStatus{}
+	if recorded != nil {
+		leader = recorded.DeepCopy()
+	}
+	if leader.Identity != identity {
+		var tc = &util.TimeConverter{}
+		var now = tc.ToString(observed.observeTime)
+		if leader.Identity != "" {
+			leader.RecentChanges = append(leader.RecentChanges, now)
+			if n := len(leader.RecentChanges); n > v1beta1.JobManagerLeaderChangeLimit {
+				leader.RecentChanges = leader.RecentChanges[n-v1beta1.JobManagerLeaderChangeLimit:]
+			}
+		}
+		leader.Identity = identity
+		leader.ChangeTime = now
+		leader.Pod = ""
+	}
+	if pod := getHALeaderPod(observed.haConfigMap, observed.pods); pod != "" {
+		leader.Pod = pod
+	}
+	return leader
+}
+
+// Names the leader JobManager by its pod, or by its identity when the pod is not resolved.
+func leaderName(leader *v1beta1.JobManagerLeaderStatus) string {
+	if leader.Pod != "" {
+		return leader.Pod
+	}
+	return leader.Identity
+}
+
+// Adds the observed checkpoint alignment sample to the recorded ones and flags sustained
+// high alignment.
+func deriveCheckpointAlignment(
+	cluster *v1beta1.FlinkCluster,
+	observedSample *v1beta1.CheckpointAlignmentSample,
+	recorded *v1beta1.CheckpointAlignmentStatus) *v1beta1.CheckpointAlignmentStatus {
+	if observedSample == nil {
+		return recorded.DeepCopy()
+	}
+	var alignment = &v1beta1.CheckpointAlignmentStatus{}
+	if recorded != nil {
+		alignment = recorded.DeepCopy()
+	}
+	alignment.Samples = append(alignment.Samples, *observedSample)
+	if n := len(alignment.Samples); n > v1beta1.CheckpointAlignmentSampleLimit {
+		alignment.Samples = alignment.Samples[n-v1beta1.CheckpointAlignmentSampleLimit:]
+	}
+	alignment.High = isCheckpointAlignmentHigh(
+		alignment.Samples,
+		getCheckpointAlignmentThreshold(cluster),
+		cluster.ParsedFlinkConfig().UnalignedCheckpoints())
+	return alignment
+}
+
+// Records the time of the sampled poll, which is only tracked when sampling is enabled.
+func deriveLastObservabilityPollTime(observed *ObservedClusterState, recorded string) string {
+	var interval = observed.cluster.Spec.ObservabilitySamplingSeconds
+	if interval == nil || *interval == 0 {
+		return ""
+	}
+	if observed.observabilityPollDue && observed.flinkConfig != nil {
+		var tc = &util.TimeConverter{}
+		return tc.ToString(observed.observeTime)
+	}
+	return recorded
+}
+
+// Derives the effective classloader resolve order of the job from the running Flink config.
+// Until the config is observed, the recorded value is kept and the spec is reported at first.
+func deriveClassloaderResolveOrder(observed *ObservedClusterState, recorded string) string {
+	if observed.flinkConfig != nil {
+		if v := strings.TrimSpace(observed.flinkConfig["classloader.resolve-order"]); v != "" {
+			return strings.ToLower(v)
+		}
+		return string(v1beta1.ClassloaderResolveOrderChildFirst)
+	}
+	if recorded != "" {
+		return recorded
+	}
+	return string(observed.cluster.ClassloaderResolveOrder())
+}
+
+// Derives the cluster conditions from the recorded ones, a condition is kept as is
+// when it cannot be derived from the current observation.
+func deriveConditions(observed *ObservedClusterState, recorded []metav1.Condition) []metav1.Condition {
+	var conditions []metav1.Condition
+	for _, c := range recorded {
+		conditions = append(conditions, *c.DeepCopy())
+	}
+	if configDrift := deriveConfigDriftCondition(observed); configDrift != nil {
+		meta.SetStatusCondition(&conditions, *configDrift)
+	}
+	if podsUnschedulable := derivePodsUnschedulableCondition(observed); podsUnschedulable != nil {
+		meta.SetStatusCondition(&conditions, *podsUnschedulable)
+	}
+	if observed.cluster.NodeArchitecture() == "" {
+		meta.RemoveStatusCondition(&conditions, v1beta1.ClusterConditionImageArchitectureConflict)
+	} else if archConflict := deriveImageArchitectureConflictCondition(observed); archConflict != nil {
+		meta.SetStatusCondition(&conditions, *archConflict)
+	}
+	if rpcUnreachable := deriveTaskManagerRPCUnreachableCondition(observed, conditions); rpcUnreachable != nil {
+		meta.SetStatusCondition(&conditions, *rpcUnreachable)
+	}
+	if savepointAvailable := deriveSavepointAvailableCondition(observed); savepointAvailable != nil {
+		// The wait restarts when the spec is changed, e.g., to retry after it timed out.
+		if recorded := meta.FindStatusCondition(conditions, savepointAvailable.Type); recorded != nil &&
+			recorded.ObservedGeneration != savepointAvailable.ObservedGeneration {
+			meta.RemoveStatusCondition(&conditions, savepointAvailable.Type)
+		}
+		meta.SetStatusCondition(&conditions, *savepointAvailable)
+	}
+	if savepointSkipped := deriveSavepointSkippedCondition(observed); savepointSkipped != nil {
+		meta.SetStatusCondition(&conditions, *savepointSkipped)
+	} else if recorded := meta.FindStatusCondition(conditions, v1beta1.ClusterConditionSavepointSkipped); recorded != nil &&
+		isJobUpdateTriggered(observed) && recorded.ObservedGeneration != observed.cluster.Generation {
+		// A later update takes the savepoint.
+		meta.RemoveStatusCondition(&conditions, v1beta1.ClusterConditionSavepointSkipped)
+	}
+	// Reconciliation is resumed.
+	if meta.IsStatusConditionTrue(conditions, v1beta1.ClusterConditionPaused) {
+		meta.SetStatusCondition(&conditions, newPausedCondition(observed.cluster, false))
+	}
+	return conditions
+}
+
+// Summarizes the next action the operator takes on the cluster from the derived status, in
+// the order the reconciler gets to them: a failed job is restarted before an update is
+// applied, and an update is applied before a requested savepoint is taken. The savepoint and
+// the scheduling waits are taken from their conditions, which only report them after a while.
+func derivePendingActionCondition(observed *ObservedClusterState, status *v1beta1.FlinkClusterStatus) metav1.Condition {
+	var cluster = observed.cluster
+	var job = status.Components.Job
+	var condition = metav1.Condition{
+		Type:               v1beta1.ClusterConditionPendingAction,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: cluster.Generation,
+	}
+
+	var savepointWait = meta.FindStatusCondition(status.Conditions, v1beta1.ClusterConditionSavepointAvailable)
+	var unschedulable = meta.FindStatusCondition(status.Conditions, v1beta1.ClusterConditionPodsUnschedulable)
+	switch {
+	case job.ShouldRestart(cluster.Spec.Job, observed.observeTime):
+		condition.Reason = v1beta1.PendingActionReasonRestartJob
+		condition.Message = "Restarting the failed job"
+		if job.IsUnexpectedCompletion(cluster.Spec.Job) {
+			condition.Message = "Restarting the completed streaming job"
+		}
+		if savepoint := job.RestoreSavepoint(); savepoint != "" {
+			condition.Message += " from savepoint " + savepoint
+		}
+	case observed.updateState == UpdateStatePreparing:
+		condition.Reason = v1beta1.PendingActionReasonUpdate
+		condition.Message = fmt.Sprintf("Preparing to update the cluster to revision %s", status.Revision.NextRevision)
+	case observed.updateState == UpdateStateInProgress:
+		condition.Reason = v1beta1.PendingActionReasonUpdate
+		condition.Message = fmt.Sprintf("Updating the cluster to revision %s", status.Revision.NextRevision)
+	case status.Savepoint != nil && status.Savepoint.State == v1beta1.SavepointStateInProgress:
+		condition.Reason = v1beta1.PendingActionReasonSavepoint
+		condition.Message = fmt.Sprintf("Savepoint %s is in progress", status.Savepoint.TriggerID)
+	case savepointWait != nil && savepointWait.Reason == v1beta1.SavepointAvailableReasonWaiting:
+		condition.Reason = v1beta1.PendingActionReasonWaitForSavepoint
+		condition.Message = savepointWait.Message
+	case unschedulable != nil && unschedulable.Status == metav1.ConditionTrue:
+		condition.Reason = v1beta1.PendingActionReasonWaitForScheduling
+		condition.Message = "Waiting for the pods to be scheduled, " + unschedulable.Message
+	default:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = v1beta1.PendingActionReasonNone
+		condition.Message = "No action is pending"
+	}
+	return condition
+}
+
+func newPausedCondition(cluster *v1beta1.FlinkCluster, paused bool) metav1.Condition {
+	if paused {
+		return metav1.Condition{
+			Type:               v1beta1.ClusterConditionPaused,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: cluster.Generation,
+			Reason:             v1beta1.PausedReasonAnnotation,
+			Message:            fmt.Sprintf("Reconciliation is paused by the %s annotation", v1beta1.ReconcilePausedAnnotation),
+		}
+	}
+	return metav1.Condition{
+		Type:               v1beta1.ClusterConditionPaused,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: cluster.Generation,
+		Reason:             v1beta1.PausedReasonResumed,
+		Message:            "Reconciliation is resumed",
+	}
+}
+
+// Sets the Paused condition while the reconciliation is paused, leaving the rest of the
+// status as recorded so that reconciliation resumes from the observed state.
+func (updater *ClusterStatusUpdater) updatePausedCondition(ctx context.Context) error {
+	var cluster = updater.observed.cluster
+	if meta.IsStatusConditionTrue(cluster.Status.Conditions, v1beta1.ClusterConditionPaused) {
+		return nil
+	}
+	updater.recorder.Event(cluster, corev1.EventTypeNormal, "ReconcilePaused",
+		fmt.Sprintf("Reconciliation is paused by the %s annotation", v1beta1.ReconcilePausedAnnotation))
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		var latest = &v1beta1.FlinkCluster{}
+		if err := updater.k8sClient.Get(ctx, client.ObjectKeyFromObject(cluster), latest); err != nil {
+			return client.IgnoreNotFound(err)
+		}
+		meta.SetStatusCondition(&latest.Status.Conditions, newPausedCondition(latest, true))
+		return updater.k8sClient.Status().Update(ctx, latest)
+	})
+}
+
+// Reports the wait of the job submission for the savepoint to restore the job from.
+func deriveSavepointAvailableCondition(observed *ObservedClusterState) *metav1.Condition {
+	var state = getSavepointWaitState(observed)
+	if state == savepointWaitNotRequired {
+		return nil
+	}
+
+	var cluster = observed.cluster
+	var location = *getAwaitedSavepoint(cluster, observed.observeTime)
+	var condition = &metav1.Condition{
+		Type:               v1beta1.ClusterConditionSavepointAvailable,
+		ObservedGeneration: cluster.Generation,
+		LastTransitionTime: metav1.NewTime(observed.observeTime),
+	}
+	switch state {
+	case savepointWaitAvailable:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = v1beta1.SavepointAvailableReasonFound
+		condition.Message = fmt.Sprintf("Savepoint %s is available", location)
+	case savepointWaitTimedOut:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = v1beta1.SavepointAvailableReasonTimedOut
+		condition.Message = fmt.Sprintf("Savepoint %s was not available within %d seconds, the job is not submitted",
+			location, cluster.Spec.Job.WaitForSavepoint.GetTimeoutSeconds())
+	default:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = v1beta1.SavepointAvailableReasonWaiting
+		condition.Message = fmt.Sprintf("Waiting for savepoint %s to be available", location)
+	}
+	return condition
+}
+
+// Records why the running job is updated without a savepoint. The condition is kept after
+// the update for auditing, until a later update takes the savepoint.
+func deriveSavepointSkippedCondition(observed *ObservedClusterState) *metav1.Condition {
+	var cluster = observed.cluster
+	if !isJobUpdateTriggered(observed) || !cluster.Status.Components.Job.IsActive() {
+		return nil
+	}
+	reason, message := getSavepointSkipReason(cluster, observed.observeTime)
+	if reason == "" {
+		return nil
+	}
+	return &metav1.Condition{
+		Type:               v1beta1.ClusterConditionSavepointSkipped,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: cluster.Generation,
+		LastTransitionTime: metav1.NewTime(observed.observeTime),
+		Reason:             reason,
+		Message:            message,
+	}
+}
+
+// isJobUpdateTriggered returns true if the update restarts the job, which is then suspended
+// with a savepoint unless it is skipped.
+func isJobUpdateTriggered(observed *ObservedClusterState) bool {
+	var cluster = observed.cluster
+	return cluster.Spec.Job != nil && cluster.Status.Revision.IsUpdateTriggered() &&
+		!isInPlaceUpdate(observed.revisions, cluster)
+}
+
+// Surfaces the scheduler reason of the JobManager and TaskManager pods which have
+// been unschedulable for a while, e.g. "3 TaskManagers unschedulable: insufficient memory".
+func derivePodsUnschedulableCondition(observed *ObservedClusterState) *metav1.Condition {
+	if observed.pods == nil {
+		return nil
+	}
+
+	var condition = &metav1.Condition{
+		Type:               v1beta1.ClusterConditionPodsUnschedulable,
+		ObservedGeneration: observed.cluster.Generation,
+	}
+	var failures = getPodSchedulingFailures(observed.pods, observed.observeTime)
+	if len(failures) == 0 {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = v1beta1.PodsUnschedulableReasonNone
+		condition.Message = "All pods are scheduled"
+		return condition
+	}
+
+	var messages []string
+	for _, f := range failures {
+		var name = "JobManager"
+		if f.component == "taskmanager" {
+			name = "TaskManager"
+		}
+		if f.count > 1 {
+			name += "s"
+		}
+		messages = append(messages, fmt.Sprintf("%d %s unschedulable: %s", f.count, name, f.message))
+	}
+	condition.Status = metav1.ConditionTrue
+	condition.Reason = failures[0].reason
+	condition.Message = strings.Join(messages, "; ")
+	return condition
+}
+
+// Reports the streaming job which completed on its own, e.g., as a bounded source ended. The
+// condition is only added once the job completed unexpectedly, and is reset when the job runs again.
+func deriveUnexpectedJobCompletionCondition(observed *ObservedClusterState, job *v1beta1.JobStatus, recorded []metav1.Condition) *metav1.Condition {
+	var jobSpec = observed.cluster.Spec.Job
+	var condition = &metav1.Condition{
+		Type:               v1beta1.ClusterConditionUnexpectedJobCompletion,
+		ObservedGeneration: observed.cluster.Generation,
+	}
+	if job.IsUnexpectedCompletion(jobSpec) {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = v1beta1.UnexpectedJobCompletionReasonStreamingJobCompleted
+		condition.Message = fmt.Sprintf("The streaming job %s completed unexpectedly, a bounded source may have ended", job.ID)
+		if job.ShouldRestart(jobSpec, observed.observeTime) {
+			condition.Message += "; restarting the job"
+		}
+		return condition
+	}
+	if !meta.IsStatusConditionTrue(recorded, condition.Type) || job.IsStopped() {
+		return nil
+	}
+	condition.Status = metav1.ConditionFalse
+	condition.Reason = v1beta1.UnexpectedJobCompletionReasonNone
+	condition.Message = "The streaming job is not completed"
+	return condition
+}
+
+// Reports the JobManager replicas which drifted from the spec and are left as is with the Warn
+// replica drift policy. The condition is only added once the replicas drifted, and is reset when
+// they match the spec again or are scaled back by the Reconcile policy.
+func deriveJobManagerReplicaDriftCondition(observed *ObservedClusterState, recorded []metav1.Condition) *metav1.Condition {
+	if observed.jmStatefulSet == nil {
+		return nil
+	}
+	var condition = &metav1.Condition{
+		Type:               v1beta1.ClusterConditionJobManagerReplicaDrift,
+		ObservedGeneration: observed.cluster.Generation,
+	}
+	if desired, actual, drifted := getJobManagerReplicaDrift(observed); drifted && !shouldRevertJobManagerReplicaDrift(observed.cluster) {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = v1beta1.JobManagerReplicaDriftReasonDrifted
+		condition.Message = fmt.Sprintf("JobManager StatefulSet %s is scaled to %d replicas instead of %d",
+			observed.jmStatefulSet.Name, actual, desired)
+		return condition
+	}
+	if !meta.IsStatusConditionTrue(recorded, condition.Type) {
+		return nil
+	}
+	condition.Status = metav1.ConditionFalse
+	condition.Reason = v1beta1.JobManagerReplicaDriftReasonNone
+	condition.Message = "JobManager StatefulSet is scaled to the replicas of the spec"
+	return condition
+}
+
+// Reports the operators of the previous job run whose state the job restored from a savepoint
+// cannot find, as their UIDs changed or they were removed. The condition is only added once
+// operator UIDs were removed, and is reset when a later job run keeps them.
+func deriveOperatorUIDsChangedCondition(observed *ObservedClusterState, job *v1beta1.JobStatus, recorded []metav1.Condition) *metav1.Condition {
+	if job == nil || job.OperatorUIDs == nil {
+		return nil
+	}
+	var uids = job.OperatorUIDs
+	var condition = &metav1.Condition{
+		Type:               v1beta1.ClusterConditionOperatorUIDsChanged,
+		ObservedGeneration: observed.cluster.Generation,
+	}
+	if len(uids.Removed) > 0 {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = v1beta1.OperatorUIDsChangedReasonRemoved
+		condition.Message = fmt.Sprintf(
+			"The job %s restored from savepoint %s has no operators with the UIDs %s of the previous job run, their state is not restored",
+			uids.JobID, job.FromSavepoint, strings.Join(uids.Removed, ", "))
+		return condition
+	}
+	if !meta.IsStatusConditionTrue(recorded, condition.Type) {
+		return nil
+	}
+	condition.Status = metav1.ConditionFalse
+	condition.Reason = v1beta1.OperatorUIDsChangedReasonNone
+	condition.Message = fmt.Sprintf("The job %s has the operator UIDs of the previous job run", uids.JobID)
+	return condition
+}
+
+// Checks the nodes of the cluster have the architecture the image is declared for, as the pods
+// cannot be scheduled otherwise.
+func deriveImageArchitectureConflictCondition(observed *ObservedClusterState) *metav1.Condition {
+	if observed.nodeArchitectures == nil {
+		return nil
+	}
+	var arch = observed.cluster.NodeArchitecture()
+	var condition = &metav1.Condition{
+		Type:               v1beta1.ClusterConditionImageArchitectureConflict,
+		ObservedGeneration: observed.cluster.Generation,
+	}
+	if slices.Contains(observed.nodeArchitectures, arch) {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = v1beta1.ImageArchitectureConflictReasonNone
+		condition.Message = fmt.Sprintf("Nodes of the image architecture %s are available", arch)
+		return condition
+	}
+	var available = "none"
+	if len(observed.nodeArchitectures) > 0 {
+		available = strings.Join(observed.nodeArchitectures, ", ")
+	}
+	condition.Status = metav1.ConditionTrue
+	condition.Reason = v1beta1.ImageArchitectureConflictReasonNoMatchingNodes
+	condition.Message = fmt.Sprintf(
+		"No node has the image architecture %s in its %s label, the nodes have %s; "+
+			"the pods cannot be scheduled, check image.architecture or add nodes of the architecture",
+		arch, corev1.LabelArchStable, available)
+	return condition
+}
+
+// Diagnoses the TaskManagers which crash-loop as they cannot reach the JobManager RPC. The
+// condition is only added once a failure is found, and is reset when it is gone.
+func deriveTaskManagerRPCUnreachableCondition(observed *ObservedClusterState, recorded []metav1.Condition) *metav1.Condition {
+	if observed.pods == nil {
+		return nil
+	}
+	var registration = observed.slotRegistration
+	if registration == nil {
+		registration = observed.cluster.Status.SlotRegistration
+	}
+	var failure = getTaskManagerRPCFailure(observed.pods, registration)
+	if failure == nil {
+		if !meta.IsStatusConditionTrue(recorded, v1beta1.ClusterConditionTaskManagerRPCUnreachable) {
+			return nil
+		}
+		return &metav1.Condition{
+			Type:               v1beta1.ClusterConditionTaskManagerRPCUnreachable,
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: observed.cluster.Generation,
+			Reason:             v1beta1.TaskManagerRPCUnreachableReasonNone,
+			Message:            "No TaskManager fails to register with the JobManager",
+		}
+	}
+
+	var name = "TaskManager"
+	if failure.count > 1 {
+		name += "s"
+	}
+	var port = "the JobManager RPC port"
+	if ports, err := observed.cluster.NetworkPorts(); err == nil {
+		port = fmt.Sprintf("JobManager RPC port %d", ports.JobManagerRPC)
+	}
+	return &metav1.Condition{
+		Type:               v1beta1.ClusterConditionTaskManagerRPCUnreachable,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: observed.cluster.Generation,
+		Reason:             v1beta1.TaskManagerRPCUnreachableReasonRegistrationFailing,
+		Message: fmt.Sprintf(
+			"%d %s restarted up to %d times without registering with the JobManager: %s; "+
+				"check that %s is reachable from the TaskManager pods, e.g., network policies and jobmanager.rpc.port",
+			failure.count, name, failure.restarts, failure.message, port),
+	}
+}
+
+// Compares the running Flink config with the config rendered in the ConfigMap.
+func deriveConfigDriftCondition(observed *ObservedClusterState) *metav1.Condition {
+	// The running config is expected to differ until the update is rolled out.
+	if observed.flinkConfig == nil ||
+		observed.updateState == UpdateStatePreparing ||
+		observed.updateState == UpdateStateInProgress ||
+		!isComponentUpdated(observed.configMap, observed.cluster) {
+		return nil
+	}
+	var rendered = getRenderedFlinkConfig(observed.configMap)
+	if rendered == nil {
+		return nil
+	}
+
+	var condition = &metav1.Condition{
+		Type:               v1beta1.ClusterConditionConfigDrift,
+		ObservedGeneration: observed.cluster.Generation,
+	}
+	if drift := getFlinkConfigDrift(rendered, observed.flinkConfig); len(drift) > 0 {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = v1beta1.ConfigDriftReasonDetected
+		condition.Message = fmt.Sprintf("Running Flink config differs from the spec for keys: %s", strings.Join(drift, ", "))
+	} else {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = v1beta1.ConfigDriftReasonNone
+		condition.Message = "Running Flink config matches the spec"
+	}
+	return condition
+}
+
 // Gets Flink job ID based on the observed state and the recorded state.
 //
 // It is possible that the recorded is not nil, but the observed is, due
@@ -631,6 +1412,137 @@ func isNonZeroExitCode(exitCode int32) bool {
 	return exitCode != 0 && exitCode != -1
 }
 
+// Counts the failures of the job restored from its fromSavepoint, attributed to the savepoint
+// only if no newer savepoint was taken since. Once the job failed maxRestoreFailures times,
+// the savepoint is marked poison and the job falls back to the latest savepoint of the
+// inventory which is not poison, if any.
+func derivePoisonSavepoint(job *v1beta1.JobStatus, jobSpec *v1beta1.JobSpec, inventory []v1beta1.SavepointRecord) {
+	if job.FromSavepoint == "" || (job.SavepointLocation != "" && job.SavepointLocation != job.FromSavepoint) {
+		return
+	}
+	job.RestoreFailureCount++
+	if !job.RestoreSourceIsPoison(jobSpec.GetMaxRestoreFailures()) || slices.Contains(job.PoisonSavepoints, job.FromSavepoint) {
+		return
+	}
+	job.PoisonSavepoints = append(job.PoisonSavepoints, job.FromSavepoint)
+	if len(job.PoisonSavepoints) > v1beta1.SavepointInventoryLimit {
+		job.PoisonSavepoints = job.PoisonSavepoints[len(job.PoisonSavepoints)-v1beta1.SavepointInventoryLimit:]
+	}
+	for i := len(inventory) - 1; i >= 0; i-- {
+		if !slices.Contains(job.PoisonSavepoints, inventory[i].Location) {
+			job.SavepointLocation = inventory[i].Location
+			job.SavepointTime = inventory[i].Time
+			job.FinalSavepoint = false
+			return
+		}
+	}
+}
+
+// Reconciles the restarts of the job by the operator with the observed restarts of its tasks
+// by Flink. The Flink restarts are kept until the next observation, and reset when the job
+// runs with another ID, since the metric counts the restarts of a job run.
+func deriveJobRestarts(oldJob, newJob *v1beta1.JobStatus, flinkInternalRestarts *int32) *v1beta1.JobRestartsStatus {
+	var restarts = &v1beta1.JobRestartsStatus{OperatorRestarts: newJob.RestartCount}
+	switch {
+	case flinkInternalRestarts != nil:
+		restarts.FlinkInternalRestarts = *flinkInternalRestarts
+	case oldJob != nil && oldJob.Restarts != nil && oldJob.ID == newJob.ID:
+		restarts.FlinkInternalRestarts = oldJob.Restarts.FlinkInternalRestarts
+	}
+	restarts.TotalRestarts = restarts.OperatorRestarts + restarts.FlinkInternalRestarts
+	return restarts
+}
+
+// Records the JAR the job submitter uploaded or ran again, and forgets the recorded JAR once the
+// JobManager no longer has it, e.g., after it restarted, so that the next submission uploads it
+// again instead of running a stale ID.
+func deriveUploadedJar(observed *ObservedClusterState, recorded *v1beta1.UploadedJarStatus) *v1beta1.UploadedJarStatus {
+	if !isRESTJarUpload(observed.cluster) {
+		return nil
+	}
+	if submitterLog := observed.flinkJobSubmitter.log; submitterLog != nil && submitterLog.jar != nil {
+		return submitterLog.jar
+	}
+	if recorded != nil && observed.uploadedJars != nil &&
+		!slices.ContainsFunc(observed.uploadedJars.Files, func(jar flink.JarFileInfo) bool { return jar.ID == recorded.ID }) {
+		return nil
+	}
+	return recorded
+}
+
+// Records the operator UIDs of the job from its observed plan, and compares them with the previous
+// job run. The UIDs of the previous run missing in a job restored from a savepoint are reported as
+// removed, as Flink cannot map their state to the new job graph. The recorded UIDs are kept until
+// the plan of another job is observed.
+func deriveOperatorUIDs(plan *flink.JobPlan, job *v1beta1.JobStatus, recorded *v1beta1.OperatorUIDsStatus) *v1beta1.OperatorUIDsStatus {
+	if plan == nil || job == nil || job.ID == "" || (recorded != nil && recorded.JobID == job.ID) {
+		return recorded
+	}
+	var uids = &v1beta1.OperatorUIDsStatus{JobID: job.ID}
+	for _, node := range plan.Plan.Nodes {
+		uids.Current = append(uids.Current, node.ID)
+	}
+	slices.Sort(uids.Current)
+	uids.Current = slices.Compact(uids.Current)
+	if recorded == nil {
+		return uids
+	}
+	uids.Previous = recorded.Current
+	if job.FromSavepoint == "" {
+		return uids
+	}
+	for _, uid := range uids.Previous {
+		if _, found := slices.BinarySearch(uids.Current, uid); !found {
+			uids.Removed = append(uids.Removed, uid)
+		}
+	}
+	return uids
+}
+
+// isJobStateTransition returns true if the derived job enters another state than recorded.
+func isJobStateTransition(oldJob, newJob *v1beta1.JobStatus) bool {
+	return oldJob == nil || oldJob.State != newJob.State
+}
+
+// Accumulates the time in the recorded state of the job when it transitions to another state,
+// and records when the job entered the new state. The time is only accumulated from the recorded
+// status on a transition, so that it survives operator restarts and is not counted twice when the
+// reconcile is requeued. A state recorded without stateTime, e.g., by an older operator, is not
+// accumulated as its start is unknown.
+func deriveJobStateDurations(oldJob, newJob *v1beta1.JobStatus, now time.Time) {
+	if !isJobStateTransition(oldJob, newJob) {
+		return
+	}
+	var tc = &util.TimeConverter{}
+	newJob.StateTime = tc.ToString(now)
+	if oldJob == nil || oldJob.StateTime == "" {
+		return
+	}
+	var seconds = max(int64(now.Sub(tc.FromString(oldJob.StateTime)).Seconds()), 0)
+	for i := range newJob.StateDurations {
+		if newJob.StateDurations[i].State == oldJob.State {
+			newJob.StateDurations[i].Seconds += seconds
+			return
+		}
+	}
+	newJob.StateDurations = append(newJob.StateDurations,
+		v1beta1.JobStateDuration{State: oldJob.State, Seconds: seconds})
+}
+
+// Reconciles the exhausted restart strategy of Flink, reported by the root exception of the
+// failed job, into the job status, where it escalates the restart to the operator. The signal
+// is kept while the same job stays failed, as the exceptions are no longer observed once the
+// JobManager is gone.
+func deriveFlinkRestartsExhausted(oldJob, newJob *v1beta1.JobStatus, exceptions *flink.JobExceptions) bool {
+	if newJob.State != v1beta1.JobStateFailed {
+		return false
+	}
+	if isFlinkRestartStrategyExhausted(exceptions) {
+		return true
+	}
+	return oldJob != nil && oldJob.Restarts != nil && oldJob.ID == newJob.ID && oldJob.Restarts.FlinkRestartsExhausted
+}
+
 func (updater *ClusterStatusUpdater) deriveJobStatus(ctx context.Context) *v1beta1.JobStatus {
 	log := logr.FromContextOrDiscard(ctx)
 
@@ -673,6 +1585,9 @@ func (updater *ClusterStatusUpdater) deriveJobStatus(ctx context.Context) *v1bet
 		newJobState = v1beta1.JobStatePending
 	case shouldUpdateJob(&observed):
 		newJobState = v1beta1.JobStateUpdating
+	// The job cancelled for the restart trigger is submitted again.
+	case oldJob.State == v1beta1.JobStateCancelled && isJobRestartTriggered(observedCluster):
+		newJobState = v1beta1.JobStateRestarting
 	case oldJob.IsStopped():
 		// When a new job is deploying, update the job state to deploying.
 		if observedSubmitter.job != nil && (observedSubmitter.job.Status.Active == 1 || isJobInitialising(observedSubmitter.job.Status)) {
@@ -744,9 +1659,10 @@ func (updater *ClusterStatusUpdater) deriveJobStatus(ctx context.Context) *v1bet
 	}
 	// Update State
 	newJob.State = newJobState
+	deriveJobStateDurations(oldJob, newJob, observed.observeTime)
 
 	// Derived new job status if the state is changed.
-	if oldJob == nil || oldJob.State != newJob.State {
+	if isJobStateTransition(oldJob, newJob) {
 		// TODO: It would be ideal to set the times with the timestamp retrieved from the Flink API like /jobs/{job-id}.
 		switch {
 		case newJob.IsPending():
@@ -754,8 +1670,16 @@ func (updater *ClusterStatusUpdater) deriveJobStatus(ctx context.Context) *v1bet
 			switch newJob.State {
 			case v1beta1.JobStateUpdating:
 				newJob.RestartCount = 0
+				newJob.RestoreFailureCount = 0
+				newJob.PoisonSavepoints = nil
+				// The checkpoint of the previous job run is not a restore source of the updated job.
+				newJob.RetainedCheckpoint = nil
 			case v1beta1.JobStateRestarting:
-				newJob.RestartCount++
+				// The restart requested with the restart-trigger annotation is not a failure
+				// and does not count against the restart budget of the job.
+				if oldJob.State != v1beta1.JobStateCancelled || !isJobRestartTriggered(observedCluster) {
+					newJob.RestartCount++
+				}
 			}
 		case newJob.State == v1beta1.JobStateRunning:
 			util.SetTimestamp(&newJob.StartTime)
@@ -765,6 +1689,7 @@ func (updater *ClusterStatusUpdater) deriveJobStatus(ctx context.Context) *v1bet
 				newJob.FinalSavepoint = false
 			}
 		case newJob.IsFailed():
+			derivePoisonSavepoint(newJob, jobSpec, recorded.SavepointInventory)
 			if len(newJob.FailureReasons) == 0 {
 				newJob.FailureReasons = []string{}
 				exceptions := observed.flinkJob.exceptions
@@ -806,6 +1731,48 @@ func (updater *ClusterStatusUpdater) deriveJobStatus(ctx context.Context) *v1bet
 		// Whereas checkpoint API returns the timestamp latest_ack_timestamp.
 		// Note: https://ci.apache.org/projects/flink/flink-docs-stable/ops/rest_api.html#jobs-jobid-checkpoints-details-checkpointid
 		util.SetTimestamp(&newJob.SavepointTime)
+		// Prime the latest savepoint for the warm standby.
+		if spec := observedCluster.Spec.Job; spec != nil && spec.WarmStandby != nil {
+			newJob.PrimedSavepoint = &v1beta1.PrimedSavepoint{
+				JobID:    observedSavepoint.status.JobID,
+				Location: newJob.SavepointLocation,
+				Time:     newJob.SavepointTime,
+			}
+		}
+	}
+
+	// Restarts
+	newJob.Restarts = deriveJobRestarts(oldJob, newJob, observed.flinkInternalRestarts)
+	newJob.Restarts.FlinkRestartsExhausted = deriveFlinkRestartsExhausted(oldJob, newJob, observed.flinkJob.exceptions)
+
+	// JAR uploaded through the REST API
+	newJob.UploadedJar = deriveUploadedJar(&observed, newJob.UploadedJar)
+
+	// Operator UIDs
+	newJob.OperatorUIDs = deriveOperatorUIDs(observed.flinkJob.plan, newJob, newJob.OperatorUIDs)
+
+	// Classloader resolve order
+	newJob.ClassloaderResolveOrder = deriveClassloaderResolveOrder(&observed, newJob.ClassloaderResolveOrder)
+
+	// Retained checkpoint
+	if observed.retainedCheckpoint != nil {
+		newJob.RetainedCheckpoint = observed.retainedCheckpoint
+	}
+
+	// A savepoint which doesn't belong to the current run of the job, e.g., recorded before
+	// the job was resubmitted from another savepoint while the operator was down, must not be
+	// taken as its state.
+	if observed.staleSavepoint && newJob.SavepointLocation == oldJob.SavepointLocation {
+		log.Info("Clearing the savepoint recorded before the current job run",
+			"savepointLocation", newJob.SavepointLocation, "savepointTime", newJob.SavepointTime)
+		newJob.SavepointLocation = ""
+		newJob.SavepointTime = ""
+		newJob.FinalSavepoint = false
+	}
+
+	// The skip-savepoint-on-next-update request is consumed once the update is completed.
+	if nonce := observedCluster.PendingSkipSavepointNonce(); nonce != "" && observed.updateState == UpdateStateFinished {
+		newJob.SkipSavepointNonce = nonce
 	}
 
 	return newJob
@@ -924,6 +1891,62 @@ func (updater *ClusterStatusUpdater) isStatusChanged(
 			newStatus.Savepoint)
 		changed = true
 	}
+	if !reflect.DeepEqual(newStatus.CoordinatedSavepoint, currentStatus.CoordinatedSavepoint) {
+		log.Info(
+			"Coordinated savepoint status changed", "current",
+			currentStatus.CoordinatedSavepoint,
+			"new",
+			newStatus.CoordinatedSavepoint)
+		changed = true
+	}
+	if !reflect.DeepEqual(newStatus.SavepointInventory, currentStatus.SavepointInventory) {
+		log.Info(
+			"Savepoint inventory changed", "current",
+			currentStatus.SavepointInventory,
+			"new",
+			newStatus.SavepointInventory)
+		changed = true
+	}
+	if !reflect.DeepEqual(newStatus.SlotRegistration, currentStatus.SlotRegistration) {
+		log.Info(
+			"Slot registration changed", "current",
+			currentStatus.SlotRegistration,
+			"new",
+			newStatus.SlotRegistration)
+		changed = true
+	}
+	if !reflect.DeepEqual(newStatus.CheckpointAlignment, currentStatus.CheckpointAlignment) {
+		log.Info(
+			"Checkpoint alignment changed", "current",
+			currentStatus.CheckpointAlignment,
+			"new",
+			newStatus.CheckpointAlignment)
+		changed = true
+	}
+	if !reflect.DeepEqual(newStatus.JobManagerLeader, currentStatus.JobManagerLeader) {
+		log.Info(
+			"JobManager leader changed", "current",
+			currentStatus.JobManagerLeader,
+			"new",
+			newStatus.JobManagerLeader)
+		changed = true
+	}
+	if !reflect.DeepEqual(newStatus.Conditions, currentStatus.Conditions) {
+		log.Info(
+			"Conditions changed", "current",
+			currentStatus.Conditions,
+			"new",
+			newStatus.Conditions)
+		changed = true
+	}
+	if newStatus.LastObservabilityPollTime != currentStatus.LastObservabilityPollTime {
+		log.Info(
+			"Last observability poll time changed", "current",
+			currentStatus.LastObservabilityPollTime,
+			"new",
+			newStatus.LastObservabilityPollTime)
+		changed = true
+	}
 	var nr = newStatus.Revision     // New revision status
 	var cr = currentStatus.Revision // Current revision status
 	if nr.CurrentRevision != cr.CurrentRevision ||
@@ -997,8 +2020,34 @@ func (updater *ClusterStatusUpdater) clearControlAnnotation(ctx context.Context,
 	return nil
 }
 
+// Records the estimated monthly cost of the cluster in its annotations when it changes. The cost
+// is only estimated if the operator is configured with a price list.
+func (updater *ClusterStatusUpdater) updateEstimatedCostAnnotation(ctx context.Context, priceList v1beta1.ResourcePriceList) error {
+	var cluster = updater.observed.cluster
+	if cluster == nil || priceList.IsZero() {
+		return nil
+	}
+	var cost = strconv.FormatFloat(cluster.EstimatedCost(priceList).Monthly, 'f', 2, 64)
+	if cluster.Annotations[v1beta1.EstimatedMonthlyCostAnnotation] == cost {
+		return nil
+	}
+	annotationPatch := objectForPatch{
+		Metadata: objectMetaForPatch{
+			Annotations: map[string]interface{}{
+				v1beta1.EstimatedMonthlyCostAnnotation: cost,
+			},
+		},
+	}
+	patchBytes, err := json.Marshal(&annotationPatch)
+	if err != nil {
+		return err
+	}
+	rawPatch := client.RawPatch(types.MergePatchType, patchBytes)
+	return updater.k8sClient.Patch(ctx, cluster, rawPatch)
+}
+
 func (updater *ClusterStatusUpdater) deriveSavepointStatus(
-	observedSavepoint *Savepoint,
+	observed *ObservedClusterState,
 	recordedSavepointStatus *v1beta1.SavepointStatus,
 	newJobStatus *v1beta1.JobStatus,
 	flinkJobID *string) *v1beta1.SavepointStatus {
@@ -1011,6 +2060,7 @@ func (updater *ClusterStatusUpdater) deriveSavepointStatus(
 	var errMsg string
 
 	// Update the savepoint status when observed savepoint is found.
+	var observedSavepoint = &observed.savepoint
 	if s.State == v1beta1.SavepointStateInProgress {
 		// Derive the state from the observed savepoint in JobManager.
 		status := observedSavepoint.status
@@ -1023,6 +2073,14 @@ func (updater *ClusterStatusUpdater) deriveSavepointStatus(
 		case observedSavepoint.error != nil:
 			s.State = v1beta1.SavepointStateFailed
 			errMsg = fmt.Sprintf("Failed to get savepoint status: %v", observedSavepoint.error)
+		case s.TriggerTime != "" && hasSavepointTimeout(observed.cluster, s.TriggerReason):
+			// The savepoint fails when it takes longer than the timeout set for its trigger
+			// source. Otherwise Flink fails it after the checkpoint timeout.
+			var timeout = getSavepointTimeout(observed.cluster, s.TriggerReason)
+			if hasTimeElapsed(s.TriggerTime, observed.observeTime, int(timeout/time.Second)) {
+				s.State = v1beta1.SavepointStateFailed
+				errMsg = fmt.Sprintf("Savepoint timed out after %v", timeout)
+			}
 		}
 
 		// Derive the failure state from Flink job status.
@@ -1056,9 +2114,88 @@ func (updater *ClusterStatusUpdater) deriveSavepointStatus(
 	return s
 }
 
+// Derives the coordinated savepoint status from the observed savepoint of each job.
+func deriveCoordinatedSavepointStatus(
+	observedSavepoints map[string]Savepoint,
+	recorded *v1beta1.CoordinatedSavepointStatus) *v1beta1.CoordinatedSavepointStatus {
+	if recorded == nil {
+		return nil
+	}
+
+	var s = recorded.DeepCopy()
+	if !s.HasPendingSavepoints() {
+		return s
+	}
+	for i := range s.Jobs {
+		var job = &s.Jobs[i]
+		observedSavepoint, ok := observedSavepoints[job.Savepoint.JobID]
+		if !ok || job.Savepoint.State != v1beta1.SavepointStateInProgress {
+			continue
+		}
+		var errMsg string
+		var status = observedSavepoint.status
+		switch {
+		case status != nil && status.IsSuccessful():
+			job.Savepoint.State = v1beta1.SavepointStateSucceeded
+			job.Location = status.Location
+		case status != nil && status.IsFailed():
+			job.Savepoint.State = v1beta1.SavepointStateFailed
+			errMsg = fmt.Sprintf("Savepoint error: %v", status.FailureCause.StackTrace)
+		case observedSavepoint.error != nil:
+			job.Savepoint.State = v1beta1.SavepointStateFailed
+			errMsg = fmt.Sprintf("Failed to get savepoint status: %v", observedSavepoint.error)
+		default:
+			continue
+		}
+		if len(errMsg) > 1024 {
+			errMsg = errMsg[:1024]
+		}
+		job.Savepoint.Message = errMsg
+		util.SetTimestamp(&job.Savepoint.UpdateTime)
+	}
+	s.Aggregate()
+	if s.State != recorded.State {
+		util.SetTimestamp(&s.UpdateTime)
+	}
+	return s
+}
+
+// Adds the savepoints of the coordinated savepoint which succeeded since the recorded status
+// to the savepoint inventory.
+func addCoordinatedSavepointRecords(
+	cluster *v1beta1.FlinkCluster,
+	recorded *v1beta1.CoordinatedSavepointStatus,
+	derived *v1beta1.CoordinatedSavepointStatus,
+	inventory []v1beta1.SavepointRecord) []v1beta1.SavepointRecord {
+	if recorded == nil || derived == nil {
+		return inventory
+	}
+	for i, job := range derived.Jobs {
+		if i >= len(recorded.Jobs) ||
+			recorded.Jobs[i].Savepoint.State == v1beta1.SavepointStateSucceeded ||
+			job.Savepoint.State != v1beta1.SavepointStateSucceeded {
+			continue
+		}
+		var record = v1beta1.SavepointRecord{
+			Location:      job.Location,
+			Time:          job.Savepoint.UpdateTime,
+			JobID:         job.Savepoint.JobID,
+			TriggerReason: job.Savepoint.TriggerReason,
+			FlinkVersion:  cluster.Spec.FlinkVersion,
+		}
+		if job.Savepoint.TriggerTime != "" && record.Time != "" {
+			var duration = int64(util.GetTime(record.Time).Sub(util.GetTime(job.Savepoint.TriggerTime)).Seconds())
+			record.DurationSeconds = &duration
+		}
+		inventory = v1beta1.AddSavepointRecord(inventory, record)
+	}
+	return inventory
+}
+
 func deriveControlStatus(
 	cluster *v1beta1.FlinkCluster,
 	newSavepoint *v1beta1.SavepointStatus,
+	newCoordinatedSavepoint *v1beta1.CoordinatedSavepointStatus,
 	newJob *v1beta1.JobStatus,
 	recordedControl *v1beta1.FlinkClusterControlStatus) *v1beta1.FlinkClusterControlStatus {
 	var controlRequest = getNewControlRequest(cluster)
@@ -1108,6 +2245,17 @@ func deriveControlStatus(
 			} else if newSavepoint.IsFailed() && newSavepoint.TriggerReason == v1beta1.SavepointReasonUserRequested {
 				c.State = v1beta1.ControlStateFailed
 			}
+		case v1beta1.ControlNameCoordinatedSavepoint:
+			switch {
+			case newCoordinatedSavepoint == nil:
+				c.Message = "Aborted: coordinated savepoint not defined"
+				c.State = v1beta1.ControlStateFailed
+			case newCoordinatedSavepoint.State == v1beta1.SavepointStateSucceeded:
+				c.State = v1beta1.ControlStateSucceeded
+			case newCoordinatedSavepoint.State == v1beta1.SavepointStateFailed:
+				c.Message = newCoordinatedSavepoint.Message
+				c.State = v1beta1.ControlStateFailed
+			}
 		}
 		// Update time when state changed.
 		if c.State != v1beta1.ControlStateInProgress {
@@ -18,14 +18,25 @@ package flinkcluster
 
 import (
 	"context"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 
 	v1beta1 "github.com/spotify/flink-on-k8s-operator/apis/flinkcluster/v1beta1"
+	"github.com/spotify/flink-on-k8s-operator/internal/flink"
+	"github.com/spotify/flink-on-k8s-operator/internal/util"
 	"gotest.tools/v3/assert"
 	appsv1 "k8s.io/api/apps/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 func TestGetStatefulSetStateNotReady(t *testing.T) {
@@ -101,6 +112,17 @@ func TestClusterStatus(t *testing.T) {
 		assert.Assert(t, updater.isStatusChanged(context.TODO(), oldStatus, newStatus))
 	})
 
+	t.Run("jobmanager leader changed", func(t *testing.T) {
+		var oldStatus = v1beta1.FlinkClusterStatus{
+			JobManagerLeader: &v1beta1.JobManagerLeaderStatus{Identity: "jm-0", Pod: "my-jobmanager-0"},
+		}
+		var newStatus = v1beta1.FlinkClusterStatus{
+			JobManagerLeader: &v1beta1.JobManagerLeaderStatus{Identity: "jm-1", Pod: "my-jobmanager-1"},
+		}
+		var updater = &ClusterStatusUpdater{}
+		assert.Assert(t, updater.isStatusChanged(context.TODO(), oldStatus, newStatus))
+	})
+
 	t.Run("derive status", func(t *testing.T) {
 		currentRevision := "1"
 		nextRevision := "1-2"
@@ -232,7 +254,7 @@ func TestDeriveControlStatus_StopWithSavepointCancel(t *testing.T) {
 		}
 		var newJob = &v1beta1.JobStatus{State: v1beta1.JobStateSucceeded}
 
-		var c = deriveControlStatus(cluster, newSavepoint, newJob, recordedControl)
+		var c = deriveControlStatus(cluster, newSavepoint, nil, newJob, recordedControl)
 
 		assert.Equal(t, newJob.State, v1beta1.JobStateCancelled)
 		assert.Equal(t, c.State, v1beta1.ControlStateSucceeded)
@@ -296,3 +318,1833 @@ func TestStoppedApplicationClusterRecoversFromActiveJob(t *testing.T) {
 		})
 	}
 }
+
+func TestDeriveJobStatusConsumesSkipSavepointNonce(t *testing.T) {
+	for _, test := range []struct {
+		name          string
+		updateState   UpdateState
+		expectedNonce string
+	}{
+		{name: "update in progress", updateState: UpdateStateInProgress, expectedNonce: ""},
+		{name: "update finished", updateState: UpdateStateFinished, expectedNonce: "nonce-1"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			var cluster = &v1beta1.FlinkCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{v1beta1.SkipSavepointOnNextUpdateAnnotation: "nonce-1"},
+				},
+				Spec: v1beta1.FlinkClusterSpec{
+					Job: &v1beta1.JobSpec{},
+				},
+				Status: v1beta1.FlinkClusterStatus{
+					Components: v1beta1.FlinkClusterComponentsStatus{
+						Job: &v1beta1.JobStatus{State: v1beta1.JobStateDeploying},
+					},
+				},
+			}
+			var observed = ObservedClusterState{cluster: cluster, updateState: test.updateState}
+			var updater = &ClusterStatusUpdater{observed: observed}
+
+			var job = updater.deriveJobStatus(context.Background())
+
+			assert.Equal(t, job.SkipSavepointNonce, test.expectedNonce)
+		})
+	}
+}
+
+func TestDeriveJobStatusRestartsForRestartTrigger(t *testing.T) {
+	for _, test := range []struct {
+		name          string
+		recordedNonce string
+		control       string
+		expectedState v1beta1.JobState
+	}{
+		{name: "new nonce", recordedNonce: "nonce-1", expectedState: v1beta1.JobStateRestarting},
+		{name: "processed nonce", recordedNonce: "nonce-2", expectedState: v1beta1.JobStateCancelled},
+		{name: "cancel requested", recordedNonce: "nonce-1", control: v1beta1.ControlNameJobCancel, expectedState: v1beta1.JobStateCancelled},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			var cluster = &v1beta1.FlinkCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{v1beta1.RestartTriggerAnnotation: "nonce-2"},
+				},
+				Spec: v1beta1.FlinkClusterSpec{
+					Job: &v1beta1.JobSpec{},
+				},
+				Status: v1beta1.FlinkClusterStatus{
+					Components: v1beta1.FlinkClusterComponentsStatus{
+						Job: &v1beta1.JobStatus{State: v1beta1.JobStateCancelled, RestartTriggerNonce: test.recordedNonce, RestartCount: 1},
+					},
+				},
+			}
+			if test.control != "" {
+				cluster.Annotations[v1beta1.ControlAnnotation] = test.control
+			}
+			var observeTime = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+			var updater = &ClusterStatusUpdater{observed: ObservedClusterState{cluster: cluster, observeTime: observeTime}}
+
+			var job = updater.deriveJobStatus(context.Background())
+
+			assert.Equal(t, job.State, test.expectedState)
+			// The triggered restart is not counted.
+			assert.Equal(t, job.RestartCount, int32(1))
+			// The state transition is timed at the observation.
+			if test.expectedState == v1beta1.JobStateRestarting {
+				assert.Equal(t, job.StateTime, "2026-01-01T00:00:00Z")
+			}
+		})
+	}
+}
+
+func TestDeriveJobStatusClearsStaleSavepoint(t *testing.T) {
+	var tc = &util.TimeConverter{}
+	var now = time.Now()
+	var maxStateAge int32 = 7200
+	for _, test := range []struct {
+		name          string
+		restored      flink.RestoredCheckpointStatistics
+		expectedStale bool
+	}{
+		{
+			name:          "restored from a later savepoint",
+			restored:      flink.RestoredCheckpointStatistics{ID: 40, IsSavepoint: true, ExternalPath: "s3://bucket/savepoints/savepoint-2"},
+			expectedStale: true,
+		},
+		{
+			name:          "recovered from a checkpoint",
+			restored:      flink.RestoredCheckpointStatistics{ID: 42, ExternalPath: "s3://bucket/checkpoints/chk-42"},
+			expectedStale: false,
+		},
+		{
+			name:          "restored from the recorded savepoint",
+			restored:      flink.RestoredCheckpointStatistics{ID: 40, IsSavepoint: true, ExternalPath: "s3://bucket/savepoints/savepoint-1"},
+			expectedStale: false,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			// given: the savepoint was recorded an hour before the current job run was restored
+			var cluster = &v1beta1.FlinkCluster{
+				Spec: v1beta1.FlinkClusterSpec{
+					Job: &v1beta1.JobSpec{MaxStateAgeToRestoreSeconds: &maxStateAge},
+				},
+				Status: v1beta1.FlinkClusterStatus{
+					Components: v1beta1.FlinkClusterComponentsStatus{
+						Job: &v1beta1.JobStatus{
+							ID:                "job-1",
+							State:             v1beta1.JobStateRunning,
+							SavepointLocation: "s3://bucket/savepoints/savepoint-1",
+							SavepointTime:     tc.ToString(now.Add(-time.Hour)),
+							FinalSavepoint:    true,
+						},
+					},
+				},
+			}
+			var flinkJob = &flink.Job{Id: "job-1", State: "RUNNING", StartTime: now.Add(-10 * time.Minute).UnixMilli()}
+			var restored = test.restored
+			restored.RestoreTimestamp = flinkJob.StartTime
+			var stats = &flink.CheckpointingStatistics{Latest: flink.LatestCheckpoints{Restored: &restored}}
+			assert.Assert(t, cluster.Status.Components.Job.IsSavepointUpToDate(cluster.Spec.Job, now))
+
+			// when
+			var observed = ObservedClusterState{
+				cluster:        cluster,
+				flinkJob:       FlinkJob{status: flinkJob},
+				staleSavepoint: isSavepointSuperseded(cluster.Status.Components.Job, stats),
+			}
+			var updater = &ClusterStatusUpdater{observed: observed}
+			var job = updater.deriveJobStatus(context.Background())
+
+			// then: a stale savepoint is cleared and no longer taken as the up-to-date job state
+			assert.Equal(t, observed.staleSavepoint, test.expectedStale)
+			assert.Equal(t, job.SavepointLocation == "", test.expectedStale)
+			assert.Equal(t, job.IsSavepointUpToDate(cluster.Spec.Job, now), !test.expectedStale)
+		})
+	}
+}
+
+func TestDeriveConfigDriftCondition(t *testing.T) {
+	var configMap = &corev1.ConfigMap{
+		Data: map[string]string{
+			"flink-conf.yaml": "jobmanager.rpc.address: mycluster-jobmanager\n" +
+				"parallelism.default: 2\n" +
+				"state.backend: rocksdb\n" +
+				"taskmanager.numberOfTaskSlots: 4\n",
+		},
+	}
+	var recorded = []metav1.Condition{{
+		Type:    v1beta1.ClusterConditionConfigDrift,
+		Status:  metav1.ConditionTrue,
+		Reason:  v1beta1.ConfigDriftReasonDetected,
+		Message: "Running Flink config differs from the spec for keys: parallelism.default",
+	}}
+
+	for _, test := range []struct {
+		name            string
+		flinkConfig     map[string]string
+		updateState     UpdateState
+		expectedStatus  metav1.ConditionStatus
+		expectedMessage string
+	}{
+		{
+			name: "drift",
+			flinkConfig: map[string]string{
+				"jobmanager.rpc.address":        "10.0.0.1",
+				"parallelism.default":           "1",
+				"taskmanager.numberOfTaskSlots": "4",
+				"jobmanager.memory.heap.size":   "1073741824b",
+			},
+			updateState:     UpdateStateNoUpdate,
+			expectedStatus:  metav1.ConditionTrue,
+			expectedMessage: "Running Flink config differs from the spec for keys: parallelism.default, state.backend",
+		},
+		{
+			name: "no drift",
+			flinkConfig: map[string]string{
+				"jobmanager.rpc.address":        "10.0.0.1",
+				"parallelism.default":           "2",
+				"state.backend":                 "rocksdb",
+				"taskmanager.numberOfTaskSlots": "4",
+			},
+			updateState:     UpdateStateFinished,
+			expectedStatus:  metav1.ConditionFalse,
+			expectedMessage: "Running Flink config matches the spec",
+		},
+		{
+			name:            "update in progress keeps the recorded condition",
+			flinkConfig:     map[string]string{"parallelism.default": "1"},
+			updateState:     UpdateStateInProgress,
+			expectedStatus:  metav1.ConditionTrue,
+			expectedMessage: "Running Flink config differs from the spec for keys: parallelism.default",
+		},
+		{
+			name:            "config not observed keeps the recorded condition",
+			updateState:     UpdateStateNoUpdate,
+			expectedStatus:  metav1.ConditionTrue,
+			expectedMessage: "Running Flink config differs from the spec for keys: parallelism.default",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			var observed = &ObservedClusterState{
+				cluster:     &v1beta1.FlinkCluster{},
+				configMap:   configMap,
+				flinkConfig: test.flinkConfig,
+				updateState: test.updateState,
+			}
+
+			var conditions = deriveConditions(observed, recorded)
+
+			assert.Equal(t, len(conditions), 1)
+			assert.Equal(t, conditions[0].Type, v1beta1.ClusterConditionConfigDrift)
+			assert.Equal(t, conditions[0].Status, test.expectedStatus)
+			assert.Equal(t, conditions[0].Message, test.expectedMessage)
+		})
+	}
+}
+
+func TestDerivePodsUnschedulableCondition(t *testing.T) {
+	var now = time.Now()
+	var newPod = func(component string, pendingFor time.Duration, message string) corev1.Pod {
+		return corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"component": component}},
+			Status: corev1.PodStatus{
+				Phase: corev1.PodPending,
+				Conditions: []corev1.PodCondition{{
+					Type:               corev1.PodScheduled,
+					Status:             corev1.ConditionFalse,
+					Reason:             corev1.PodReasonUnschedulable,
+					Message:            message,
+					LastTransitionTime: metav1.NewTime(now.Add(-pendingFor)),
+				}},
+			},
+		}
+	}
+	var runningPod = corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"component": "taskmanager"}},
+		Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+	var insufficientMemory = "0/3 nodes are available: 3 Insufficient memory. preemption: 0/3 nodes are available: 3 No preemption victims found for incoming pod."
+
+	for _, test := range []struct {
+		name            string
+		pods            []corev1.Pod
+		expectedStatus  metav1.ConditionStatus
+		expectedReason  string
+		expectedMessage string
+	}{
+		{
+			name: "taskmanagers unschedulable",
+			pods: []corev1.Pod{
+				runningPod,
+				newPod("taskmanager", 5*time.Minute, insufficientMemory),
+				newPod("taskmanager", 5*time.Minute, insufficientMemory),
+				newPod("taskmanager", 3*time.Minute, insufficientMemory),
+			},
+			expectedStatus:  metav1.ConditionTrue,
+			expectedReason:  v1beta1.PodsUnschedulableReasonInsufficientResources,
+			expectedMessage: "3 TaskManagers unschedulable: insufficient memory",
+		},
+		{
+			name: "jobmanager and taskmanager unschedulable",
+			pods: []corev1.Pod{
+				newPod("jobmanager", 5*time.Minute, "0/3 nodes are available: 3 node(s) didn't match Pod's node affinity/selector."),
+				newPod("taskmanager", 5*time.Minute, "0/3 nodes are available: pod has unbound immediate PersistentVolumeClaims."),
+			},
+			expectedStatus:  metav1.ConditionTrue,
+			expectedReason:  v1beta1.PodsUnschedulableReasonUnsatisfiableAffinity,
+			expectedMessage: "1 JobManager unschedulable: unsatisfiable affinity; 1 TaskManager unschedulable: unbound PersistentVolumeClaim",
+		},
+		{
+			name:            "pending during startup",
+			pods:            []corev1.Pod{runningPod, newPod("taskmanager", 30*time.Second, insufficientMemory)},
+			expectedStatus:  metav1.ConditionFalse,
+			expectedReason:  v1beta1.PodsUnschedulableReasonNone,
+			expectedMessage: "All pods are scheduled",
+		},
+		{
+			name:            "all pods scheduled",
+			pods:            []corev1.Pod{runningPod},
+			expectedStatus:  metav1.ConditionFalse,
+			expectedReason:  v1beta1.PodsUnschedulableReasonNone,
+			expectedMessage: "All pods are scheduled",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			var observed = &ObservedClusterState{
+				cluster:     &v1beta1.FlinkCluster{},
+				pods:        &corev1.PodList{Items: test.pods},
+				observeTime: now,
+			}
+
+			var conditions = deriveConditions(observed, nil)
+
+			assert.Equal(t, len(conditions), 1)
+			assert.Equal(t, conditions[0].Type, v1beta1.ClusterConditionPodsUnschedulable)
+			assert.Equal(t, conditions[0].Status, test.expectedStatus)
+			assert.Equal(t, conditions[0].Reason, test.expectedReason)
+			assert.Equal(t, conditions[0].Message, test.expectedMessage)
+		})
+	}
+}
+
+func TestDeriveTaskManagerRPCUnreachableCondition(t *testing.T) {
+	var newPod = func(ready bool, restarts int32, terminated corev1.ContainerStateTerminated) corev1.Pod {
+		var readyStatus = corev1.ConditionFalse
+		if ready {
+			readyStatus = corev1.ConditionTrue
+		}
+		return corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"component": "taskmanager"}},
+			Status: corev1.PodStatus{
+				Phase:      corev1.PodRunning,
+				Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: readyStatus}},
+				ContainerStatuses: []corev1.ContainerStatus{{
+					Name:                 "taskmanager",
+					RestartCount:         restarts,
+					LastTerminationState: corev1.ContainerState{Terminated: &terminated},
+				}},
+			},
+		}
+	}
+	var registrationTimeout = corev1.ContainerStateTerminated{
+		ExitCode: 1,
+		Reason:   "Error",
+		Message: "INFO  org.apache.flink.runtime.taskexecutor.TaskExecutor - Connecting to ResourceManager.\n" +
+			"ERROR org.apache.flink.runtime.taskexecutor.TaskManagerRunner - Fatal error occurred while executing the TaskManager. " +
+			"Could not register at the ResourceManager within the specified maximum registration duration PT5M.\n",
+	}
+	var connectionRefused = corev1.ContainerStateTerminated{
+		ExitCode: 1,
+		Reason:   "Error",
+		Message:  "Caused by: java.net.ConnectException: Connection refused: flink-jobmanager/10.0.0.5:6123",
+	}
+	var applicationError = corev1.ContainerStateTerminated{
+		ExitCode: 1,
+		Reason:   "Error",
+		Message:  "java.lang.NullPointerException\n\tat com.example.Job.map(Job.java:42)",
+	}
+	var outOfMemory = corev1.ContainerStateTerminated{ExitCode: 137, Reason: "OOMKilled"}
+	var heapExhausted = corev1.ContainerStateTerminated{
+		ExitCode: 1,
+		Reason:   "Error",
+		Message:  "java.lang.OutOfMemoryError: Java heap space\nCaused by: java.net.ConnectException: Connection refused",
+	}
+
+	for _, test := range []struct {
+		name            string
+		pods            []corev1.Pod
+		registration    *v1beta1.SlotRegistrationStatus
+		recorded        []metav1.Condition
+		expectedStatus  metav1.ConditionStatus
+		expectedReason  string
+		expectedMessage string
+	}{
+		{
+			name:            "registration timeout",
+			pods:            []corev1.Pod{newPod(false, 5, registrationTimeout), newPod(false, 3, registrationTimeout)},
+			expectedStatus:  metav1.ConditionTrue,
+			expectedReason:  v1beta1.TaskManagerRPCUnreachableReasonRegistrationFailing,
+			expectedMessage: "2 TaskManagers restarted up to 5 times without registering with the JobManager: ERROR org.apache.flink.runtime.taskexecutor.TaskManagerRunner - Fatal error occurred while executing the TaskManager. Could not register at the ResourceManager within the specified maximum registration duration PT5M.; check that JobManager RPC port 6123 is reachable from the TaskManager pods, e.g., network policies and jobmanager.rpc.port",
+		},
+		{
+			name:            "connection refused",
+			pods:            []corev1.Pod{newPod(false, 4, connectionRefused)},
+			expectedStatus:  metav1.ConditionTrue,
+			expectedReason:  v1beta1.TaskManagerRPCUnreachableReasonRegistrationFailing,
+			expectedMessage: "1 TaskManager restarted up to 4 times without registering with the JobManager: Caused by: java.net.ConnectException: Connection refused: flink-jobmanager/10.0.0.5:6123; check that JobManager RPC port 6123 is reachable from the TaskManager pods, e.g., network policies and jobmanager.rpc.port",
+		},
+		{
+			name: "ready but slot registration lagging",
+			pods: []corev1.Pod{newPod(true, 3, connectionRefused)},
+			registration: &v1beta1.SlotRegistrationStatus{
+				Groups: []v1beta1.TaskManagerGroupSlots{{Name: "taskmanager", ExpectedSlots: 4}},
+			},
+			expectedStatus:  metav1.ConditionTrue,
+			expectedReason:  v1beta1.TaskManagerRPCUnreachableReasonRegistrationFailing,
+			expectedMessage: "1 TaskManager restarted up to 3 times without registering with the JobManager: Caused by: java.net.ConnectException: Connection refused: flink-jobmanager/10.0.0.5:6123; check that JobManager RPC port 6123 is reachable from the TaskManager pods, e.g., network policies and jobmanager.rpc.port",
+		},
+		{
+			name: "registered after restarts",
+			pods: []corev1.Pod{newPod(true, 3, connectionRefused)},
+			registration: &v1beta1.SlotRegistrationStatus{
+				Groups: []v1beta1.TaskManagerGroupSlots{{Name: "taskmanager", ExpectedSlots: 4, RegisteredSlots: 4}},
+			},
+		},
+		{
+			name: "few restarts",
+			pods: []corev1.Pod{newPod(false, 2, registrationTimeout)},
+		},
+		{
+			name: "application crash",
+			pods: []corev1.Pod{newPod(false, 5, applicationError)},
+		},
+		{
+			name: "out of memory",
+			pods: []corev1.Pod{newPod(false, 5, outOfMemory), newPod(false, 5, heapExhausted)},
+		},
+		{
+			name: "recovered",
+			pods: []corev1.Pod{newPod(true, 5, registrationTimeout)},
+			recorded: []metav1.Condition{{
+				Type:   v1beta1.ClusterConditionTaskManagerRPCUnreachable,
+				Status: metav1.ConditionTrue,
+				Reason: v1beta1.TaskManagerRPCUnreachableReasonRegistrationFailing,
+			}},
+			expectedStatus:  metav1.ConditionFalse,
+			expectedReason:  v1beta1.TaskManagerRPCUnreachableReasonNone,
+			expectedMessage: "No TaskManager fails to register with the JobManager",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			var observed = &ObservedClusterState{
+				cluster:          &v1beta1.FlinkCluster{},
+				pods:             &corev1.PodList{Items: test.pods},
+				slotRegistration: test.registration,
+			}
+
+			var condition = meta.FindStatusCondition(
+				deriveConditions(observed, test.recorded), v1beta1.ClusterConditionTaskManagerRPCUnreachable)
+
+			if test.expectedStatus == "" {
+				assert.Assert(t, condition == nil)
+				return
+			}
+			assert.Assert(t, condition != nil)
+			assert.Equal(t, condition.Status, test.expectedStatus)
+			assert.Equal(t, condition.Reason, test.expectedReason)
+			assert.Equal(t, condition.Message, test.expectedMessage)
+		})
+	}
+}
+
+func TestDeriveImageArchitectureConflictCondition(t *testing.T) {
+	var arm64 = v1beta1.ImageArchitectureARM64
+	var multiArch = v1beta1.ImageArchitectureMultiArch
+	var newNode = func(name, arch string) *corev1.Node {
+		return &corev1.Node{ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{"kubernetes.io/arch": arch},
+		}}
+	}
+
+	for _, test := range []struct {
+		name            string
+		architecture    *v1beta1.ImageArchitecture
+		nodes           []*corev1.Node
+		expectedStatus  metav1.ConditionStatus
+		expectedReason  string
+		expectedMessage string
+	}{
+		{
+			name:            "matching node pool",
+			architecture:    &arm64,
+			nodes:           []*corev1.Node{newNode("node-1", "amd64"), newNode("node-2", "arm64")},
+			expectedStatus:  metav1.ConditionFalse,
+			expectedReason:  v1beta1.ImageArchitectureConflictReasonNone,
+			expectedMessage: "Nodes of the image architecture arm64 are available",
+		},
+		{
+			name:           "conflicting node pools",
+			architecture:   &arm64,
+			nodes:          []*corev1.Node{newNode("node-1", "amd64"), newNode("node-2", "amd64"), newNode("node-3", "s390x")},
+			expectedStatus: metav1.ConditionTrue,
+			expectedReason: v1beta1.ImageArchitectureConflictReasonNoMatchingNodes,
+			expectedMessage: "No node has the image architecture arm64 in its kubernetes.io/arch label, the nodes have amd64, s390x; " +
+				"the pods cannot be scheduled, check image.architecture or add nodes of the architecture",
+		},
+		{
+			name:         "multi-arch image",
+			architecture: &multiArch,
+			nodes:        []*corev1.Node{newNode("node-1", "amd64")},
+		},
+		{
+			name:  "not declared",
+			nodes: []*corev1.Node{newNode("node-1", "amd64")},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			var builder = fake.NewClientBuilder()
+			for _, node := range test.nodes {
+				builder = builder.WithObjects(node)
+			}
+			var cluster = &v1beta1.FlinkCluster{
+				Spec: v1beta1.FlinkClusterSpec{Image: v1beta1.ImageSpec{Name: "flink:1.18", Architecture: test.architecture}},
+			}
+			var observed = &ObservedClusterState{cluster: cluster}
+			var observer = &ClusterStateObserver{k8sClient: builder.Build()}
+			observer.observeNodeArchitectures(context.Background(), observed)
+
+			var recorder = record.NewFakeRecorder(4)
+			var updater = &ClusterStatusUpdater{observed: *observed, recorder: recorder}
+			var oldStatus = v1beta1.FlinkClusterStatus{}
+			var newStatus = v1beta1.FlinkClusterStatus{Conditions: deriveConditions(observed, nil)}
+			updater.createStatusChangeEvents(oldStatus, newStatus)
+
+			var condition = meta.FindStatusCondition(newStatus.Conditions, v1beta1.ClusterConditionImageArchitectureConflict)
+			if test.expectedStatus == "" {
+				assert.Assert(t, condition == nil)
+				return
+			}
+			assert.Assert(t, condition != nil)
+			assert.Equal(t, condition.Status, test.expectedStatus)
+			assert.Equal(t, condition.Reason, test.expectedReason)
+			assert.Equal(t, condition.Message, test.expectedMessage)
+			if test.expectedStatus == metav1.ConditionTrue {
+				assert.Equal(t, <-recorder.Events, "Warning ImageArchitectureConflict "+test.expectedMessage)
+			}
+			assert.Equal(t, len(recorder.Events), 0)
+		})
+	}
+}
+
+func TestDeriveUnexpectedJobCompletionCondition(t *testing.T) {
+	var streaming = v1beta1.JobExecutionModeStreaming
+	var batch = v1beta1.JobExecutionModeBatch
+	var restartPolicy = v1beta1.JobRestartPolicyFromSavepointOnFailure
+	var completed = []metav1.Condition{{
+		Type:   v1beta1.ClusterConditionUnexpectedJobCompletion,
+		Status: metav1.ConditionTrue,
+		Reason: v1beta1.UnexpectedJobCompletionReasonStreamingJobCompleted,
+	}}
+
+	for _, test := range []struct {
+		name            string
+		executionMode   *v1beta1.JobExecutionMode
+		restartPolicy   *v1beta1.JobRestartPolicy
+		job             *v1beta1.JobStatus
+		recorded        []metav1.Condition
+		expectedStatus  metav1.ConditionStatus
+		expectedMessage string
+	}{
+		{
+			name:            "streaming job completed",
+			executionMode:   &streaming,
+			restartPolicy:   &restartPolicy,
+			job:             &v1beta1.JobStatus{ID: "a1b2c3", State: v1beta1.JobStateSucceeded, SavepointLocation: "gs://my-bucket/savepoint-1"},
+			expectedStatus:  metav1.ConditionTrue,
+			expectedMessage: "The streaming job a1b2c3 completed unexpectedly, a bounded source may have ended; restarting the job",
+		},
+		{
+			name:            "streaming job completed without restart policy",
+			executionMode:   &streaming,
+			job:             &v1beta1.JobStatus{ID: "a1b2c3", State: v1beta1.JobStateSucceeded},
+			expectedStatus:  metav1.ConditionTrue,
+			expectedMessage: "The streaming job a1b2c3 completed unexpectedly, a bounded source may have ended",
+		},
+		{
+			name:          "streaming job stopped with a final savepoint",
+			executionMode: &streaming,
+			job:           &v1beta1.JobStatus{ID: "a1b2c3", State: v1beta1.JobStateSucceeded, FinalSavepoint: true},
+		},
+		{
+			name:          "batch job completed",
+			executionMode: &batch,
+			job:           &v1beta1.JobStatus{ID: "a1b2c3", State: v1beta1.JobStateSucceeded},
+		},
+		{
+			name: "job without execution mode completed",
+			job:  &v1beta1.JobStatus{ID: "a1b2c3", State: v1beta1.JobStateSucceeded},
+		},
+		{
+			name:            "restarted streaming job running again",
+			executionMode:   &streaming,
+			job:             &v1beta1.JobStatus{ID: "d4e5f6", State: v1beta1.JobStateRunning},
+			recorded:        completed,
+			expectedStatus:  metav1.ConditionFalse,
+			expectedMessage: "The streaming job is not completed",
+		},
+		{
+			name:          "streaming job running",
+			executionMode: &streaming,
+			job:           &v1beta1.JobStatus{ID: "d4e5f6", State: v1beta1.JobStateRunning},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			var observed = &ObservedClusterState{
+				cluster: &v1beta1.FlinkCluster{
+					Spec: v1beta1.FlinkClusterSpec{
+						Job: &v1beta1.JobSpec{ExecutionMode: test.executionMode, RestartPolicy: test.restartPolicy},
+					},
+				},
+			}
+			var recorder = record.NewFakeRecorder(4)
+			var updater = &ClusterStatusUpdater{observed: *observed, recorder: recorder}
+			var oldStatus = v1beta1.FlinkClusterStatus{Conditions: test.recorded}
+			var newStatus = v1beta1.FlinkClusterStatus{}
+			if condition := deriveUnexpectedJobCompletionCondition(observed, test.job, test.recorded); condition != nil {
+				meta.SetStatusCondition(&newStatus.Conditions, *condition)
+			}
+			updater.createStatusChangeEvents(oldStatus, newStatus)
+
+			var condition = meta.FindStatusCondition(newStatus.Conditions, v1beta1.ClusterConditionUnexpectedJobCompletion)
+			if test.expectedStatus == "" {
+				assert.Assert(t, condition == nil)
+				return
+			}
+			assert.Assert(t, condition != nil)
+			assert.Equal(t, condition.Status, test.expectedStatus)
+			assert.Equal(t, condition.Message, test.expectedMessage)
+			if test.expectedStatus == metav1.ConditionTrue {
+				assert.Equal(t, <-recorder.Events, "Warning UnexpectedJobCompletion "+test.expectedMessage)
+			}
+			assert.Equal(t, len(recorder.Events), 0)
+		})
+	}
+}
+
+func TestDeriveJobManagerReplicaDriftCondition(t *testing.T) {
+	var replicas int32 = 1
+	var scaledDown int32 = 0
+	var warn = v1beta1.ReplicaDriftPolicyWarn
+	var observed = &ObservedClusterState{
+		cluster: &v1beta1.FlinkCluster{
+			Spec: v1beta1.FlinkClusterSpec{
+				JobManager: &v1beta1.JobManagerSpec{Replicas: &replicas, ReplicaDriftPolicy: &warn},
+			},
+		},
+		jmStatefulSet: &appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-jobmanager"},
+			Spec:       appsv1.StatefulSetSpec{Replicas: &scaledDown},
+		},
+	}
+	var recorder = record.NewFakeRecorder(4)
+	var updater = &ClusterStatusUpdater{observed: *observed, recorder: recorder}
+	var derive = func(oldStatus v1beta1.FlinkClusterStatus) v1beta1.FlinkClusterStatus {
+		var newStatus = v1beta1.FlinkClusterStatus{Conditions: oldStatus.Conditions}
+		if condition := deriveJobManagerReplicaDriftCondition(observed, oldStatus.Conditions); condition != nil {
+			newStatus.Conditions = nil
+			meta.SetStatusCondition(&newStatus.Conditions, *condition)
+		}
+		updater.createStatusChangeEvents(oldStatus, newStatus)
+		return newStatus
+	}
+
+	// The drift begins with the Warn policy.
+	var status = derive(v1beta1.FlinkClusterStatus{})
+	var condition = meta.FindStatusCondition(status.Conditions, v1beta1.ClusterConditionJobManagerReplicaDrift)
+	assert.Equal(t, condition.Status, metav1.ConditionTrue)
+	assert.Equal(t, condition.Reason, v1beta1.JobManagerReplicaDriftReasonDrifted)
+	assert.Equal(t, <-recorder.Events,
+		"Warning JobManagerReplicaDrift JobManager StatefulSet cluster-jobmanager is scaled to 0 replicas instead of 1")
+
+	// The ongoing drift is not reported again.
+	status = derive(status)
+	assert.Assert(t, meta.IsStatusConditionTrue(status.Conditions, v1beta1.ClusterConditionJobManagerReplicaDrift))
+	assert.Equal(t, len(recorder.Events), 0)
+
+	// The StatefulSet is scaled back.
+	observed.jmStatefulSet.Spec.Replicas = &replicas
+	status = derive(status)
+	condition = meta.FindStatusCondition(status.Conditions, v1beta1.ClusterConditionJobManagerReplicaDrift)
+	assert.Equal(t, condition.Status, metav1.ConditionFalse)
+	assert.Equal(t, condition.Reason, v1beta1.JobManagerReplicaDriftReasonNone)
+
+	// The drift is scaled back by the reconciler with the Reconcile policy.
+	observed.jmStatefulSet.Spec.Replicas = &scaledDown
+	observed.cluster.Spec.JobManager.ReplicaDriftPolicy = nil
+	assert.Assert(t, deriveJobManagerReplicaDriftCondition(observed, nil) == nil)
+	assert.Equal(t, len(recorder.Events), 0)
+}
+
+func TestDeriveOperatorUIDs(t *testing.T) {
+	var plan = &flink.JobPlan{}
+	plan.Plan.Nodes = []flink.JobPlanNode{
+		{ID: "90bea66de1c231edf33913ecd54406c1", Description: "Sink: output"},
+		{ID: "cbc357ccb763df2852fee8c4fc7d55f2", Description: "Source: events"},
+	}
+	var recorded = &v1beta1.OperatorUIDsStatus{
+		JobID:   "a1b2c3",
+		Current: []string{"6d2677a0ecc3fd8df0b72ec675edf8f4", "cbc357ccb763df2852fee8c4fc7d55f2"},
+	}
+	for _, test := range []struct {
+		name     string
+		plan     *flink.JobPlan
+		job      *v1beta1.JobStatus
+		recorded *v1beta1.OperatorUIDsStatus
+		expected *v1beta1.OperatorUIDsStatus
+	}{
+		{
+			name: "first job run",
+			plan: plan,
+			job:  &v1beta1.JobStatus{ID: "a1b2c3"},
+			expected: &v1beta1.OperatorUIDsStatus{
+				JobID:   "a1b2c3",
+				Current: []string{"90bea66de1c231edf33913ecd54406c1", "cbc357ccb763df2852fee8c4fc7d55f2"},
+			},
+		},
+		{
+			name:     "plan not observed",
+			job:      &v1beta1.JobStatus{ID: "d4e5f6"},
+			recorded: recorded,
+			expected: recorded,
+		},
+		{
+			name:     "plan of the recorded job",
+			plan:     plan,
+			job:      &v1beta1.JobStatus{ID: "a1b2c3"},
+			recorded: recorded,
+			expected: recorded,
+		},
+		{
+			name:     "job restored from a savepoint with a removed operator",
+			plan:     plan,
+			job:      &v1beta1.JobStatus{ID: "d4e5f6", FromSavepoint: "gs://my-bucket/savepoint-1"},
+			recorded: recorded,
+			expected: &v1beta1.OperatorUIDsStatus{
+				JobID:    "d4e5f6",
+				Current:  []string{"90bea66de1c231edf33913ecd54406c1", "cbc357ccb763df2852fee8c4fc7d55f2"},
+				Previous: []string{"6d2677a0ecc3fd8df0b72ec675edf8f4", "cbc357ccb763df2852fee8c4fc7d55f2"},
+				Removed:  []string{"6d2677a0ecc3fd8df0b72ec675edf8f4"},
+			},
+		},
+		{
+			name:     "job started without a savepoint",
+			plan:     plan,
+			job:      &v1beta1.JobStatus{ID: "d4e5f6"},
+			recorded: recorded,
+			expected: &v1beta1.OperatorUIDsStatus{
+				JobID:    "d4e5f6",
+				Current:  []string{"90bea66de1c231edf33913ecd54406c1", "cbc357ccb763df2852fee8c4fc7d55f2"},
+				Previous: []string{"6d2677a0ecc3fd8df0b72ec675edf8f4", "cbc357ccb763df2852fee8c4fc7d55f2"},
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			assert.DeepEqual(t, deriveOperatorUIDs(test.plan, test.job, test.recorded), test.expected)
+		})
+	}
+}
+
+func TestDeriveOperatorUIDsChangedCondition(t *testing.T) {
+	var changed = []metav1.Condition{{
+		Type:    v1beta1.ClusterConditionOperatorUIDsChanged,
+		Status:  metav1.ConditionTrue,
+		Reason:  v1beta1.OperatorUIDsChangedReasonRemoved,
+		Message: "The job a1b2c3 restored from savepoint gs://my-bucket/savepoint-1 has no operators with the UIDs 6d2677a0 of the previous job run, their state is not restored",
+	}}
+
+	for _, test := range []struct {
+		name            string
+		job             *v1beta1.JobStatus
+		recorded        []metav1.Condition
+		expectedStatus  metav1.ConditionStatus
+		expectedMessage string
+		expectedEvent   bool
+	}{
+		{
+			name: "operator UIDs removed",
+			job: &v1beta1.JobStatus{
+				ID:            "d4e5f6",
+				FromSavepoint: "gs://my-bucket/savepoint-2",
+				OperatorUIDs:  &v1beta1.OperatorUIDsStatus{JobID: "d4e5f6", Removed: []string{"6d2677a0", "90bea66d"}},
+			},
+			recorded:        changed,
+			expectedStatus:  metav1.ConditionTrue,
+			expectedMessage: "The job d4e5f6 restored from savepoint gs://my-bucket/savepoint-2 has no operators with the UIDs 6d2677a0, 90bea66d of the previous job run, their state is not restored",
+			expectedEvent:   true,
+		},
+		{
+			name: "same job reconciled again",
+			job: &v1beta1.JobStatus{
+				ID:            "a1b2c3",
+				FromSavepoint: "gs://my-bucket/savepoint-1",
+				OperatorUIDs:  &v1beta1.OperatorUIDsStatus{JobID: "a1b2c3", Removed: []string{"6d2677a0"}},
+			},
+			recorded:        changed,
+			expectedStatus:  metav1.ConditionTrue,
+			expectedMessage: changed[0].Message,
+		},
+		{
+			name:            "operator UIDs kept by the next job run",
+			job:             &v1beta1.JobStatus{ID: "d4e5f6", OperatorUIDs: &v1beta1.OperatorUIDsStatus{JobID: "d4e5f6"}},
+			recorded:        changed,
+			expectedStatus:  metav1.ConditionFalse,
+			expectedMessage: "The job d4e5f6 has the operator UIDs of the previous job run",
+		},
+		{
+			name: "operator UIDs stable",
+			job:  &v1beta1.JobStatus{ID: "d4e5f6", OperatorUIDs: &v1beta1.OperatorUIDsStatus{JobID: "d4e5f6"}},
+		},
+		{
+			name: "operator UIDs not observed",
+			job:  &v1beta1.JobStatus{ID: "d4e5f6"},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			var observed = &ObservedClusterState{cluster: &v1beta1.FlinkCluster{}}
+			var recorder = record.NewFakeRecorder(4)
+			var updater = &ClusterStatusUpdater{observed: *observed, recorder: recorder}
+			var oldStatus = v1beta1.FlinkClusterStatus{Conditions: test.recorded}
+			var newStatus = v1beta1.FlinkClusterStatus{Conditions: slices.Clone(test.recorded)}
+			if condition := deriveOperatorUIDsChangedCondition(observed, test.job, test.recorded); condition != nil {
+				meta.SetStatusCondition(&newStatus.Conditions, *condition)
+			}
+			updater.createStatusChangeEvents(oldStatus, newStatus)
+
+			var condition = meta.FindStatusCondition(newStatus.Conditions, v1beta1.ClusterConditionOperatorUIDsChanged)
+			if test.expectedStatus == "" {
+				assert.Assert(t, condition == nil)
+				return
+			}
+			assert.Assert(t, condition != nil)
+			assert.Equal(t, condition.Status, test.expectedStatus)
+			assert.Equal(t, condition.Message, test.expectedMessage)
+			if test.expectedEvent {
+				assert.Equal(t, <-recorder.Events, "Warning OperatorUIDsChanged "+test.expectedMessage)
+			}
+			assert.Equal(t, len(recorder.Events), 0)
+		})
+	}
+}
+
+func TestDerivePendingActionCondition(t *testing.T) {
+	var restartPolicy = v1beta1.JobRestartPolicyFromSavepointOnFailure
+	var running = &v1beta1.JobStatus{State: v1beta1.JobStateRunning}
+	var revision = v1beta1.RevisionStatus{CurrentRevision: "cluster-1", NextRevision: "cluster-2"}
+	tests := []struct {
+		name            string
+		status          v1beta1.FlinkClusterStatus
+		updateState     UpdateState
+		expectedStatus  metav1.ConditionStatus
+		expectedReason  string
+		expectedMessage string
+	}{
+		{
+			name:            "stable",
+			status:          v1beta1.FlinkClusterStatus{Components: v1beta1.FlinkClusterComponentsStatus{Job: running}},
+			expectedStatus:  metav1.ConditionFalse,
+			expectedReason:  v1beta1.PendingActionReasonNone,
+			expectedMessage: "No action is pending",
+		},
+		{
+			name: "failed job restart",
+			status: v1beta1.FlinkClusterStatus{Components: v1beta1.FlinkClusterComponentsStatus{
+				Job: &v1beta1.JobStatus{State: v1beta1.JobStateFailed, SavepointLocation: "gs://my-bucket/savepoint-1"},
+			}},
+			updateState:     UpdateStatePreparing,
+			expectedStatus:  metav1.ConditionTrue,
+			expectedReason:  v1beta1.PendingActionReasonRestartJob,
+			expectedMessage: "Restarting the failed job from savepoint gs://my-bucket/savepoint-1",
+		},
+		{
+			name:            "update preparing",
+			status:          v1beta1.FlinkClusterStatus{Components: v1beta1.FlinkClusterComponentsStatus{Job: running}, Revision: revision},
+			updateState:     UpdateStatePreparing,
+			expectedStatus:  metav1.ConditionTrue,
+			expectedReason:  v1beta1.PendingActionReasonUpdate,
+			expectedMessage: "Preparing to update the cluster to revision cluster-2",
+		},
+		{
+			name:            "update in progress",
+			status:          v1beta1.FlinkClusterStatus{Revision: revision},
+			updateState:     UpdateStateInProgress,
+			expectedStatus:  metav1.ConditionTrue,
+			expectedReason:  v1beta1.PendingActionReasonUpdate,
+			expectedMessage: "Updating the cluster to revision cluster-2",
+		},
+		{
+			name: "savepoint in progress",
+			status: v1beta1.FlinkClusterStatus{
+				Components: v1beta1.FlinkClusterComponentsStatus{Job: running},
+				Savepoint:  &v1beta1.SavepointStatus{TriggerID: "trigger-1", State: v1beta1.SavepointStateInProgress},
+			},
+			expectedStatus:  metav1.ConditionTrue,
+			expectedReason:  v1beta1.PendingActionReasonSavepoint,
+			expectedMessage: "Savepoint trigger-1 is in progress",
+		},
+		{
+			name: "waiting for savepoint",
+			status: v1beta1.FlinkClusterStatus{Conditions: []metav1.Condition{{
+				Type:    v1beta1.ClusterConditionSavepointAvailable,
+				Status:  metav1.ConditionFalse,
+				Reason:  v1beta1.SavepointAvailableReasonWaiting,
+				Message: "Waiting for savepoint gs://my-bucket/savepoint-1 to be available",
+			}}},
+			expectedStatus:  metav1.ConditionTrue,
+			expectedReason:  v1beta1.PendingActionReasonWaitForSavepoint,
+			expectedMessage: "Waiting for savepoint gs://my-bucket/savepoint-1 to be available",
+		},
+		{
+			name: "scale-up waiting for nodes",
+			status: v1beta1.FlinkClusterStatus{
+				Components: v1beta1.FlinkClusterComponentsStatus{Job: running},
+				Conditions: []metav1.Condition{{
+					Type:    v1beta1.ClusterConditionPodsUnschedulable,
+					Status:  metav1.ConditionTrue,
+					Reason:  v1beta1.PodsUnschedulableReasonInsufficientResources,
+					Message: "2 TaskManagers unschedulable: 0/3 nodes are available: 3 Insufficient cpu.",
+				}},
+			},
+			expectedStatus:  metav1.ConditionTrue,
+			expectedReason:  v1beta1.PendingActionReasonWaitForScheduling,
+			expectedMessage: "Waiting for the pods to be scheduled, 2 TaskManagers unschedulable: 0/3 nodes are available: 3 Insufficient cpu.",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var observed = &ObservedClusterState{
+				cluster: &v1beta1.FlinkCluster{
+					ObjectMeta: metav1.ObjectMeta{Generation: 2},
+					Spec:       v1beta1.FlinkClusterSpec{Job: &v1beta1.JobSpec{RestartPolicy: &restartPolicy}},
+				},
+				updateState: tt.updateState,
+			}
+
+			var condition = derivePendingActionCondition(observed, &tt.status)
+
+			assert.Equal(t, condition.Type, v1beta1.ClusterConditionPendingAction)
+			assert.Equal(t, condition.Status, tt.expectedStatus)
+			assert.Equal(t, condition.Reason, tt.expectedReason)
+			assert.Equal(t, condition.Message, tt.expectedMessage)
+			assert.Equal(t, condition.ObservedGeneration, int64(2))
+		})
+	}
+}
+
+func TestDeriveSavepointSkippedCondition(t *testing.T) {
+	var disabled = false
+	var budget int32 = 60
+	var slow int64 = 90
+	var fromSavepoint = "gs://my-bucket/savepoint-1"
+	tests := []struct {
+		name            string
+		jobSpec         v1beta1.JobSpec
+		annotations     map[string]string
+		inventory       []v1beta1.SavepointRecord
+		expectedReason  string
+		expectedMessage string
+	}{
+		{
+			name: "savepoint taken",
+		},
+		{
+			name:            "takeSavepointOnUpdate disabled",
+			jobSpec:         v1beta1.JobSpec{TakeSavepointOnUpdate: &disabled},
+			expectedReason:  v1beta1.SavepointSkippedReasonDisabled,
+			expectedMessage: "The job was updated without a savepoint as takeSavepointOnUpdate is false",
+		},
+		{
+			name:           "skip annotation",
+			annotations:    map[string]string{v1beta1.SkipSavepointOnNextUpdateAnnotation: "1"},
+			expectedReason: v1beta1.SavepointSkippedReasonAnnotation,
+			expectedMessage: "The job was updated without a savepoint as requested by the " +
+				"flinkclusters.flinkoperator.k8s.io/skip-savepoint-on-next-update annotation with nonce 1",
+		},
+		{
+			name:            "downtime budget exceeded",
+			jobSpec:         v1beta1.JobSpec{MaxUpdateDowntimeSeconds: &budget},
+			inventory:       []v1beta1.SavepointRecord{{Location: fromSavepoint, DurationSeconds: &slow}},
+			expectedReason:  v1beta1.SavepointSkippedReasonBudgetExceeded,
+			expectedMessage: "The job was updated without a savepoint as it does not fit in maxUpdateDowntimeSeconds 60",
+		},
+		{
+			name:    "restored from fromSavepoint",
+			jobSpec: v1beta1.JobSpec{TakeSavepointOnUpdate: &disabled, FromSavepoint: &fromSavepoint},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var jobSpec = tt.jobSpec
+			var observed = &ObservedClusterState{
+				cluster: &v1beta1.FlinkCluster{
+					ObjectMeta: metav1.ObjectMeta{Generation: 2, Annotations: tt.annotations},
+					Spec:       v1beta1.FlinkClusterSpec{Job: &jobSpec},
+					Status: v1beta1.FlinkClusterStatus{
+						Components:         v1beta1.FlinkClusterComponentsStatus{Job: &v1beta1.JobStatus{State: v1beta1.JobStateRunning}},
+						Revision:           v1beta1.RevisionStatus{CurrentRevision: "cluster-1", NextRevision: "cluster-2"},
+						SavepointInventory: tt.inventory,
+					},
+				},
+				observeTime: time.Now(),
+			}
+
+			var conditions = deriveConditions(observed, nil)
+			var skipped = meta.FindStatusCondition(conditions, v1beta1.ClusterConditionSavepointSkipped)
+			if tt.expectedReason == "" {
+				assert.Assert(t, skipped == nil)
+				return
+			}
+			assert.Equal(t, skipped.Status, metav1.ConditionTrue)
+			assert.Equal(t, skipped.Reason, tt.expectedReason)
+			assert.Equal(t, skipped.Message, tt.expectedMessage)
+			assert.Equal(t, skipped.ObservedGeneration, int64(2))
+
+			// The condition is kept once the update is completed.
+			observed.cluster.Status.Revision.CurrentRevision = "cluster-2"
+			observed.cluster.Status.Components.Job.SkipSavepointNonce = "1"
+			conditions = deriveConditions(observed, conditions)
+			assert.Assert(t, meta.IsStatusConditionTrue(conditions, v1beta1.ClusterConditionSavepointSkipped))
+
+			// A later update taking the savepoint clears it.
+			observed.cluster.Generation = 3
+			observed.cluster.Status.Revision.NextRevision = "cluster-3"
+			observed.cluster.Spec.Job = &v1beta1.JobSpec{}
+			observed.cluster.Status.SavepointInventory = nil
+			conditions = deriveConditions(observed, conditions)
+			assert.Assert(t, meta.FindStatusCondition(conditions, v1beta1.ClusterConditionSavepointSkipped) == nil)
+		})
+	}
+}
+
+func TestDeriveSavepointStatusTimeout(t *testing.T) {
+	var updateSeconds int32 = 1800
+	var scheduledSeconds int32 = 300
+	var jobID = "job-1"
+	var updater = &ClusterStatusUpdater{}
+	var observed = &ObservedClusterState{
+		cluster: &v1beta1.FlinkCluster{
+			Spec: v1beta1.FlinkClusterSpec{
+				Job: &v1beta1.JobSpec{
+					SavepointTimeouts: &v1beta1.SavepointTimeoutsSpec{
+						UpdateSeconds:    &updateSeconds,
+						ScheduledSeconds: &scheduledSeconds,
+					},
+				},
+			},
+		},
+		observeTime: time.Date(2026, 1, 1, 0, 15, 0, 0, time.UTC),
+	}
+	var inProgress = func(reason v1beta1.SavepointReason) *v1beta1.SavepointStatus {
+		return &v1beta1.SavepointStatus{
+			JobID:         jobID,
+			TriggerTime:   "2026-01-01T00:00:00Z",
+			TriggerReason: reason,
+			State:         v1beta1.SavepointStateInProgress,
+		}
+	}
+	var jobStatus = &v1beta1.JobStatus{State: v1beta1.JobStateRunning}
+
+	// The scheduled savepoint took longer than its timeout.
+	var s = updater.deriveSavepointStatus(observed, inProgress(v1beta1.SavepointReasonScheduled), jobStatus, &jobID)
+	assert.Equal(t, s.State, v1beta1.SavepointStateFailed)
+	assert.Equal(t, s.Message, "Savepoint timed out after 5m0s")
+
+	// The update savepoint is allowed to take longer.
+	s = updater.deriveSavepointStatus(observed, inProgress(v1beta1.SavepointReasonUpdate), jobStatus, &jobID)
+	assert.Equal(t, s.State, v1beta1.SavepointStateInProgress)
+
+	// The savepoint requested by the user without a timeout is left for Flink to time out.
+	s = updater.deriveSavepointStatus(observed, inProgress(v1beta1.SavepointReasonUserRequested), jobStatus, &jobID)
+	assert.Equal(t, s.State, v1beta1.SavepointStateInProgress)
+}
+
+func TestDeriveSavepointInventory(t *testing.T) {
+	var size int64 = 1024
+	var cluster = &v1beta1.FlinkCluster{Spec: v1beta1.FlinkClusterSpec{FlinkVersion: "1.20"}}
+	var tc = &util.TimeConverter{}
+	var recordedSavepoint = &v1beta1.SavepointStatus{
+		JobID:         "job-1",
+		TriggerReason: v1beta1.SavepointReasonScheduled,
+		State:         v1beta1.SavepointStateInProgress,
+		TriggerTime:   tc.ToString(time.Now().Add(-time.Minute)),
+	}
+	var recorded = []v1beta1.SavepointRecord{{Location: "gs://bucket/savepoint-0", JobID: "job-1"}}
+
+	// given: a successful savepoint
+	var observed = &Savepoint{
+		status: &flink.SavepointStatus{JobID: "job-1", Completed: true, Location: "gs://bucket/savepoint-1"},
+		size:   &size,
+	}
+
+	// when
+	var inventory = deriveSavepointInventory(cluster, observed, recordedSavepoint, recorded)
+
+	// then: the savepoint is appended with its producing version and size
+	assert.Equal(t, len(inventory), 2)
+	assert.Equal(t, inventory[1].Location, "gs://bucket/savepoint-1")
+	assert.Equal(t, inventory[1].JobID, "job-1")
+	assert.Equal(t, inventory[1].TriggerReason, v1beta1.SavepointReasonScheduled)
+	assert.Equal(t, inventory[1].FlinkVersion, "1.20")
+	assert.Equal(t, *inventory[1].SizeBytes, size)
+	assert.Assert(t, inventory[1].Time != "")
+	assert.Assert(t, *inventory[1].DurationSeconds >= 60)
+
+	// when: the same savepoint is observed again
+	inventory = deriveSavepointInventory(cluster, observed, recordedSavepoint, inventory)
+
+	// then: it is not duplicated
+	assert.Equal(t, len(inventory), 2)
+
+	// when: the savepoint is still in progress
+	observed = &Savepoint{status: &flink.SavepointStatus{JobID: "job-1"}}
+	inventory = deriveSavepointInventory(cluster, observed, recordedSavepoint, recorded)
+
+	// then: the recorded inventory is kept
+	assert.DeepEqual(t, inventory, recorded)
+}
+
+func TestDeriveSlotRegistration(t *testing.T) {
+	var start = time.Now()
+	var snapshot = func(registeredA, registeredB int32) *v1beta1.SlotRegistrationStatus {
+		return &v1beta1.SlotRegistrationStatus{Groups: []v1beta1.TaskManagerGroupSlots{
+			{Name: "tm-a", ExpectedSlots: 8, RegisteredSlots: registeredA},
+			{Name: "tm-b", ExpectedSlots: 8, RegisteredSlots: registeredB},
+		}}
+	}
+
+	// given: both groups are starting up
+	var registration = deriveSlotRegistration(snapshot(2, 0), nil, start)
+
+	// then: the transient lag is tracked but not reported
+	assert.Assert(t, registration.Groups[0].LaggingSince != "")
+	assert.Assert(t, registration.Groups[1].LaggingSince != "")
+	assert.Assert(t, registration.LaggingGroups == nil)
+
+	// when: one group registers while the other stalls within the grace period
+	registration = deriveSlotRegistration(snapshot(8, 2), registration, start.Add(time.Minute))
+
+	// then
+	assert.Equal(t, registration.Groups[0].LaggingSince, "")
+	assert.Assert(t, registration.LaggingGroups == nil)
+
+	// when: the other group stays stalled past the grace period
+	registration = deriveSlotRegistration(snapshot(8, 2), registration, start.Add(6*time.Minute))
+
+	// then: the lagging group is reported
+	assert.DeepEqual(t, registration.LaggingGroups, []string{"tm-b"})
+
+	// when: not polled
+	var recorded = deriveSlotRegistration(nil, registration, start.Add(7*time.Minute))
+
+	// then: the recorded registration is kept
+	assert.DeepEqual(t, recorded, registration)
+
+	// when: the group catches up
+	registration = deriveSlotRegistration(snapshot(8, 8), registration, start.Add(8*time.Minute))
+
+	// then: the lag is cleared
+	assert.Equal(t, registration.Groups[1].LaggingSince, "")
+	assert.Assert(t, registration.LaggingGroups == nil)
+}
+
+func TestDeriveSlotRegistrationHealth(t *testing.T) {
+	var start = time.Now()
+	var observed = &v1beta1.SlotRegistrationStatus{Groups: []v1beta1.TaskManagerGroupSlots{
+		{Name: "tm-a", Replicas: 2, ReadyReplicas: 2, ExpectedSlots: 4, RegisteredSlots: 4},
+		{Name: "tm-b", Replicas: 2, ReadyReplicas: 1, ExpectedSlots: 2, RegisteredSlots: 2,
+			LastError: "tm-b-1: CrashLoopBackOff: back-off restarting failed container"},
+		{Name: "tm-c", Replicas: 2, ReadyReplicas: 2, ExpectedSlots: 4, RegisteredSlots: 0},
+		{Name: "tm-d"},
+	}}
+
+	// when: one group has a crashing pod and another one has not registered its slots yet
+	var registration = deriveSlotRegistration(observed, nil, start)
+
+	// then: the groups are not reported unhealthy within the grace period
+	assert.Equal(t, registration.Groups[0].UnhealthySince, "")
+	assert.Assert(t, registration.Groups[1].UnhealthySince != "")
+	assert.Assert(t, registration.Groups[2].UnhealthySince != "")
+	for _, group := range registration.Groups {
+		assert.Equal(t, group.Healthy, true)
+	}
+	assert.Assert(t, registration.UnhealthyGroups == nil)
+
+	// when: the groups stay degraded past the grace period
+	registration = deriveSlotRegistration(observed, registration, start.Add(6*time.Minute))
+
+	// then
+	assert.Equal(t, registration.Groups[0].Healthy, true)
+	assert.Equal(t, registration.Groups[1].Healthy, false)
+	assert.Equal(t, registration.Groups[2].Healthy, false)
+	assert.Equal(t, registration.Groups[3].Healthy, true)
+	assert.DeepEqual(t, registration.UnhealthyGroups, []string{"tm-b", "tm-c"})
+
+	// when: the crashing pod recovers
+	observed.Groups[1].ReadyReplicas = 2
+	registration = deriveSlotRegistration(observed, registration, start.Add(7*time.Minute))
+
+	// then
+	assert.Equal(t, registration.Groups[1].UnhealthySince, "")
+	assert.Equal(t, registration.Groups[1].Healthy, true)
+	assert.DeepEqual(t, registration.UnhealthyGroups, []string{"tm-c"})
+}
+
+func TestDeriveJobManagerLeader(t *testing.T) {
+	var haProperties = map[string]string{
+		"high-availability":            "kubernetes",
+		"kubernetes.cluster-id":        "my-cluster",
+		"high-availability.storageDir": "gs://my-bucket/ha",
+	}
+	var pods = &corev1.PodList{Items: []corev1.Pod{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "mycluster-jobmanager-0", Labels: map[string]string{"component": "jobmanager"}},
+			Status:     corev1.PodStatus{PodIP: "10.0.0.5"},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "mycluster-jobmanager-1", Labels: map[string]string{"component": "jobmanager"}},
+			Status:     corev1.PodStatus{PodIP: "10.0.0.50"},
+		},
+	}}
+	var leaderConfigMap = func(identity, ip string) *corev1.ConfigMap {
+		var configMap = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{
+				"control-plane.alpha.kubernetes.io/leader": `{"holderIdentity":"` + identity + `","leaseDuration":15.000000000}`,
+			}},
+		}
+		if ip != "" {
+			configMap.Data = map[string]string{
+				"org.apache.flink.k8s.leader.restserver": "http://" + ip + ":8081",
+				"org.apache.flink.k8s.leader.dispatcher": "pekko.tcp://flink@" + ip + ":6123/user/rpc/dispatcher_1",
+			}
+		}
+		return configMap
+	}
+	type step struct {
+		identity string
+		ip       string
+	}
+	var start = time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	for _, test := range []struct {
+		name             string
+		steps            []step
+		expectedIdentity string
+		expectedPod      string
+		expectedChanges  int
+		expectedFlapping bool
+	}{
+		{
+			name:             "stable leader",
+			steps:            []step{{"leader-a", "10.0.0.5"}, {"leader-a", "10.0.0.5"}, {"leader-a", "10.0.0.5"}},
+			expectedIdentity: "leader-a",
+			expectedPod:      "mycluster-jobmanager-0",
+		},
+		{
+			name:             "single failover with a leaderless window",
+			steps:            []step{{"leader-a", "10.0.0.5"}, {"", ""}, {"", ""}, {"leader-b", "10.0.0.50"}},
+			expectedIdentity: "leader-b",
+			expectedPod:      "mycluster-jobmanager-1",
+			expectedChanges:  1,
+		},
+		{
+			name:             "leader lost and elected again",
+			steps:            []step{{"leader-a", "10.0.0.5"}, {"", ""}, {"leader-a", "10.0.0.5"}},
+			expectedIdentity: "leader-a",
+			expectedPod:      "mycluster-jobmanager-0",
+		},
+		{
+			name: "flapping leadership",
+			steps: []step{
+				{"leader-a", "10.0.0.5"}, {"leader-b", "10.0.0.50"}, {"", ""},
+				{"leader-c", "10.0.0.5"}, {"leader-d", "10.0.0.50"},
+			},
+			expectedIdentity: "leader-d",
+			expectedPod:      "mycluster-jobmanager-1",
+			expectedChanges:  3,
+			expectedFlapping: true,
+		},
+		{
+			name:             "leader address not resolved",
+			steps:            []step{{"leader-a", "10.0.0.7"}},
+			expectedIdentity: "leader-a",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			var observed = &ObservedClusterState{
+				cluster: &v1beta1.FlinkCluster{Spec: v1beta1.FlinkClusterSpec{FlinkProperties: haProperties}},
+				pods:    pods,
+			}
+			var leader *v1beta1.JobManagerLeaderStatus
+			for i, s := range test.steps {
+				observed.haConfigMap = leaderConfigMap(s.identity, s.ip)
+				observed.observeTime = start.Add(time.Duration(i) * time.Minute)
+				leader = deriveJobManagerLeader(observed, leader)
+			}
+			assert.Equal(t, leader.Identity, test.expectedIdentity)
+			assert.Equal(t, leader.Pod, test.expectedPod)
+			assert.Equal(t, len(leader.RecentChanges), test.expectedChanges)
+			assert.Equal(t, leader.IsFlapping(observed.observeTime), test.expectedFlapping)
+			// The flapping settles once the changes are out of the churn window.
+			assert.Equal(t, leader.IsFlapping(observed.observeTime.Add(v1beta1.JobManagerLeaderChurnWindow)), false)
+		})
+	}
+
+	// Only the Kubernetes HA services elect the leader in the HA ConfigMap.
+	var observed = &ObservedClusterState{
+		cluster:     &v1beta1.FlinkCluster{Spec: v1beta1.FlinkClusterSpec{FlinkProperties: map[string]string{}}},
+		haConfigMap: leaderConfigMap("leader-a", "10.0.0.5"),
+	}
+	assert.Assert(t, deriveJobManagerLeader(observed, nil) == nil)
+	observed.cluster.Spec.FlinkProperties = map[string]string{
+		"high-availability":            "zookeeper",
+		"kubernetes.cluster-id":        "my-cluster",
+		"high-availability.storageDir": "gs://my-bucket/ha",
+	}
+	assert.Assert(t, deriveJobManagerLeader(observed, nil) == nil)
+}
+
+func TestDeriveCheckpointAlignment(t *testing.T) {
+	var cluster = &v1beta1.FlinkCluster{}
+	var alignment *v1beta1.CheckpointAlignmentStatus
+
+	// given: checkpoints whose alignment takes longer than half of the default timeout
+	for id := int64(1); id <= 6; id++ {
+		var sample = &v1beta1.CheckpointAlignmentSample{CheckpointID: id, AlignmentDurationMillis: 360000}
+		// when
+		alignment = deriveCheckpointAlignment(cluster, sample, alignment)
+	}
+
+	// then: the samples are bounded and the sustained high alignment is flagged
+	assert.Equal(t, len(alignment.Samples), v1beta1.CheckpointAlignmentSampleLimit)
+	assert.Equal(t, alignment.Samples[0].CheckpointID, int64(2))
+	assert.Equal(t, alignment.High, true)
+
+	// when: no new checkpoint is sampled
+	var recorded = deriveCheckpointAlignment(cluster, nil, alignment)
+
+	// then: the recorded status is kept
+	assert.DeepEqual(t, recorded, alignment)
+
+	// when: unaligned checkpoints are configured
+	cluster.Spec.FlinkProperties = map[string]string{"execution.checkpointing.unaligned.enabled": "true"}
+	alignment = deriveCheckpointAlignment(cluster, &v1beta1.CheckpointAlignmentSample{CheckpointID: 7, AlignmentDurationMillis: 360000}, alignment)
+
+	// then: no high alignment is reported
+	assert.Equal(t, alignment.High, false)
+}
+
+func TestDeriveCoordinatedSavepointStatus(t *testing.T) {
+	var recorded = &v1beta1.CoordinatedSavepointStatus{
+		State: v1beta1.SavepointStateInProgress,
+		Jobs: []v1beta1.CoordinatedSavepointJobStatus{
+			{JobName: "source", Savepoint: v1beta1.SavepointStatus{JobID: "job-1", TriggerID: "t1", State: v1beta1.SavepointStateInProgress}},
+			{JobName: "sink", Savepoint: v1beta1.SavepointStatus{JobID: "job-2", TriggerID: "t2", State: v1beta1.SavepointStateInProgress}},
+		},
+	}
+	var recordedControl = &v1beta1.FlinkClusterControlStatus{
+		Name:  v1beta1.ControlNameCoordinatedSavepoint,
+		State: v1beta1.ControlStateInProgress,
+	}
+	var cluster = &v1beta1.FlinkCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{v1beta1.ControlAnnotation: v1beta1.ControlNameCoordinatedSavepoint},
+		},
+		Status: v1beta1.FlinkClusterStatus{Control: recordedControl},
+	}
+	var succeeded = func(location string) Savepoint {
+		return Savepoint{status: &flink.SavepointStatus{Completed: true, Location: location}}
+	}
+
+	t.Run("all succeed", func(t *testing.T) {
+		var group = deriveCoordinatedSavepointStatus(map[string]Savepoint{
+			"job-1": succeeded("gs://sp/1"),
+			"job-2": succeeded("gs://sp/2"),
+		}, recorded)
+
+		assert.Equal(t, group.State, v1beta1.SavepointStateSucceeded)
+		assert.Equal(t, group.Jobs[0].Location, "gs://sp/1")
+		assert.Equal(t, group.Jobs[1].Location, "gs://sp/2")
+		var inventory = addCoordinatedSavepointRecords(cluster, recorded, group, nil)
+		assert.Equal(t, len(inventory), 2)
+		assert.Equal(t, inventory[1].Location, "gs://sp/2")
+		assert.Equal(t, inventory[1].JobID, "job-2")
+		var control = deriveControlStatus(cluster, nil, group, nil, recordedControl)
+		assert.Equal(t, control.State, v1beta1.ControlStateSucceeded)
+	})
+
+	t.Run("partial failure", func(t *testing.T) {
+		var group = deriveCoordinatedSavepointStatus(map[string]Savepoint{
+			"job-1": succeeded("gs://sp/1"),
+			"job-2": {status: &flink.SavepointStatus{
+				Completed:    true,
+				FailureCause: flink.SavepointFailureCause{StackTrace: "checkpoint declined"},
+			}},
+		}, recorded)
+
+		assert.Equal(t, group.State, v1beta1.SavepointStateFailed)
+		assert.DeepEqual(t, group.FailedJobs, []string{"sink"})
+		assert.Equal(t, group.Jobs[1].Savepoint.Message, "Savepoint error: checkpoint declined")
+		var control = deriveControlStatus(cluster, nil, group, nil, recordedControl)
+		assert.Equal(t, control.State, v1beta1.ControlStateFailed)
+		assert.Equal(t, control.Message, "Savepoint failed for jobs: sink")
+	})
+
+	t.Run("partial failure keeps polling the other jobs", func(t *testing.T) {
+		var failed = deriveCoordinatedSavepointStatus(map[string]Savepoint{
+			"job-2": {status: &flink.SavepointStatus{
+				Completed:    true,
+				FailureCause: flink.SavepointFailureCause{StackTrace: "checkpoint declined"},
+			}},
+		}, recorded)
+		assert.Equal(t, failed.State, v1beta1.SavepointStateFailed)
+		assert.Equal(t, failed.HasPendingSavepoints(), true)
+
+		var group = deriveCoordinatedSavepointStatus(map[string]Savepoint{
+			"job-1": succeeded("gs://sp/1"),
+		}, failed)
+		assert.Equal(t, group.State, v1beta1.SavepointStateFailed)
+		assert.DeepEqual(t, group.FailedJobs, []string{"sink"})
+		assert.Equal(t, group.Jobs[0].Savepoint.State, v1beta1.SavepointStateSucceeded)
+		assert.Equal(t, group.Jobs[0].Location, "gs://sp/1")
+		assert.Equal(t, group.HasPendingSavepoints(), false)
+
+		var inventory = addCoordinatedSavepointRecords(cluster, failed, group, nil)
+		assert.Equal(t, len(inventory), 1)
+		assert.Equal(t, inventory[0].Location, "gs://sp/1")
+		assert.Equal(t, inventory[0].JobID, "job-1")
+		// The savepoint is recorded only once.
+		assert.Equal(t, len(addCoordinatedSavepointRecords(cluster, group, group, inventory)), 1)
+	})
+
+	t.Run("in progress", func(t *testing.T) {
+		var group = deriveCoordinatedSavepointStatus(map[string]Savepoint{
+			"job-1": succeeded("gs://sp/1"),
+		}, recorded)
+
+		assert.Equal(t, group.State, v1beta1.SavepointStateInProgress)
+		assert.Equal(t, recorded.Jobs[0].Savepoint.State, v1beta1.SavepointStateInProgress)
+	})
+}
+
+func TestJobManagerBestEffortEvent(t *testing.T) {
+	var haProperties = map[string]string{
+		"high-availability":            "kubernetes",
+		"kubernetes.cluster-id":        "test-cluster",
+		"high-availability.storageDir": "gs://bucket/ha",
+	}
+	tests := []struct {
+		name            string
+		oldQoS          corev1.PodQOSClass
+		newQoS          corev1.PodQOSClass
+		flinkProperties map[string]string
+		expectedEvent   bool
+	}{
+		{name: "becomes best effort", oldQoS: corev1.PodQOSBurstable, newQoS: corev1.PodQOSBestEffort, expectedEvent: true},
+		{name: "stays best effort", oldQoS: corev1.PodQOSBestEffort, newQoS: corev1.PodQOSBestEffort},
+		{name: "burstable", newQoS: corev1.PodQOSBurstable},
+		{name: "best effort with high availability", newQoS: corev1.PodQOSBestEffort, flinkProperties: haProperties},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var recorder = record.NewFakeRecorder(10)
+			var updater = &ClusterStatusUpdater{
+				recorder: recorder,
+				observed: ObservedClusterState{cluster: &v1beta1.FlinkCluster{
+					Spec: v1beta1.FlinkClusterSpec{FlinkProperties: tt.flinkProperties},
+				}},
+			}
+			var oldStatus, newStatus v1beta1.FlinkClusterStatus
+			oldStatus.Components.JobManager = &v1beta1.JobManagerStatus{QoSClass: tt.oldQoS}
+			newStatus.Components.JobManager = &v1beta1.JobManagerStatus{QoSClass: tt.newQoS}
+
+			updater.createStatusChangeEvents(oldStatus, newStatus)
+
+			var events []string
+			for len(recorder.Events) > 0 {
+				events = append(events, <-recorder.Events)
+			}
+			assert.Equal(t, slices.ContainsFunc(events, func(e string) bool {
+				return strings.HasPrefix(e, "Warning JobManagerBestEffort")
+			}), tt.expectedEvent)
+		})
+	}
+}
+
+func TestDeriveJobRestarts(t *testing.T) {
+	var observed int32 = 4
+	var oldJob = &v1beta1.JobStatus{
+		ID:           "job-1",
+		RestartCount: 1,
+		Restarts:     &v1beta1.JobRestartsStatus{OperatorRestarts: 1, FlinkInternalRestarts: 2, TotalRestarts: 3},
+	}
+
+	// Observed Flink restarts.
+	var newJob = &v1beta1.JobStatus{ID: "job-1", RestartCount: 1}
+	assert.DeepEqual(t, deriveJobRestarts(oldJob, newJob, &observed),
+		&v1beta1.JobRestartsStatus{OperatorRestarts: 1, FlinkInternalRestarts: 4, TotalRestarts: 5})
+
+	// Kept until the next observation.
+	assert.DeepEqual(t, deriveJobRestarts(oldJob, newJob, nil),
+		&v1beta1.JobRestartsStatus{OperatorRestarts: 1, FlinkInternalRestarts: 2, TotalRestarts: 3})
+
+	// Reset for a new run of the job restarted by the operator.
+	newJob = &v1beta1.JobStatus{ID: "job-2", RestartCount: 2}
+	assert.DeepEqual(t, deriveJobRestarts(oldJob, newJob, nil),
+		&v1beta1.JobRestartsStatus{OperatorRestarts: 2, TotalRestarts: 2})
+
+	// No recorded job.
+	assert.DeepEqual(t, deriveJobRestarts(nil, newJob, nil),
+		&v1beta1.JobRestartsStatus{OperatorRestarts: 2, TotalRestarts: 2})
+}
+
+func TestDeriveJobStatusEscalatesFlinkRestarts(t *testing.T) {
+	var restartOnFailure = v1beta1.JobRestartPolicyFromSavepointOnFailure
+	for _, test := range []struct {
+		name              string
+		flinkState        string
+		flinkRestarts     int32
+		rootException     string
+		expectedState     v1beta1.JobState
+		expectedExhausted bool
+		expectedRestart   bool
+	}{
+		{
+			name:          "restarted by Flink",
+			flinkState:    "RESTARTING",
+			flinkRestarts: 2,
+			expectedState: v1beta1.JobStateRunning,
+		},
+		{
+			name:              "restarts of Flink exhausted",
+			flinkState:        "FAILED",
+			flinkRestarts:     2,
+			rootException:     "org.apache.flink.runtime.JobException: Recovery is suppressed by FixedDelayRestartBackoffTimeStrategy(maxNumberRestartAttempts=3, backoffTimeMS=10000)",
+			expectedState:     v1beta1.JobStateFailed,
+			expectedExhausted: true,
+			expectedRestart:   true,
+		},
+		{
+			name:          "failure Flink does not recover from",
+			flinkState:    "FAILED",
+			flinkRestarts: 1,
+			rootException: "org.apache.flink.runtime.JobException: The failure is not recoverable",
+			expectedState: v1beta1.JobStateFailed,
+		},
+		{
+			name:            "observed restarts reached the attempts",
+			flinkState:      "FAILED",
+			flinkRestarts:   3,
+			expectedState:   v1beta1.JobStateFailed,
+			expectedRestart: true,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			var cluster = &v1beta1.FlinkCluster{
+				Spec: v1beta1.FlinkClusterSpec{
+					Job: &v1beta1.JobSpec{
+						RestartPolicy:     &restartOnFailure,
+						RestartEscalation: &v1beta1.RestartEscalationSpec{FlinkRestartAttempts: 3},
+					},
+				},
+				Status: v1beta1.FlinkClusterStatus{
+					Components: v1beta1.FlinkClusterComponentsStatus{
+						Job: &v1beta1.JobStatus{
+							ID:                "job-1",
+							State:             v1beta1.JobStateRunning,
+							SavepointLocation: "s3://bucket/savepoints/savepoint-1",
+						},
+					},
+				},
+			}
+			var observed = ObservedClusterState{
+				cluster:               cluster,
+				flinkJob:              FlinkJob{status: &flink.Job{Id: "job-1", State: test.flinkState}},
+				flinkInternalRestarts: &test.flinkRestarts,
+			}
+			if test.rootException != "" {
+				observed.flinkJob.exceptions = &flink.JobExceptions{RootException: test.rootException}
+			}
+			var updater = &ClusterStatusUpdater{observed: observed}
+
+			var job = updater.deriveJobStatus(context.Background())
+
+			assert.Equal(t, job.State, test.expectedState)
+			assert.Equal(t, job.Restarts.FlinkRestartsExhausted, test.expectedExhausted)
+			assert.Equal(t, job.ShouldRestart(cluster.Spec.Job, time.Now()), test.expectedRestart)
+		})
+	}
+}
+
+func TestDeriveJobStateDurations(t *testing.T) {
+	var tc = &util.TimeConverter{}
+	var start = tc.FromString("2024-01-01T00:00:00Z")
+	var transition = func(job *v1beta1.JobStatus, state v1beta1.JobState, now time.Time) *v1beta1.JobStatus {
+		var newJob = job.DeepCopy()
+		newJob.State = state
+		deriveJobStateDurations(job, newJob, now)
+		return newJob
+	}
+
+	var job = &v1beta1.JobStatus{State: v1beta1.JobStateDeploying}
+	deriveJobStateDurations(nil, job, start)
+	assert.Equal(t, job.StateTime, "2024-01-01T00:00:00Z")
+	assert.Assert(t, job.StateDurations == nil)
+
+	job = transition(job, v1beta1.JobStateRunning, start.Add(time.Minute))
+	job = transition(job, v1beta1.JobStateFailed, start.Add(61*time.Minute))
+	job = transition(job, v1beta1.JobStateRestarting, start.Add(66*time.Minute))
+	job = transition(job, v1beta1.JobStateDeploying, start.Add(67*time.Minute))
+	job = transition(job, v1beta1.JobStateRunning, start.Add(68*time.Minute))
+
+	// A requeued reconcile without a transition does not count the time again.
+	job = transition(job, v1beta1.JobStateRunning, start.Add(70*time.Minute))
+	job = transition(job, v1beta1.JobStateRunning, start.Add(70*time.Minute))
+
+	assert.DeepEqual(t, job.StateDurations, []v1beta1.JobStateDuration{
+		{State: v1beta1.JobStateDeploying, Seconds: 120},
+		{State: v1beta1.JobStateRunning, Seconds: 3600},
+		{State: v1beta1.JobStateFailed, Seconds: 300},
+		{State: v1beta1.JobStateRestarting, Seconds: 60},
+	})
+	assert.Equal(t, job.StateTime, "2024-01-01T01:08:00Z")
+
+	// The current state counts up to now.
+	var now = start.Add(98 * time.Minute)
+	assert.Equal(t, job.RunningTime(now), 90*time.Minute)
+	assert.Equal(t, job.FailedTime(now), 5*time.Minute)
+	assert.Equal(t, job.TimeInState(v1beta1.JobStateSucceeded, now), time.Duration(0))
+
+	job = transition(job, v1beta1.JobStateLost, now)
+	assert.Equal(t, job.FailedTime(now.Add(10*time.Minute)), 15*time.Minute)
+	assert.Equal(t, job.RunningTime(now.Add(10*time.Minute)), 90*time.Minute)
+
+	// A state recorded without the time it was entered is not accumulated.
+	job = &v1beta1.JobStatus{State: v1beta1.JobStateRunning}
+	job = transition(job, v1beta1.JobStateFailed, now)
+	assert.Assert(t, job.StateDurations == nil)
+	assert.Equal(t, job.StateTime, tc.ToString(now))
+}
+
+func TestDeriveUploadedJar(t *testing.T) {
+	var enabled = true
+	var jarFile = "/opt/flink/job.jar"
+	var recorded = &v1beta1.UploadedJarStatus{ID: "6077eca7_job.jar", Hash: "a9c2f3d7"}
+	for _, test := range []struct {
+		name         string
+		submitterJar *v1beta1.UploadedJarStatus
+		uploadedJars *flink.JarsList
+		expected     *v1beta1.UploadedJarStatus
+	}{
+		{
+			name:         "cache hit, the submitter ran the recorded jar again",
+			submitterJar: &v1beta1.UploadedJarStatus{ID: "6077eca7_job.jar", Hash: "a9c2f3d7"},
+			expected:     recorded,
+		},
+		{
+			name:         "cache miss, the submitter uploaded the changed jar",
+			submitterJar: &v1beta1.UploadedJarStatus{ID: "9d1b0c52_job.jar", Hash: "5e884898"},
+			expected:     &v1beta1.UploadedJarStatus{ID: "9d1b0c52_job.jar", Hash: "5e884898"},
+		},
+		{
+			name:         "recorded jar still on the JobManager",
+			uploadedJars: &flink.JarsList{Files: []flink.JarFileInfo{{ID: "6077eca7_job.jar", Name: "job.jar"}}},
+			expected:     recorded,
+		},
+		{
+			name:         "stale jar, the JobManager restarted",
+			uploadedJars: &flink.JarsList{},
+		},
+		{
+			name:     "jars not observed",
+			expected: recorded,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			var observed = ObservedClusterState{
+				cluster: &v1beta1.FlinkCluster{
+					Spec: v1beta1.FlinkClusterSpec{
+						Job: &v1beta1.JobSpec{JarFile: &jarFile, RESTJarUpload: &enabled},
+					},
+				},
+				uploadedJars: test.uploadedJars,
+			}
+			if test.submitterJar != nil {
+				observed.flinkJobSubmitter.log = &SubmitterLog{jobID: "job-1", jar: test.submitterJar}
+			}
+
+			assert.DeepEqual(t, deriveUploadedJar(&observed, recorded), test.expected)
+		})
+	}
+
+	// The stale jar is not passed to the next submission, which uploads the jar again.
+	var parallelism int32 = 2
+	var cluster = &v1beta1.FlinkCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "mycluster"},
+		Spec: v1beta1.FlinkClusterSpec{
+			Job: &v1beta1.JobSpec{JarFile: &jarFile, Parallelism: &parallelism, RESTJarUpload: &enabled},
+		},
+	}
+	var jobStatus = &v1beta1.JobStatus{UploadedJar: deriveUploadedJar(&ObservedClusterState{
+		cluster:      cluster,
+		uploadedJars: &flink.JarsList{},
+	}, recorded)}
+	cluster.Status.Components.Job = jobStatus
+	for _, env := range getRESTJarUploadEnvVars(cluster, nil) {
+		assert.Assert(t, env.Name != cachedJarIDEnvVar)
+	}
+}
+
+func TestDeriveClassloaderResolveOrder(t *testing.T) {
+	var parentFirst = v1beta1.ClassloaderResolveOrderParentFirst
+	var observed = &ObservedClusterState{
+		cluster: &v1beta1.FlinkCluster{
+			Spec: v1beta1.FlinkClusterSpec{
+				Job: &v1beta1.JobSpec{ClassloaderResolveOrder: &parentFirst},
+			},
+		},
+	}
+
+	// The spec is reported until the running config is observed.
+	assert.Equal(t, deriveClassloaderResolveOrder(observed, ""), "parent-first")
+	assert.Equal(t, deriveClassloaderResolveOrder(observed, "child-first"), "child-first")
+
+	// The running config is reported once observed.
+	observed.flinkConfig = map[string]string{"classloader.resolve-order": "Parent-First"}
+	assert.Equal(t, deriveClassloaderResolveOrder(observed, "child-first"), "parent-first")
+
+	// The Flink default applies when the running config does not set it.
+	observed.flinkConfig = map[string]string{}
+	assert.Equal(t, deriveClassloaderResolveOrder(observed, "parent-first"), "child-first")
+
+	// The Flink default is reported for a spec without it.
+	observed.flinkConfig = nil
+	observed.cluster.Spec.Job.ClassloaderResolveOrder = nil
+	assert.Equal(t, deriveClassloaderResolveOrder(observed, ""), "child-first")
+}
+
+func TestDerivePoisonSavepoint(t *testing.T) {
+	var restartOnFailure = v1beta1.JobRestartPolicyFromSavepointOnFailure
+	var maxRestoreFailures int32 = 3
+	var jobSpec = &v1beta1.JobSpec{RestartPolicy: &restartOnFailure, MaxRestoreFailures: &maxRestoreFailures}
+	var inventory = []v1beta1.SavepointRecord{
+		{Location: "gs://my-bucket/savepoint-1", Time: "2026-01-01T00:00:00Z"},
+		{Location: "gs://my-bucket/savepoint-2", Time: "2026-01-01T01:00:00Z"},
+	}
+	// The job is restored from savepoint-2 and crashes before taking any newer savepoint.
+	var restoreAndCrash = func(job *v1beta1.JobStatus) {
+		if job.FromSavepoint != job.RestoreSavepoint() {
+			job.RestoreFailureCount = 0
+		}
+		job.FromSavepoint = job.RestoreSavepoint()
+		job.SavepointLocation = job.FromSavepoint
+		job.State = v1beta1.JobStateFailed
+		derivePoisonSavepoint(job, jobSpec, inventory)
+	}
+
+	var job = &v1beta1.JobStatus{SavepointLocation: "gs://my-bucket/savepoint-2", SavepointTime: "2026-01-01T01:00:00Z"}
+	for range maxRestoreFailures - 1 {
+		restoreAndCrash(job)
+		assert.Assert(t, job.ShouldRestart(jobSpec, time.Now()))
+		assert.Equal(t, job.RestoreSavepoint(), "gs://my-bucket/savepoint-2")
+	}
+	assert.Assert(t, len(job.PoisonSavepoints) == 0)
+
+	// The threshold is reached, the job falls back to the older savepoint.
+	restoreAndCrash(job)
+	assert.Assert(t, job.RestoreSourceIsPoison(jobSpec.GetMaxRestoreFailures()))
+	assert.DeepEqual(t, job.PoisonSavepoints, []string{"gs://my-bucket/savepoint-2"})
+	assert.Equal(t, job.SavepointLocation, "gs://my-bucket/savepoint-1")
+	assert.Equal(t, job.SavepointTime, "2026-01-01T00:00:00Z")
+	assert.Assert(t, job.ShouldRestart(jobSpec, time.Now()))
+
+	// The older savepoint crashes the job as well, there is nothing left to restore from.
+	for range maxRestoreFailures {
+		restoreAndCrash(job)
+	}
+	assert.DeepEqual(t, job.PoisonSavepoints, []string{"gs://my-bucket/savepoint-2", "gs://my-bucket/savepoint-1"})
+	assert.Equal(t, job.RestoreSavepoint(), "gs://my-bucket/savepoint-1")
+	assert.Assert(t, !job.ShouldRestart(jobSpec, time.Now()))
+
+	// Failures of the job after it took a newer savepoint are not attributed to the restore source.
+	job = &v1beta1.JobStatus{
+		State:             v1beta1.JobStateFailed,
+		FromSavepoint:     "gs://my-bucket/savepoint-1",
+		SavepointLocation: "gs://my-bucket/savepoint-2",
+	}
+	derivePoisonSavepoint(job, jobSpec, inventory)
+	assert.Equal(t, job.RestoreFailureCount, int32(0))
+}
+
+func TestPoisonSavepointEvent(t *testing.T) {
+	var recorder = record.NewFakeRecorder(10)
+	var updater = &ClusterStatusUpdater{
+		recorder: recorder,
+		observed: ObservedClusterState{cluster: &v1beta1.FlinkCluster{}},
+	}
+	var oldStatus, newStatus v1beta1.FlinkClusterStatus
+	oldStatus.Components.Job = &v1beta1.JobStatus{State: v1beta1.JobStateRunning}
+	newStatus.Components.Job = &v1beta1.JobStatus{
+		State:               v1beta1.JobStateFailed,
+		FromSavepoint:       "gs://my-bucket/savepoint-2",
+		SavepointLocation:   "gs://my-bucket/savepoint-1",
+		RestoreFailureCount: 3,
+		PoisonSavepoints:    []string{"gs://my-bucket/savepoint-2"},
+	}
+
+	updater.createStatusChangeEvents(oldStatus, newStatus)
+
+	var events []string
+	for len(recorder.Events) > 0 {
+		events = append(events, <-recorder.Events)
+	}
+	assert.Assert(t, slices.Contains(events,
+		"Warning PoisonSavepoint The job failed 3 times in a row after restoring from savepoint gs://my-bucket/savepoint-2, "+
+			"marked it poison, the job is restarted from savepoint gs://my-bucket/savepoint-1"))
+}
+
+func TestUpdateEstimatedCostAnnotation(t *testing.T) {
+	var scheme = runtime.NewScheme()
+	assert.NilError(t, v1beta1.AddToScheme(scheme))
+
+	var tmReplicas int32 = 2
+	var cluster = &v1beta1.FlinkCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster", Namespace: "default"},
+		Spec: v1beta1.FlinkClusterSpec{
+			TaskManager: &v1beta1.TaskManagerSpec{
+				Replicas: &tmReplicas,
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+				},
+			},
+		},
+	}
+	var fakeClient = fake.NewClientBuilder().WithScheme(scheme).WithObjects(cluster).Build()
+	var updater = &ClusterStatusUpdater{
+		k8sClient: fakeClient,
+		observed:  ObservedClusterState{cluster: cluster},
+	}
+	var getAnnotations = func() map[string]string {
+		var updated v1beta1.FlinkCluster
+		assert.NilError(t, fakeClient.Get(context.Background(),
+			types.NamespacedName{Name: cluster.Name, Namespace: cluster.Namespace}, &updated))
+		return updated.Annotations
+	}
+
+	// No price list, no estimate.
+	assert.NilError(t, updater.updateEstimatedCostAnnotation(context.Background(), v1beta1.ResourcePriceList{}))
+	assert.Equal(t, getAnnotations()[v1beta1.EstimatedMonthlyCostAnnotation], "")
+
+	// 4 CPUs for 730 hours.
+	var priceList = v1beta1.ResourcePriceList{CPUPerHour: 0.05}
+	assert.NilError(t, updater.updateEstimatedCostAnnotation(context.Background(), priceList))
+	assert.Equal(t, getAnnotations()[v1beta1.EstimatedMonthlyCostAnnotation], "146.00")
+}
@@ -24,7 +24,10 @@ import (
 	"fmt"
 	"maps"
 	"os"
+	"reflect"
 	"regexp"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -41,8 +44,11 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/kubernetes"
 )
 
@@ -58,6 +64,7 @@ const (
 
 var (
 	jobIdRegexp = regexp.MustCompile("JobID (.*)\n")
+	jarIdRegexp = regexp.MustCompile("JarID (\\S+) JarHash (\\S+)\n")
 )
 
 type UpdateState string
@@ -95,7 +102,227 @@ func getFlinkAPIBaseURL(cluster *v1beta1.FlinkCluster) string {
 		getJobManagerServiceName(cluster.Name),
 		cluster.Namespace,
 		clusterDomain,
-		*cluster.Spec.JobManager.Ports.UI)
+		getNetworkPorts(cluster).REST)
+}
+
+// Returns true if the failed job fails over to its primed savepoint, which is still fresh
+// at now.
+func shouldFailOverToPrimedSavepoint(cluster *v1beta1.FlinkCluster, now time.Time) bool {
+	var job = cluster.Status.Components.Job
+	return job.IsFailed() && job.IsPrimedSavepointFresh(cluster.Spec.Job, now)
+}
+
+// The fraction of the expected slots of a TaskManager group which may be unregistered
+// before the slot registration of the group is lagging.
+const slotRegistrationLagThreshold = 0.25
+
+// The time TaskManagers may take to register their slots during a normal startup or
+// rolling update before a lagging group is reported.
+const slotRegistrationGracePeriodSeconds = 300
+
+// The time the pods of a TaskManager group may take to get ready and register their slots
+// during a normal startup or rolling update before the group is reported unhealthy.
+const taskManagerGroupUnhealthyGracePeriodSeconds = 300
+
+// Gets the slot registration and pod readiness of the TaskManager groups. The TaskManager
+// pods are grouped by their owner workload and the running ones are matched with the
+// registered TaskManagers by address. The desired replicas of a group are taken from
+// desiredReplicas, keyed by the name of the workload, when known, and are the number of its
+// pods otherwise.
+func getTaskManagerGroupSlots(
+	pods *corev1.PodList,
+	taskManagers []flink.TaskManagerInfo,
+	slotsPerTaskManager int32,
+	desiredReplicas map[string]int32) []v1beta1.TaskManagerGroupSlots {
+	var registered = make(map[string]int32)
+	for _, tm := range taskManagers {
+		if host := getTaskManagerHost(tm); host != "" {
+			registered[host] += tm.SlotsNumber
+		}
+	}
+
+	var groups []v1beta1.TaskManagerGroupSlots
+	var index = make(map[string]int)
+	var getGroup = func(name string) *v1beta1.TaskManagerGroupSlots {
+		j, ok := index[name]
+		if !ok {
+			j = len(groups)
+			index[name] = j
+			groups = append(groups, v1beta1.TaskManagerGroupSlots{Name: name})
+		}
+		return &groups[j]
+	}
+	for name := range desiredReplicas {
+		getGroup(name)
+	}
+	for i := range pods.Items {
+		var pod = &pods.Items[i]
+		if pod.Labels["component"] != "taskmanager" || pod.DeletionTimestamp != nil {
+			continue
+		}
+		var group = getGroup(getTaskManagerGroupName(pod))
+		group.Replicas++
+		if isPodReady(pod) {
+			group.ReadyReplicas++
+		}
+		if err := getPodError(pod); err != "" {
+			group.LastError = err
+		}
+		if pod.Status.Phase != corev1.PodRunning {
+			continue
+		}
+		group.ExpectedSlots += slotsPerTaskManager
+		if pod.Status.PodIP != "" {
+			group.RegisteredSlots += registered[pod.Status.PodIP]
+		}
+		group.RegisteredSlots += registered[pod.Name]
+	}
+	for name, replicas := range desiredReplicas {
+		groups[index[name]].Replicas = replicas
+	}
+	sort.Slice(groups, func(a, b int) bool { return groups[a].Name < groups[b].Name })
+	return groups
+}
+
+// Gets the name of the workload owning the TaskManager pod. The pods of a Deployment are owned
+// by its ReplicaSets, named after the Deployment and the pod template hash, so that the pods of
+// all revisions of the Deployment are in its group.
+func getTaskManagerGroupName(pod *corev1.Pod) string {
+	var owner = metav1.GetControllerOf(pod)
+	if owner == nil {
+		return "taskmanager"
+	}
+	if hash := pod.Labels[appsv1.DefaultDeploymentUniqueLabelKey]; owner.Kind == "ReplicaSet" && hash != "" {
+		if name, ok := strings.CutSuffix(owner.Name, "-"+hash); ok {
+			return name
+		}
+	}
+	return owner.Name
+}
+
+// Returns true if the pod has a Ready condition.
+func isPodReady(pod *corev1.Pod) bool {
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == corev1.PodReady {
+			return cond.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// Gets the error which keeps the pod from running, such as "tm-0: CrashLoopBackOff: back-off
+// restarting failed container", or an empty string.
+func getPodError(pod *corev1.Pod) string {
+	if cond := getPodUnschedulableCondition(pod); cond != nil {
+		return fmt.Sprintf("%s: %s: %s", pod.Name, cond.Reason, cond.Message)
+	}
+	if pod.Status.Phase == corev1.PodFailed {
+		return fmt.Sprintf("%s: %s: %s", pod.Name, pod.Status.Reason, pod.Status.Message)
+	}
+	for _, status := range pod.Status.ContainerStatuses {
+		if waiting := status.State.Waiting; waiting != nil &&
+			waiting.Reason != "" && waiting.Reason != "ContainerCreating" && waiting.Reason != "PodInitializing" {
+			return fmt.Sprintf("%s: %s: %s", pod.Name, waiting.Reason, waiting.Message)
+		}
+	}
+	return ""
+}
+
+// Gets the host a TaskManager registered with, from its RPC path such as
+// "pekko.tcp://flink@10.0.0.5:6122/user/rpc/taskmanager_0", or its default resource ID
+// such as "10.0.0.5:6122-1a2b3c".
+func getTaskManagerHost(tm flink.TaskManagerInfo) string {
+	var address = tm.ID
+	if i := strings.Index(tm.Path, "@"); i >= 0 {
+		address = tm.Path[i+1:]
+	}
+	if i := strings.IndexAny(address, ":/"); i >= 0 {
+		address = address[:i]
+	}
+	return address
+}
+
+// Returns true if less than the threshold of the expected slots of the group are registered.
+func isTaskManagerGroupLagging(group v1beta1.TaskManagerGroupSlots) bool {
+	return group.ExpectedSlots > 0 &&
+		float64(group.RegisteredSlots) < float64(group.ExpectedSlots)*(1-slotRegistrationLagThreshold)
+}
+
+// Returns true if all desired pods of the group are ready and its slot registration is not
+// lagging. A group without desired pods is healthy.
+func isTaskManagerGroupHealthy(group v1beta1.TaskManagerGroupSlots, lagging bool) bool {
+	if group.Replicas == 0 {
+		return true
+	}
+	return group.ReadyReplicas >= group.Replicas && !lagging
+}
+
+// Aggregates the health of the TaskManager groups into the health of the TaskManagers,
+// which are healthy when all groups are. The unhealthy groups are returned for drill-down.
+func getTaskManagerHealth(groups []v1beta1.TaskManagerGroupSlots) (bool, []string) {
+	var unhealthy []string
+	for _, group := range groups {
+		if !group.Healthy {
+			unhealthy = append(unhealthy, group.Name)
+		}
+	}
+	return len(unhealthy) == 0, unhealthy
+}
+
+// The number of the latest aligned checkpoints whose alignment must be high to report
+// sustained backpressure.
+const highCheckpointAlignmentSamples = 3
+
+// Gets the alignment duration from which a checkpoint alignment is high: half of the
+// checkpoint timeout, 10 minutes by default in Flink.
+func getCheckpointAlignmentThreshold(cluster *v1beta1.FlinkCluster) time.Duration {
+	return cluster.CheckpointTimeout() / 2
+}
+
+// Gets the maximum time the savepoint triggered for the reason may take: the timeout of
+// its trigger source in `savepointTimeouts`, or the checkpoint timeout, 10 minutes by
+// default in Flink. The final savepoint of the deleted cluster is always bounded so that
+// the teardown does not hang.
+func getSavepointTimeout(cluster *v1beta1.FlinkCluster, reason v1beta1.SavepointReason) time.Duration {
+	var timeout = cluster.CheckpointTimeout()
+	if cluster.Spec.Job != nil {
+		if seconds := cluster.Spec.Job.SavepointTimeouts.GetSeconds(reason); seconds != nil {
+			timeout = time.Duration(*seconds) * time.Second
+		}
+	}
+	const maxDeleteTimeout = v1beta1.MaxDeleteSavepointTimeoutSeconds * time.Second
+	if reason == v1beta1.SavepointReasonDelete && timeout > maxDeleteTimeout {
+		timeout = maxDeleteTimeout
+	}
+	return timeout
+}
+
+// Returns true if `savepointTimeouts` sets the timeout of the savepoint triggered for the reason.
+func hasSavepointTimeout(cluster *v1beta1.FlinkCluster, reason v1beta1.SavepointReason) bool {
+	return cluster.Spec.Job != nil && cluster.Spec.Job.SavepointTimeouts.GetSeconds(reason) != nil
+}
+
+// Returns true if the alignment of the latest sampled checkpoints has been high, which
+// indicates sustained backpressure. Unaligned checkpoints do not wait for the alignment,
+// so they are never reported.
+func isCheckpointAlignmentHigh(
+	samples []v1beta1.CheckpointAlignmentSample, threshold time.Duration, unalignedConfigured bool) bool {
+	if unalignedConfigured || len(samples) < highCheckpointAlignmentSamples {
+		return false
+	}
+	for _, s := range samples[len(samples)-highCheckpointAlignmentSamples:] {
+		if s.Unaligned || time.Duration(s.AlignmentDurationMillis)*time.Millisecond < threshold {
+			return false
+		}
+	}
+	return true
+}
+
+// Gets the resolved Flink network ports of the cluster. Invalid port properties are
+// rejected by the validating webhook, the spec ports are used for them otherwise.
+func getNetworkPorts(cluster *v1beta1.FlinkCluster) v1beta1.FlinkNetworkPorts {
+	ports, _ := cluster.NetworkPorts()
+	return ports
 }
 
 // Gets ConfigMap name
@@ -133,6 +360,46 @@ func getTaskManagerName(clusterName string) string {
 	return clusterName + "-taskmanager"
 }
 
+// Gets the name of the TaskManager pod with the given ordinal. The name follows
+// the StatefulSet pod naming, so an ordinal maps to a stable TaskManager identity.
+func getTaskManagerPodName(clusterName string, ordinal int32) string {
+	return fmt.Sprintf("%s-%d", getTaskManagerName(clusterName), ordinal)
+}
+
+// Gets the ordinal of the TaskManager pod from its name.
+func getTaskManagerPodOrdinal(clusterName string, podName string) (int32, bool) {
+	var prefix = getTaskManagerName(clusterName) + "-"
+	if !strings.HasPrefix(podName, prefix) {
+		return 0, false
+	}
+	ordinal, err := strconv.ParseInt(strings.TrimPrefix(podName, prefix), 10, 32)
+	if err != nil || ordinal < 0 {
+		return 0, false
+	}
+	return int32(ordinal), true
+}
+
+// Computes the TaskManager ordinals to remove and to create so that the
+// existing ordinals become the contiguous set [0, replicas). Ordinals to remove
+// are returned in descending order so the highest ordinals are drained first,
+// and gaps left by prior failures are filled in ascending order.
+func getTaskManagerOrdinalChanges(existing []int32, replicas int32) (toRemove []int32, toCreate []int32) {
+	var seen = make(map[int32]bool, len(existing))
+	for _, ordinal := range existing {
+		if ordinal >= replicas && !seen[ordinal] {
+			toRemove = append(toRemove, ordinal)
+		}
+		seen[ordinal] = true
+	}
+	sort.Slice(toRemove, func(i, j int) bool { return toRemove[i] > toRemove[j] })
+	for ordinal := int32(0); ordinal < replicas; ordinal++ {
+		if !seen[ordinal] {
+			toCreate = append(toCreate, ordinal)
+		}
+	}
+	return toRemove, toCreate
+}
+
 func getJobManagerJobName(clusterName string) string {
 	return getJobManagerName(clusterName)
 }
@@ -146,11 +413,18 @@ func canTakeSavepoint(cluster *v1beta1.FlinkCluster) bool {
 	var jobSpec = cluster.Spec.Job
 	var savepointStatus = cluster.Status.Savepoint
 	var job = cluster.Status.Components.Job
-	return jobSpec != nil && jobSpec.SavepointsDir != nil &&
+	return jobSpec != nil && cluster.SavepointsConfigured() &&
 		!job.IsStopped() &&
 		(savepointStatus == nil || savepointStatus.State != v1beta1.SavepointStateInProgress)
 }
 
+// Checks if a cancelled job should be stopped with a savepoint, which is skipped
+// with the Cancel stop mode.
+func shouldStopWithSavepoint(cluster *v1beta1.FlinkCluster) bool {
+	var jobSpec = cluster.Spec.Job
+	return jobSpec == nil || jobSpec.StopMode == nil || *jobSpec.StopMode != v1beta1.JobStopModeCancel
+}
+
 // Checks if the job should be stopped because a job-cancel was requested
 func shouldStopJob(cluster *v1beta1.FlinkCluster) bool {
 	var userControl = cluster.Annotations[v1beta1.ControlAnnotation]
@@ -195,16 +469,23 @@ func newRevision(cluster *v1beta1.FlinkCluster, revision int64, collisionCount *
 
 func newRevisionDataPatch(cluster *v1beta1.FlinkCluster) ([]byte, error) {
 	// Ignore fields not related to rendering job resource.
-	var c *v1beta1.FlinkCluster
-	if cluster.Spec.Job != nil {
-		c = cluster.DeepCopy()
+	var c = cluster.DeepCopy()
+	c.Spec.CoordinatedSavepoint = nil
+	c.Spec.ConfigChangeRestartPolicy = nil
+	c.Spec.ObservabilitySamplingSeconds = nil
+	c.Spec.GracefulShutdownTimeoutSeconds = nil
+	if c.Spec.JobManager != nil {
+		c.Spec.JobManager.ReplicaDriftPolicy = nil
+	}
+	if c.Spec.Job != nil {
 		c.Spec.Job.CleanupPolicy = nil
 		c.Spec.Job.RestartPolicy = nil
 		c.Spec.Job.CancelRequested = nil
 		c.Spec.Job.SavepointGeneration = 0
 		c.Spec.Job.SavepointFormatType = nil
-	} else {
-		c = cluster
+		c.Spec.Job.StopMode = nil
+		c.Spec.Job.WarmStandby = nil
+		c.Spec.Job.MaxUpdateDowntimeSeconds = nil
 	}
 
 	str := &bytes.Buffer{}
@@ -230,6 +511,95 @@ func newRevisionDataPatch(cluster *v1beta1.FlinkCluster) ([]byte, error) {
 	return patch, err
 }
 
+// canonicalRevisionData decodes a revision data patch and drops its null, empty map and empty list
+// values. They serialize differently depending on whether a field was unset, defaulted or set to
+// an empty value, but render the same resources.
+func canonicalRevisionData(patch []byte) (interface{}, error) {
+	var data interface{}
+	if err := json.Unmarshal(patch, &data); err != nil {
+		return nil, err
+	}
+	return pruneEmptyRevisionValues(data), nil
+}
+
+func pruneEmptyRevisionValues(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		var pruned = make(map[string]interface{})
+		for key, item := range v {
+			if item = pruneEmptyRevisionValues(item); item != nil {
+				pruned[key] = item
+			}
+		}
+		if len(pruned) == 0 {
+			return nil
+		}
+		return pruned
+	case []interface{}:
+		if len(v) == 0 {
+			return nil
+		}
+		var pruned = make([]interface{}, len(v))
+		for i, item := range v {
+			pruned[i] = pruneEmptyRevisionValues(item)
+		}
+		return pruned
+	}
+	return value
+}
+
+// revisionHashChurn compares the data patches of two revisions. It reports the revision hash as
+// unstable when the patches differ only in their serialization, together with the fields
+// contributing to the churn.
+func revisionHashChurn(lhs, rhs []byte) ([]string, bool) {
+	if bytes.Equal(lhs, rhs) {
+		return nil, false
+	}
+	lhsData, err := canonicalRevisionData(lhs)
+	if err != nil {
+		return nil, false
+	}
+	rhsData, err := canonicalRevisionData(rhs)
+	if err != nil {
+		return nil, false
+	}
+	if !reflect.DeepEqual(lhsData, rhsData) {
+		return nil, false
+	}
+	var lhsRaw, rhsRaw interface{}
+	json.Unmarshal(lhs, &lhsRaw)
+	json.Unmarshal(rhs, &rhsRaw)
+	var fields []string
+	collectRevisionDiffFields("", lhsRaw, rhsRaw, &fields)
+	sort.Strings(fields)
+	return fields, true
+}
+
+func collectRevisionDiffFields(path string, lhs, rhs interface{}, fields *[]string) {
+	lhsMap, lhsIsMap := lhs.(map[string]interface{})
+	rhsMap, rhsIsMap := rhs.(map[string]interface{})
+	if !lhsIsMap || !rhsIsMap {
+		if !reflect.DeepEqual(lhs, rhs) {
+			*fields = append(*fields, path)
+		}
+		return
+	}
+	var keys = make(map[string]bool)
+	for key := range lhsMap {
+		keys[key] = true
+	}
+	for key := range rhsMap {
+		keys[key] = true
+	}
+	for key := range keys {
+		var fieldPath = key
+		if path != "" {
+			fieldPath = path + "." + key
+		}
+		collectRevisionDiffFields(fieldPath, lhsMap[key], rhsMap[key], fields)
+	}
+}
+
 func getCurrentRevisionName(r *v1beta1.RevisionStatus) string {
 	return r.CurrentRevision[:strings.LastIndex(r.CurrentRevision, "-")]
 }
@@ -318,7 +688,8 @@ func getSavepointEvent(status v1beta1.SavepointStatus) (eventType string, eventR
 		msg = msg[:100] + "..."
 	}
 	var triggerReason = status.TriggerReason
-	if triggerReason == v1beta1.SavepointReasonJobCancel || triggerReason == v1beta1.SavepointReasonUpdate {
+	if triggerReason == v1beta1.SavepointReasonJobCancel || triggerReason == v1beta1.SavepointReasonUpdate ||
+		triggerReason == v1beta1.SavepointReasonDelete || triggerReason == v1beta1.SavepointReasonRestart {
 		triggerReason = "for " + triggerReason
 	}
 	switch status.State {
@@ -355,6 +726,17 @@ func hasTimeElapsed(timeToCheckStr string, now time.Time, intervalSec int) bool
 	return now.After(intervalPassedTime)
 }
 
+// isObservabilityPollDue checks whether the sampled Flink REST API endpoints should be
+// polled, that is, sampling is disabled or the sampling interval has elapsed since the last poll.
+func isObservabilityPollDue(cluster *v1beta1.FlinkCluster, now time.Time) bool {
+	var interval = cluster.Spec.ObservabilitySamplingSeconds
+	var lastPollTime = cluster.Status.LastObservabilityPollTime
+	if interval == nil || *interval == 0 || lastPollTime == "" {
+		return true
+	}
+	return hasTimeElapsed(lastPollTime, now, int(*interval))
+}
+
 // isComponentUpdated checks whether the component updated.
 // If the component is observed as well as the next revision name in status.nextRevision and component's label `flinkoperator.k8s.io/hash` are equal, then it is updated already.
 // If the component is not observed and it is required, then it is not updated yet.
@@ -426,7 +808,7 @@ func isClusterUpdateToDate(observed *ObservedClusterState) bool {
 		observed.jmService,
 	}
 
-	if IsApplicationModeCluster(observed.cluster) && !isScaleUpdate(observed.revisions, observed.cluster) {
+	if IsApplicationModeCluster(observed.cluster) && !isInPlaceUpdate(observed.revisions, observed.cluster) {
 		components = append(components, observed.flinkJobSubmitter.job)
 	} else if !IsApplicationModeCluster(observed.cluster) {
 		components = append(components, observed.jmStatefulSet)
@@ -462,7 +844,281 @@ func isFlinkAPIReady(list *flink.JobsOverview) bool {
 func finalSavepointRequested(jobID string, s *v1beta1.SavepointStatus) bool {
 	return s != nil && s.JobID == jobID &&
 		(s.TriggerReason == v1beta1.SavepointReasonUpdate ||
-			s.TriggerReason == v1beta1.SavepointReasonJobCancel)
+			s.TriggerReason == v1beta1.SavepointReasonJobCancel ||
+			s.TriggerReason == v1beta1.SavepointReasonDelete ||
+			s.TriggerReason == v1beta1.SavepointReasonRestart)
+}
+
+// Checks if the job should be restarted for a new nonce of the restart-trigger annotation.
+// The job is not restarted while it is requested to stop.
+func isJobRestartTriggered(cluster *v1beta1.FlinkCluster) bool {
+	return cluster.Spec.Job != nil && cluster.Status.Components.Job != nil &&
+		!shouldStopJob(cluster) && cluster.PendingRestartTriggerNonce() != ""
+}
+
+// Checks if the job is restarted with a savepoint for the restart-trigger annotation, which
+// is skipped like the savepoint on update when takeSavepointOnUpdate is false.
+func shouldRestartWithSavepoint(cluster *v1beta1.FlinkCluster) bool {
+	var jobSpec = cluster.Spec.Job
+	return cluster.SavepointsConfigured() &&
+		(jobSpec.TakeSavepointOnUpdate == nil || *jobSpec.TakeSavepointOnUpdate)
+}
+
+// Checks if the savepoint recorded in the job status is superseded in the run of the job reported
+// by the checkpointing statistics, i.e., the job was restored from another savepoint after the
+// recorded one was taken, and did not take it since. A job recovered from a checkpoint, e.g., by
+// high availability, still descends from the recorded savepoint, which is kept.
+func isSavepointSuperseded(job *v1beta1.JobStatus, stats *flink.CheckpointingStatistics) bool {
+	if job == nil || job.SavepointLocation == "" || job.SavepointTime == "" {
+		return false
+	}
+	var restored = stats.Latest.Restored
+	if restored == nil || !restored.IsSavepoint || restored.ExternalPath == job.SavepointLocation {
+		return false
+	}
+	if restored.RestoreTimestamp > 0 &&
+		!util.GetTime(job.SavepointTime).Before(time.UnixMilli(restored.RestoreTimestamp)) {
+		return false
+	}
+	for _, c := range stats.History {
+		if c.IsSavepoint && c.ExternalPath == job.SavepointLocation {
+			return false
+		}
+	}
+	return true
+}
+
+// savepointWaitState is the state of the wait of the job submission for the savepoint to
+// restore the job from, with `waitForSavepoint`.
+type savepointWaitState string
+
+const (
+	// The job is not going to be submitted from a savepoint it waits for.
+	savepointWaitNotRequired savepointWaitState = ""
+	// The savepoint is not available yet.
+	savepointWaitInProgress savepointWaitState = "InProgress"
+	// The savepoint is available and the job can be submitted from it.
+	savepointWaitAvailable savepointWaitState = "Available"
+	// The savepoint was not available in time, the job is not submitted until the spec changes.
+	savepointWaitTimedOut savepointWaitState = "TimedOut"
+)
+
+// Gets the savepoint the job submission waits for, nil if the job is not going to be
+// submitted from the `fromSavepoint` it waits for, or was already submitted from it.
+func getAwaitedSavepoint(cluster *v1beta1.FlinkCluster, now time.Time) *string {
+	var jobSpec = cluster.Spec.Job
+	if jobSpec == nil || jobSpec.WaitForSavepoint == nil || util.IsBlank(jobSpec.FromSavepoint) {
+		return nil
+	}
+	var job = cluster.Status.Components.Job
+	if job.IsActive() {
+		return nil
+	}
+	var fromSavepoint = convertFromSavepoint(cluster, now)
+	if fromSavepoint == nil || *fromSavepoint != *jobSpec.FromSavepoint ||
+		(job != nil && job.DeployTime != "" && job.FromSavepoint == *fromSavepoint) {
+		return nil
+	}
+	return fromSavepoint
+}
+
+// Gets the savepoint or checkpoint the job is going to be restored from, resolved like the job
+// submission, which the savepoint validation webhook must approve. Nil if no webhook is set or
+// the job is not restored.
+func getSavepointToValidate(cluster *v1beta1.FlinkCluster, now time.Time) *string {
+	var jobSpec = cluster.Spec.Job
+	if jobSpec == nil || jobSpec.SavepointValidationWebhook == nil {
+		return nil
+	}
+	return convertFromSavepoint(cluster, now)
+}
+
+// Derives the SavepointValidated condition from the response of the savepoint validation webhook
+// for the savepoint at the location, and whether the job can be restored from it. A failed call
+// blocks the restore unless the failure policy of the webhook is FailOpen.
+func deriveSavepointValidatedCondition(
+	cluster *v1beta1.FlinkCluster,
+	location string,
+	response *flink.SavepointValidationResponse,
+	err error) (*metav1.Condition, bool) {
+	var webhook = cluster.Spec.Job.SavepointValidationWebhook
+	var condition = &metav1.Condition{
+		Type:               v1beta1.ClusterConditionSavepointValidated,
+		ObservedGeneration: cluster.Generation,
+	}
+	switch {
+	case err != nil && webhook.FailsOpen():
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = v1beta1.SavepointValidatedReasonFailedOpen
+		condition.Message = fmt.Sprintf("The validation webhook failed for savepoint %s: %v, restoring from it as the failurePolicy is FailOpen",
+			location, err)
+		return condition, true
+	case err != nil:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = v1beta1.SavepointValidatedReasonFailed
+		condition.Message = fmt.Sprintf("The validation webhook failed for savepoint %s: %v, the job is not submitted until it is approved",
+			location, err)
+		return condition, false
+	case response.Approved:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = v1beta1.SavepointValidatedReasonApproved
+		condition.Message = fmt.Sprintf("Savepoint %s is approved by the validation webhook", location)
+		return condition, true
+	}
+	condition.Status = metav1.ConditionFalse
+	condition.Reason = v1beta1.SavepointValidatedReasonRejected
+	condition.Message = fmt.Sprintf("Savepoint %s is rejected by the validation webhook", location)
+	if response.Reason != "" {
+		condition.Message += ": " + response.Reason
+	}
+	condition.Message += ", the job is not submitted until it is approved"
+	return condition, false
+}
+
+// Derives the state of the wait for the savepoint. The wait starts when the
+// SavepointAvailable condition is first recorded for the current generation of the spec.
+func getSavepointWaitState(observed *ObservedClusterState) savepointWaitState {
+	var cluster = observed.cluster
+	if getAwaitedSavepoint(cluster, observed.observeTime) == nil {
+		return savepointWaitNotRequired
+	}
+	var condition = meta.FindStatusCondition(cluster.Status.Conditions, v1beta1.ClusterConditionSavepointAvailable)
+	var waiting = condition != nil && condition.ObservedGeneration == cluster.Generation &&
+		condition.Status == metav1.ConditionFalse
+	var timeout = time.Duration(cluster.Spec.Job.WaitForSavepoint.GetTimeoutSeconds()) * time.Second
+	switch {
+	case waiting && condition.Reason == v1beta1.SavepointAvailableReasonTimedOut:
+		return savepointWaitTimedOut
+	case observed.savepointAvailable:
+		return savepointWaitAvailable
+	case waiting && observed.observeTime.Sub(condition.LastTransitionTime.Time) >= timeout:
+		return savepointWaitTimedOut
+	}
+	return savepointWaitInProgress
+}
+
+// Checks if any of the clusters recorded the savepoint at the location as completed,
+// as the latest savepoint of its job or in its savepoint inventory.
+func isSavepointRecorded(clusters []v1beta1.FlinkCluster, location string) bool {
+	var matches = func(recorded string) bool {
+		return recorded != "" && strings.TrimSuffix(recorded, "/") == strings.TrimSuffix(location, "/")
+	}
+	for i := range clusters {
+		var status = &clusters[i].Status
+		if job := status.Components.Job; job != nil && matches(job.SavepointLocation) {
+			return true
+		}
+		for _, record := range status.SavepointInventory {
+			if matches(record.Location) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// teardownPhase is a phase of the teardown of a deleted cluster by its finalizer.
+// The phases run in the order below. Each phase is derived from the observed state,
+// so the teardown resumes where it left off across reconciles and operator restarts.
+type teardownPhase string
+
+const (
+	// Stop the running Flink jobs, with a final savepoint unless the stop mode is Cancel.
+	teardownPhaseDrainJob teardownPhase = "DrainJob"
+	// Wait for the final savepoint of the job to complete.
+	teardownPhaseFinalSavepoint teardownPhase = "FinalSavepoint"
+	// Delete the JobManager, TaskManager and job submitter workloads, and wait until
+	// their pods are gone.
+	teardownPhaseDeletePods teardownPhase = "DeletePods"
+	// Delete the HA ConfigMap, once no JobManager is left to recreate it.
+	teardownPhaseCleanupHA teardownPhase = "CleanupHA"
+	// Delete the PersistentVolumeClaims of the cluster.
+	teardownPhaseCleanupStorage teardownPhase = "CleanupStorage"
+	// The teardown is complete and the finalizer can be removed.
+	teardownPhaseDone teardownPhase = "Done"
+)
+
+// Computes the next teardown phase of the deleted cluster from the observed state.
+func getTeardownPhase(observed *ObservedClusterState) teardownPhase {
+	var cluster = observed.cluster
+	var job = cluster.Status.Components.Job
+	var savepoint = cluster.Status.Savepoint
+	var waitingForSavepoint = job != nil && finalSavepointRequested(job.ID, savepoint) &&
+		(savepoint.State == v1beta1.SavepointStateInProgress || job.FinalSavepoint)
+
+	switch {
+	case len(getRunningFlinkJobIDs(observed)) > 0 && waitingForSavepoint:
+		return teardownPhaseFinalSavepoint
+	case len(getRunningFlinkJobIDs(observed)) > 0:
+		return teardownPhaseDrainJob
+	case observed.jmStatefulSet != nil || observed.tmStatefulSet != nil || observed.tmDeployment != nil ||
+		observed.flinkJobSubmitter.job != nil || (observed.pods != nil && len(observed.pods.Items) > 0):
+		return teardownPhaseDeletePods
+	case observed.haConfigMap != nil:
+		return teardownPhaseCleanupHA
+	case observed.persistentVolumeClaims != nil && len(observed.persistentVolumeClaims.Items) > 0:
+		return teardownPhaseCleanupStorage
+	}
+	return teardownPhaseDone
+}
+
+// Checks if the job of the deleted cluster should be stopped with a final savepoint.
+// When the final savepoint has already failed, the job is cancelled without one so
+// that the teardown is not blocked.
+func shouldDrainJobWithSavepoint(cluster *v1beta1.FlinkCluster) bool {
+	var job = cluster.Status.Components.Job
+	var savepoint = cluster.Status.Savepoint
+	var savepointFailed = job != nil && finalSavepointRequested(job.ID, savepoint) &&
+		(savepoint.State == v1beta1.SavepointStateFailed || savepoint.State == v1beta1.SavepointStateTriggerFailed)
+	return shouldStopWithSavepoint(cluster) && !savepointFailed
+}
+
+// Gets the IDs of the running Flink jobs, the job of the cluster and the unexpected ones.
+func getRunningFlinkJobIDs(observed *ObservedClusterState) []string {
+	var runningJobs = observed.flinkJob.unexpected
+	var flinkJob = observed.flinkJob.status
+	if flinkJob != nil && flinkJob.Id != "" &&
+		getFlinkJobDeploymentState(flinkJob.State) == v1beta1.JobStateRunning {
+		runningJobs = append(runningJobs, flinkJob.Id)
+	}
+	return runningJobs
+}
+
+// Derives the JobsDrained condition of the graceful shutdown from the running jobs, and the
+// deadline after which the remaining jobs are cancelled without a savepoint. The graceful
+// shutdown timeout starts when the condition turns false, so it holds across reconciles.
+func deriveJobsDrainedCondition(cluster *v1beta1.FlinkCluster, runningJobs []string, now time.Time) (*metav1.Condition, time.Time) {
+	var recorded = meta.FindStatusCondition(cluster.Status.Conditions, v1beta1.ClusterConditionJobsDrained)
+	var start = now
+	if recorded != nil && recorded.Status == metav1.ConditionFalse {
+		start = recorded.LastTransitionTime.Time
+	}
+	var timeout = cluster.GracefulShutdownTimeout()
+	var deadline = start.Add(timeout)
+	var condition = &metav1.Condition{
+		Type:               v1beta1.ClusterConditionJobsDrained,
+		ObservedGeneration: cluster.Generation,
+	}
+	switch {
+	case len(runningJobs) == 0 && recorded != nil && recorded.Reason == v1beta1.JobsDrainedReasonTimedOut:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = v1beta1.JobsDrainedReasonTimedOut
+		condition.Message = fmt.Sprintf("All jobs are stopped, the jobs not stopped with a savepoint within %v were cancelled without one", timeout)
+	case len(runningJobs) == 0:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = v1beta1.JobsDrainedReasonDrained
+		condition.Message = "All jobs are stopped, the cluster can be torn down"
+	case !now.Before(deadline):
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = v1beta1.JobsDrainedReasonTimedOut
+		condition.Message = fmt.Sprintf("Timed out after %v stopping jobs with a savepoint, cancelling jobs %s without one",
+			timeout, strings.Join(runningJobs, ", "))
+	default:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = v1beta1.JobsDrainedReasonDraining
+		condition.Message = fmt.Sprintf("Stopping jobs %s with a savepoint", strings.Join(runningJobs, ", "))
+	}
+	return condition, deadline
 }
 
 func getUpdateState(observed *ObservedClusterState) UpdateState {
@@ -477,8 +1133,8 @@ func getUpdateState(observed *ObservedClusterState) UpdateState {
 
 	jobStatus := clusterStatus.Components.Job
 	switch {
-	case !isScaleUpdate(observed.revisions, observed.cluster) &&
-		!jobStatus.UpdateReady(observed.cluster.Spec.Job, observed.observeTime):
+	case !isInPlaceUpdate(observed.revisions, observed.cluster) &&
+		!jobStatus.UpdateReady(observed.cluster.Spec.Job, observed.observeTime, skipSavepointOnUpdate(observed.cluster, observed.observeTime)):
 		return UpdateStatePreparing
 	case !isClusterUpdateToDate(observed):
 		return UpdateStateInProgress
@@ -522,12 +1178,273 @@ func isScaleUpdate(revisions []*appsv1.ControllerRevision, cluster *v1beta1.Flin
 	return left != right
 }
 
+// updateStep is a step of a TaskManager update which changes both the image and the replicas.
+type updateStep string
+
+const (
+	updateStepRollImage updateStep = "RollImage"
+	updateStepScale     updateStep = "Scale"
+)
+
+// getScaleAndImageUpdateOrder returns the order to apply a TaskManager update which changes
+// both the image and the replicas at now, nil if the update does not change both.
+//
+// Scaling down goes first so that the pods to be removed are not rolled. Scaling up goes
+// after the image roll so that no pods are created with the old image only to be replaced,
+// unless the adaptive scheduler is used without a savepoint restore: the running jobs then
+// rescale onto the added TaskManagers in place and keep the capacity while the image rolls.
+func getScaleAndImageUpdateOrder(revisions []*appsv1.ControllerRevision, cluster *v1beta1.FlinkCluster, now time.Time) []updateStep {
+	diff := revisionDiff(revisions)
+	if _, ok := diff["image"]; !ok {
+		return nil
+	}
+	tmDiff, ok := diff["taskManager"]
+	if !ok {
+		return nil
+	}
+	left, _ := tmDiff.Left.(map[string]any)["replicas"].(float64)
+	right, _ := tmDiff.Right.(map[string]any)["replicas"].(float64)
+	if left == right {
+		return nil
+	}
+
+	var restoreFromSavepoint = isSavepointRestoreUpdate(cluster, now)
+	if right < left || (cluster.SupportsInPlaceRescale() && !restoreFromSavepoint) {
+		return []updateStep{updateStepScale, updateStepRollImage}
+	}
+	return []updateStep{updateStepRollImage, updateStepScale}
+}
+
+// isSavepointRestoreUpdate returns true if the job is restored from a savepoint after the update
+// applied at now.
+func isSavepointRestoreUpdate(cluster *v1beta1.FlinkCluster, now time.Time) bool {
+	var jobSpec = cluster.Spec.Job
+	if jobSpec == nil {
+		return false
+	}
+	if jobSpec.FromSavepoint != nil && *jobSpec.FromSavepoint != "" {
+		return true
+	}
+	var takeSavepointOnUpdate = jobSpec.TakeSavepointOnUpdate == nil || *jobSpec.TakeSavepointOnUpdate
+	return takeSavepointOnUpdate && !skipSavepointOnUpdate(cluster, now)
+}
+
+// skipSavepointOnUpdate returns true if the update proceeds without the savepoint, either
+// requested by the skip-savepoint-on-next-update annotation or to honor the downtime budget.
+func skipSavepointOnUpdate(cluster *v1beta1.FlinkCluster, now time.Time) bool {
+	return cluster.SkipSavepointOnNextUpdate() || cluster.ExceedsUpdateDowntimeBudget(now)
+}
+
+// getSavepointSkipReason returns the reason the job is updated without taking a savepoint
+// with a message describing it, or an empty reason if the savepoint is taken. No savepoint
+// is skipped when the job is restored from spec.job.fromSavepoint.
+func getSavepointSkipReason(cluster *v1beta1.FlinkCluster, now time.Time) (string, string) {
+	var jobSpec = cluster.Spec.Job
+	switch {
+	case jobSpec == nil || !util.IsBlank(jobSpec.FromSavepoint):
+		return "", ""
+	case jobSpec.TakeSavepointOnUpdate != nil && !*jobSpec.TakeSavepointOnUpdate:
+		return v1beta1.SavepointSkippedReasonDisabled,
+			"The job was updated without a savepoint as takeSavepointOnUpdate is false"
+	case cluster.SkipSavepointOnNextUpdate():
+		return v1beta1.SavepointSkippedReasonAnnotation,
+			fmt.Sprintf("The job was updated without a savepoint as requested by the %s annotation with nonce %s",
+				v1beta1.SkipSavepointOnNextUpdateAnnotation, cluster.PendingSkipSavepointNonce())
+	case cluster.ExceedsUpdateDowntimeBudget(now):
+		return v1beta1.SavepointSkippedReasonBudgetExceeded,
+			fmt.Sprintf("The job was updated without a savepoint as it does not fit in maxUpdateDowntimeSeconds %d",
+				*jobSpec.MaxUpdateDowntimeSeconds)
+	}
+	return "", ""
+}
+
+// getTaskManagerUpdateStage returns the TaskManager StatefulSet or Deployment to apply for the
+// first step of a staged update. It returns nil once the first step is applied, with wait set
+// until it has rolled out, after which the desired object can be applied.
+func getTaskManagerUpdateStage(observedObj, desiredObj client.Object, first updateStep) (staged client.Object, wait bool) {
+	switch observed := observedObj.(type) {
+	case *appsv1.StatefulSet:
+		desired := desiredObj.(*appsv1.StatefulSet)
+		if !isUpdateStepApplied(&observed.Spec.Template, observed.Spec.Replicas, &desired.Spec.Template, desired.Spec.Replicas, first) {
+			staged := observed.DeepCopy()
+			applyUpdateStep(&staged.Spec.Template, &staged.Spec.Replicas, &desired.Spec.Template, desired.Spec.Replicas, first)
+			return staged, false
+		}
+		var replicas = getReplicas(observed.Spec.Replicas)
+		var status = observed.Status
+		return nil, status.ObservedGeneration < observed.Generation ||
+			status.UpdatedReplicas != replicas || status.ReadyReplicas != replicas
+	case *appsv1.Deployment:
+		desired := desiredObj.(*appsv1.Deployment)
+		if !isUpdateStepApplied(&observed.Spec.Template, observed.Spec.Replicas, &desired.Spec.Template, desired.Spec.Replicas, first) {
+			staged := observed.DeepCopy()
+			applyUpdateStep(&staged.Spec.Template, &staged.Spec.Replicas, &desired.Spec.Template, desired.Spec.Replicas, first)
+			return staged, false
+		}
+		var replicas = getReplicas(observed.Spec.Replicas)
+		var status = observed.Status
+		return nil, status.ObservedGeneration < observed.Generation ||
+			status.UpdatedReplicas != replicas || status.ReadyReplicas != replicas || status.Replicas != replicas
+	}
+	return nil, false
+}
+
+// Sets how many TaskManager pods the StatefulSet or Deployment rolls at once, keeping the rest
+// of its update strategy. A StatefulSet updated on delete or a Deployment recreated on update
+// does not roll its pods and is left as is. The StatefulSet only rolls more than one pod at
+// once with the MaxUnavailableStatefulSet feature gate enabled.
+func setTaskManagerRollConcurrency(obj client.Object, concurrency int32) {
+	var maxUnavailable = intstr.FromInt32(concurrency)
+	switch workload := obj.(type) {
+	case *appsv1.StatefulSet:
+		var strategy = &workload.Spec.UpdateStrategy
+		if strategy.Type == appsv1.OnDeleteStatefulSetStrategyType {
+			return
+		}
+		if strategy.RollingUpdate == nil {
+			strategy.RollingUpdate = &appsv1.RollingUpdateStatefulSetStrategy{}
+		}
+		strategy.RollingUpdate.MaxUnavailable = &maxUnavailable
+	case *appsv1.Deployment:
+		var strategy = &workload.Spec.Strategy
+		if strategy.Type == appsv1.RecreateDeploymentStrategyType {
+			return
+		}
+		if strategy.RollingUpdate == nil {
+			strategy.RollingUpdate = &appsv1.RollingUpdateDeployment{}
+		}
+		strategy.RollingUpdate.MaxUnavailable = &maxUnavailable
+	}
+}
+
+func isUpdateStepApplied(
+	template *corev1.PodTemplateSpec, replicas *int32,
+	desiredTemplate *corev1.PodTemplateSpec, desiredReplicas *int32,
+	step updateStep) bool {
+	if step == updateStepScale {
+		return getReplicas(replicas) == getReplicas(desiredReplicas)
+	}
+	return getContainerImage(template, "taskmanager") == getContainerImage(desiredTemplate, "taskmanager")
+}
+
+func applyUpdateStep(
+	template *corev1.PodTemplateSpec, replicas **int32,
+	desiredTemplate *corev1.PodTemplateSpec, desiredReplicas *int32,
+	step updateStep) {
+	if step == updateStepScale {
+		*replicas = desiredReplicas
+		return
+	}
+	*template = *desiredTemplate.DeepCopy()
+}
+
+// Replicas of StatefulSets and Deployments default to 1.
+func getReplicas(replicas *int32) int32 {
+	if replicas == nil {
+		return 1
+	}
+	return *replicas
+}
+
+func getContainerImage(template *corev1.PodTemplateSpec, name string) string {
+	for _, container := range template.Spec.Containers {
+		if container.Name == name {
+			return container.Image
+		}
+	}
+	return ""
+}
+
+// isConfigUpdateWithoutRestart returns true if the update only changes the Flink
+// properties and the config change restart policy does not restart the job for them.
+func isConfigUpdateWithoutRestart(revisions []*appsv1.ControllerRevision, cluster *v1beta1.FlinkCluster) bool {
+	if cluster == nil || cluster.Spec.Job == nil {
+		return false
+	}
+
+	diff := revisionDiff(revisions)
+	propsDiff, ok := diff["flinkProperties"]
+	if len(diff) != 1 || !ok {
+		return false
+	}
+
+	var policy = v1beta1.ConfigChangeRestartPolicyAlways
+	if cluster.Spec.ConfigChangeRestartPolicy != nil {
+		policy = *cluster.Spec.ConfigChangeRestartPolicy
+	}
+	switch policy {
+	case v1beta1.ConfigChangeRestartPolicyNever:
+		return true
+	case v1beta1.ConfigChangeRestartPolicySensitiveOnly:
+		left, _ := propsDiff.Left.(map[string]any)
+		right, _ := propsDiff.Right.(map[string]any)
+		for _, key := range changedKeys(left, right) {
+			if v1beta1.ConfigChangeRequiresRestart(key) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// changedKeys returns the keys whose values differ between the two maps.
+func changedKeys(a, b map[string]any) []string {
+	var keys []string
+	for k, v := range a {
+		if bv, ok := b[k]; !ok || v != bv {
+			keys = append(keys, k)
+		}
+	}
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	return keys
+}
+
+// isInPlaceUpdate returns true if the update is applied to the cluster components
+// without stopping and resubmitting the job.
+func isInPlaceUpdate(revisions []*appsv1.ControllerRevision, cluster *v1beta1.FlinkCluster) bool {
+	return isScaleUpdate(revisions, cluster) || isConfigUpdateWithoutRestart(revisions, cluster)
+}
+
 func shouldUpdateJob(observed *ObservedClusterState) bool {
-	return observed.updateState == UpdateStateInProgress && !isScaleUpdate(observed.revisions, observed.cluster)
+	return observed.updateState == UpdateStateInProgress && !isInPlaceUpdate(observed.revisions, observed.cluster)
+}
+
+// Gets the JobManager replicas of the spec and of the observed StatefulSet, and whether they
+// differ, e.g. because the StatefulSet was scaled manually. The replicas of a StatefulSet
+// being replaced by an update are not compared.
+func getJobManagerReplicaDrift(observed *ObservedClusterState) (desired int32, actual int32, drifted bool) {
+	var cluster = observed.cluster
+	var sts = observed.jmStatefulSet
+	if sts == nil || cluster.Spec.JobManager == nil ||
+		(shouldUpdateCluster(observed) && !isComponentUpdated(sts, cluster)) {
+		return 0, 0, false
+	}
+	desired = 1
+	if cluster.Spec.JobManager.Replicas != nil {
+		desired = *cluster.Spec.JobManager.Replicas
+	}
+	actual = 1
+	if sts.Spec.Replicas != nil {
+		actual = *sts.Spec.Replicas
+	}
+	return desired, actual, desired != actual
+}
+
+// Returns true if drifted JobManager replicas are scaled back to the spec, which is not done
+// with the Warn policy. The JobManager can also be scaled manually during an incident while
+// the reconciliation is paused, as nothing is reconciled then.
+func shouldRevertJobManagerReplicaDrift(cluster *v1beta1.FlinkCluster) bool {
+	var policy = cluster.Spec.JobManager.ReplicaDriftPolicy
+	return policy == nil || *policy != v1beta1.ReplicaDriftPolicyWarn
 }
 
 func shouldUpdateCluster(observed *ObservedClusterState) bool {
-	if isScaleUpdate(observed.revisions, observed.cluster) {
+	if isInPlaceUpdate(observed.revisions, observed.cluster) {
 		return observed.updateState == UpdateStateInProgress
 	}
 
@@ -537,7 +1454,7 @@ func shouldUpdateCluster(observed *ObservedClusterState) bool {
 
 func shouldRecreateOnUpdate(observed *ObservedClusterState) bool {
 	ru := observed.cluster.Spec.RecreateOnUpdate
-	return *ru && !isScaleUpdate(observed.revisions, observed.cluster)
+	return *ru && !isInPlaceUpdate(observed.revisions, observed.cluster)
 }
 
 func getFlinkJobDeploymentState(flinkJobState string) v1beta1.JobState {
@@ -566,11 +1483,14 @@ func getFlinkJobSubmitLog(clientset *kubernetes.Clientset, observedPod *corev1.P
 }
 
 func getFlinkJobSubmitLogFromString(podLog string) *SubmitterLog {
+	var submitterLog = &SubmitterLog{message: podLog}
 	if result := jobIdRegexp.FindStringSubmatch(podLog); len(result) > 0 {
-		return &SubmitterLog{jobID: result[1], message: podLog}
-	} else {
-		return &SubmitterLog{jobID: "", message: podLog}
+		submitterLog.jobID = result[1]
 	}
+	if result := jarIdRegexp.FindStringSubmatch(podLog); len(result) > 0 {
+		submitterLog.jar = &v1beta1.UploadedJarStatus{ID: result[1], Hash: result[2]}
+	}
+	return submitterLog
 }
 
 func IsApplicationModeCluster(cluster *v1beta1.FlinkCluster) bool {
@@ -600,58 +1520,385 @@ func isJobInitialising(jobStatus batchv1.JobStatus) bool {
 	return jobStatus.Active == 0 && jobStatus.Succeeded == 0 && jobStatus.Failed == 0
 }
 
-// parseFlinkDuration parses a Flink duration string into a time.Duration.
-// The format is "{length}{unit}", e.g. "123ms", "321 s", "5000".
-// If no unit is specified, milliseconds is assumed.
-//
-// Supported units (matching org.apache.flink.util.TimeUtils):
-//   - DAYS: "d", "day", "days"
-//   - HOURS: "h", "hour", "hours"
-//   - MINUTES: "min", "m", "minute", "minutes"
-//   - SECONDS: "s", "sec", "secs", "second", "seconds"
-//   - MILLISECONDS: "ms", "milli", "millis", "millisecond", "milliseconds"
-//   - MICROSECONDS: "µs", "micro", "micros", "microsecond", "microseconds"
-//   - NANOSECONDS: "ns", "nano", "nanos", "nanosecond", "nanoseconds"
-func parseFlinkDuration(s string) (time.Duration, error) {
-	s = strings.TrimSpace(s)
-	if s == "" {
-		return 0, fmt.Errorf("empty duration string")
+// Flink config keys which are derived by Flink or the deployment environment, so
+// their running values legitimately differ from the rendered config.
+var configDriftIgnoredKeys = map[string]struct{}{
+	"jobmanager.rpc.address":  {},
+	"jobmanager.bind-host":    {},
+	"taskmanager.host":        {},
+	"taskmanager.bind-host":   {},
+	"taskmanager.resource-id": {},
+	"rest.address":            {},
+	"rest.bind-address":       {},
+	"web.tmpdir":              {},
+	"io.tmp.dirs":             {},
+	"execution.target":        {},
+	"pipeline.jars":           {},
+	"pipeline.classpaths":     {},
+}
+
+var configDriftIgnoredKeyPrefixes = []string{"$internal.", "internal."}
+
+// Flink masks the values of sensitive config keys in REST responses.
+const flinkMaskedConfigValue = "******"
+
+// getFlinkConfigDrift returns the sorted keys of the rendered Flink config whose value
+// in the running config is different or missing.
+func getFlinkConfigDrift(rendered map[string]string, running map[string]string) []string {
+	var drift []string
+	for key, value := range rendered {
+		if isConfigDriftIgnoredKey(key) {
+			continue
+		}
+		runningValue, ok := running[key]
+		if ok && (runningValue == flinkMaskedConfigValue || strings.TrimSpace(runningValue) == value) {
+			continue
+		}
+		drift = append(drift, key)
+	}
+	sort.Strings(drift)
+	return drift
+}
+
+func isConfigDriftIgnoredKey(key string) bool {
+	if _, ok := configDriftIgnoredKeys[key]; ok {
+		return true
 	}
+	for _, prefix := range configDriftIgnoredKeyPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
 
-	i := 0
-	for i < len(s) && s[i] >= '0' && s[i] <= '9' {
-		i++
+// getRenderedFlinkConfig returns the Flink properties rendered in the cluster ConfigMap.
+func getRenderedFlinkConfig(configMap *corev1.ConfigMap) map[string]string {
+	if configMap == nil {
+		return nil
 	}
-	if i == 0 {
-		return 0, fmt.Errorf("invalid duration %q: does not start with a number", s)
+	for _, name := range []string{"config.yaml", "flink-conf.yaml"} {
+		if conf, ok := configMap.Data[name]; ok {
+			return parseFlinkProperties(conf)
+		}
 	}
+	return nil
+}
 
-	val, err := strconv.ParseInt(s[:i], 10, 64)
-	if err != nil {
-		return 0, fmt.Errorf("invalid duration %q: %w", s, err)
-	}
-
-	unit := strings.TrimSpace(s[i:])
-	if unit == "" {
-		return time.Duration(val) * time.Millisecond, nil
-	}
-
-	switch strings.ToLower(unit) {
-	case "d", "day", "days":
-		return time.Duration(val) * 24 * time.Hour, nil
-	case "h", "hour", "hours":
-		return time.Duration(val) * time.Hour, nil
-	case "m", "min", "minute", "minutes":
-		return time.Duration(val) * time.Minute, nil
-	case "s", "sec", "secs", "second", "seconds":
-		return time.Duration(val) * time.Second, nil
-	case "ms", "milli", "millis", "millisecond", "milliseconds":
-		return time.Duration(val) * time.Millisecond, nil
-	case "µs", "micro", "micros", "microsecond", "microseconds":
-		return time.Duration(val) * time.Microsecond, nil
-	case "ns", "nano", "nanos", "nanosecond", "nanoseconds":
-		return time.Duration(val) * time.Nanosecond, nil
-	default:
-		return 0, fmt.Errorf("unknown duration unit %q in %q", unit, s)
+// Pods are reported unschedulable only after staying unschedulable for this long,
+// so that a short Pending phase while the cluster starts up or scales is not reported.
+const podUnschedulableThreshold = 2 * time.Minute
+
+var insufficientResourceRegex = regexp.MustCompile(`Insufficient ([\w./-]*\w)`)
+
+// PodSchedulingFailure is the summarized scheduling failure of the pods of a component.
+type PodSchedulingFailure struct {
+	component string
+	count     int
+	reason    string
+	message   string
+}
+
+// getPodSchedulingFailures returns the scheduling failures of the JobManager and
+// TaskManager pods which have been unschedulable for longer than the threshold.
+func getPodSchedulingFailures(pods *corev1.PodList, now time.Time) []PodSchedulingFailure {
+	if pods == nil {
+		return nil
+	}
+	var failures []PodSchedulingFailure
+	for _, component := range []string{"jobmanager", "taskmanager"} {
+		var failure = PodSchedulingFailure{component: component}
+		for i := range pods.Items {
+			var pod = &pods.Items[i]
+			if pod.Labels["component"] != component {
+				continue
+			}
+			cond := getPodUnschedulableCondition(pod)
+			if cond == nil || now.Sub(cond.LastTransitionTime.Time) < podUnschedulableThreshold {
+				continue
+			}
+			if failure.count == 0 {
+				failure.reason, failure.message = classifySchedulingFailure(cond.Message)
+			}
+			failure.count++
+		}
+		if failure.count > 0 {
+			failures = append(failures, failure)
+		}
+	}
+	return failures
+}
+
+// getPodUnschedulableCondition returns the PodScheduled condition of a pending pod
+// which the scheduler failed to place.
+func getPodUnschedulableCondition(pod *corev1.Pod) *corev1.PodCondition {
+	if pod.Status.Phase != corev1.PodPending {
+		return nil
+	}
+	for i := range pod.Status.Conditions {
+		var cond = &pod.Status.Conditions[i]
+		if cond.Type == corev1.PodScheduled &&
+			cond.Status == corev1.ConditionFalse &&
+			cond.Reason == corev1.PodReasonUnschedulable {
+			return cond
+		}
 	}
+	return nil
+}
+
+// The annotation of the HA ConfigMap in which the Kubernetes HA services of Flink record
+// the leader election of the JobManager.
+const haLeaderAnnotation = "control-plane.alpha.kubernetes.io/leader"
+
+// The prefix of the HA ConfigMap data keys in which the leader JobManager publishes the
+// addresses of its components, e.g., `org.apache.flink.k8s.leader.restserver`.
+const haLeaderDataKeyPrefix = "org.apache.flink.k8s.leader."
+
+// getHALeaderIdentity returns the identity of the lock held by the leader JobManager in the
+// HA ConfigMap, empty while no JobManager is leader.
+func getHALeaderIdentity(haConfigMap *corev1.ConfigMap) string {
+	if haConfigMap == nil {
+		return ""
+	}
+	var record struct {
+		HolderIdentity string `json:"holderIdentity"`
+	}
+	if err := json.Unmarshal([]byte(haConfigMap.Annotations[haLeaderAnnotation]), &record); err != nil {
+		return ""
+	}
+	return record.HolderIdentity
+}
+
+// getHALeaderPod returns the name of the JobManager pod whose IP or hostname is in the
+// addresses published by the leader in the HA ConfigMap, empty if none is.
+func getHALeaderPod(haConfigMap *corev1.ConfigMap, pods *corev1.PodList) string {
+	if haConfigMap == nil || pods == nil {
+		return ""
+	}
+	for i := range pods.Items {
+		var pod = &pods.Items[i]
+		if pod.Labels["component"] != "jobmanager" {
+			continue
+		}
+		for key, address := range haConfigMap.Data {
+			if !strings.HasPrefix(key, haLeaderDataKeyPrefix) {
+				continue
+			}
+			if hasAddressHost(address, pod.Status.PodIP) || hasAddressHost(address, pod.Name) {
+				return pod.Name
+			}
+		}
+	}
+	return ""
+}
+
+// hasAddressHost returns true if the address is on the host, an IP or the first label of a
+// hostname, e.g., the host 10.0.0.5 in `akka.tcp://flink@10.0.0.5:6123/user/rpc/dispatcher_1`
+// but not in `http://10.0.0.50:8081`.
+func hasAddressHost(address, host string) bool {
+	if host == "" {
+		return false
+	}
+	return regexp.MustCompile(`(^|[/@])` + regexp.QuoteMeta(host) + `[:.]`).MatchString(address)
+}
+
+// getGatedTaskManagerPods returns the TaskManager pods whose scheduling is gated until
+// the JobManager is ready.
+func getGatedTaskManagerPods(pods *corev1.PodList) []*corev1.Pod {
+	if pods == nil {
+		return nil
+	}
+	var gated []*corev1.Pod
+	for i := range pods.Items {
+		var pod = &pods.Items[i]
+		if pod.Labels["component"] != "taskmanager" {
+			continue
+		}
+		for _, gate := range pod.Spec.SchedulingGates {
+			if gate.Name == v1beta1.JobManagerReadySchedulingGate {
+				gated = append(gated, pod)
+				break
+			}
+		}
+	}
+	return gated
+}
+
+// shouldRemoveTaskManagerSchedulingGates returns true if the TaskManagers can connect to
+// the JobManager: a JobManager pod is ready and, with high availability, a JobManager is
+// recorded as the leader in the HA ConfigMap, as the TaskManagers register with the leader.
+func shouldRemoveTaskManagerSchedulingGates(observed *ObservedClusterState) bool {
+	var jmStatefulSet = observed.jmStatefulSet
+	if jmStatefulSet == nil || jmStatefulSet.Status.ReadyReplicas < 1 {
+		return false
+	}
+	if !observed.cluster.IsHighAvailabilityEnabled() {
+		return true
+	}
+	return getHALeaderIdentity(observed.haConfigMap) != ""
+}
+
+// hasUnschedulablePods returns true if any of the pods is pending because the
+// scheduler cannot place it.
+func hasUnschedulablePods(pods *corev1.PodList) bool {
+	if pods == nil {
+		return false
+	}
+	for i := range pods.Items {
+		if getPodUnschedulableCondition(&pods.Items[i]) != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// classifySchedulingFailure maps the scheduler message, e.g.
+// "0/3 nodes are available: 3 Insufficient memory.", to a condition reason
+// and a short description.
+func classifySchedulingFailure(message string) (string, string) {
+	switch {
+	case strings.Contains(strings.ToLower(message), "persistentvolumeclaim"):
+		return v1beta1.PodsUnschedulableReasonUnboundVolumeClaim, "unbound PersistentVolumeClaim"
+	case insufficientResourceRegex.MatchString(message):
+		var resources []string
+		for _, match := range insufficientResourceRegex.FindAllStringSubmatch(message, -1) {
+			if !slices.Contains(resources, match[1]) {
+				resources = append(resources, match[1])
+			}
+		}
+		return v1beta1.PodsUnschedulableReasonInsufficientResources, "insufficient " + strings.Join(resources, ", ")
+	case strings.Contains(message, "affinity"):
+		return v1beta1.PodsUnschedulableReasonUnsatisfiableAffinity, "unsatisfiable affinity"
+	}
+	return v1beta1.PodsUnschedulableReasonUnschedulable, strings.TrimSpace(message)
+}
+
+// A TaskManager is diagnosed unable to reach the JobManager RPC only after its container
+// has restarted this many times, so that a restart while the JobManager starts up or fails
+// over is not reported.
+const taskManagerRPCRestartThreshold = 3
+
+// The categories of the failures a TaskManager container terminates with.
+const (
+	taskManagerFailureRPC         = "RPC"
+	taskManagerFailureOutOfMemory = "OutOfMemory"
+	taskManagerFailureApplication = "Application"
+)
+
+// Lowercase fragments of the errors Flink logs when a TaskManager cannot connect or
+// register to the ResourceManager of the JobManager.
+var taskManagerRPCErrors = []string{
+	"could not register at the resourcemanager",
+	"could not resolve resourcemanager address",
+	"registration timeout",
+	"connection refused",
+	"connectexception",
+	"no route to host",
+	"unknownhostexception",
+	"association with remote system",
+}
+
+// TaskManagerRPCFailure is the summarized failure of the TaskManager pods which
+// repeatedly terminate because they cannot reach the JobManager RPC.
+type TaskManagerRPCFailure struct {
+	count    int
+	restarts int32
+	message  string
+}
+
+// classifyTaskManagerFailure returns the category of the termination of a TaskManager
+// container and the log line it is derived from. The termination message holds the tail
+// of the log when the container fails without writing one.
+func classifyTaskManagerFailure(terminated *corev1.ContainerStateTerminated) (string, string) {
+	if terminated.Reason == "OOMKilled" {
+		return taskManagerFailureOutOfMemory, terminated.Reason
+	}
+	var lines = strings.Split(terminated.Message, "\n")
+	for _, line := range lines {
+		if strings.Contains(line, "java.lang.OutOfMemoryError") {
+			return taskManagerFailureOutOfMemory, strings.TrimSpace(line)
+		}
+	}
+	for _, line := range lines {
+		var lower = strings.ToLower(line)
+		for _, fragment := range taskManagerRPCErrors {
+			if strings.Contains(lower, fragment) {
+				return taskManagerFailureRPC, strings.TrimSpace(line)
+			}
+		}
+	}
+	return taskManagerFailureApplication, strings.TrimSpace(terminated.Message)
+}
+
+// getTaskManagerRPCFailure returns the failure of the TaskManager pods which have restarted
+// at least the threshold times, last terminated with an RPC failure and are not registered,
+// or nil. A pod is taken as unregistered when it is not ready or the slot registration of
+// its group lags. Crashes of other categories, e.g., of the job code, are not reported.
+func getTaskManagerRPCFailure(
+	pods *corev1.PodList,
+	registration *v1beta1.SlotRegistrationStatus) *TaskManagerRPCFailure {
+	if pods == nil {
+		return nil
+	}
+	var laggingGroups = make(map[string]bool)
+	if registration != nil {
+		for _, group := range registration.Groups {
+			laggingGroups[group.Name] = isTaskManagerGroupLagging(group)
+		}
+	}
+	var failure = &TaskManagerRPCFailure{}
+	for i := range pods.Items {
+		var pod = &pods.Items[i]
+		if pod.Labels["component"] != "taskmanager" || pod.DeletionTimestamp != nil {
+			continue
+		}
+		var name = "taskmanager"
+		if owner := metav1.GetControllerOf(pod); owner != nil {
+			name = owner.Name
+		}
+		if isPodReady(pod) && !laggingGroups[name] {
+			continue
+		}
+		for _, status := range pod.Status.ContainerStatuses {
+			var terminated = status.LastTerminationState.Terminated
+			if status.Name != "taskmanager" || terminated == nil ||
+				status.RestartCount < taskManagerRPCRestartThreshold {
+				continue
+			}
+			category, message := classifyTaskManagerFailure(terminated)
+			if category != taskManagerFailureRPC {
+				continue
+			}
+			if failure.count == 0 {
+				failure.message = message
+			}
+			failure.count++
+			if status.RestartCount > failure.restarts {
+				failure.restarts = status.RestartCount
+			}
+		}
+	}
+	if failure.count == 0 {
+		return nil
+	}
+	return failure
+}
+
+// The message of the root exception of a job Flink does not restart any more because its
+// restart strategy is exhausted, e.g., `Recovery is suppressed by
+// FixedDelayRestartBackoffTimeStrategy(maxNumberRestartAttempts=3, backoffTimeMS=10000)`.
+// A failure Flink does not recover from regardless of the strategy is reported otherwise.
+const flinkRecoverySuppressedMessage = "Recovery is suppressed by"
+
+// isFlinkRestartStrategyExhausted returns true if the Flink API reports that the restart
+// strategy of the failed job gave up on it.
+func isFlinkRestartStrategyExhausted(exceptions *flink.JobExceptions) bool {
+	return exceptions != nil && strings.Contains(exceptions.RootException, flinkRecoverySuppressedMessage)
+}
+
+// isRESTJarUpload returns true if the job JAR is uploaded through the REST API of the JobManager
+// and run by its ID.
+func isRESTJarUpload(cluster *v1beta1.FlinkCluster) bool {
+	var jobSpec = cluster.Spec.Job
+	return jobSpec != nil && jobSpec.RESTJarUpload != nil && *jobSpec.RESTJarUpload && !IsApplicationModeCluster(cluster)
 }
@@ -18,6 +18,8 @@ package flinkcluster
 
 import (
 	"os"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
@@ -29,8 +31,11 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 
 	v1beta1 "github.com/spotify/flink-on-k8s-operator/apis/flinkcluster/v1beta1"
+	"github.com/spotify/flink-on-k8s-operator/internal/controllers/history"
+	"github.com/spotify/flink-on-k8s-operator/internal/flink"
 	"github.com/spotify/flink-on-k8s-operator/internal/util"
 	"gotest.tools/v3/assert"
 )
@@ -180,6 +185,88 @@ func TestNewRevisionDataPatchExcludesSavepointFormatType(t *testing.T) {
 		"nil vs NATIVE should produce the same revision patch")
 }
 
+func TestNewRevisionStableAcrossMapOrdering(t *testing.T) {
+	var keys = []string{"taskmanager.numberOfTaskSlots", "state.backend", "parallelism.default", "rest.port", "jobmanager.memory.process.size"}
+	var newCluster = func(order []string) *v1beta1.FlinkCluster {
+		var properties = make(map[string]string)
+		var labels = make(map[string]string)
+		for _, key := range order {
+			properties[key] = "value-" + key
+			labels[strings.ReplaceAll(key, ".", "-")] = "true"
+		}
+		return &v1beta1.FlinkCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "mycluster", Namespace: "default"},
+			Spec: v1beta1.FlinkClusterSpec{
+				Image:           v1beta1.ImageSpec{Name: "flink:1.17.0"},
+				FlinkProperties: properties,
+				TaskManager:     &v1beta1.TaskManagerSpec{PodLabels: labels},
+			},
+		}
+	}
+
+	var collisionCount int32
+	expected, err := newRevision(newCluster(keys), 1, &collisionCount)
+	assert.NilError(t, err)
+	var reversed = slices.Clone(keys)
+	slices.Reverse(reversed)
+	for i := 0; i < 20; i++ {
+		var order = reversed
+		if i%2 == 0 {
+			order = slices.Clone(keys)
+			order[0], order[i%len(order)] = order[i%len(order)], order[0]
+		}
+		revision, err := newRevision(newCluster(order), 1, &collisionCount)
+		assert.NilError(t, err)
+		assert.Equal(t, string(revision.Data.Raw), string(expected.Data.Raw))
+		assert.Equal(t, revision.Labels[history.ControllerRevisionHashLabel], expected.Labels[history.ControllerRevisionHashLabel])
+	}
+}
+
+func TestRevisionHashChurn(t *testing.T) {
+	var newCluster = func(tmSpec *v1beta1.TaskManagerSpec) *v1beta1.FlinkCluster {
+		return &v1beta1.FlinkCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "mycluster", Namespace: "default"},
+			Spec: v1beta1.FlinkClusterSpec{
+				Image:       v1beta1.ImageSpec{Name: "flink:1.17.0"},
+				TaskManager: tmSpec,
+			},
+		}
+	}
+	var replicas int32 = 3
+	unset, err := newRevisionDataPatch(newCluster(&v1beta1.TaskManagerSpec{}))
+	assert.NilError(t, err)
+	// Defaulted to empty values.
+	defaulted, err := newRevisionDataPatch(newCluster(&v1beta1.TaskManagerSpec{
+		FineGrainedResources: &v1beta1.FineGrainedResourcesSpec{SlotProfiles: []v1beta1.SlotResourceProfile{}},
+	}))
+	assert.NilError(t, err)
+	changed, err := newRevisionDataPatch(newCluster(&v1beta1.TaskManagerSpec{Replicas: &replicas}))
+	assert.NilError(t, err)
+
+	var fields, unstable = revisionHashChurn(unset, unset)
+	assert.Assert(t, !unstable)
+	assert.Assert(t, fields == nil)
+
+	fields, unstable = revisionHashChurn(unset, defaulted)
+	assert.Assert(t, unstable)
+	assert.DeepEqual(t, fields, []string{"spec.taskManager.fineGrainedResources"})
+
+	fields, unstable = revisionHashChurn(defaulted, unset)
+	assert.Assert(t, unstable)
+	assert.DeepEqual(t, fields, []string{"spec.taskManager.fineGrainedResources"})
+
+	fields, unstable = revisionHashChurn(unset, changed)
+	assert.Assert(t, !unstable)
+	assert.Assert(t, fields == nil)
+
+	// Null and empty values of a field present on both sides.
+	fields, unstable = revisionHashChurn(
+		[]byte(`{"spec":{"$patch":"replace","job":{"args":null,"restartPolicy":null}}}`),
+		[]byte(`{"spec":{"$patch":"replace","job":{"args":[],"restartPolicy":null}}}`))
+	assert.Assert(t, unstable)
+	assert.DeepEqual(t, fields, []string{"spec.job.args"})
+}
+
 func TestCanTakeSavepoint(t *testing.T) {
 	// session cluster
 	var cluster = v1beta1.FlinkCluster{
@@ -237,6 +324,81 @@ func TestCanTakeSavepoint(t *testing.T) {
 	assert.Equal(t, take, false)
 }
 
+func TestGetTaskManagerPodOrdinal(t *testing.T) {
+	var podName = getTaskManagerPodName("mycluster", 3)
+	assert.Equal(t, podName, "mycluster-taskmanager-3")
+
+	ordinal, ok := getTaskManagerPodOrdinal("mycluster", podName)
+	assert.Assert(t, ok)
+	assert.Equal(t, ordinal, int32(3))
+
+	for _, name := range []string{"mycluster-jobmanager-0", "mycluster-taskmanager-abc", "mycluster-taskmanager--1", "other-taskmanager-0"} {
+		_, ok = getTaskManagerPodOrdinal("mycluster", name)
+		assert.Assert(t, !ok, name)
+	}
+}
+
+func TestGetTaskManagerOrdinalChanges(t *testing.T) {
+	tests := []struct {
+		name             string
+		existing         []int32
+		replicas         int32
+		expectedToRemove []int32
+		expectedToCreate []int32
+	}{
+		{
+			name:             "no change",
+			existing:         []int32{0, 1, 2},
+			replicas:         3,
+			expectedToRemove: nil,
+			expectedToCreate: nil,
+		},
+		{
+			name:             "scale up",
+			existing:         []int32{0, 1},
+			replicas:         4,
+			expectedToRemove: nil,
+			expectedToCreate: []int32{2, 3},
+		},
+		{
+			name:             "scale down removes highest ordinals first",
+			existing:         []int32{3, 0, 4, 1, 2},
+			replicas:         2,
+			expectedToRemove: []int32{4, 3, 2},
+			expectedToCreate: nil,
+		},
+		{
+			name:             "scale down to zero",
+			existing:         []int32{0, 1},
+			replicas:         0,
+			expectedToRemove: []int32{1, 0},
+			expectedToCreate: nil,
+		},
+		{
+			name:             "gaps are filled",
+			existing:         []int32{0, 2, 3, 5},
+			replicas:         3,
+			expectedToRemove: []int32{5, 3},
+			expectedToCreate: []int32{1},
+		},
+		{
+			name:             "duplicated ordinals",
+			existing:         []int32{0, 3, 3},
+			replicas:         2,
+			expectedToRemove: []int32{3},
+			expectedToCreate: []int32{1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			toRemove, toCreate := getTaskManagerOrdinalChanges(tt.existing, tt.replicas)
+			assert.DeepEqual(t, toRemove, tt.expectedToRemove)
+			assert.DeepEqual(t, toCreate, tt.expectedToCreate)
+		})
+	}
+}
+
 func TestGetNextRevisionNumber(t *testing.T) {
 	var revisions []*appsv1.ControllerRevision
 	var nextRevision = util.GetNextRevisionNumber(revisions)
@@ -354,6 +516,61 @@ func TestGetUpdateState(t *testing.T) {
 	assert.Equal(t, state, UpdateStateFinished)
 }
 
+func TestGetUpdateStateSkipSavepointOnNextUpdate(t *testing.T) {
+	var observed = ObservedClusterState{
+		cluster: &v1beta1.FlinkCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{v1beta1.SkipSavepointOnNextUpdateAnnotation: "nonce-1"},
+			},
+			Spec: v1beta1.FlinkClusterSpec{
+				JobManager:  &v1beta1.JobManagerSpec{Ingress: &v1beta1.JobManagerIngressSpec{}},
+				TaskManager: &v1beta1.TaskManagerSpec{DeploymentType: v1beta1.DeploymentTypeStatefulSet},
+				Job:         &v1beta1.JobSpec{},
+			},
+			Status: v1beta1.FlinkClusterStatus{
+				Components: v1beta1.FlinkClusterComponentsStatus{Job: &v1beta1.JobStatus{State: v1beta1.JobStateRunning}},
+				Revision:   v1beta1.RevisionStatus{CurrentRevision: "cluster-85dc8f749-2", NextRevision: "cluster-aa5e3a87z-3"}},
+		},
+		jmStatefulSet: &appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{RevisionNameLabel: "cluster-85dc8f749"}}},
+	}
+
+	// The update proceeds without waiting for the final savepoint.
+	assert.Equal(t, getUpdateState(&observed), UpdateStateInProgress)
+
+	// After the nonce is consumed, the next update waits for the savepoint again.
+	observed.cluster.Status.Components.Job.SkipSavepointNonce = "nonce-1"
+	assert.Equal(t, getUpdateState(&observed), UpdateStatePreparing)
+}
+
+func TestGetUpdateStateMaxUpdateDowntime(t *testing.T) {
+	var budget int32 = 60
+	var fast int64 = 20
+	var slow int64 = 120
+	var observed = ObservedClusterState{
+		cluster: &v1beta1.FlinkCluster{
+			Spec: v1beta1.FlinkClusterSpec{
+				JobManager:  &v1beta1.JobManagerSpec{Ingress: &v1beta1.JobManagerIngressSpec{}},
+				TaskManager: &v1beta1.TaskManagerSpec{DeploymentType: v1beta1.DeploymentTypeStatefulSet},
+				Job:         &v1beta1.JobSpec{MaxUpdateDowntimeSeconds: &budget},
+			},
+			Status: v1beta1.FlinkClusterStatus{
+				Components:         v1beta1.FlinkClusterComponentsStatus{Job: &v1beta1.JobStatus{State: v1beta1.JobStateRunning}},
+				Revision:           v1beta1.RevisionStatus{CurrentRevision: "cluster-85dc8f749-2", NextRevision: "cluster-aa5e3a87z-3"},
+				SavepointInventory: []v1beta1.SavepointRecord{{Location: "gs://bucket/savepoint-1", DurationSeconds: &fast}},
+			},
+		},
+		jmStatefulSet: &appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{RevisionNameLabel: "cluster-85dc8f749"}}},
+		observeTime:   time.Now(),
+	}
+
+	// The savepoint fits in the budget, the update waits for it.
+	assert.Equal(t, getUpdateState(&observed), UpdateStatePreparing)
+
+	// The savepoint is projected over the budget, the update proceeds without it.
+	observed.cluster.Status.SavepointInventory[0].DurationSeconds = &slow
+	assert.Equal(t, getUpdateState(&observed), UpdateStateInProgress)
+}
+
 func TestGetUpdateStateApplicationModeRequiresNextRevisionJob(t *testing.T) {
 	var applicationMode = v1beta1.JobModeApplication
 	var currentRevision = "cluster-current-2"
@@ -429,6 +646,298 @@ func TestGetUpdateStateApplicationModeScaleDoesNotRequireNewJob(t *testing.T) {
 	assert.Equal(t, getUpdateState(&observed), UpdateStateFinished)
 }
 
+func TestConfigChangeRestartPolicy(t *testing.T) {
+	var newRevisions = func(before, after string) []*appsv1.ControllerRevision {
+		return []*appsv1.ControllerRevision{
+			{Revision: 1, Data: runtime.RawExtension{Raw: []byte(`{"spec":{"flinkProperties":` + before + `}}`)}},
+			{Revision: 2, Data: runtime.RawExtension{Raw: []byte(`{"spec":{"flinkProperties":` + after + `}}`)}},
+		}
+	}
+	var base = `{"taskmanager.numberOfTaskSlots":"1","web.refresh-interval":"3000"}`
+	var benign = `{"taskmanager.numberOfTaskSlots":"1","web.refresh-interval":"5000","metrics.latency.interval":"1000"}`
+	var sensitive = `{"taskmanager.numberOfTaskSlots":"2","web.refresh-interval":"5000"}`
+	var always = v1beta1.ConfigChangeRestartPolicyAlways
+	var sensitiveOnly = v1beta1.ConfigChangeRestartPolicySensitiveOnly
+	var never = v1beta1.ConfigChangeRestartPolicyNever
+	var recreateOnUpdate = true
+
+	tests := []struct {
+		name            string
+		policy          *v1beta1.ConfigChangeRestartPolicy
+		after           string
+		expectedRestart bool
+	}{
+		{name: "benign change restarts by default", after: benign, expectedRestart: true},
+		{name: "benign change restarts with Always", policy: &always, after: benign, expectedRestart: true},
+		{name: "benign change does not restart with SensitiveOnly", policy: &sensitiveOnly, after: benign},
+		{name: "sensitive change restarts with SensitiveOnly", policy: &sensitiveOnly, after: sensitive, expectedRestart: true},
+		{name: "benign change does not restart with Never", policy: &never, after: benign},
+		{name: "sensitive change does not restart with Never", policy: &never, after: sensitive},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			var observed = ObservedClusterState{
+				cluster: &v1beta1.FlinkCluster{Spec: v1beta1.FlinkClusterSpec{
+					Job:                       &v1beta1.JobSpec{},
+					RecreateOnUpdate:          &recreateOnUpdate,
+					ConfigChangeRestartPolicy: tt.policy,
+				}},
+				revisions:   newRevisions(base, tt.after),
+				updateState: UpdateStateInProgress,
+			}
+
+			// when
+			var restart = shouldUpdateJob(&observed)
+
+			// then
+			assert.Equal(t, restart, tt.expectedRestart)
+			assert.Equal(t, shouldRecreateOnUpdate(&observed), tt.expectedRestart)
+		})
+	}
+
+	// Changes beyond the Flink properties always follow the regular update.
+	var observed = ObservedClusterState{
+		cluster: &v1beta1.FlinkCluster{Spec: v1beta1.FlinkClusterSpec{
+			Job:                       &v1beta1.JobSpec{},
+			ConfigChangeRestartPolicy: &never,
+		}},
+		revisions: []*appsv1.ControllerRevision{
+			{Revision: 1, Data: runtime.RawExtension{Raw: []byte(`{"spec":{"image":{"name":"flink:1.20"},"flinkProperties":` + base + `}}`)}},
+			{Revision: 2, Data: runtime.RawExtension{Raw: []byte(`{"spec":{"image":{"name":"flink:2.0"},"flinkProperties":` + benign + `}}`)}},
+		},
+		updateState: UpdateStateInProgress,
+	}
+	assert.Equal(t, shouldUpdateJob(&observed), true)
+}
+
+func TestGetScaleAndImageUpdateOrder(t *testing.T) {
+	var newRevisions = func(before, after string) []*appsv1.ControllerRevision {
+		return []*appsv1.ControllerRevision{
+			{Revision: 1, Data: runtime.RawExtension{Raw: []byte(`{"spec":` + before + `}`)}},
+			{Revision: 2, Data: runtime.RawExtension{Raw: []byte(`{"spec":` + after + `}`)}},
+		}
+	}
+	var base = `{"image":{"name":"flink:1.20"},"taskManager":{"replicas":3}}`
+	var scaleUp = `{"image":{"name":"flink:2.0"},"taskManager":{"replicas":5}}`
+	var scaleDown = `{"image":{"name":"flink:2.0"},"taskManager":{"replicas":1}}`
+	var adaptive = map[string]string{"jobmanager.scheduler": "adaptive"}
+	var takeSavepointOnUpdate = false
+
+	tests := []struct {
+		name            string
+		after           string
+		job             *v1beta1.JobSpec
+		flinkProperties map[string]string
+		expectedOrder   []updateStep
+	}{
+		{
+			name:          "scale up rolls image first",
+			after:         scaleUp,
+			job:           &v1beta1.JobSpec{},
+			expectedOrder: []updateStep{updateStepRollImage, updateStepScale},
+		},
+		{
+			name:          "scale down scales first",
+			after:         scaleDown,
+			job:           &v1beta1.JobSpec{},
+			expectedOrder: []updateStep{updateStepScale, updateStepRollImage},
+		},
+		{
+			name:            "scale up with adaptive scheduler and savepoint restore rolls image first",
+			after:           scaleUp,
+			job:             &v1beta1.JobSpec{},
+			flinkProperties: adaptive,
+			expectedOrder:   []updateStep{updateStepRollImage, updateStepScale},
+		},
+		{
+			name:            "scale up with adaptive scheduler without savepoint restore scales first",
+			after:           scaleUp,
+			job:             &v1beta1.JobSpec{TakeSavepointOnUpdate: &takeSavepointOnUpdate},
+			flinkProperties: adaptive,
+			expectedOrder:   []updateStep{updateStepScale, updateStepRollImage},
+		},
+		{
+			name:            "scale up of session cluster with reactive mode scales first",
+			after:           scaleUp,
+			flinkProperties: map[string]string{"scheduler-mode": "reactive"},
+			expectedOrder:   []updateStep{updateStepScale, updateStepRollImage},
+		},
+		{
+			name:  "image only",
+			after: `{"image":{"name":"flink:2.0"},"taskManager":{"replicas":3}}`,
+			job:   &v1beta1.JobSpec{},
+		},
+		{
+			name:  "scale only",
+			after: `{"image":{"name":"flink:1.20"},"taskManager":{"replicas":5}}`,
+			job:   &v1beta1.JobSpec{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			var cluster = &v1beta1.FlinkCluster{Spec: v1beta1.FlinkClusterSpec{
+				Job:             tt.job,
+				FlinkProperties: tt.flinkProperties,
+			}}
+
+			// when
+			var order = getScaleAndImageUpdateOrder(newRevisions(base, tt.after), cluster, time.Now())
+
+			// then
+			assert.DeepEqual(t, order, tt.expectedOrder)
+		})
+	}
+}
+
+func TestSetTaskManagerRollConcurrency(t *testing.T) {
+	var maxUnavailable = intstr.FromInt32(3)
+	var maxSurge = intstr.FromString("25%")
+	var partition int32 = 1
+
+	var statefulSet = &appsv1.StatefulSet{}
+	setTaskManagerRollConcurrency(statefulSet, 3)
+	assert.DeepEqual(t, statefulSet.Spec.UpdateStrategy, appsv1.StatefulSetUpdateStrategy{
+		RollingUpdate: &appsv1.RollingUpdateStatefulSetStrategy{MaxUnavailable: &maxUnavailable},
+	})
+
+	// The other fields of the rolling update are kept.
+	statefulSet.Spec.UpdateStrategy = appsv1.StatefulSetUpdateStrategy{
+		Type:          appsv1.RollingUpdateStatefulSetStrategyType,
+		RollingUpdate: &appsv1.RollingUpdateStatefulSetStrategy{Partition: &partition},
+	}
+	setTaskManagerRollConcurrency(statefulSet, 3)
+	assert.DeepEqual(t, statefulSet.Spec.UpdateStrategy, appsv1.StatefulSetUpdateStrategy{
+		Type:          appsv1.RollingUpdateStatefulSetStrategyType,
+		RollingUpdate: &appsv1.RollingUpdateStatefulSetStrategy{Partition: &partition, MaxUnavailable: &maxUnavailable},
+	})
+
+	// A StatefulSet updated on delete does not roll.
+	statefulSet.Spec.UpdateStrategy = appsv1.StatefulSetUpdateStrategy{Type: appsv1.OnDeleteStatefulSetStrategyType}
+	setTaskManagerRollConcurrency(statefulSet, 3)
+	assert.DeepEqual(t, statefulSet.Spec.UpdateStrategy, appsv1.StatefulSetUpdateStrategy{Type: appsv1.OnDeleteStatefulSetStrategyType})
+
+	var deployment = &appsv1.Deployment{
+		Spec: appsv1.DeploymentSpec{
+			Strategy: appsv1.DeploymentStrategy{
+				Type:          appsv1.RollingUpdateDeploymentStrategyType,
+				RollingUpdate: &appsv1.RollingUpdateDeployment{MaxSurge: &maxSurge},
+			},
+		},
+	}
+	setTaskManagerRollConcurrency(deployment, 3)
+	assert.DeepEqual(t, deployment.Spec.Strategy, appsv1.DeploymentStrategy{
+		Type:          appsv1.RollingUpdateDeploymentStrategyType,
+		RollingUpdate: &appsv1.RollingUpdateDeployment{MaxSurge: &maxSurge, MaxUnavailable: &maxUnavailable},
+	})
+
+	// A Deployment recreated on update does not roll.
+	deployment.Spec.Strategy = appsv1.DeploymentStrategy{Type: appsv1.RecreateDeploymentStrategyType}
+	setTaskManagerRollConcurrency(deployment, 3)
+	assert.DeepEqual(t, deployment.Spec.Strategy, appsv1.DeploymentStrategy{Type: appsv1.RecreateDeploymentStrategyType})
+}
+
+func TestGetTaskManagerUpdateStage(t *testing.T) {
+	var three int32 = 3
+	var five int32 = 5
+	var newStatefulSet = func(image string, replicas *int32, ready int32) *appsv1.StatefulSet {
+		return &appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Generation: 1},
+			Spec: appsv1.StatefulSetSpec{
+				Replicas: replicas,
+				Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "taskmanager", Image: image}},
+				}},
+			},
+			Status: appsv1.StatefulSetStatus{ObservedGeneration: 1, UpdatedReplicas: ready, ReadyReplicas: ready},
+		}
+	}
+	var desired = newStatefulSet("flink:2.0", &five, 0)
+
+	// Rolls the image with the observed replicas first.
+	staged, wait := getTaskManagerUpdateStage(newStatefulSet("flink:1.20", &three, 3), desired, updateStepRollImage)
+	assert.Assert(t, !wait)
+	assert.Equal(t, staged.(*appsv1.StatefulSet).Spec.Template.Spec.Containers[0].Image, "flink:2.0")
+	assert.Equal(t, *staged.(*appsv1.StatefulSet).Spec.Replicas, three)
+
+	// Waits for the image roll to complete.
+	staged, wait = getTaskManagerUpdateStage(newStatefulSet("flink:2.0", &three, 1), desired, updateStepRollImage)
+	assert.Assert(t, staged == nil)
+	assert.Assert(t, wait)
+
+	// Then applies the desired state.
+	staged, wait = getTaskManagerUpdateStage(newStatefulSet("flink:2.0", &three, 3), desired, updateStepRollImage)
+	assert.Assert(t, staged == nil)
+	assert.Assert(t, !wait)
+
+	// Scales with the observed image first.
+	staged, wait = getTaskManagerUpdateStage(newStatefulSet("flink:1.20", &three, 3), desired, updateStepScale)
+	assert.Assert(t, !wait)
+	assert.Equal(t, staged.(*appsv1.StatefulSet).Spec.Template.Spec.Containers[0].Image, "flink:1.20")
+	assert.Equal(t, *staged.(*appsv1.StatefulSet).Spec.Replicas, five)
+}
+
+func TestClassifySchedulingFailure(t *testing.T) {
+	for _, test := range []struct {
+		message         string
+		expectedReason  string
+		expectedSummary string
+	}{
+		{
+			message:         "0/3 nodes are available: 1 Insufficient cpu, 2 Insufficient memory, 2 Insufficient cpu.",
+			expectedReason:  v1beta1.PodsUnschedulableReasonInsufficientResources,
+			expectedSummary: "insufficient cpu, memory",
+		},
+		{
+			message:         "0/3 nodes are available: 3 node(s) didn't match pod anti-affinity rules.",
+			expectedReason:  v1beta1.PodsUnschedulableReasonUnsatisfiableAffinity,
+			expectedSummary: "unsatisfiable affinity",
+		},
+		{
+			message:         "persistentvolumeclaim \"data-mycluster-taskmanager-0\" not found",
+			expectedReason:  v1beta1.PodsUnschedulableReasonUnboundVolumeClaim,
+			expectedSummary: "unbound PersistentVolumeClaim",
+		},
+		{
+			message:         "0/3 nodes are available: 3 node(s) had untolerated taint {dedicated: flink}.",
+			expectedReason:  v1beta1.PodsUnschedulableReasonUnschedulable,
+			expectedSummary: "0/3 nodes are available: 3 node(s) had untolerated taint {dedicated: flink}.",
+		},
+	} {
+		reason, summary := classifySchedulingFailure(test.message)
+		assert.Equal(t, reason, test.expectedReason)
+		assert.Equal(t, summary, test.expectedSummary)
+	}
+}
+
+func TestIsObservabilityPollDue(t *testing.T) {
+	var tc = &util.TimeConverter{}
+	var now = tc.FromString("2020-01-01T00:01:00+00:00")
+	var interval int32 = 30
+	var disabled int32 = 0
+	for _, test := range []struct {
+		name         string
+		interval     *int32
+		lastPollTime string
+		expected     bool
+	}{
+		{name: "sampling unset", lastPollTime: "2020-01-01T00:00:59+00:00", expected: true},
+		{name: "sampling disabled", interval: &disabled, lastPollTime: "2020-01-01T00:00:59+00:00", expected: true},
+		{name: "never polled", interval: &interval, expected: true},
+		{name: "within the interval", interval: &interval, lastPollTime: "2020-01-01T00:00:40+00:00", expected: false},
+		{name: "interval elapsed", interval: &interval, lastPollTime: "2020-01-01T00:00:20+00:00", expected: true},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			var cluster = &v1beta1.FlinkCluster{
+				Spec:   v1beta1.FlinkClusterSpec{ObservabilitySamplingSeconds: test.interval},
+				Status: v1beta1.FlinkClusterStatus{LastObservabilityPollTime: test.lastPollTime},
+			}
+			assert.Equal(t, isObservabilityPollDue(cluster, now), test.expected)
+		})
+	}
+}
+
 func TestHasTimeElapsed(t *testing.T) {
 	var tc = &util.TimeConverter{}
 	var timeToCheckStr = "2020-01-01T00:00:00+00:00"
@@ -512,98 +1021,16 @@ func TestGetFlinkJobSubmitLog(t *testing.T) {
 	// job ID not found
 	submit = getFlinkJobSubmitLogFromString("")
 	assert.Equal(t, submit.jobID, "")
-}
-
-func TestParseFlinkDuration(t *testing.T) {
-	tests := []struct {
-		input    string
-		expected time.Duration
-	}{
-		// Plain milliseconds (no unit)
-		{"5000", 5 * time.Second},
-		{"0", 0},
-		{"100", 100 * time.Millisecond},
-
-		// Days
-		{"1d", 24 * time.Hour},
-		{"2 d", 48 * time.Hour},
-		{"1 day", 24 * time.Hour},
-		{"3 days", 72 * time.Hour},
-
-		// Hours
-		{"1h", time.Hour},
-		{"2 h", 2 * time.Hour},
-		{"1 hour", time.Hour},
-		{"3 hours", 3 * time.Hour},
-
-		// Minutes
-		{"10min", 10 * time.Minute},
-		{"5 min", 5 * time.Minute},
-		{"1m", time.Minute},
-		{"1 minute", time.Minute},
-		{"2 minutes", 2 * time.Minute},
-
-		// Seconds
-		{"30s", 30 * time.Second},
-		{"5 s", 5 * time.Second},
-		{"1 sec", time.Second},
-		{"2 secs", 2 * time.Second},
-		{"1 second", time.Second},
-		{"3 seconds", 3 * time.Second},
-
-		// Milliseconds
-		{"500ms", 500 * time.Millisecond},
-		{"1 milli", time.Millisecond},
-		{"2 millis", 2 * time.Millisecond},
-		{"1 millisecond", time.Millisecond},
-		{"3 milliseconds", 3 * time.Millisecond},
-
-		// Microseconds
-		{"100µs", 100 * time.Microsecond},
-		{"1 micro", time.Microsecond},
-		{"2 micros", 2 * time.Microsecond},
-		{"1 microsecond", time.Microsecond},
-		{"3 microseconds", 3 * time.Microsecond},
-
-		// Nanoseconds
-		{"500ns", 500 * time.Nanosecond},
-		{"1 nano", time.Nanosecond},
-		{"2 nanos", 2 * time.Nanosecond},
-		{"1 nanosecond", time.Nanosecond},
-		{"3 nanoseconds", 3 * time.Nanosecond},
-
-		// Whitespace handling
-		{"  5 s  ", 5 * time.Second},
-		{"10   ms", 10 * time.Millisecond},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.input, func(t *testing.T) {
-			got, err := parseFlinkDuration(tt.input)
-			assert.NilError(t, err)
-			assert.Equal(t, got, tt.expected)
-		})
-	}
-}
-
-func TestParseFlinkDuration_Invalid(t *testing.T) {
-	tests := []struct {
-		input string
-	}{
-		{""},
-		{"abc"},
-		{"s"},
-		{"10 foo"},
-		{"10.5 s"},
-		{"100s0"},
-	}
+	assert.Assert(t, submit.jar == nil)
 
-	for _, tt := range tests {
-		t.Run(tt.input, func(t *testing.T) {
-			_, err := parseFlinkDuration(tt.input)
-			assert.Assert(t, err != nil, "expected error for input %q", tt.input)
-		})
-	}
+	// JAR uploaded through the REST API
+	log = `
+  Running the jar with JarID 6077eca7_job.jar JarHash a9c2f3d7
+  Job has been submitted with JobID ec74209eb4e3db8ae72db00bd7a830aa
+`
+	submit = getFlinkJobSubmitLogFromString(log)
+	assert.Equal(t, submit.jobID, "ec74209eb4e3db8ae72db00bd7a830aa")
+	assert.DeepEqual(t, submit.jar, &v1beta1.UploadedJarStatus{ID: "6077eca7_job.jar", Hash: "a9c2f3d7"})
 }
 
 func TestSavepointFormatType(t *testing.T) {
@@ -667,3 +1094,627 @@ func TestSavepointFormatType(t *testing.T) {
 		})
 	}
 }
+
+func TestGetFlinkConfigDrift(t *testing.T) {
+	var rendered = parseFlinkProperties("blob.server.port: 6124\n" +
+		"jobmanager.rpc.address: mycluster-jobmanager\n" +
+		"s3.secret-key: secret\n" +
+		"state.checkpoints.dir: gs://my-bucket/checkpoints\n" +
+		"taskmanager.numberOfTaskSlots: 4\n")
+	var running = map[string]string{
+		"blob.server.port":                "6124",
+		"jobmanager.rpc.address":          "10.0.0.1",
+		"s3.secret-key":                   "******",
+		"taskmanager.numberOfTaskSlots":   "2",
+		"$internal.deployment.config-dir": "/opt/flink/conf",
+	}
+
+	assert.DeepEqual(t, getFlinkConfigDrift(rendered, running),
+		[]string{"state.checkpoints.dir", "taskmanager.numberOfTaskSlots"})
+	assert.Assert(t, getFlinkConfigDrift(rendered, rendered) == nil)
+}
+
+func TestShouldFailOverToPrimedSavepoint(t *testing.T) {
+	var tc = &util.TimeConverter{}
+	var now = time.Now()
+	var maxSavepointAge int32 = 300
+	var savepointLocation = "gs://my-bucket/savepoint-latest"
+	var newCluster = func(state v1beta1.JobState, savepointTime time.Time) *v1beta1.FlinkCluster {
+		return &v1beta1.FlinkCluster{
+			Spec: v1beta1.FlinkClusterSpec{
+				Job: &v1beta1.JobSpec{
+					WarmStandby: &v1beta1.WarmStandbySpec{MaxSavepointAgeSeconds: &maxSavepointAge},
+				},
+			},
+			Status: v1beta1.FlinkClusterStatus{
+				Components: v1beta1.FlinkClusterComponentsStatus{
+					Job: &v1beta1.JobStatus{
+						State:             state,
+						SavepointLocation: savepointLocation,
+						SavepointTime:     tc.ToString(savepointTime),
+						PrimedSavepoint: &v1beta1.PrimedSavepoint{
+							Location: savepointLocation,
+							Time:     tc.ToString(savepointTime),
+						},
+					},
+				},
+				Revision: v1beta1.RevisionStatus{CurrentRevision: "cluster-85dc8f749-1", NextRevision: "cluster-85dc8f749-1"},
+			},
+		}
+	}
+
+	// The failed job fails over to the fresh primed savepoint.
+	var cluster = newCluster(v1beta1.JobStateFailed, now.Add(-time.Minute))
+	var job = cluster.Status.Components.Job
+	assert.Equal(t, shouldFailOverToPrimedSavepoint(cluster, now), true)
+	assert.Equal(t, job.ShouldRestart(cluster.Spec.Job, now), true)
+	var fromSavepoint = convertFromSavepoint(cluster, now)
+	assert.Equal(t, *fromSavepoint, savepointLocation)
+
+	// The freshness is checked at the given time.
+	assert.Equal(t, shouldFailOverToPrimedSavepoint(cluster, now.Add(5*time.Minute)), false)
+	assert.Equal(t, job.ShouldRestart(cluster.Spec.Job, now.Add(5*time.Minute)), false)
+
+	// Fallback: the stale primed savepoint is not used.
+	cluster = newCluster(v1beta1.JobStateFailed, now.Add(-10*time.Minute))
+	assert.Equal(t, shouldFailOverToPrimedSavepoint(cluster, now), false)
+	assert.Equal(t, cluster.Status.Components.Job.ShouldRestart(cluster.Spec.Job, now), false)
+
+	// The running job does not fail over.
+	cluster = newCluster(v1beta1.JobStateRunning, now.Add(-time.Minute))
+	assert.Equal(t, shouldFailOverToPrimedSavepoint(cluster, now), false)
+}
+
+func TestConvertFromSavepoint(t *testing.T) {
+	var tc = &util.TimeConverter{}
+	var now = time.Now()
+	var specSavepoint = "gs://my-bucket/savepoint-spec"
+	var latestSavepoint = "gs://my-bucket/savepoint-2"
+	var checkpoint = &v1beta1.RetainedCheckpoint{ID: 7, Location: "gs://my-bucket/chk-7", Time: tc.ToString(now.Add(-time.Hour))}
+	tests := []struct {
+		name       string
+		revision   v1beta1.RevisionStatus
+		job        *v1beta1.JobStatus
+		expected   *string
+		validation bool
+	}{
+		{
+			name:     "first submission from the spec",
+			expected: &specSavepoint,
+		},
+		{
+			name:     "update with fromSavepoint",
+			revision: v1beta1.RevisionStatus{CurrentRevision: "cluster-85dc8f749-1", NextRevision: "cluster-85dc8f749-2"},
+			job: &v1beta1.JobStatus{
+				SavepointLocation:  "gs://my-bucket/savepoint-2",
+				SavepointTime:      tc.ToString(now.Add(-time.Minute)),
+				RetainedCheckpoint: checkpoint,
+			},
+			expected: &specSavepoint,
+		},
+		{
+			name: "newer poison savepoint skipped for older checkpoint",
+			job: &v1beta1.JobStatus{
+				FromSavepoint:      "gs://my-bucket/savepoint-2",
+				SavepointLocation:  "gs://my-bucket/savepoint-2",
+				SavepointTime:      tc.ToString(now.Add(-time.Minute)),
+				PoisonSavepoints:   []string{"gs://my-bucket/savepoint-2"},
+				RetainedCheckpoint: checkpoint,
+			},
+			expected:   &checkpoint.Location,
+			validation: true,
+		},
+		{
+			name: "newer savepoint over older checkpoint",
+			job: &v1beta1.JobStatus{
+				SavepointLocation:  "gs://my-bucket/savepoint-2",
+				SavepointTime:      tc.ToString(now.Add(-time.Minute)),
+				RetainedCheckpoint: checkpoint,
+			},
+			expected:   &latestSavepoint,
+			validation: true,
+		},
+		{
+			name: "all sources poison",
+			job: &v1beta1.JobStatus{
+				FromSavepoint:     "gs://my-bucket/savepoint-2",
+				SavepointLocation: "gs://my-bucket/savepoint-2",
+				PoisonSavepoints:  []string{"gs://my-bucket/savepoint-2"},
+			},
+			expected: &latestSavepoint,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cluster = &v1beta1.FlinkCluster{
+				Spec: v1beta1.FlinkClusterSpec{Job: &v1beta1.JobSpec{FromSavepoint: &specSavepoint}},
+				Status: v1beta1.FlinkClusterStatus{
+					Components: v1beta1.FlinkClusterComponentsStatus{Job: tt.job},
+					Revision:   tt.revision,
+				},
+			}
+			assert.DeepEqual(t, convertFromSavepoint(cluster, now), tt.expected)
+
+			// The validation webhook is asked for the chosen restore source.
+			if tt.validation {
+				assert.Assert(t, getSavepointToValidate(cluster, now) == nil)
+				cluster.Spec.Job.SavepointValidationWebhook = &v1beta1.SavepointValidationWebhookSpec{URL: "http://validator/validate"}
+				assert.DeepEqual(t, getSavepointToValidate(cluster, now), tt.expected)
+			}
+		})
+	}
+}
+
+func TestIsCheckpointAlignmentHigh(t *testing.T) {
+	var samples = func(durationsMillis ...int64) []v1beta1.CheckpointAlignmentSample {
+		var s []v1beta1.CheckpointAlignmentSample
+		for i, d := range durationsMillis {
+			s = append(s, v1beta1.CheckpointAlignmentSample{CheckpointID: int64(i + 1), AlignmentDurationMillis: d})
+		}
+		return s
+	}
+	var withUnaligned = samples(400000, 400000, 400000)
+	withUnaligned[1].Unaligned = true
+
+	tests := []struct {
+		name                string
+		samples             []v1beta1.CheckpointAlignmentSample
+		unalignedConfigured bool
+		expected            bool
+	}{
+		{name: "sustained high alignment", samples: samples(1000, 310000, 400000, 300000), expected: true},
+		{name: "recovered alignment", samples: samples(310000, 400000, 300000, 1000)},
+		{name: "single spike", samples: samples(1000, 400000, 1000)},
+		{name: "not enough samples", samples: samples(400000, 400000)},
+		{name: "unaligned checkpoint", samples: withUnaligned},
+		{name: "unaligned checkpoints configured", samples: samples(400000, 400000, 400000), unalignedConfigured: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, isCheckpointAlignmentHigh(tt.samples, 5*time.Minute, tt.unalignedConfigured), tt.expected)
+		})
+	}
+}
+
+func TestIsSavepointSuperseded(t *testing.T) {
+	var tc = &util.TimeConverter{}
+	var restore = time.Now().Add(-10 * time.Minute)
+	var job = &v1beta1.JobStatus{
+		SavepointLocation: "s3://bucket/savepoints/savepoint-1",
+		SavepointTime:     tc.ToString(restore.Add(-time.Hour)),
+	}
+	var stats = &flink.CheckpointingStatistics{Latest: flink.LatestCheckpoints{
+		Restored: &flink.RestoredCheckpointStatistics{
+			ID: 2, RestoreTimestamp: restore.UnixMilli(), IsSavepoint: true, ExternalPath: "s3://bucket/savepoints/savepoint-2",
+		},
+	}}
+	assert.Equal(t, isSavepointSuperseded(job, stats), true)
+
+	// The job took the savepoint after the restore.
+	stats.History = []flink.CheckpointStatistics{{ID: 3, IsSavepoint: true, ExternalPath: job.SavepointLocation}}
+	assert.Equal(t, isSavepointSuperseded(job, stats), false)
+	stats.History = nil
+	job.SavepointTime = tc.ToString(restore.Add(time.Minute))
+	assert.Equal(t, isSavepointSuperseded(job, stats), false)
+	job.SavepointTime = tc.ToString(restore.Add(-time.Hour))
+
+	// The job was restored from the recorded savepoint.
+	stats.Latest.Restored.ExternalPath = job.SavepointLocation
+	assert.Equal(t, isSavepointSuperseded(job, stats), false)
+
+	// The job was recovered from a checkpoint, e.g., by high availability.
+	stats.Latest.Restored = &flink.RestoredCheckpointStatistics{
+		ID: 42, RestoreTimestamp: restore.UnixMilli(), ExternalPath: "s3://bucket/checkpoints/chk-42",
+	}
+	assert.Equal(t, isSavepointSuperseded(job, stats), false)
+
+	// The job started without state.
+	stats.Latest.Restored = nil
+	assert.Equal(t, isSavepointSuperseded(job, stats), false)
+}
+
+func TestGetAwaitedSavepoint(t *testing.T) {
+	var location = "gs://bucket/savepoints/savepoint-1"
+	var other = "gs://bucket/savepoints/savepoint-2"
+	tests := []struct {
+		name     string
+		wait     bool
+		job      *v1beta1.JobStatus
+		expected bool
+	}{
+		{name: "new job", wait: true, job: &v1beta1.JobStatus{State: v1beta1.JobStatePending}, expected: true},
+		{name: "no wait", job: &v1beta1.JobStatus{State: v1beta1.JobStatePending}},
+		{name: "running job", wait: true, job: &v1beta1.JobStatus{State: v1beta1.JobStateRunning}},
+		{
+			name: "submitted from the savepoint",
+			wait: true,
+			job:  &v1beta1.JobStatus{State: v1beta1.JobStateFailed, DeployTime: "2026-10-14T10:00:00Z", FromSavepoint: location},
+		},
+		{
+			name: "restarted from a later savepoint",
+			wait: true,
+			job:  &v1beta1.JobStatus{State: v1beta1.JobStateFailed, DeployTime: "2026-10-14T10:00:00Z", SavepointLocation: other},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cluster = &v1beta1.FlinkCluster{
+				Spec:   v1beta1.FlinkClusterSpec{Job: &v1beta1.JobSpec{FromSavepoint: &location}},
+				Status: v1beta1.FlinkClusterStatus{Components: v1beta1.FlinkClusterComponentsStatus{Job: tt.job}},
+			}
+			if tt.wait {
+				cluster.Spec.Job.WaitForSavepoint = &v1beta1.WaitForSavepointSpec{}
+			}
+			assert.Equal(t, getAwaitedSavepoint(cluster, time.Now()) != nil, tt.expected)
+		})
+	}
+}
+
+func TestIsSavepointRecorded(t *testing.T) {
+	var clusters = []v1beta1.FlinkCluster{
+		{Status: v1beta1.FlinkClusterStatus{Components: v1beta1.FlinkClusterComponentsStatus{
+			Job: &v1beta1.JobStatus{SavepointLocation: "gs://bucket/savepoints/savepoint-1"},
+		}}},
+		{Status: v1beta1.FlinkClusterStatus{SavepointInventory: []v1beta1.SavepointRecord{
+			{Location: "gs://bucket/savepoints/savepoint-2/"},
+		}}},
+	}
+	assert.Assert(t, isSavepointRecorded(clusters, "gs://bucket/savepoints/savepoint-1"))
+	assert.Assert(t, isSavepointRecorded(clusters, "gs://bucket/savepoints/savepoint-2"))
+	assert.Assert(t, !isSavepointRecorded(clusters, "gs://bucket/savepoints/savepoint-3"))
+	assert.Assert(t, !isSavepointRecorded(nil, "gs://bucket/savepoints/savepoint-1"))
+}
+
+func TestGetTeardownPhase(t *testing.T) {
+	var running = &flink.Job{Id: "job-1", State: "RUNNING"}
+	var newObserved = func(jobStatus *flink.Job, savepoint *v1beta1.SavepointStatus) *ObservedClusterState {
+		return &ObservedClusterState{
+			cluster: &v1beta1.FlinkCluster{Status: v1beta1.FlinkClusterStatus{
+				Components: v1beta1.FlinkClusterComponentsStatus{Job: &v1beta1.JobStatus{ID: "job-1"}},
+				Savepoint:  savepoint,
+			}},
+			flinkJob:               FlinkJob{status: jobStatus},
+			jmStatefulSet:          &appsv1.StatefulSet{},
+			haConfigMap:            &corev1.ConfigMap{},
+			persistentVolumeClaims: &corev1.PersistentVolumeClaimList{Items: []corev1.PersistentVolumeClaim{{}}},
+		}
+	}
+	var inProgress = &v1beta1.SavepointStatus{
+		JobID:         "job-1",
+		TriggerReason: v1beta1.SavepointReasonJobCancel,
+		State:         v1beta1.SavepointStateInProgress,
+	}
+
+	var observed = newObserved(running, nil)
+	assert.Equal(t, getTeardownPhase(observed), teardownPhaseDrainJob)
+
+	observed = newObserved(running, inProgress)
+	assert.Equal(t, getTeardownPhase(observed), teardownPhaseFinalSavepoint)
+
+	// The job stopped with the final savepoint.
+	observed = newObserved(&flink.Job{Id: "job-1", State: "FINISHED"}, inProgress)
+	assert.Equal(t, getTeardownPhase(observed), teardownPhaseDeletePods)
+
+	// The JobManager is gone, but its pod is still terminating.
+	observed = newObserved(nil, nil)
+	observed.jmStatefulSet = nil
+	observed.pods = &corev1.PodList{Items: []corev1.Pod{{}}}
+	assert.Equal(t, getTeardownPhase(observed), teardownPhaseDeletePods)
+
+	observed.pods = &corev1.PodList{}
+	assert.Equal(t, getTeardownPhase(observed), teardownPhaseCleanupHA)
+
+	observed.haConfigMap = nil
+	assert.Equal(t, getTeardownPhase(observed), teardownPhaseCleanupStorage)
+
+	observed.persistentVolumeClaims = &corev1.PersistentVolumeClaimList{}
+	assert.Equal(t, getTeardownPhase(observed), teardownPhaseDone)
+}
+
+func TestShouldDrainJobWithSavepoint(t *testing.T) {
+	var cluster = v1beta1.FlinkCluster{
+		Spec: v1beta1.FlinkClusterSpec{Job: &v1beta1.JobSpec{}},
+		Status: v1beta1.FlinkClusterStatus{
+			Components: v1beta1.FlinkClusterComponentsStatus{Job: &v1beta1.JobStatus{ID: "job-1"}},
+		},
+	}
+	assert.Equal(t, shouldDrainJobWithSavepoint(&cluster), true)
+
+	var cancel = v1beta1.JobStopModeCancel
+	cluster.Spec.Job.StopMode = &cancel
+	assert.Equal(t, shouldDrainJobWithSavepoint(&cluster), false)
+
+	// The final savepoint failed.
+	cluster.Spec.Job.StopMode = nil
+	cluster.Status.Savepoint = &v1beta1.SavepointStatus{
+		JobID:         "job-1",
+		TriggerReason: v1beta1.SavepointReasonJobCancel,
+		State:         v1beta1.SavepointStateFailed,
+	}
+	assert.Equal(t, shouldDrainJobWithSavepoint(&cluster), false)
+}
+
+func TestDeriveJobsDrainedCondition(t *testing.T) {
+	var now = time.Now()
+	var timeoutSeconds int32 = 60
+	var cluster = v1beta1.FlinkCluster{
+		Spec: v1beta1.FlinkClusterSpec{GracefulShutdownTimeoutSeconds: &timeoutSeconds},
+	}
+
+	// The graceful shutdown starts.
+	var condition, deadline = deriveJobsDrainedCondition(&cluster, []string{"job-1", "job-2"}, now)
+	assert.Equal(t, condition.Status, metav1.ConditionFalse)
+	assert.Equal(t, condition.Reason, v1beta1.JobsDrainedReasonDraining)
+	assert.Equal(t, condition.Message, "Stopping jobs job-1, job-2 with a savepoint")
+	assert.Equal(t, deadline, now.Add(time.Minute))
+
+	// The timeout starts with the recorded condition.
+	var start = now.Add(-30 * time.Second)
+	condition.LastTransitionTime = metav1.NewTime(start)
+	cluster.Status.Conditions = []metav1.Condition{*condition}
+	condition, deadline = deriveJobsDrainedCondition(&cluster, []string{"job-2"}, now)
+	assert.Equal(t, condition.Reason, v1beta1.JobsDrainedReasonDraining)
+	assert.Equal(t, deadline, start.Add(time.Minute))
+
+	condition, _ = deriveJobsDrainedCondition(&cluster, nil, now)
+	assert.Equal(t, condition.Status, metav1.ConditionTrue)
+	assert.Equal(t, condition.Reason, v1beta1.JobsDrainedReasonDrained)
+
+	// The timeout has passed.
+	condition, _ = deriveJobsDrainedCondition(&cluster, []string{"job-2"}, now.Add(time.Minute))
+	assert.Equal(t, condition.Status, metav1.ConditionFalse)
+	assert.Equal(t, condition.Reason, v1beta1.JobsDrainedReasonTimedOut)
+	assert.Equal(t, condition.Message, "Timed out after 1m0s stopping jobs with a savepoint, cancelling jobs job-2 without one")
+
+	condition.LastTransitionTime = metav1.NewTime(start)
+	cluster.Status.Conditions = []metav1.Condition{*condition}
+	condition, _ = deriveJobsDrainedCondition(&cluster, nil, now.Add(time.Minute))
+	assert.Equal(t, condition.Status, metav1.ConditionTrue)
+	assert.Equal(t, condition.Reason, v1beta1.JobsDrainedReasonTimedOut)
+
+	// A new graceful shutdown after the previous one completed.
+	condition.LastTransitionTime = metav1.NewTime(start)
+	cluster.Status.Conditions = []metav1.Condition{*condition}
+	condition, deadline = deriveJobsDrainedCondition(&cluster, []string{"job-3"}, now)
+	assert.Equal(t, condition.Reason, v1beta1.JobsDrainedReasonDraining)
+	assert.Equal(t, deadline, now.Add(time.Minute))
+}
+
+func TestGetTaskManagerGroupSlots(t *testing.T) {
+	var newPod = func(name, owner, ip string, phase corev1.PodPhase) corev1.Pod {
+		var controller = true
+		return corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            name,
+				Labels:          map[string]string{"component": "taskmanager"},
+				OwnerReferences: []metav1.OwnerReference{{Kind: "StatefulSet", Name: owner, Controller: &controller}},
+			},
+			Status: corev1.PodStatus{Phase: phase, PodIP: ip},
+		}
+	}
+	var pods = &corev1.PodList{Items: []corev1.Pod{
+		newPod("tm-a-0", "tm-a", "10.0.0.1", corev1.PodRunning),
+		newPod("tm-a-1", "tm-a", "10.0.0.2", corev1.PodRunning),
+		newPod("tm-b-0", "tm-b", "10.0.1.1", corev1.PodRunning),
+		newPod("tm-b-1", "tm-b", "10.0.1.2", corev1.PodRunning),
+		newPod("tm-b-2", "tm-b", "", corev1.PodPending),
+		{ObjectMeta: metav1.ObjectMeta{Name: "jm-0", Labels: map[string]string{"component": "jobmanager"}},
+			Status: corev1.PodStatus{Phase: corev1.PodRunning, PodIP: "10.0.2.1"}},
+	}}
+	var taskManagers = []flink.TaskManagerInfo{
+		{ID: "10.0.0.1:6122-1a2b3c", Path: "pekko.tcp://flink@10.0.0.1:6122/user/rpc/taskmanager_0", SlotsNumber: 2},
+		{ID: "10.0.0.2:6122-4d5e6f", SlotsNumber: 2},
+		{ID: "10.0.1.1:6122-7a8b9c", Path: "akka.tcp://flink@10.0.1.1:6122/user/rpc/taskmanager_0", SlotsNumber: 2},
+	}
+
+	var groups = getTaskManagerGroupSlots(pods, taskManagers, 2, nil)
+
+	assert.DeepEqual(t, groups, []v1beta1.TaskManagerGroupSlots{
+		{Name: "tm-a", Replicas: 2, ExpectedSlots: 4, RegisteredSlots: 4},
+		{Name: "tm-b", Replicas: 3, ExpectedSlots: 4, RegisteredSlots: 2},
+	})
+	assert.Equal(t, isTaskManagerGroupLagging(groups[0]), false)
+	assert.Equal(t, isTaskManagerGroupLagging(groups[1]), true)
+
+	// The pods of a Deployment are owned by the ReplicaSets of its revisions.
+	var controller = true
+	var newDeploymentPod = func(name, hash string) corev1.Pod {
+		return corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            name,
+				Labels:          map[string]string{"component": "taskmanager", "pod-template-hash": hash},
+				OwnerReferences: []metav1.OwnerReference{{Kind: "ReplicaSet", Name: "tm-" + hash, Controller: &controller}},
+			},
+			Status: corev1.PodStatus{Phase: corev1.PodPending},
+		}
+	}
+	pods = &corev1.PodList{Items: []corev1.Pod{
+		newDeploymentPod("tm-5d8f7c-x1", "5d8f7c"),
+		newDeploymentPod("tm-6b9a4e-y2", "6b9a4e"),
+	}}
+
+	groups = getTaskManagerGroupSlots(pods, nil, 2, map[string]int32{"tm": 3})
+
+	assert.DeepEqual(t, groups, []v1beta1.TaskManagerGroupSlots{{Name: "tm", Replicas: 3}})
+}
+
+func TestGetTaskManagerHealth(t *testing.T) {
+	var newPod = func(name, owner string, ready bool, waitingReason string) corev1.Pod {
+		var controller = true
+		var pod = corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            name,
+				Labels:          map[string]string{"component": "taskmanager"},
+				OwnerReferences: []metav1.OwnerReference{{Kind: "StatefulSet", Name: owner, Controller: &controller}},
+			},
+			Status: corev1.PodStatus{Phase: corev1.PodRunning},
+		}
+		if ready {
+			pod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+		}
+		if waitingReason != "" {
+			pod.Status.ContainerStatuses = []corev1.ContainerStatus{{
+				State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: waitingReason, Message: "back-off restarting failed container"}},
+			}}
+		}
+		return pod
+	}
+	var pods = &corev1.PodList{Items: []corev1.Pod{
+		newPod("tm-a-0", "tm-a", true, ""),
+		newPod("tm-a-1", "tm-a", true, ""),
+		newPod("tm-b-0", "tm-b", true, ""),
+		newPod("tm-b-1", "tm-b", false, "CrashLoopBackOff"),
+	}}
+	var taskManagers = []flink.TaskManagerInfo{
+		{ID: "tm-a-0", SlotsNumber: 2},
+		{ID: "tm-a-1", SlotsNumber: 2},
+		{ID: "tm-b-0", SlotsNumber: 2},
+	}
+
+	var groups = getTaskManagerGroupSlots(pods, taskManagers, 2, map[string]int32{"tm-a": 2, "tm-c": 0})
+
+	assert.DeepEqual(t, groups, []v1beta1.TaskManagerGroupSlots{
+		{Name: "tm-a", Replicas: 2, ReadyReplicas: 2, ExpectedSlots: 4, RegisteredSlots: 4},
+		{Name: "tm-b", Replicas: 2, ReadyReplicas: 1, ExpectedSlots: 4, RegisteredSlots: 2,
+			LastError: "tm-b-1: CrashLoopBackOff: back-off restarting failed container"},
+		{Name: "tm-c"},
+	})
+	assert.Equal(t, isTaskManagerGroupHealthy(groups[0], false), true)
+	assert.Equal(t, isTaskManagerGroupHealthy(groups[0], true), false)
+	assert.Equal(t, isTaskManagerGroupHealthy(groups[1], false), false)
+	// A group scaled to zero is not degraded.
+	assert.Equal(t, isTaskManagerGroupHealthy(groups[2], false), true)
+
+	for i := range groups {
+		groups[i].Healthy = isTaskManagerGroupHealthy(groups[i], false)
+	}
+	healthy, unhealthy := getTaskManagerHealth(groups)
+	assert.Equal(t, healthy, false)
+	assert.DeepEqual(t, unhealthy, []string{"tm-b"})
+
+	healthy, unhealthy = getTaskManagerHealth([]v1beta1.TaskManagerGroupSlots{groups[0], groups[2]})
+	assert.Equal(t, healthy, true)
+	assert.Assert(t, unhealthy == nil)
+}
+
+func TestGetCheckpointAlignmentThreshold(t *testing.T) {
+	var cluster = &v1beta1.FlinkCluster{}
+	assert.Equal(t, getCheckpointAlignmentThreshold(cluster), 5*time.Minute)
+
+	cluster.Spec.FlinkProperties = map[string]string{"execution.checkpointing.timeout": "2 min"}
+	assert.Equal(t, getCheckpointAlignmentThreshold(cluster), time.Minute)
+}
+
+func TestGetSavepointTimeout(t *testing.T) {
+	var updateSeconds int32 = 1800
+	var userRequestedSeconds int32 = 60
+	var cluster = &v1beta1.FlinkCluster{
+		Spec: v1beta1.FlinkClusterSpec{
+			FlinkProperties: map[string]string{"execution.checkpointing.timeout": "2 h"},
+			Job: &v1beta1.JobSpec{
+				SavepointTimeouts: &v1beta1.SavepointTimeoutsSpec{
+					UpdateSeconds:        &updateSeconds,
+					UserRequestedSeconds: &userRequestedSeconds,
+				},
+			},
+		},
+	}
+	assert.Equal(t, getSavepointTimeout(cluster, v1beta1.SavepointReasonUpdate), 30*time.Minute)
+	assert.Equal(t, getSavepointTimeout(cluster, v1beta1.SavepointReasonUserRequested), time.Minute)
+	// Unset timeouts default to the checkpoint timeout.
+	assert.Equal(t, getSavepointTimeout(cluster, v1beta1.SavepointReasonScheduled), 2*time.Hour)
+	assert.Equal(t, getSavepointTimeout(cluster, v1beta1.SavepointReasonJobCancel), 2*time.Hour)
+	// The final savepoint of the deleted cluster is bounded.
+	assert.Equal(t, getSavepointTimeout(cluster, v1beta1.SavepointReasonDelete), time.Hour)
+	assert.Equal(t, hasSavepointTimeout(cluster, v1beta1.SavepointReasonUserRequested), true)
+	assert.Equal(t, hasSavepointTimeout(cluster, v1beta1.SavepointReasonScheduled), false)
+
+	cluster.Spec.FlinkProperties = nil
+	assert.Equal(t, getSavepointTimeout(cluster, v1beta1.SavepointReasonScheduled), 10*time.Minute)
+}
+
+func TestShouldRemoveTaskManagerSchedulingGates(t *testing.T) {
+	var haProperties = map[string]string{
+		"high-availability":            "kubernetes",
+		"kubernetes.cluster-id":        "my-cluster",
+		"high-availability.storageDir": "gs://my-bucket/ha",
+	}
+	var gatedPod = corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "mycluster-taskmanager-0", Labels: map[string]string{"component": "taskmanager"}},
+		Spec:       corev1.PodSpec{SchedulingGates: []corev1.PodSchedulingGate{{Name: v1beta1.JobManagerReadySchedulingGate}}},
+	}
+	var jobManagerPod = corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "mycluster-jobmanager-0", Labels: map[string]string{"component": "jobmanager"}},
+	}
+	var pods = &corev1.PodList{Items: []corev1.Pod{gatedPod, jobManagerPod, {
+		ObjectMeta: metav1.ObjectMeta{Name: "mycluster-taskmanager-1", Labels: map[string]string{"component": "taskmanager"}},
+	}}}
+	assert.DeepEqual(t, getGatedTaskManagerPods(pods), []*corev1.Pod{&pods.Items[0]})
+
+	var observed = &ObservedClusterState{
+		cluster:       &v1beta1.FlinkCluster{},
+		jmStatefulSet: &appsv1.StatefulSet{},
+	}
+	// The JobManager pod is not ready yet.
+	assert.Assert(t, !shouldRemoveTaskManagerSchedulingGates(observed))
+	observed.jmStatefulSet.Status.ReadyReplicas = 1
+	assert.Assert(t, shouldRemoveTaskManagerSchedulingGates(observed))
+
+	// With high availability, the TaskManagers wait for the leader election.
+	observed.cluster.Spec.FlinkProperties = haProperties
+	assert.Assert(t, !shouldRemoveTaskManagerSchedulingGates(observed))
+	observed.haConfigMap = &corev1.ConfigMap{}
+	assert.Assert(t, !shouldRemoveTaskManagerSchedulingGates(observed))
+	observed.haConfigMap.Annotations = map[string]string{
+		"control-plane.alpha.kubernetes.io/leader": `{"holderIdentity":"","leaseDuration":15.000000000}`,
+	}
+	assert.Assert(t, !shouldRemoveTaskManagerSchedulingGates(observed))
+	observed.haConfigMap.Annotations["control-plane.alpha.kubernetes.io/leader"] =
+		`{"holderIdentity":"6d8b8e5f-6c5e-4b8c-9c7f-2b1f0a5c9d3e","leaseDuration":15.000000000}`
+	assert.Assert(t, shouldRemoveTaskManagerSchedulingGates(observed))
+
+	// The leader is recorded but no JobManager is ready, e.g., after it crashed.
+	observed.jmStatefulSet.Status.ReadyReplicas = 0
+	assert.Assert(t, !shouldRemoveTaskManagerSchedulingGates(observed))
+}
+
+func TestHasAddressHost(t *testing.T) {
+	assert.Assert(t, hasAddressHost("pekko.tcp://flink@10.0.0.5:6123/user/rpc/dispatcher_1", "10.0.0.5"))
+	assert.Assert(t, hasAddressHost("http://10.0.0.5:8081", "10.0.0.5"))
+	assert.Assert(t, !hasAddressHost("http://10.0.0.50:8081", "10.0.0.5"))
+	assert.Assert(t, !hasAddressHost("http://110.0.0.5:8081", "10.0.0.5"))
+	assert.Assert(t, hasAddressHost("http://mycluster-jobmanager-0.mycluster-jobmanager.default.svc:8081", "mycluster-jobmanager-0"))
+	assert.Assert(t, !hasAddressHost("http://mycluster-jobmanager-10.mycluster-jobmanager.default.svc:8081", "mycluster-jobmanager-1"))
+	assert.Assert(t, !hasAddressHost("http://10.0.0.5:8081", ""))
+}
+
+func TestGetJobManagerReplicaDrift(t *testing.T) {
+	var replicas int32 = 1
+	var scaledDown int32 = 0
+	var observed = &ObservedClusterState{
+		cluster: &v1beta1.FlinkCluster{
+			Spec: v1beta1.FlinkClusterSpec{JobManager: &v1beta1.JobManagerSpec{Replicas: &replicas}},
+		},
+		jmStatefulSet: &appsv1.StatefulSet{Spec: appsv1.StatefulSetSpec{Replicas: &replicas}},
+	}
+	var _, _, drifted = getJobManagerReplicaDrift(observed)
+	assert.Equal(t, drifted, false)
+
+	// The StatefulSet is scaled to zero manually.
+	observed.jmStatefulSet.Spec.Replicas = &scaledDown
+	desired, actual, drifted := getJobManagerReplicaDrift(observed)
+	assert.Equal(t, drifted, true)
+	assert.Equal(t, desired, int32(1))
+	assert.Equal(t, actual, int32(0))
+	assert.Equal(t, shouldRevertJobManagerReplicaDrift(observed.cluster), true)
+
+	// Warn only.
+	var warn = v1beta1.ReplicaDriftPolicyWarn
+	observed.cluster.Spec.JobManager.ReplicaDriftPolicy = &warn
+	assert.Equal(t, shouldRevertJobManagerReplicaDrift(observed.cluster), false)
+
+	// Not observed.
+	observed.jmStatefulSet = nil
+	_, _, drifted = getJobManagerReplicaDrift(observed)
+	assert.Equal(t, drifted, false)
+}
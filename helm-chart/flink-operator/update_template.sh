@@ -18,6 +18,10 @@ function modifyManifests() {
   yqi "$rbacProxySelector |= sort_keys(.)"
 
   yqi "$managerSelector"'.args += "--watch-namespace=__WATCH_NAMESPACE__"'
+  yqi "$managerSelector"'.args += "--default-take-savepoint-on-update=__DEFAULT_TAKE_SAVEPOINT_ON_UPDATE__"'
+  yqi "$managerSelector"'.args += "--cpu-price-per-hour=__CPU_PRICE_PER_HOUR__"'
+  yqi "$managerSelector"'.args += "--memory-price-per-hour=__MEMORY_PRICE_PER_HOUR__"'
+  yqi "$managerSelector"'.args += "--gpu-price-per-hour=__GPU_PRICE_PER_HOUR__"'
   yqi "$managerSelector"'.resources.limits.cpu = "__LIMITS_CPU__"'
   yqi "$managerSelector"'.resources.limits.memory = "__LIMITS_MEMORY__"'
   yqi "$managerSelector"'.resources.requests.cpu = "__REQUESTS_CPU__"'
@@ -37,6 +41,10 @@ function modifyManifests() {
 
 function helmTemplating() {
   sed 's/__WATCH_NAMESPACE__/{{ .Values.watchNamespace.name }}/' |
+  sed 's/__DEFAULT_TAKE_SAVEPOINT_ON_UPDATE__/{{ .Values.defaults.takeSavepointOnUpdate }}/' |
+  sed 's/__CPU_PRICE_PER_HOUR__/{{ .Values.priceList.cpuPerHour }}/' |
+  sed 's/__MEMORY_PRICE_PER_HOUR__/{{ .Values.priceList.memoryGiBPerHour }}/' |
+  sed 's/__GPU_PRICE_PER_HOUR__/{{ .Values.priceList.gpuPerHour }}/' |
   sed 's/__SERVICE_ACCOUNT__/{{ template "flink-operator.serviceAccountName" . }}/' |
   sed 's/__NAMESPACE__/{{ .Values.flinkOperatorNamespace.name }}/g' |
   sed 's/__LIMITS_CPU__/{{ .Values.resources.limits.cpu }}/' |
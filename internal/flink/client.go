@@ -17,12 +17,15 @@ limitations under the License.
 package flink
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 )
@@ -82,7 +85,8 @@ type JobException struct {
 }
 
 type JobExceptions struct {
-	Exceptions []JobException `json:"all-exceptions"`
+	RootException string         `json:"root-exception"`
+	Exceptions    []JobException `json:"all-exceptions"`
 }
 
 // Job defines Flink job status.
@@ -106,6 +110,147 @@ func (jst JobByStartTime) Len() int           { return len(jst) }
 func (jst JobByStartTime) Swap(i, j int)      { jst[i], jst[j] = jst[j], jst[i] }
 func (jst JobByStartTime) Less(i, j int) bool { return jst[i].StartTime > jst[j].StartTime }
 
+// Metric defines a Flink metric and its value.
+type Metric struct {
+	ID    string `json:"id"`
+	Value string `json:"value"`
+}
+
+// ConfigEntry defines a Flink configuration entry.
+type ConfigEntry struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// CheckpointStatistics defines the statistics of a checkpoint or savepoint.
+type CheckpointStatistics struct {
+	ID           int64  `json:"id"`
+	Status       string `json:"status"`
+	IsSavepoint  bool   `json:"is_savepoint"`
+	StateSize    int64  `json:"state_size"`
+	ExternalPath string `json:"external_path"`
+	// The time the last task acknowledged the checkpoint, in milliseconds since the epoch.
+	LatestAckTimestamp int64 `json:"latest_ack_timestamp"`
+	// CHECKPOINT, UNALIGNED_CHECKPOINT, SAVEPOINT or SYNC_SAVEPOINT.
+	CheckpointType string `json:"checkpoint_type"`
+	// The statistics of the job vertices, keyed by vertex ID. Only present in the checkpoint details.
+	Tasks map[string]TaskCheckpointStatistics `json:"tasks,omitempty"`
+}
+
+// TaskCheckpointStatistics defines the checkpoint statistics of a job vertex.
+type TaskCheckpointStatistics struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+}
+
+// TaskCheckpointDetails defines the checkpoint statistics of a job vertex with the summary
+// of its subtasks.
+type TaskCheckpointDetails struct {
+	ID      string                `json:"id"`
+	Status  string                `json:"status"`
+	Summary TaskCheckpointSummary `json:"summary"`
+}
+
+// TaskCheckpointSummary defines the summary of the subtask checkpoint statistics.
+type TaskCheckpointSummary struct {
+	Alignment CheckpointAlignmentSummary `json:"alignment"`
+}
+
+// CheckpointAlignmentSummary defines the summary of the checkpoint alignment of subtasks.
+type CheckpointAlignmentSummary struct {
+	// Alignment duration in milliseconds.
+	Duration MinMaxAvgStatistics `json:"duration"`
+}
+
+// MinMaxAvgStatistics defines the minimum, maximum and average of a statistic.
+type MinMaxAvgStatistics struct {
+	Min int64 `json:"min"`
+	Max int64 `json:"max"`
+	Avg int64 `json:"avg"`
+}
+
+// CheckpointingStatistics defines the checkpointing statistics of a job.
+type CheckpointingStatistics struct {
+	History []CheckpointStatistics `json:"history"`
+	Latest  LatestCheckpoints      `json:"latest"`
+}
+
+// LatestCheckpoints defines the latest checkpoints of a job.
+type LatestCheckpoints struct {
+	// The checkpoint or savepoint the job was restored from, nil if the job started without state.
+	Restored *RestoredCheckpointStatistics `json:"restored"`
+}
+
+// RestoredCheckpointStatistics defines the checkpoint or savepoint a job was restored from.
+type RestoredCheckpointStatistics struct {
+	ID               int64  `json:"id"`
+	RestoreTimestamp int64  `json:"restore_timestamp"`
+	IsSavepoint      bool   `json:"is_savepoint"`
+	ExternalPath     string `json:"external_path"`
+}
+
+// TaskManagerInfo defines a TaskManager registered with the JobManager.
+type TaskManagerInfo struct {
+	ID          string `json:"id"`
+	Path        string `json:"path"`
+	SlotsNumber int32  `json:"slotsNumber"`
+	FreeSlots   int32  `json:"freeSlots"`
+}
+
+// TaskManagersInfo defines the TaskManagers registered with the JobManager.
+type TaskManagersInfo struct {
+	TaskManagers []TaskManagerInfo `json:"taskmanagers"`
+}
+
+// JobPlanNode defines a vertex of the job graph. Flink derives the vertex ID from the UID of
+// the operator chain head, or generates it when the operator sets no UID.
+type JobPlanNode struct {
+	ID          string `json:"id"`
+	Description string `json:"description"`
+}
+
+// JobPlan defines the dataflow plan of a job.
+type JobPlan struct {
+	Plan struct {
+		Nodes []JobPlanNode `json:"nodes"`
+	} `json:"plan"`
+}
+
+// JarFileInfo defines a JAR uploaded to the JobManager.
+type JarFileInfo struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// The upload time in milliseconds since the epoch.
+	Uploaded int64 `json:"uploaded"`
+}
+
+// JarsList defines the JARs uploaded to the JobManager.
+type JarsList struct {
+	Files []JarFileInfo `json:"files"`
+}
+
+// JarRunRequest defines the request body to run an uploaded JAR.
+type JarRunRequest struct {
+	EntryClass            string   `json:"entryClass,omitempty"`
+	ProgramArgsList       []string `json:"programArgsList,omitempty"`
+	Parallelism           *int32   `json:"parallelism,omitempty"`
+	SavepointPath         string   `json:"savepointPath,omitempty"`
+	AllowNonRestoredState bool     `json:"allowNonRestoredState,omitempty"`
+}
+
+// SavepointValidationRequest defines the request body to the savepoint validation webhook.
+type SavepointValidationRequest struct {
+	Namespace string `json:"namespace"`
+	Cluster   string `json:"cluster"`
+	Savepoint string `json:"savepoint"`
+}
+
+// SavepointValidationResponse defines whether the savepoint validation webhook approved the savepoint.
+type SavepointValidationResponse struct {
+	Approved bool   `json:"approved"`
+	Reason   string `json:"reason,omitempty"`
+}
+
 // SavepointTriggerID defines trigger ID of an async savepoint operation.
 type SavepointTriggerID struct {
 	RequestID string `json:"request-id"`
@@ -322,6 +467,168 @@ func (c *Client) GetJobExceptions(apiBaseURL string, jobId string) (*JobExceptio
 	return exp, nil
 }
 
+// GetCheckpointingStatistics returns the checkpointing statistics of the job, including
+// the recent checkpoints and savepoints.
+func (c *Client) GetCheckpointingStatistics(apiBaseURL string, jobID string) (*CheckpointingStatistics, error) {
+	url := fmt.Sprintf("%s/jobs/%s/checkpoints", apiBaseURL, jobID)
+	resp, err := c.httpClient.Get(url)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &CheckpointingStatistics{}
+	if err := parseJson(resp, stats); err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+// GetCheckpointDetails returns the statistics of the checkpoint including its job vertices.
+func (c *Client) GetCheckpointDetails(apiBaseURL string, jobID string, checkpointID int64) (*CheckpointStatistics, error) {
+	url := fmt.Sprintf("%s/jobs/%s/checkpoints/details/%d", apiBaseURL, jobID, checkpointID)
+	resp, err := c.httpClient.Get(url)
+	if err != nil {
+		return nil, err
+	}
+
+	details := &CheckpointStatistics{}
+	if err := parseJson(resp, details); err != nil {
+		return nil, err
+	}
+
+	return details, nil
+}
+
+// GetTaskCheckpointDetails returns the checkpoint statistics of the job vertex with the
+// summary of its subtasks.
+func (c *Client) GetTaskCheckpointDetails(apiBaseURL string, jobID string, checkpointID int64, vertexID string) (*TaskCheckpointDetails, error) {
+	url := fmt.Sprintf("%s/jobs/%s/checkpoints/details/%d/subtasks/%s", apiBaseURL, jobID, checkpointID, vertexID)
+	resp, err := c.httpClient.Get(url)
+	if err != nil {
+		return nil, err
+	}
+
+	details := &TaskCheckpointDetails{}
+	if err := parseJson(resp, details); err != nil {
+		return nil, err
+	}
+
+	return details, nil
+}
+
+// GetJobPlan returns the dataflow plan of the job with the vertices of its job graph.
+func (c *Client) GetJobPlan(apiBaseURL string, jobID string) (*JobPlan, error) {
+	url := fmt.Sprintf("%s/jobs/%s/plan", apiBaseURL, jobID)
+	resp, err := c.httpClient.Get(url)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &JobPlan{}
+	if err := parseJson(resp, plan); err != nil {
+		return nil, err
+	}
+
+	return plan, nil
+}
+
+// GetTaskManagers returns the TaskManagers registered with the JobManager.
+func (c *Client) GetTaskManagers(apiBaseURL string) (*TaskManagersInfo, error) {
+	resp, err := c.httpClient.Get(apiBaseURL + "/taskmanagers")
+	if err != nil {
+		return nil, err
+	}
+
+	var taskManagers = &TaskManagersInfo{}
+	if err := parseJson(resp, taskManagers); err != nil {
+		return nil, err
+	}
+
+	return taskManagers, nil
+}
+
+// GetJobManagerConfig returns the effective configuration of the running JobManager.
+// Flink masks the values of sensitive keys in the response.
+func (c *Client) GetJobManagerConfig(apiBaseURL string) (map[string]string, error) {
+	resp, err := c.httpClient.Get(apiBaseURL + "/jobmanager/config")
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []ConfigEntry
+	if err := parseJson(resp, &entries); err != nil {
+		return nil, err
+	}
+
+	config := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		config[entry.Key] = entry.Value
+	}
+	return config, nil
+}
+
+// GetJobMetrics returns the values of the job metrics by name. Flink omits the metrics which
+// are not registered for the job.
+func (c *Client) GetJobMetrics(apiBaseURL string, jobID string, names ...string) (map[string]string, error) {
+	url := fmt.Sprintf("%s/jobs/%s/metrics?get=%s", apiBaseURL, jobID, strings.Join(names, ","))
+	resp, err := c.httpClient.Get(url)
+	if err != nil {
+		return nil, err
+	}
+
+	var metrics []Metric
+	if err := parseJson(resp, &metrics); err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]string, len(metrics))
+	for _, metric := range metrics {
+		values[metric.ID] = metric.Value
+	}
+	return values, nil
+}
+
+// GetJars returns the JARs uploaded to the JobManager, which it keeps until it restarts.
+func (c *Client) GetJars(apiBaseURL string) (*JarsList, error) {
+	resp, err := c.httpClient.Get(apiBaseURL + "/jars")
+	if err != nil {
+		return nil, err
+	}
+
+	jars := &JarsList{}
+	if err := parseJson(resp, jars); err != nil {
+		return nil, err
+	}
+	return jars, nil
+}
+
+// ValidateSavepoint asks the external savepoint validation webhook at url whether the job can be
+// restored from the savepoint, waiting for the response up to timeout.
+func (c *Client) ValidateSavepoint(url string, request SavepointValidationRequest, timeout time.Duration) (*SavepointValidationResponse, error) {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	validation := &SavepointValidationResponse{}
+	if err := parseJson(resp, validation); err != nil {
+		return nil, err
+	}
+	return validation, nil
+}
+
 func NewDefaultClient(log logr.Logger) *Client {
 	return NewClient(log, &http.Client{})
 }
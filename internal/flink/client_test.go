@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/go-logr/logr"
 	"gotest.tools/v3/assert"
@@ -134,3 +135,165 @@ func TestStopJobWithSavepointPayload(t *testing.T) {
 		})
 	}
 }
+
+func TestGetJobManagerConfig(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, r.URL.Path, "/jobmanager/config")
+		w.Header().Set("Content-Type", "application/json")
+		_, err := w.Write([]byte(`[{"key":"parallelism.default","value":"2"},{"key":"s3.secret-key","value":"******"}]`))
+		assert.NilError(t, err)
+	}))
+	defer server.Close()
+
+	client := NewClient(logr.Discard(), server.Client())
+	config, err := client.GetJobManagerConfig(server.URL)
+
+	assert.NilError(t, err)
+	assert.DeepEqual(t, config, map[string]string{
+		"parallelism.default": "2",
+		"s3.secret-key":       "******",
+	})
+}
+
+func TestGetCheckpointingStatistics(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, r.URL.Path, "/jobs/job-1/checkpoints")
+		w.Header().Set("Content-Type", "application/json")
+		_, err := w.Write([]byte(`{"history":[` +
+			`{"id":2,"status":"COMPLETED","is_savepoint":true,"state_size":2048,"external_path":"gs://bucket/savepoint-2"},` +
+			`{"id":1,"status":"COMPLETED","is_savepoint":false,"state_size":1024,"external_path":"<checkpoint-not-externally-addressable>"}],` +
+			`"latest":{"restored":{"id":0,"restore_timestamp":1700000000000,"is_savepoint":true,"external_path":"gs://bucket/savepoint-0"}}}`))
+		assert.NilError(t, err)
+	}))
+	defer server.Close()
+
+	client := NewClient(logr.Discard(), server.Client())
+	stats, err := client.GetCheckpointingStatistics(server.URL, "job-1")
+
+	assert.NilError(t, err)
+	assert.DeepEqual(t, stats.History, []CheckpointStatistics{
+		{ID: 2, Status: "COMPLETED", IsSavepoint: true, StateSize: 2048, ExternalPath: "gs://bucket/savepoint-2"},
+		{ID: 1, Status: "COMPLETED", StateSize: 1024, ExternalPath: "<checkpoint-not-externally-addressable>"},
+	})
+	assert.DeepEqual(t, stats.Latest.Restored, &RestoredCheckpointStatistics{RestoreTimestamp: 1700000000000, IsSavepoint: true, ExternalPath: "gs://bucket/savepoint-0"})
+}
+
+func TestGetTaskCheckpointDetails(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, r.URL.Path, "/jobs/job-1/checkpoints/details/7/subtasks/vertex-1")
+		w.Header().Set("Content-Type", "application/json")
+		_, err := w.Write([]byte(`{"id":"vertex-1","status":"COMPLETED","summary":` +
+			`{"alignment":{"duration":{"min":10,"max":120000,"avg":3000},"buffered":{"min":0,"max":0,"avg":0}}}}`))
+		assert.NilError(t, err)
+	}))
+	defer server.Close()
+
+	client := NewClient(logr.Discard(), server.Client())
+	details, err := client.GetTaskCheckpointDetails(server.URL, "job-1", 7, "vertex-1")
+
+	assert.NilError(t, err)
+	assert.Equal(t, details.Summary.Alignment.Duration.Max, int64(120000))
+}
+
+func TestGetJobPlan(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, r.URL.Path, "/jobs/job-1/plan")
+		w.Header().Set("Content-Type", "application/json")
+		_, err := w.Write([]byte(`{"plan":{"jid":"job-1","name":"job","nodes":[` +
+			`{"id":"cbc357ccb763df2852fee8c4fc7d55f2","parallelism":2,"description":"Source: events"},` +
+			`{"id":"90bea66de1c231edf33913ecd54406c1","parallelism":2,"description":"Sink: output",` +
+			`"inputs":[{"num":0,"id":"cbc357ccb763df2852fee8c4fc7d55f2","ship_strategy":"HASH"}]}]}}`))
+		assert.NilError(t, err)
+	}))
+	defer server.Close()
+
+	client := NewClient(logr.Discard(), server.Client())
+	plan, err := client.GetJobPlan(server.URL, "job-1")
+
+	assert.NilError(t, err)
+	assert.DeepEqual(t, plan.Plan.Nodes, []JobPlanNode{
+		{ID: "cbc357ccb763df2852fee8c4fc7d55f2", Description: "Source: events"},
+		{ID: "90bea66de1c231edf33913ecd54406c1", Description: "Sink: output"},
+	})
+}
+
+func TestGetTaskManagers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, r.URL.Path, "/taskmanagers")
+		w.Header().Set("Content-Type", "application/json")
+		_, err := w.Write([]byte(`{"taskmanagers":[{"id":"10.0.0.1:6122-1a2b3c",` +
+			`"path":"pekko.tcp://flink@10.0.0.1:6122/user/rpc/taskmanager_0","dataPort":6121,"slotsNumber":2,"freeSlots":1}]}`))
+		assert.NilError(t, err)
+	}))
+	defer server.Close()
+
+	client := NewClient(logr.Discard(), server.Client())
+	taskManagers, err := client.GetTaskManagers(server.URL)
+
+	assert.NilError(t, err)
+	assert.DeepEqual(t, taskManagers.TaskManagers, []TaskManagerInfo{{
+		ID:          "10.0.0.1:6122-1a2b3c",
+		Path:        "pekko.tcp://flink@10.0.0.1:6122/user/rpc/taskmanager_0",
+		SlotsNumber: 2,
+		FreeSlots:   1,
+	}})
+}
+
+func TestGetJobMetrics(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, r.URL.Path, "/jobs/job-1/metrics")
+		assert.Equal(t, r.URL.Query().Get("get"), "numRestarts,uptime")
+		w.Header().Set("Content-Type", "application/json")
+		_, err := w.Write([]byte(`[{"id":"numRestarts","value":"3"},{"id":"uptime","value":"60000"}]`))
+		assert.NilError(t, err)
+	}))
+	defer server.Close()
+
+	client := NewClient(logr.Discard(), server.Client())
+	metrics, err := client.GetJobMetrics(server.URL, "job-1", "numRestarts", "uptime")
+
+	assert.NilError(t, err)
+	assert.DeepEqual(t, metrics, map[string]string{"numRestarts": "3", "uptime": "60000"})
+}
+
+func TestGetJars(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, r.URL.Path, "/jars")
+		w.Header().Set("Content-Type", "application/json")
+		_, err := w.Write([]byte(`{"address":"http://flink-jobmanager:8081","files":[{"id":"6077eca7_job.jar",` +
+			`"name":"job.jar","uploaded":1700000000000,"entry":[{"name":"org.example.Job","description":null}]}]}`))
+		assert.NilError(t, err)
+	}))
+	defer server.Close()
+
+	client := NewClient(logr.Discard(), server.Client())
+	jars, err := client.GetJars(server.URL)
+
+	assert.NilError(t, err)
+	assert.DeepEqual(t, jars.Files, []JarFileInfo{{ID: "6077eca7_job.jar", Name: "job.jar", Uploaded: 1700000000000}})
+}
+
+func TestValidateSavepoint(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, r.Method, http.MethodPost)
+		assert.Equal(t, r.URL.Path, "/validate")
+		assert.Equal(t, r.Header.Get("Content-Type"), "application/json")
+		var request SavepointValidationRequest
+		assert.NilError(t, json.NewDecoder(r.Body).Decode(&request))
+		assert.DeepEqual(t, request, SavepointValidationRequest{
+			Namespace: "default", Cluster: "flinkjobcluster-sample", Savepoint: "gs://my-bucket/savepoint-1",
+		})
+		w.Header().Set("Content-Type", "application/json")
+		_, err := w.Write([]byte(`{"approved":false,"reason":"lineage not approved"}`))
+		assert.NilError(t, err)
+	}))
+	defer server.Close()
+
+	client := NewClient(logr.Discard(), server.Client())
+	response, err := client.ValidateSavepoint(server.URL+"/validate", SavepointValidationRequest{
+		Namespace: "default", Cluster: "flinkjobcluster-sample", Savepoint: "gs://my-bucket/savepoint-1",
+	}, time.Second)
+
+	assert.NilError(t, err)
+	assert.DeepEqual(t, response, &SavepointValidationResponse{Approved: false, Reason: "lineage not approved"})
+}
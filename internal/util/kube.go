@@ -5,6 +5,8 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"slices"
+	"strings"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -61,6 +63,12 @@ func GetNonLiveHistory(revisions []*appsv1.ControllerRevision, historyLimit int)
 	return nonLiveHistory
 }
 
+// IsExtendedResourceName returns true if the resource is not a native Kubernetes resource,
+// e.g., `nvidia.com/gpu`.
+func IsExtendedResourceName(name corev1.ResourceName) bool {
+	return strings.Contains(string(name), "/") && !strings.Contains(string(name), corev1.ResourceDefaultNamespacePrefix)
+}
+
 func UpperBoundedResourceList(resources corev1.ResourceRequirements) *corev1.ResourceList {
 	rl := corev1.ResourceList{}
 
@@ -84,5 +92,66 @@ func UpperBoundedResourceList(resources corev1.ResourceRequirements) *corev1.Res
 		rl[corev1.ResourceEphemeralStorage] = *resources.Requests.StorageEphemeral()
 	}
 
+	// Extended resources, e.g., GPUs, must be set in limits and may be set in requests
+	// with the same amount.
+	for _, list := range []corev1.ResourceList{resources.Requests, resources.Limits} {
+		for name, quantity := range list {
+			if IsExtendedResourceName(name) && !quantity.IsZero() {
+				rl[name] = quantity.DeepCopy()
+			}
+		}
+	}
+
 	return &rl
 }
+
+// AddResourceRequests adds the resources requested by the containers of the given number of
+// pods to the total. Requests default to the limits if unset.
+func AddResourceRequests(total corev1.ResourceList, containers []corev1.Container, replicas int32) {
+	for _, container := range containers {
+		var requests = container.Resources.Requests.DeepCopy()
+		if requests == nil {
+			requests = corev1.ResourceList{}
+		}
+		for name, limit := range container.Resources.Limits {
+			if _, ok := requests[name]; !ok {
+				requests[name] = limit.DeepCopy()
+			}
+		}
+		for name, request := range requests {
+			var quantity = total[name]
+			for n := int32(0); n < replicas; n++ {
+				quantity.Add(request)
+			}
+			total[name] = quantity
+		}
+	}
+}
+
+// GetPodQOSClass returns the QoS class Kubernetes assigns to a pod with the containers, based on
+// their CPU and memory requests and limits. Requests default to the limits if unset.
+func GetPodQOSClass(initContainers []corev1.Container, containers []corev1.Container) corev1.PodQOSClass {
+	var bestEffort, guaranteed = true, true
+	for _, container := range append(slices.Clone(initContainers), containers...) {
+		for _, name := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+			var limit, hasLimit = container.Resources.Limits[name]
+			var request, hasRequest = container.Resources.Requests[name]
+			if !hasRequest {
+				request, hasRequest = limit, hasLimit
+			}
+			if hasRequest && !request.IsZero() || hasLimit && !limit.IsZero() {
+				bestEffort = false
+			}
+			if !hasLimit || limit.IsZero() || request.Cmp(limit) != 0 {
+				guaranteed = false
+			}
+		}
+	}
+	switch {
+	case bestEffort:
+		return corev1.PodQOSBestEffort
+	case guaranteed:
+		return corev1.PodQOSGuaranteed
+	}
+	return corev1.PodQOSBurstable
+}
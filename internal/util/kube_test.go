@@ -46,3 +46,103 @@ func TestUpperBoundedResourceList(t *testing.T) {
 		assert.Equal(t, *resourceList.Memory(), resource.MustParse("2Gi"))
 	})
 }
+
+func TestUpperBoundedResourceListExtendedResources(t *testing.T) {
+	resourceList := UpperBoundedResourceList(corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU:                   resource.MustParse("1"),
+			"nvidia.com/gpu":                     resource.MustParse("2"),
+			"example.com/fpga":                   resource.MustParse("1"),
+			corev1.ResourceName("hugepages-2Mi"): resource.MustParse("1Gi"),
+		},
+		Limits: corev1.ResourceList{
+			"nvidia.com/gpu": resource.MustParse("2"),
+		},
+	})
+	assert.Equal(t, (*resourceList)["nvidia.com/gpu"], resource.MustParse("2"))
+	assert.Equal(t, (*resourceList)["example.com/fpga"], resource.MustParse("1"))
+	_, ok := (*resourceList)["hugepages-2Mi"]
+	assert.Equal(t, ok, false)
+}
+
+func TestGetPodQOSClass(t *testing.T) {
+	var resources = corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("1"),
+		corev1.ResourceMemory: resource.MustParse("1Gi"),
+	}
+	var lowerResources = corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("500m"),
+		corev1.ResourceMemory: resource.MustParse("1Gi"),
+	}
+	tests := []struct {
+		name           string
+		initContainers []corev1.Container
+		containers     []corev1.Container
+		expected       corev1.PodQOSClass
+	}{
+		{
+			name:       "no resources",
+			containers: []corev1.Container{{Name: "main"}},
+			expected:   corev1.PodQOSBestEffort,
+		},
+		{
+			name: "only extended resources",
+			containers: []corev1.Container{{Name: "main", Resources: corev1.ResourceRequirements{
+				Limits: corev1.ResourceList{"nvidia.com/gpu": resource.MustParse("1")},
+			}}},
+			expected: corev1.PodQOSBestEffort,
+		},
+		{
+			name:       "limits equal to requests",
+			containers: []corev1.Container{{Name: "main", Resources: corev1.ResourceRequirements{Requests: resources, Limits: resources}}},
+			expected:   corev1.PodQOSGuaranteed,
+		},
+		{
+			name:       "only limits",
+			containers: []corev1.Container{{Name: "main", Resources: corev1.ResourceRequirements{Limits: resources}}},
+			expected:   corev1.PodQOSGuaranteed,
+		},
+		{
+			name:       "only requests",
+			containers: []corev1.Container{{Name: "main", Resources: corev1.ResourceRequirements{Requests: resources}}},
+			expected:   corev1.PodQOSBurstable,
+		},
+		{
+			name:       "requests lower than limits",
+			containers: []corev1.Container{{Name: "main", Resources: corev1.ResourceRequirements{Requests: lowerResources, Limits: resources}}},
+			expected:   corev1.PodQOSBurstable,
+		},
+		{
+			name: "only memory limit",
+			containers: []corev1.Container{{Name: "main", Resources: corev1.ResourceRequirements{
+				Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+			}}},
+			expected: corev1.PodQOSBurstable,
+		},
+		{
+			name: "sidecar without resources",
+			containers: []corev1.Container{
+				{Name: "main", Resources: corev1.ResourceRequirements{Limits: resources}},
+				{Name: "sidecar"},
+			},
+			expected: corev1.PodQOSBurstable,
+		},
+		{
+			name:           "init container without resources",
+			initContainers: []corev1.Container{{Name: "init"}},
+			containers:     []corev1.Container{{Name: "main", Resources: corev1.ResourceRequirements{Limits: resources}}},
+			expected:       corev1.PodQOSBurstable,
+		},
+		{
+			name:           "init container with resources",
+			initContainers: []corev1.Container{{Name: "init", Resources: corev1.ResourceRequirements{Requests: resources}}},
+			containers:     []corev1.Container{{Name: "main"}},
+			expected:       corev1.PodQOSBurstable,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, GetPodQOSClass(tt.initContainers, tt.containers), tt.expected)
+		})
+	}
+}
@@ -52,6 +52,12 @@ var (
 	leaderElectionID        = flag.String("leader-election-id", "flink-operator-lock", "The name that leader election will use for holding the leader lock")
 	watchNamespace          = flag.String("watch-namespace", "", "Watch custom resources in the namespace, ignore other namespaces. If empty, all namespaces will be watched.")
 	maxConcurrentReconciles = flag.Int("max-concurrent-reconciles", 1, "The maximum number of concurrent Reconciles which can be run. Defaults to 1.")
+
+	defaultTakeSavepointOnUpdate = flag.String("default-take-savepoint-on-update", "", "The default of spec.job.takeSavepointOnUpdate applied by the webhook when it is unset, true or false. If empty, the field is left unset.")
+
+	cpuPricePerHour    = flag.String("cpu-price-per-hour", "", "The price of a CPU core per hour, used to estimate the monthly cost of the clusters.")
+	memoryPricePerHour = flag.String("memory-price-per-hour", "", "The price of a GiB of memory per hour, used to estimate the monthly cost of the clusters.")
+	gpuPricePerHour    = flag.String("gpu-price-per-hour", "", "The price of a GPU per hour, used to estimate the monthly cost of the clusters.")
 )
 
 func init() {
@@ -75,6 +81,18 @@ func main() {
 		WithName("FlinkCluster")
 	ctrl.SetLogger(logger)
 
+	defaultingConfig, err := v1beta1.ParseDefaultingConfig(*defaultTakeSavepointOnUpdate)
+	if err != nil {
+		setupLog.Error(err, "Invalid operator defaults")
+		os.Exit(1)
+	}
+
+	priceList, err := v1beta1.ParseResourcePriceList(*cpuPricePerHour, *memoryPricePerHour, *gpuPricePerHour)
+	if err != nil {
+		setupLog.Error(err, "Invalid resource price list")
+		os.Exit(1)
+	}
+
 	defaultNamespaces := make(map[string]cache.Config)
 	if *watchNamespace != "" {
 		setupLog.Info("Watching custom resources in the namespace", "namespace", *watchNamespace)
@@ -113,6 +131,7 @@ func main() {
 		setupLog.Error(err, "Unable to create reconciler")
 		os.Exit(1)
 	}
+	reconciler.PriceList = priceList
 	err = reconciler.SetupWithManager(mgr, *maxConcurrentReconciles)
 	if err != nil {
 		setupLog.Error(err, "Unable to create controller", "controller", "FlinkCluster")
@@ -122,7 +141,7 @@ func main() {
 	// Set up webhooks for the custom resource.
 	// Disable it with `FLINK_OPERATOR_ENABLE_WEBHOOKS=false` when we run locally.
 	if os.Getenv("FLINK_OPERATOR_ENABLE_WEBHOOKS") != "false" {
-		if err = (&v1beta1.FlinkCluster{}).SetupWebhookWithManager(mgr); err != nil {
+		if err = (&v1beta1.FlinkCluster{}).SetupWebhookWithManager(mgr, defaultingConfig); err != nil {
 			setupLog.Error(err, "Unable to setup webhooks", "webhook", "FlinkCluster")
 			os.Exit(1)
 		}